@@ -0,0 +1,302 @@
+// Package cbor は WebAuthn の認証器のデータを扱うための CBOR (RFC 8949) の符号化と復号を行います。
+// 認証器が使う長さが確定した整数、バイト列、文字列、配列、マップ、真偽値、null のみを扱い、タグや浮動小数点数、長さが不定の値はエラーにします。
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorText     = 3
+	majorArray    = 4
+	majorMap      = 5
+	majorTag      = 6
+	majorSimple   = 7
+
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+
+	// maxDepth は入れ子の深さの上限です。不正なデータでスタックを使い果たさないよう制限します。
+	maxDepth = 16
+)
+
+// ErrUnexpectedEnd はデータが途中で終わっている場合のエラーです。
+var ErrUnexpectedEnd = errors.New("cbor: unexpected end of data")
+
+// Unmarshal は data をすべて復号します。値の後ろに余分なデータがある場合はエラーを返します。
+// 整数は int64、バイト列は []byte、文字列は string、配列は []interface{}、マップは map[interface{}]interface{} になります。
+func Unmarshal(data []byte) (interface{}, error) {
+	v, rest, err := UnmarshalPrefix(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("cbor: %d bytes of trailing data", len(rest))
+	}
+	return v, nil
+}
+
+// UnmarshalPrefix は data の先頭の1つの値を復号し、残りのデータを返します。
+func UnmarshalPrefix(data []byte) (interface{}, []byte, error) {
+	d := decoder{data: data}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, d.data[d.off:], nil
+}
+
+type decoder struct {
+	data []byte
+	off  int
+}
+
+func (d *decoder) decode(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, errors.New("cbor: nesting too deep")
+	}
+
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUnsigned:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: integer overflows int64")
+		}
+		return int64(arg), nil
+	case majorNegative:
+		if arg > math.MaxInt64 {
+			return nil, errors.New("cbor: integer overflows int64")
+		}
+		return -1 - int64(arg), nil
+	case majorBytes:
+		b, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case majorText:
+		b, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case majorArray:
+		// 要素は少なくとも1バイトあるため、残りのデータより多い要素数は不正なデータとして扱う
+		if arg > uint64(len(d.data)-d.off) {
+			return nil, ErrUnexpectedEnd
+		}
+		a := make([]interface{}, 0, arg)
+		for n := uint64(0); n < arg; n++ {
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		return a, nil
+	case majorMap:
+		if arg > uint64(len(d.data)-d.off)/2 {
+			return nil, ErrUnexpectedEnd
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for n := uint64(0); n < arg; n++ {
+			k, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, fmt.Errorf("cbor: unsupported map key type %T", k)
+			}
+			if _, ok := m[k]; ok {
+				return nil, fmt.Errorf("cbor: duplicate map key %v", k)
+			}
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		return m, nil
+	case majorTag:
+		return nil, errors.New("cbor: tags are not supported")
+	default:
+		switch arg {
+		case simpleFalse:
+			return false, nil
+		case simpleTrue:
+			return true, nil
+		case simpleNull:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", arg)
+	}
+}
+
+// head は値の先頭の型と引数を読み取ります。
+func (d *decoder) head() (byte, uint64, error) {
+	if d.off >= len(d.data) {
+		return 0, 0, ErrUnexpectedEnd
+	}
+	b := d.data[d.off]
+	d.off++
+
+	major := b >> 5
+	info := b & 0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info <= 27:
+		n := 1 << (info - 24)
+		v, err := d.bytes(uint64(n))
+		if err != nil {
+			return 0, 0, err
+		}
+		var arg uint64
+		for _, c := range v {
+			arg = arg<<8 | uint64(c)
+		}
+		if major == majorSimple && info != 24 {
+			return 0, 0, errors.New("cbor: floating-point numbers are not supported")
+		}
+		return major, arg, nil
+	default:
+		return 0, 0, errors.New("cbor: indefinite-length values are not supported")
+	}
+}
+
+func (d *decoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return nil, ErrUnexpectedEnd
+	}
+	b := d.data[d.off : d.off+int(n)]
+	d.off += int(n)
+	return b, nil
+}
+
+// Marshal は v を CBOR に符号化します。
+// 扱える型は整数、[]byte、string、bool、nil、[]interface{}、map[interface{}]interface{}、map[string]interface{} です。
+// マップのキーは CTAP2 の正規の形式と同じく、符号化したキーの長さ、バイト列の順に並べます。
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(majorSimple<<5 | simpleNull)
+	case bool:
+		if v {
+			buf.WriteByte(majorSimple<<5 | simpleTrue)
+		} else {
+			buf.WriteByte(majorSimple<<5 | simpleFalse)
+		}
+	case int:
+		encodeInt(buf, int64(v))
+	case int64:
+		encodeInt(buf, v)
+	case uint64:
+		writeHead(buf, majorUnsigned, v)
+	case []byte:
+		writeHead(buf, majorBytes, uint64(len(v)))
+		buf.Write(v)
+	case string:
+		writeHead(buf, majorText, uint64(len(v)))
+		buf.WriteString(v)
+	case []interface{}:
+		writeHead(buf, majorArray, uint64(len(v)))
+		for _, e := range v {
+			if err := encode(buf, e); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, e := range v {
+			m[k] = e
+		}
+		return encode(buf, m)
+	case map[interface{}]interface{}:
+		return encodeMap(buf, v)
+	default:
+		return fmt.Errorf("cbor: unsupported type %T", v)
+	}
+	return nil
+}
+
+func encodeInt(buf *bytes.Buffer, v int64) {
+	if v < 0 {
+		writeHead(buf, majorNegative, uint64(-1-v))
+		return
+	}
+	writeHead(buf, majorUnsigned, uint64(v))
+}
+
+func encodeMap(buf *bytes.Buffer, m map[interface{}]interface{}) error {
+	type entry struct {
+		key   []byte
+		value interface{}
+	}
+
+	entries := make([]entry, 0, len(m))
+	for k, v := range m {
+		var kb bytes.Buffer
+		if err := encode(&kb, k); err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: kb.Bytes(), value: v})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].key) != len(entries[j].key) {
+			return len(entries[i].key) < len(entries[j].key)
+		}
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	writeHead(buf, majorMap, uint64(len(entries)))
+	for _, e := range entries {
+		buf.Write(e.key)
+		if err := encode(buf, e.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHead は値の先頭の型と引数を最も短い形式で書き込みます。
+func writeHead(buf *bytes.Buffer, major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		buf.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(arg))
+	case arg <= math.MaxUint16:
+		buf.WriteByte(major<<5 | 25)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(arg)))
+	case arg <= math.MaxUint32:
+		buf.WriteByte(major<<5 | 26)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(arg)))
+	default:
+		buf.WriteByte(major<<5 | 27)
+		buf.Write(binary.BigEndian.AppendUint64(nil, arg))
+	}
+}
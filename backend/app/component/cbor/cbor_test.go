@@ -0,0 +1,99 @@
+package cbor
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 8949 Appendix A の例のうち扱う型のもの
+func TestMarshal_RFC8949(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "0", value: int64(0), want: "00"},
+		{name: "23", value: int64(23), want: "17"},
+		{name: "24", value: int64(24), want: "1818"},
+		{name: "1000", value: int64(1000), want: "1903e8"},
+		{name: "1000000", value: int64(1000000), want: "1a000f4240"},
+		{name: "1000000000000", value: int64(1000000000000), want: "1b000000e8d4a51000"},
+		{name: "-1", value: int64(-1), want: "20"},
+		{name: "-1000", value: int64(-1000), want: "3903e7"},
+		{name: "false", value: false, want: "f4"},
+		{name: "true", value: true, want: "f5"},
+		{name: "null", value: nil, want: "f6"},
+		{name: "バイト列", value: []byte{1, 2, 3, 4}, want: "4401020304"},
+		{name: "文字列", value: "IETF", want: "6449455446"},
+		{name: "マルチバイトの文字列", value: "水", want: "63e6b0b4"},
+		{name: "配列", value: []interface{}{int64(1), []interface{}{int64(2), int64(3)}}, want: "8201820203"},
+		{name: "マップ", value: map[interface{}]interface{}{"a": int64(1), "b": []interface{}{int64(2), int64(3)}}, want: "a26161016162820203"},
+		{name: "整数のキーのマップ", value: map[interface{}]interface{}{int64(1): int64(2), int64(3): int64(4)}, want: "a201020304"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			b, err := Marshal(tt.value)
+			require.NoError(err)
+			assert.Equal(tt.want, hex.EncodeToString(b))
+
+			data, err := hex.DecodeString(tt.want)
+			require.NoError(err)
+			v, err := Unmarshal(data)
+			require.NoError(err)
+			assert.Equal(tt.value, v)
+		})
+	}
+}
+
+func TestMarshal_CanonicalMapOrder(t *testing.T) {
+	// COSE の鍵と同じく、符号化したキーの短い順、同じ長さはバイト列の順に並べる
+	b, err := Marshal(map[interface{}]interface{}{int64(-2): "x", int64(3): "alg", int64(1): "kty", int64(-1): "crv"})
+	require.NoError(t, err)
+	assert.Equal(t, "a401636b74790363616c672063637276216178", hex.EncodeToString(b))
+}
+
+func TestUnmarshalPrefix(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	v, rest, err := UnmarshalPrefix([]byte{0x01, 0x02, 0x03})
+	require.NoError(err)
+	assert.Equal(int64(1), v)
+	assert.Equal([]byte{0x02, 0x03}, rest)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "空", data: ""},
+		{name: "途中で終わるバイト列", data: "4401"},
+		{name: "途中で終わる配列", data: "8301"},
+		{name: "長すぎる長さ", data: "5bffffffffffffffff"},
+		{name: "余分なデータ", data: "0101"},
+		{name: "長さが不定の配列", data: "9f01ff"},
+		{name: "タグ", data: "c11a514b67b0"},
+		{name: "浮動小数点数", data: "f93c00"},
+		{name: "重複したキー", data: "a201020103"},
+		{name: "バイト列のキー", data: "a1410101"},
+		{name: "int64 を超える整数", data: "1bffffffffffffffff"},
+		{name: "深すぎる入れ子", data: "818181818181818181818181818181818181818100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			require.NoError(t, err)
+			_, err = Unmarshal(data)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package i18n
+
+// englishCatalog は日本語のメッセージから英語への翻訳です。
+// 書式を含むメッセージは同じ順序と種類の書式で翻訳します。
+var englishCatalog = map[string]string{
+	// usecase のメッセージ
+	"サーバーエラーが発生しました。再試行してください。再試行しても解決しない場合は、管理者にお問い合わせください。": "A server error occurred. Please try again. If the problem persists, contact the administrator.",
+	"メールアドレスまたはパスワードが間違っています":                                 "The email address or password is incorrect.",
+	"入力されたメールアドレスはすでに登録されています":                                "The email address is already registered.",
+	"入力されたメールアドレスは登録されていません":                                  "The email address is not registered.",
+	"トークンが無効もしくは期限切れです":                                       "The token is invalid or has expired.",
+	"指定されたスケジュールは存在しません":                                      "The schedule does not exist.",
+	"%sの形式が正しくありません":                                          "Invalid format: %s",
+	"ログインしてください":                                              "Please sign in.",
+	"ユーザーが見つかりません":                                            "The user was not found.",
+	"リクエストの形式が正しくありません":                                       "The request format is invalid.",
+	"新しいメールアドレスが現在と同じです":                                      "The new email address is the same as the current one.",
+	"指定された受講できない期間は存在しません":                                    "The blackout period does not exist.",
+	"講義回の順番が逆転するため変更できません（%s）":                                "The change would reverse the lecture order (%s).",
+	"指定されたタグは存在しません":                                          "The tag does not exist.",
+	"同じ名前のタグがすでに存在します":                                        "A tag with the same name already exists.",
+	"指定された色は存在しません":                                           "The color does not exist.",
+	"ユーザーが定義できる色は%d件までです":                                     "You can define up to %d custom colors.",
+	"スケジュールまたは科目で使われている色は削除できません":                             "A color used by a schedule or subject cannot be deleted.",
+	"指定されたメールは存在しません":                                         "The email does not exist.",
+	"送信に失敗したメールのみ再送できます":                                      "Only emails that failed to send can be resent.",
+	"サインインの失敗が続いたため、しばらく時間をおいてから再試行してください":                    "Too many failed sign-in attempts. Please wait a while and try again.",
+	"メールを送信したばかりです。しばらく時間をおいてから再試行してください":                     "An email was sent just now. Please wait a while and try again.",
+	"二段階認証はすでに有効です":                                           "Two-factor authentication is already enabled.",
+	"二段階認証の設定を開始してください":                                       "Start setting up two-factor authentication first.",
+	"認証コードが正しくありません":                                          "The authentication code is incorrect.",
+	"認証コードを入力してください":                                          "Enter the authentication code.",
+	"パスキーの確認の有効期限が切れました。もう一度やり直してください":                        "The passkey request has expired. Please try again.",
+	"パスキーを確認できませんでした":                                         "The passkey could not be verified.",
+	"このパスキーはすでに登録されています":                                      "This passkey is already registered.",
+	"指定されたパスキーは存在しません":                                        "The passkey does not exist.",
+	"登録できるパスキーは%d件までです":                                       "You can register up to %d passkeys.",
+	"サインインのリンクはリクエストしたブラウザで開いてください":                           "Open the sign-in link in the same browser you requested it from.",
+	"指定された ID プロバイダーは存在しません":                                  "The identity provider does not exist.",
+	"サインインの有効期限が切れました。もう一度やり直してください":                          "The sign-in request has expired. Please try again.",
+	"外部アカウントでのサインインに失敗しました":                                   "Sign-in with the external account failed.",
+	"外部アカウントのメールアドレスが確認されていません":                               "The email address of the external account is not verified.",
+	"このメールアドレスのドメインではサインインできません":                              "You cannot sign in with an email address in this domain.",
+	"指定されたセッションは存在しません":                                       "The session does not exist.",
+	"開始日":    "start date",
+	"終了日":    "end date",
+	"%sの開始日": "start date of %s",
+	"%sの終了日": "end date of %s",
+
+	// 講義回の順番と受講の上限の警告
+	"「%s」が「%s」より前に予定されています":       "\"%s\" is scheduled before \"%s\".",
+	"「%s」が「%s」（%s）より後に予定されています":   "\"%s\" is scheduled after \"%s\" (%s).",
+	"「%s」が受講しない曜日（%s曜日）に予定されています": "\"%s\" is scheduled on a day you do not attend (%s).",
+	"「%s」が受講できない期間「%s」に予定されています":  "\"%s\" is scheduled during the blackout period \"%s\".",
+	"%s に%d件の受講が予定されています（上限%d件）":  "%s has %d lectures scheduled (limit %d).",
+	"日": "Sun",
+	"月": "Mon",
+	"火": "Tue",
+	"水": "Wed",
+	"木": "Thu",
+	"金": "Fri",
+	"土": "Sat",
+
+	// model の不変条件
+	"パスワードは%d～%d文字以内にしてください":                       "The password must be %d to %d characters long.",
+	"パスワードは大文字の英字が1文字以上必要です":                       "The password must contain at least one uppercase letter.",
+	"パスワードは小文字の英字が1文字以上必要です":                       "The password must contain at least one lowercase letter.",
+	"パスワードは数字が1文字以上必要です":                           "The password must contain at least one digit.",
+	"パスワードは記号が1文字以上必要です":                           "The password must contain at least one symbol.",
+	"スケジュール名を入力してください":                             "Please enter a schedule name.",
+	"スケジュール名は%d文字以内で入力してください":                      "The schedule name must be %d characters or fewer.",
+	"開始日を入力してください":                                 "Please enter a start date.",
+	"終了日を入力してください":                                 "Please enter an end date.",
+	"終了日は開始日以降の日付を入力してください":                        "The end date must be on or after the start date.",
+	"時間指定のスケジュールは終了日時を開始日時より後にしてください":              "For a timed schedule, the end time must be after the start time.",
+	"色を指定してください":                                   "Please specify a color.",
+	"色はパレットの色またはユーザーが定義した色を指定してください":               "The color must be a palette color or a custom color.",
+	"スケジュールの種類を指定してください":                           "Please specify a schedule type.",
+	"スケジュールの種類は %s または %s を指定してください":               "The schedule type must be %s or %s.",
+	"講義回は1～%dで指定してください":                            "The lecture number must be between 1 and %d.",
+	"ノートは%d文字以内で入力してください":                          "The note must be %d characters or fewer.",
+	"メモは%d文字以内で入力してください":                           "The memo must be %d characters or fewer.",
+	"リンクは%d件以内で指定してください":                           "You can specify up to %d links.",
+	"リンクは%d文字以内で入力してください":                          "Each link must be %d characters or fewer.",
+	"リンクは http または https の URL を入力してください":          "Each link must be an http or https URL.",
+	"タグは%d件以内で指定してください":                            "You can specify up to %d tags.",
+	"タグIDを指定してください":                                "Please specify a tag ID.",
+	"科目名を入力してください":                                 "Please enter a subject name.",
+	"科目名は%d文字以内で入力してください":                          "The subject name must be %d characters or fewer.",
+	"メールアドレスを入力してください":                             "Please enter an email address.",
+	"メールアドレスの形式が正しくありません":                          "The email address format is invalid.",
+	"名前は%d文字以内で入力してください":                           "The name must be %d characters or fewer.",
+	"タイムゾーンは IANA タイムゾーン名（例: Asia/Tokyo）で指定してください": "The timezone must be an IANA timezone name (e.g. Asia/Tokyo).",
+	"言語は %s または %s を指定してください":                      "The language must be %s or %s.",
+
+	// request のバリデーション
+	"ユーザーIDが指定されていません":                                    "The user ID is not specified.",
+	"ユーザーIDを指定してください":                                     "Please specify a user ID.",
+	"スケジュールIDを指定してください":                                   "Please specify a schedule ID.",
+	"スケジュールを指定してください":                                     "Please specify schedules.",
+	"スケジュールは%d件以内で指定してください":                               "You can specify up to %d schedules.",
+	"開始日は yyyy-MM-dd の形式で入力してください":                        "The start date must be in yyyy-MM-dd format.",
+	"終了日は yyyy-MM-dd の形式で入力してください":                        "The end date must be in yyyy-MM-dd format.",
+	"開始日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください":  "The start date must be in yyyy-MM-dd HH:mm:ss or RFC 3339 format.",
+	"終了日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください":  "The end date must be in yyyy-MM-dd HH:mm:ss or RFC 3339 format.",
+	"更新日時は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください": "The updated time must be in yyyy-MM-dd HH:mm:ss or RFC 3339 format.",
+	"出力形式は %s または %s を指定してください":                           "The output format must be %s or %s.",
+	"項目 %s は選択できません":                                      "The field %s cannot be selected.",
+	"講義回の順番の確認は %s、%s、%s のいずれかを指定してください":                  "The lecture order check must be one of %s, %s or %s.",
+	"完了状態は true または false を指定してください":                      "The completion state must be true or false.",
+	"タグを付けるまたは外すスケジュールを指定してください":                          "Please specify the schedules to tag or untag.",
+	"タグ名を入力してください":                                        "Please enter a tag name.",
+	"タグ名は%d文字以内で入力してください":                                 "The tag name must be %d characters or fewer.",
+	"統合先のタグIDを指定してください":                                   "Please specify the tag ID to merge into.",
+	"統合先には統合元と異なるタグを指定してください":                             "The tag to merge into must differ from the source tag.",
+	"科目IDを指定してください":                                       "Please specify a subject ID.",
+	"受講できない期間のIDを指定してください":                                "Please specify a blackout period ID.",
+	"ラベルを入力してください":                                        "Please enter a label.",
+	"ラベルは%d文字以内で入力してください":                                 "The label must be %d characters or fewer.",
+	"1日あたりの受講上限は0～%d件で指定してください":                           "The daily lecture limit must be between 0 and %d.",
+	"曜日は %d～%d の範囲で指定してください":                              "Weekdays must be between %d and %d.",
+	"曜日が重複しています":                                          "Weekdays are duplicated.",
+	"検索語を入力してください":                                        "Please enter a search term.",
+	"検索語は%d文字以内で入力してください":                                 "The search term must be %d characters or fewer.",
+	"色IDを指定してください":                                        "Please specify a color ID.",
+	"色の名前を入力してください":                                       "Please enter a color name.",
+	"色の名前は%d文字以内で入力してください":                                "The color name must be %d characters or fewer.",
+	"色コードを入力してください":                                       "Please enter a color code.",
+	"色コードは #rrggbb の形式で入力してください":                          "The color code must be in #rrggbb format.",
+	"表示方法は %s または %s を指定してください":                           "The style must be %s or %s.",
+	"パスワードを入力してください":                                      "Please enter a password.",
+	"リフレッシュトークンが指定されていません":                                "The refresh token is not specified.",
+	"セッションIDが指定されていません":                                   "The session ID is not specified.",
+	"トークンが指定されていません":                                      "The token is not specified.",
+	"メールIDを指定してください":                                      "Please specify an email ID.",
+	"パスキーIDが指定されていません":                                    "The passkey ID is not specified.",
+	"パスキーの名前は%d文字以内で入力してください":                             "The passkey name must be %d characters or fewer.",
+	"パスキーの応答が指定されていません":                                   "The passkey response is not specified.",
+	"nonce が指定されていません":                                    "The nonce is not specified.",
+	"IDプロバイダーが指定されていません":                                  "The identity provider is not specified.",
+	"state が指定されていません":                                    "The state is not specified.",
+	"認可コードが指定されていません":                                     "The authorization code is not specified.",
+}
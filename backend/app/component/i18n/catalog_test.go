@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// messageDirs はユーザー向けのメッセージを定義しているパッケージです。
+var messageDirs = []string{
+	filepath.Join("..", "..", "model"),
+	filepath.Join("..", "..", "request"),
+	filepath.Join("..", "..", "usecase"),
+}
+
+// nonMessageFiles はメッセージではない日本語のデータを定義しているファイルです。
+var nonMessageFiles = map[string]bool{
+	"color.go":   true, // パレットの色の表示名
+	"lecture.go": true, // 講義名の区切り文字
+	"stub.go":    true, // テスト用のデータ
+}
+
+// nonMessages はメッセージではない日本語の文字列です。
+var nonMessages = map[string]bool{
+	"単位認定試験": true, // 学事スケジュールの名前に含まれるキーワード
+	"受講計画":   true, // iCalendar のカレンダー名
+	"パスキー":   true, // 名前を指定せずに登録したパスキーの名前
+}
+
+var verbPattern = regexp.MustCompile(`%[sd]`)
+
+func TestEnglishCatalog_Verbs(t *testing.T) {
+	for ja, en := range englishCatalog {
+		assert.Equal(t, verbPattern.FindAllString(ja, -1), verbPattern.FindAllString(en, -1), ja)
+	}
+}
+
+// TestEnglishCatalog_Coverage はユーザー向けの日本語のメッセージがすべて英語のカタログにあることを確認します。
+// 文字列の連結の一部はカタログの書式で翻訳するため対象外です。
+func TestEnglishCatalog_Coverage(t *testing.T) {
+	fset := token.NewFileSet()
+	count := 0
+
+	for _, dir := range messageDirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		require.NoError(t, err)
+
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") || nonMessageFiles[filepath.Base(file)] {
+				continue
+			}
+
+			f, err := parser.ParseFile(fset, file, nil, 0)
+			require.NoError(t, err)
+
+			operands := map[*ast.BasicLit]bool{}
+			ast.Inspect(f, func(n ast.Node) bool {
+				if be, ok := n.(*ast.BinaryExpr); ok && be.Op == token.ADD {
+					for _, e := range []ast.Expr{be.X, be.Y} {
+						if lit, ok := e.(*ast.BasicLit); ok {
+							operands[lit] = true
+						}
+					}
+				}
+				return true
+			})
+
+			ast.Inspect(f, func(n ast.Node) bool {
+				lit, ok := n.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING || operands[lit] {
+					return true
+				}
+
+				s, err := strconv.Unquote(lit.Value)
+				if err != nil || !containsJapanese(s) || nonMessages[s] {
+					return true
+				}
+
+				count++
+				_, ok = englishCatalog[s]
+				assert.True(t, ok, "英語のカタログにないメッセージ %q: %s", s, fset.Position(lit.Pos()))
+				return true
+			})
+		}
+	}
+
+	assert.NotZero(t, count)
+}
+
+// TestEnglishCatalog_Weekdays は警告のメッセージに埋め込む曜日の表記がすべて英語のカタログにあることを確認します。
+func TestEnglishCatalog_Weekdays(t *testing.T) {
+	for _, wd := range []string{"日", "月", "火", "水", "木", "金", "土"} {
+		assert.NotEqual(t, wd, Translate(English, wd), wd)
+	}
+}
+
+func containsJapanese(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+// Package i18n はユーザー向けのメッセージの翻訳を提供します。
+// メッセージは日本語の文言をそのままキーとし、日本語以外の言語はメッセージカタログで翻訳します。
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Locale は言語を表す型です。
+type Locale string
+
+const (
+	// Japanese は日本語です。メッセージの原文の言語です。
+	Japanese Locale = "ja"
+	// English は英語です。
+	English Locale = "en"
+)
+
+// Default は言語が指定されていない場合に適用する言語です。
+const Default = Japanese
+
+// catalogs は言語ごとのメッセージカタログです。原文の日本語は含めません。
+var catalogs = map[Locale]map[string]string{
+	English: englishCatalog,
+}
+
+// Supported は対応している言語を返します。
+func Supported() []Locale {
+	return []Locale{Japanese, English}
+}
+
+// Valid は対応している言語かどうかを返します。
+func (l Locale) Valid() bool {
+	for _, s := range Supported() {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+// String は言語を文字列で返します。
+func (l Locale) String() string {
+	return string(l)
+}
+
+// Parse は en-US のような言語タグから対応している言語を返します。
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	l := Locale(tag)
+	return l, l.Valid()
+}
+
+// ParseAcceptLanguage は Accept-Language ヘッダーから品質値の高い順に対応している言語を探します。
+// 対応している言語がない場合は空文字を返します。
+func ParseAcceptLanguage(header string) Locale {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		l, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			v, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{locale: l, quality: quality})
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].locale
+}
+
+// Resolve は適用する言語を返します。
+// ユーザーが設定した言語を優先し、設定がない場合は Accept-Language、どちらもない場合は Default を返します。
+func Resolve(preference string, acceptLanguage string) Locale {
+	if l, ok := Parse(preference); ok {
+		return l
+	}
+	if l := ParseAcceptLanguage(acceptLanguage); l != "" {
+		return l
+	}
+	return Default
+}
+
+// Translate はメッセージを指定された言語に翻訳します。
+// カタログにない場合や日本語の場合は原文を返します。
+// 書式を埋め込んだメッセージは書式の形で照合し、埋め込まれた文字列も翻訳します。
+func Translate(locale Locale, message string) string {
+	catalog, ok := catalogs[locale]
+	if !ok || message == "" {
+		return message
+	}
+
+	if t, ok := catalog[message]; ok {
+		return t
+	}
+
+	for _, tmpl := range templatesOf(locale) {
+		m := tmpl.pattern.FindStringSubmatch(message)
+		if m == nil {
+			continue
+		}
+
+		args := make([]any, 0, len(tmpl.verbs))
+		for i, verb := range tmpl.verbs {
+			s := m[i+1]
+			if verb == 'd' {
+				n, err := strconv.Atoi(s)
+				if err != nil {
+					return message
+				}
+				args = append(args, n)
+				continue
+			}
+			args = append(args, Translate(locale, s))
+		}
+		return fmt.Sprintf(tmpl.translation, args...)
+	}
+
+	return message
+}
+
+// Sprintf は書式を指定された言語に翻訳してからメッセージを組み立てます。
+// 埋め込む値は翻訳しないため、翻訳が必要な値は呼び出し側で Translate してから渡します。
+func Sprintf(locale Locale, format string, args ...any) string {
+	return fmt.Sprintf(Translate(locale, format), args...)
+}
+
+// template は書式を含むメッセージの照合に使う構造体です。
+type template struct {
+	pattern     *regexp.Regexp
+	verbs       []byte
+	translation string
+}
+
+var (
+	templatesMu    sync.Mutex
+	templatesCache = map[Locale][]template{}
+)
+
+// templatesOf は言語ごとの書式を含むメッセージを、長い書式から順に返します。
+func templatesOf(locale Locale) []template {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+
+	if ts, ok := templatesCache[locale]; ok {
+		return ts
+	}
+
+	keys := make([]string, 0, len(catalogs[locale]))
+	for k := range catalogs[locale] {
+		if strings.Contains(k, "%") {
+			keys = append(keys, k)
+		}
+	}
+	// 固定の文言が長い書式ほど具体的なため先に照合する
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	ts := make([]template, 0, len(keys))
+	for _, k := range keys {
+		pattern, verbs := compileTemplate(k)
+		ts = append(ts, template{pattern: pattern, verbs: verbs, translation: catalogs[locale][k]})
+	}
+	templatesCache[locale] = ts
+
+	return ts
+}
+
+// compileTemplate は %s と %d を含む書式を正規表現に変換します。
+func compileTemplate(format string) (*regexp.Regexp, []byte) {
+	var b strings.Builder
+	var verbs []byte
+
+	b.WriteString("^")
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			switch format[i+1] {
+			case 's':
+				b.WriteString("(.+?)")
+				verbs = append(verbs, 's')
+				i++
+				continue
+			case 'd':
+				b.WriteString(`(-?\d+)`)
+				verbs = append(verbs, 'd')
+				i++
+				continue
+			}
+		}
+		b.WriteString(regexp.QuoteMeta(format[i : i+1]))
+	}
+	b.WriteString("$")
+
+	return regexp.MustCompile(b.String()), verbs
+}
@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		tag    string
+		want   Locale
+		wantOK bool
+	}{
+		{name: "言語のみ", tag: "en", want: English, wantOK: true},
+		{name: "地域を含む", tag: "en-US", want: English, wantOK: true},
+		{name: "大文字と下線", tag: "JA_jp", want: Japanese, wantOK: true},
+		{name: "対応していない言語", tag: "fr", want: "fr", wantOK: false},
+		{name: "空文字", tag: "", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.tag)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   Locale
+	}{
+		{name: "先頭の言語", header: "en-US,en;q=0.9,ja;q=0.8", want: English},
+		{name: "品質値の高い言語", header: "ja;q=0.5,en;q=0.8", want: English},
+		{name: "対応していない言語を飛ばす", header: "fr-FR,fr;q=0.9,ja;q=0.7", want: Japanese},
+		{name: "品質値が0の言語は使わない", header: "en;q=0", want: ""},
+		{name: "対応している言語がない", header: "fr,de", want: ""},
+		{name: "空文字", header: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		preference     string
+		acceptLanguage string
+		want           Locale
+	}{
+		{name: "ユーザーの設定を優先する", preference: "ja", acceptLanguage: "en", want: Japanese},
+		{name: "設定がない場合は Accept-Language", acceptLanguage: "en-GB", want: English},
+		{name: "どちらもない場合は既定の言語", want: Default},
+		{name: "設定が対応していない言語の場合は Accept-Language", preference: "fr", acceptLanguage: "en", want: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Resolve(tt.preference, tt.acceptLanguage))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  Locale
+		message string
+		want    string
+	}{
+		{name: "日本語は原文のまま", locale: Japanese, message: "ログインしてください", want: "ログインしてください"},
+		{name: "固定の文言", locale: English, message: "ログインしてください", want: "Please sign in."},
+		{name: "数値を埋め込んだ文言", locale: English, message: "スケジュール名は50文字以内で入力してください", want: "The schedule name must be 50 characters or fewer."},
+		{name: "複数の数値を埋め込んだ文言", locale: English, message: "パスワードは8～64文字以内にしてください", want: "The password must be 8 to 64 characters long."},
+		{name: "埋め込んだ文字列も翻訳する", locale: English, message: "統計学の開始日の形式が正しくありません", want: "Invalid format: start date of 統計学"},
+		{name: "カタログにない文言は原文のまま", locale: English, message: "未登録の文言", want: "未登録の文言"},
+		{name: "対応していない言語は原文のまま", locale: "fr", message: "ログインしてください", want: "ログインしてください"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Translate(tt.locale, tt.message))
+		})
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		want   string
+	}{
+		{name: "日本語は原文の書式で組み立てる", locale: Japanese, want: "2024-07-01 に7件の受講が予定されています（上限6件）"},
+		{name: "書式を翻訳してから組み立てる", locale: English, want: "2024-07-01 has 7 lectures scheduled (limit 6)."},
+		{name: "言語が空の場合は原文の書式で組み立てる", locale: "", want: "2024-07-01 に7件の受講が予定されています（上限6件）"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sprintf(tt.locale, "%s に%d件の受講が予定されています（上限%d件）", "2024-07-01", 7, 6))
+		})
+	}
+}
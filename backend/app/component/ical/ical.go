@@ -0,0 +1,93 @@
+// Package ical は RFC 5545 の iCalendar 形式のカレンダーを生成します。
+package ical
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+
+	// maxLineOctets は折り返す前の 1 行の最大オクテット数です。
+	maxLineOctets = 75
+)
+
+// Event はカレンダーの予定を表す構造体です。
+// AllDay が true の場合、Start と End は loc のタイムゾーンの日付のみを扱い、End の日を含みます。
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Stamp       time.Time
+}
+
+// Calendar はカレンダーを表す構造体です。
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// String はカレンダーを iCalendar 形式の文字列に変換します。
+// 終日の予定は日付で、時間指定の予定は UTC の日時で DTSTART と DTEND を出力します。
+// 終日の予定の DTEND は RFC 5545 に従い最終日の翌日とします。
+func (c Calendar) String(loc *time.Location) string {
+	var b strings.Builder
+
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+c.ProdID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+e.Stamp.UTC().Format(dateTimeFormat))
+		if e.AllDay {
+			writeLine(&b, "DTSTART;VALUE=DATE:"+e.Start.In(loc).Format(dateFormat))
+			writeLine(&b, "DTEND;VALUE=DATE:"+e.End.In(loc).AddDate(0, 0, 1).Format(dateFormat))
+		} else {
+			writeLine(&b, "DTSTART:"+e.Start.UTC().Format(dateTimeFormat))
+			writeLine(&b, "DTEND:"+e.End.UTC().Format(dateTimeFormat))
+		}
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+
+	return b.String()
+}
+
+// escapeText は TEXT 型の値に含まれる特殊文字をエスケープします。
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// writeLine は 1 行を CRLF で終端して書き込みます。
+// 75 オクテットを超える行は、マルチバイト文字の途中で分割しないように折り返します。
+func writeLine(b *strings.Builder, line string) {
+	octets := 0
+	for _, r := range line {
+		n := len(string(r))
+		if octets+n > maxLineOctets {
+			b.WriteString("\r\n ")
+			octets = 1
+		}
+		b.WriteRune(r)
+		octets += n
+	}
+	b.WriteString("\r\n")
+}
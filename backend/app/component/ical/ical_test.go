@@ -0,0 +1,80 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_String(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	stamp := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event Event
+		want  []string
+	}{
+		{
+			name: "終日の予定は日付で出力し DTEND は翌日とする",
+			event: Event{
+				UID:     "id-1",
+				Summary: "統計学 第1回",
+				Start:   time.Date(2024, 5, 31, 15, 0, 0, 0, time.UTC),
+				End:     time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC),
+				AllDay:  true,
+				Stamp:   stamp,
+			},
+			want: []string{"DTSTART;VALUE=DATE:20240601", "DTEND;VALUE=DATE:20240603"},
+		},
+		{
+			name: "時間指定の予定は UTC の日時で出力する",
+			event: Event{
+				UID:     "id-2",
+				Summary: "自習",
+				Start:   time.Date(2024, 6, 1, 20, 0, 0, 0, tokyo),
+				End:     time.Date(2024, 6, 1, 21, 30, 0, 0, tokyo),
+				Stamp:   stamp,
+			},
+			want: []string{"DTSTART:20240601T110000Z", "DTEND:20240601T123000Z"},
+		},
+		{
+			name: "テキストの特殊文字をエスケープする",
+			event: Event{
+				UID:         "id-3",
+				Summary:     "課題; 提出, 確認",
+				Description: "1行目\n2行目",
+				Start:       stamp,
+				End:         stamp,
+				AllDay:      true,
+				Stamp:       stamp,
+			},
+			want: []string{`SUMMARY:課題\; 提出\, 確認`, `DESCRIPTION:1行目\n2行目`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Calendar{ProdID: "-//test//JA", Events: []Event{tt.event}}.String(tokyo)
+
+			lines := strings.Split(got, "\r\n")
+			for _, w := range tt.want {
+				assert.Contains(t, lines, w)
+			}
+			assert.True(t, strings.HasPrefix(got, "BEGIN:VCALENDAR\r\n"))
+			assert.True(t, strings.HasSuffix(got, "END:VCALENDAR\r\n"))
+		})
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "SUMMARY:"+strings.Repeat("あ", 30))
+
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("あ", 30), strings.ReplaceAll(strings.TrimSuffix(b.String(), "\r\n"), "\r\n ", ""))
+}
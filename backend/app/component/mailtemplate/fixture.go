@@ -0,0 +1,27 @@
+package mailtemplate
+
+// Fixtures はテンプレートごとのプレビューとスナップショットテストに使うデータを返します。
+func Fixtures() []Data {
+	return []Data{
+		PasswordSetData{
+			ServiceName: "受講計画",
+			URL:         "https://example.com/password/set?token=sample-token",
+		},
+		PasswordResetData{
+			ServiceName: "受講計画",
+			URL:         "https://example.com/password/set?token=sample-token",
+		},
+		EmailChangeData{
+			ServiceName: "受講計画",
+			URL:         "https://example.com/email/set?token=sample-token",
+		},
+		AccountUnlockData{
+			ServiceName: "受講計画",
+			URL:         "https://example.com/unlock?token=sample-token",
+		},
+		MagicLinkData{
+			ServiceName: "受講計画",
+			URL:         "https://example.com/signin/link?token=sample-token",
+		},
+	}
+}
@@ -0,0 +1,172 @@
+// Package mailtemplate はトランザクションメールのテンプレートを提供します。
+// テンプレートは名前ごとに件名、テキスト、HTML を言語別に持ち、バイナリに埋め込みます。
+package mailtemplate
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Name はテンプレートの名前を表す型です。
+type Name string
+
+const (
+	// NamePasswordSet はサインアップ時のパスワード設定のメールです。
+	NamePasswordSet Name = "password_set"
+	// NamePasswordReset はパスワードリセットのメールです。
+	NamePasswordReset Name = "password_reset"
+	// NameEmailChange はメールアドレス変更のメールです。
+	NameEmailChange Name = "email_change"
+	// NameAccountUnlock はサインインの失敗によるロックを解除するメールです。
+	NameAccountUnlock Name = "account_unlock"
+	// NameMagicLink はパスワードを使わずにサインインするリンクのメールです。
+	NameMagicLink Name = "magic_link"
+)
+
+// Names はすべてのテンプレートの名前を返します。
+func Names() []Name {
+	return []Name{NamePasswordSet, NamePasswordReset, NameEmailChange, NameAccountUnlock, NameMagicLink}
+}
+
+// Data はテンプレートに埋め込むデータを表すインターフェースです。
+// データの型ごとに使うテンプレートが決まります。
+type Data interface {
+	TemplateName() Name
+}
+
+// PasswordSetData はパスワード設定のメールのデータを表す構造体です。
+type PasswordSetData struct {
+	ServiceName string
+	URL         string
+}
+
+// TemplateName はテンプレートの名前を返します。
+func (PasswordSetData) TemplateName() Name { return NamePasswordSet }
+
+// PasswordResetData はパスワードリセットのメールのデータを表す構造体です。
+type PasswordResetData struct {
+	ServiceName string
+	URL         string
+}
+
+// TemplateName はテンプレートの名前を返します。
+func (PasswordResetData) TemplateName() Name { return NamePasswordReset }
+
+// EmailChangeData はメールアドレス変更のメールのデータを表す構造体です。
+type EmailChangeData struct {
+	ServiceName string
+	URL         string
+}
+
+// TemplateName はテンプレートの名前を返します。
+func (EmailChangeData) TemplateName() Name { return NameEmailChange }
+
+// AccountUnlockData はロックを解除するメールのデータを表す構造体です。
+type AccountUnlockData struct {
+	ServiceName string
+	URL         string
+}
+
+// TemplateName はテンプレートの名前を返します。
+func (AccountUnlockData) TemplateName() Name { return NameAccountUnlock }
+
+// MagicLinkData はサインインのリンクのメールのデータを表す構造体です。
+type MagicLinkData struct {
+	ServiceName string
+	URL         string
+}
+
+// TemplateName はテンプレートの名前を返します。
+func (MagicLinkData) TemplateName() Name { return NameMagicLink }
+
+// Message はレンダリングしたメールを表す構造体です。
+// Text と HTML は同じ内容の代替表現で、multipart/alternative として送信します。
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+const layoutPath = "templates/layout.html.tmpl"
+
+// Render は指定された言語でテンプレートをレンダリングします。
+// 言語のテンプレートがない場合は既定の言語のテンプレートを使います。
+func Render(locale i18n.Locale, data Data) (Message, error) {
+	name := data.TemplateName()
+	locale = templateLocale(name, locale)
+
+	subject, err := renderText(templatePath(name, locale, "subject"), data)
+	if err != nil {
+		return Message{}, err
+	}
+	subject = strings.TrimSpace(subject)
+
+	text, err := renderText(templatePath(name, locale, "txt"), data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	html, err := renderHTML(templatePath(name, locale, "html"), locale, subject, data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{Subject: subject, Text: text, HTML: html}, nil
+}
+
+// templateLocale はテンプレートがある言語を返します。
+func templateLocale(name Name, locale i18n.Locale) i18n.Locale {
+	if _, err := fs.Stat(templateFS, templatePath(name, locale, "subject")); err == nil {
+		return locale
+	}
+	return i18n.Default
+}
+
+func templatePath(name Name, locale i18n.Locale, kind string) string {
+	return fmt.Sprintf("templates/%s/%s.%s.tmpl", name, locale, kind)
+}
+
+func renderText(path string, data Data) (string, error) {
+	t, err := texttemplate.New("").Option("missingkey=error").ParseFS(templateFS, path)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := t.ExecuteTemplate(&b, pathBase(path), data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func renderHTML(path string, locale i18n.Locale, subject string, data Data) (string, error) {
+	funcs := htmltemplate.FuncMap{
+		"lang":    func() string { return locale.String() },
+		"subject": func() string { return subject },
+	}
+
+	t, err := htmltemplate.New("").Funcs(funcs).Option("missingkey=error").ParseFS(templateFS, layoutPath, path)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := t.ExecuteTemplate(&b, "layout", data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func pathBase(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
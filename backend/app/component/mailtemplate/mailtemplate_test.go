@@ -0,0 +1,84 @@
+package mailtemplate
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update を指定するとスナップショットを現在のレンダリング結果で更新します。
+//
+//	go test ./app/component/mailtemplate -update
+var update = flag.Bool("update", false, "スナップショットを更新する")
+
+func TestFixtures(t *testing.T) {
+	names := map[Name]bool{}
+	for _, d := range Fixtures() {
+		names[d.TemplateName()] = true
+	}
+
+	for _, name := range Names() {
+		assert.True(t, names[name], "フィクスチャのないテンプレート: %s", name)
+	}
+}
+
+// TestRender_Snapshot はすべてのテンプレートと言語のレンダリング結果をスナップショットと比較します。
+func TestRender_Snapshot(t *testing.T) {
+	for _, data := range Fixtures() {
+		for _, locale := range i18n.Supported() {
+			t.Run(fmt.Sprintf("%s/%s", data.TemplateName(), locale), func(t *testing.T) {
+				require := require.New(t)
+
+				msg, err := Render(locale, data)
+				require.NoError(err)
+				require.NotEmpty(msg.Subject)
+				require.NotEmpty(msg.Text)
+				require.NotEmpty(msg.HTML)
+
+				parts := map[string]string{
+					"subject.txt": msg.Subject + "\n",
+					"txt":         msg.Text,
+					"html":        msg.HTML,
+				}
+				for ext, got := range parts {
+					path := filepath.Join("testdata", fmt.Sprintf("%s.%s.%s", data.TemplateName(), locale, ext))
+					if *update {
+						require.NoError(os.WriteFile(path, []byte(got), 0o644))
+						continue
+					}
+
+					want, err := os.ReadFile(path)
+					require.NoError(err, "スナップショットがありません。-update を指定して作成してください")
+					assert.Equal(t, string(want), got, path)
+				}
+			})
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Run("HTML では埋め込む値をエスケープする", func(t *testing.T) {
+		assert := assert.New(t)
+
+		msg, err := Render(i18n.Japanese, PasswordSetData{ServiceName: "<b>受講計画</b>", URL: "javascript:alert(1)"})
+		assert.NoError(err)
+		assert.Contains(msg.HTML, "&lt;b&gt;受講計画&lt;/b&gt;")
+		assert.NotContains(msg.HTML, `href="javascript:`)
+		assert.Contains(msg.Text, "<b>受講計画</b>")
+	})
+
+	t.Run("テンプレートのない言語は既定の言語で表示する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		msg, err := Render("fr", PasswordSetData{ServiceName: "受講計画", URL: "https://example.com"})
+		assert.NoError(err)
+		assert.Equal("パスワードを設定してください | 受講計画", msg.Subject)
+		assert.Contains(msg.HTML, `<html lang="ja">`)
+	})
+}
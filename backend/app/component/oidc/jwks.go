@@ -0,0 +1,196 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// supportedAlgorithms は ID トークンの署名として受け付けるアルゴリズムです。HS256 などの共通鍵の署名は受け付けません。
+var supportedAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}
+
+// jwksRefreshInterval は kid が見つからない場合に JWKS を取得し直す最短の間隔です。
+// 存在しない kid のトークンでプロバイダーへのリクエストを増やされないようにします。
+const jwksRefreshInterval = time.Minute
+
+var (
+	// metadataCache は発行者ごとのディスカバリーの情報のキャッシュです。
+	metadataCache sync.Map
+	// jwksCache は JWKS の URL ごとの公開鍵のキャッシュです。
+	jwksCache sync.Map
+)
+
+// jsonWebKey は JWKS の鍵を表す構造体です。
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+// publicKey は署名の検証に使う公開鍵を表す構造体です。
+type publicKey struct {
+	kid       string
+	algorithm string
+	key       any
+}
+
+// accepts は鍵で alg のアルゴリズムの署名を検証できるかどうかを返します。
+// 鍵がアルゴリズムを指定している場合は一致するもののみ、指定していない場合は鍵の種類に合うものを受け付けます。
+func (k publicKey) accepts(alg string) bool {
+	if k.algorithm != "" {
+		return k.algorithm == alg
+	}
+
+	switch k.key.(type) {
+	case *rsa.PublicKey:
+		return alg == "RS256" || alg == "RS384" || alg == "RS512" || alg == "PS256"
+	case *ecdsa.PublicKey:
+		return alg == "ES256" || alg == "ES384"
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	default:
+		return false
+	}
+}
+
+// jwksEntry は取得した JWKS と取得した日時を表す構造体です。
+type jwksEntry struct {
+	keys      []publicKey
+	fetchedAt time.Time
+}
+
+// publicKey は JWKS から kid の公開鍵を探します。
+// kid を持たないトークンは、JWKS の鍵が1つのみの場合にその鍵で検証します。
+func (p *Provider) publicKey(ctx context.Context, jwksURI, kid string) (publicKey, error) {
+	entry, cached := jwksCache.Load(jwksURI)
+	if cached {
+		if k, ok := findKey(entry.(jwksEntry).keys, kid); ok {
+			return k, nil
+		}
+		if time.Since(entry.(jwksEntry).fetchedAt) < jwksRefreshInterval {
+			return publicKey{}, fmt.Errorf("unknown kid %q", kid)
+		}
+	}
+
+	keys, err := p.fetchJWKS(ctx, jwksURI)
+	if err != nil {
+		return publicKey{}, err
+	}
+	jwksCache.Store(jwksURI, jwksEntry{keys: keys, fetchedAt: time.Now()})
+
+	if k, ok := findKey(keys, kid); ok {
+		return k, nil
+	}
+	return publicKey{}, fmt.Errorf("unknown kid %q", kid)
+}
+
+// findKey は kid の鍵を探します。
+func findKey(keys []publicKey, kid string) (publicKey, bool) {
+	if kid == "" {
+		if len(keys) == 1 {
+			return keys[0], true
+		}
+		return publicKey{}, false
+	}
+
+	for _, k := range keys {
+		if k.kid == kid {
+			return k, true
+		}
+	}
+	return publicKey{}, false
+}
+
+// fetchJWKS は JWKS を取得し、署名の検証に使える鍵のみを返します。対応していない種類の鍵は無視します。
+func (p *Provider) fetchJWKS(ctx context.Context, jwksURI string) ([]publicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make([]publicKey, 0, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys = append(keys, publicKey{kid: jwk.KeyID, algorithm: jwk.Algorithm, key: key})
+	}
+
+	return keys, nil
+}
+
+// publicKey は JWK を Go の公開鍵に変換します。
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) < 256 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("rsa key is too weak or invalid")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, fmt.Errorf("invalid ec key length")
+		}
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key length")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+	}
+}
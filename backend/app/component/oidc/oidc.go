@@ -0,0 +1,348 @@
+// Package oidc は OpenID Connect の RP (relying party) として、外部の ID プロバイダーでのサインインを扱います。
+// 認可コードフローに PKCE (S256) を組み合わせ、ID トークンはプロバイダーの JWKS の公開鍵で検証します。
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// ErrVerification は ID トークンの検証やプロバイダーとのやり取りに失敗した場合のエラーです。
+var ErrVerification = errors.New("oidc: verification failed")
+
+// defaultScopes はスコープを指定しないプロバイダーに要求するスコープです。
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// clockSkew は ID トークンの有効期限と発行日時の確認で許容する時刻のずれです。
+const clockSkew = time.Minute
+
+// ProviderConfig は ID プロバイダーの設定を表す構造体です。
+// ID はエンドポイントでプロバイダーを指定するための名前で、Name はサインインのボタンに表示する名前です。
+// ClientSecret を指定しない場合は公開クライアントとして PKCE のみでトークンを取得します。
+// AllowedDomains を指定した場合は、メールアドレスのドメインがいずれかに一致するアカウントのみサインインできます。
+type ProviderConfig struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Issuer         string   `json:"issuer"`
+	ClientID       string   `json:"client_id"`
+	ClientSecret   string   `json:"client_secret"`
+	Scopes         []string `json:"scopes"`
+	AllowedDomains []string `json:"allowed_domains"`
+}
+
+// ParseProviderConfigs は ID プロバイダーの設定の JSON の配列を読み取ります。空の場合はプロバイダーがないものとします。
+func ParseProviderConfigs(s string) ([]ProviderConfig, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var configs []ProviderConfig
+	if err := json.Unmarshal([]byte(s), &configs); err != nil {
+		return nil, fmt.Errorf("oidc providers are not valid json: %w", err)
+	}
+
+	ids := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if c.ID == "" {
+			return nil, fmt.Errorf("oidc provider id is required")
+		}
+		if ids[c.ID] {
+			return nil, fmt.Errorf("oidc provider id %q is duplicated", c.ID)
+		}
+		ids[c.ID] = true
+
+		u, err := url.Parse(c.Issuer)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Hostname() != "localhost" && u.Hostname() != "127.0.0.1") {
+			return nil, fmt.Errorf("oidc provider %q issuer must be an https url", c.ID)
+		}
+		if c.ClientID == "" {
+			return nil, fmt.Errorf("oidc provider %q client_id is required", c.ID)
+		}
+	}
+
+	return configs, nil
+}
+
+// AllowsEmail はメールアドレスのドメインでサインインを許可するかどうかを返します。
+func (c ProviderConfig) AllowsEmail(email string) bool {
+	if len(c.AllowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range c.AllowedDomains {
+		if strings.EqualFold(domain, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// scopes は認可のリクエストで要求するスコープを返します。openid は常に含めます。
+func (c ProviderConfig) scopes() []string {
+	if len(c.Scopes) == 0 {
+		return defaultScopes
+	}
+	for _, s := range c.Scopes {
+		if s == "openid" {
+			return c.Scopes
+		}
+	}
+	return append([]string{"openid"}, c.Scopes...)
+}
+
+// Metadata はプロバイダーのディスカバリーの情報 (OpenID Provider Metadata) を表す構造体です。
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Claims は検証した ID トークンの内容を表す構造体です。
+// Subject はプロバイダーの中で一意なユーザーの ID で、Issuer と組み合わせてアカウントを識別します。
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// idTokenClaims は ID トークンの claims を表す構造体です。
+type idTokenClaims struct {
+	Nonce           string   `json:"nonce"`
+	Email           string   `json:"email"`
+	EmailVerified   flexBool `json:"email_verified"`
+	Name            string   `json:"name"`
+	AuthorizedParty string   `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// flexBool は真偽値を文字列で返すプロバイダーに対応するための真偽値です。
+type flexBool bool
+
+// UnmarshalJSON は true と "true" のどちらも真として読み取ります。
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// Provider は ID プロバイダーとのやり取りを表す構造体です。
+// RedirectURI はプロバイダーに登録したフロントエンドのコールバックの URL です。
+type Provider struct {
+	Config      ProviderConfig
+	RedirectURI string
+	HTTPClient  *http.Client
+}
+
+// NewProvider は Provider を生成します。client を指定しない場合はタイムアウトを設定したクライアントを使います。
+func NewProvider(config ProviderConfig, redirectURI string, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{Config: config, RedirectURI: redirectURI, HTTPClient: client}
+}
+
+// Discover はプロバイダーのディスカバリーの情報を取得します。
+// 取得した情報はプロセスの中でキャッシュし、Lambda のコンテナを再利用する間は再取得しません。
+func (p *Provider) Discover(ctx context.Context) (*Metadata, error) {
+	if m, ok := metadataCache.Load(p.Config.Issuer); ok {
+		return m.(*Metadata), nil
+	}
+
+	var m Metadata
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.Config.Issuer, "/")+"/.well-known/openid-configuration", &m); err != nil {
+		return nil, err
+	}
+	// 別の発行者になりすましたディスカバリーの情報を使わないよう、発行者が設定と一致することを確認する
+	if m.Issuer != p.Config.Issuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match", ErrVerification, m.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: metadata is incomplete", ErrVerification)
+	}
+
+	metadataCache.Store(p.Config.Issuer, &m)
+	return &m, nil
+}
+
+// AuthorizationURL はブラウザを遷移させる認可のリクエストの URL を返します。
+// state はコールバックで認可のリクエストを照合する値、nonce は ID トークンに含めさせる値、
+// codeChallenge は PKCE の S256 のコードチャレンジです。
+func (p *Provider) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	m, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(m.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: authorization endpoint: %v", ErrVerification, err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.Config.ClientID)
+	q.Set("redirect_uri", p.RedirectURI)
+	q.Set("scope", strings.Join(p.Config.scopes(), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// tokenResponse はトークンエンドポイントのレスポンスを表す構造体です。
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange は認可コードと PKCE のコード検証値でトークンを取得し、ID トークンを返します。
+// ClientSecret を指定した場合は client_secret_basic でクライアントを認証します。
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	m, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURI)
+	form.Set("code_verifier", codeVerifier)
+	if p.Config.ClientSecret == "" {
+		form.Set("client_id", p.Config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.Config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.Config.ClientID), url.QueryEscape(p.Config.ClientSecret))
+	}
+
+	res, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var tr tokenResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(&tr); err != nil {
+		return "", fmt.Errorf("%w: token response: %v", ErrVerification, err)
+	}
+	if res.StatusCode != http.StatusOK || tr.Error != "" {
+		return "", fmt.Errorf("%w: token endpoint returned %d %s %s", ErrVerification, res.StatusCode, tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return "", fmt.Errorf("%w: token response has no id_token", ErrVerification)
+	}
+
+	return tr.IDToken, nil
+}
+
+// VerifyIDToken は ID トークンの署名、発行者、audience、有効期限と nonce を検証して内容を返します。
+// 署名はプロバイダーの JWKS のうちヘッダーの kid の鍵で検証し、見つからない場合は鍵の切り替えに備えて JWKS を取得し直します。
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string, now time.Time) (*Claims, error) {
+	m, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims idTokenClaims
+	_, err = jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := p.publicKey(ctx, m.JWKSURI, kid)
+		if err != nil {
+			return nil, err
+		}
+		// 鍵と異なるアルゴリズムで署名されたトークンを受け付けないようにする
+		if !key.accepts(token.Method.Alg()) {
+			return nil, fmt.Errorf("unexpected algorithm %q", token.Method.Alg())
+		}
+		return key.key, nil
+	},
+		jwt.WithValidMethods(supportedAlgorithms),
+		jwt.WithIssuer(p.Config.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerification, err)
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: id token has no sub", ErrVerification)
+	}
+	// 複数の audience を持つトークンは、自分に向けて発行されたものであることを azp で確認する
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.Config.ClientID {
+		return nil, fmt.Errorf("%w: azp %q", ErrVerification, claims.AuthorizedParty)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrVerification)
+	}
+
+	return &Claims{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// maxResponseBytes はプロバイダーのレスポンスとして読み取る最大のバイト数です。
+const maxResponseBytes = 1 << 20
+
+// getJSON は URL を GET して JSON のレスポンスを読み取ります。
+func (p *Provider) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET %s returned %d", ErrVerification, u, res.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseBytes)).Decode(v); err != nil {
+		return fmt.Errorf("%w: GET %s: %v", ErrVerification, u, err)
+	}
+	return nil
+}
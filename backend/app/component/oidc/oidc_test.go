@@ -0,0 +1,206 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc"
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURI = "https://example.com/signin/oidc/callback"
+
+var testUser = oidctest.User{Subject: "subject-1", Email: "student@example.ac.jp", EmailVerified: true, Name: "テストユーザー"}
+
+// newTestProvider はモックのプロバイダーを起動し、そのプロバイダーの Provider を返します。
+func newTestProvider(t *testing.T, clientSecret string) (*oidctest.Server, *oidc.Provider) {
+	t.Helper()
+
+	server, err := oidctest.NewServer("test-client", clientSecret, testUser)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	config := oidc.ProviderConfig{ID: "test", Name: "テスト", Issuer: server.URL, ClientID: "test-client", ClientSecret: clientSecret}
+	return server, oidc.NewProvider(config, testRedirectURI, server.Client())
+}
+
+func TestProvider_Flow(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+	}{
+		{name: "機密クライアント", clientSecret: "test-secret"},
+		{name: "公開クライアント", clientSecret: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server, provider := newTestProvider(t, tt.clientSecret)
+
+			verifier, err := oidc.NewCodeVerifier()
+			require.NoError(t, err)
+			authURL, err := provider.AuthorizationURL(ctx, "test-state", "test-nonce", oidc.CodeChallenge(verifier))
+			require.NoError(t, err)
+
+			u, err := url.Parse(authURL)
+			require.NoError(t, err)
+			assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+			assert.Equal(t, testRedirectURI, u.Query().Get("redirect_uri"))
+
+			code, state, err := server.Authorize(authURL)
+			require.NoError(t, err)
+			assert.Equal(t, "test-state", state)
+
+			rawIDToken, err := provider.Exchange(ctx, code, verifier)
+			require.NoError(t, err)
+
+			claims, err := provider.VerifyIDToken(ctx, rawIDToken, "test-nonce", time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, &oidc.Claims{Issuer: server.URL, Subject: "subject-1", Email: "student@example.ac.jp", EmailVerified: true, Name: "テストユーザー"}, claims)
+
+			// 認可コードは1回のみ使える
+			_, err = provider.Exchange(ctx, code, verifier)
+			assert.ErrorIs(t, err, oidc.ErrVerification)
+		})
+	}
+}
+
+func TestProvider_Exchange_WrongVerifier(t *testing.T) {
+	ctx := context.Background()
+	server, provider := newTestProvider(t, "test-secret")
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+	authURL, err := provider.AuthorizationURL(ctx, "test-state", "test-nonce", oidc.CodeChallenge(verifier))
+	require.NoError(t, err)
+	code, _, err := server.Authorize(authURL)
+	require.NoError(t, err)
+
+	_, err = provider.Exchange(ctx, code, "other-verifier")
+	assert.ErrorIs(t, err, oidc.ErrVerification)
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	ctx := context.Background()
+	server, provider := newTestProvider(t, "test-secret")
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		modify  func(claims map[string]any)
+		nonce   string
+		wantErr bool
+	}{
+		{name: "正常", modify: func(map[string]any) {}, nonce: "test-nonce"},
+		{name: "email_verified が文字列", modify: func(c map[string]any) { c["email_verified"] = "true" }, nonce: "test-nonce"},
+		{name: "nonce が異なる", modify: func(map[string]any) {}, nonce: "other-nonce", wantErr: true},
+		{name: "nonce を指定しない", modify: func(map[string]any) {}, nonce: "", wantErr: true},
+		{name: "audience が異なる", modify: func(c map[string]any) { c["aud"] = "other-client" }, nonce: "test-nonce", wantErr: true},
+		{name: "発行者が異なる", modify: func(c map[string]any) { c["iss"] = "https://other.example.com" }, nonce: "test-nonce", wantErr: true},
+		{name: "有効期限切れ", modify: func(c map[string]any) { c["exp"] = now.Add(-time.Hour).Unix() }, nonce: "test-nonce", wantErr: true},
+		{name: "有効期限がない", modify: func(c map[string]any) { delete(c, "exp") }, nonce: "test-nonce", wantErr: true},
+		{name: "sub がない", modify: func(c map[string]any) { delete(c, "sub") }, nonce: "test-nonce", wantErr: true},
+		{name: "複数の audience で azp が異なる", modify: func(c map[string]any) { c["aud"] = []string{"test-client", "other-client"}; c["azp"] = "other-client" }, nonce: "test-nonce", wantErr: true},
+		{name: "複数の audience で azp が一致する", modify: func(c map[string]any) { c["aud"] = []string{"test-client", "other-client"}; c["azp"] = "test-client" }, nonce: "test-nonce"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := server.Claims(testUser, "test-nonce", now)
+			tt.modify(claims)
+			rawIDToken, err := server.IssueIDToken(claims)
+			require.NoError(t, err)
+
+			got, err := provider.VerifyIDToken(ctx, rawIDToken, tt.nonce, now)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, oidc.ErrVerification)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "subject-1", got.Subject)
+			assert.True(t, got.EmailVerified)
+		})
+	}
+}
+
+func TestProvider_VerifyIDToken_OtherKey(t *testing.T) {
+	ctx := context.Background()
+	_, provider := newTestProvider(t, "test-secret")
+
+	// 別のプロバイダーの鍵で署名したトークンは受け付けない
+	other, err := oidctest.New(provider.Config.Issuer, "test-client", "", testUser)
+	require.NoError(t, err)
+	rawIDToken, err := other.IssueIDToken(other.Claims(testUser, "test-nonce", time.Now()))
+	require.NoError(t, err)
+
+	_, err = provider.VerifyIDToken(ctx, rawIDToken, "test-nonce", time.Now())
+	assert.ErrorIs(t, err, oidc.ErrVerification)
+}
+
+func TestParseProviderConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantIDs []string
+		wantErr bool
+	}{
+		{name: "空", input: "", wantIDs: nil},
+		{name: "1件", input: `[{"id":"google","name":"Google","issuer":"https://accounts.google.com","client_id":"client"}]`, wantIDs: []string{"google"}},
+		{name: "ローカルのモック", input: `[{"id":"mock","issuer":"http://localhost:9000","client_id":"client"}]`, wantIDs: []string{"mock"}},
+		{name: "JSON ではない", input: `google`, wantErr: true},
+		{name: "ID がない", input: `[{"issuer":"https://accounts.google.com","client_id":"client"}]`, wantErr: true},
+		{name: "ID が重複", input: `[{"id":"a","issuer":"https://a.example.com","client_id":"c"},{"id":"a","issuer":"https://b.example.com","client_id":"c"}]`, wantErr: true},
+		{name: "発行者が http", input: `[{"id":"a","issuer":"http://a.example.com","client_id":"c"}]`, wantErr: true},
+		{name: "クライアント ID がない", input: `[{"id":"a","issuer":"https://a.example.com"}]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oidc.ParseProviderConfigs(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var ids []string
+			for _, c := range got {
+				ids = append(ids, c.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestProviderConfig_AllowsEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		domains []string
+		email   string
+		want    bool
+	}{
+		{name: "ドメインの指定なし", domains: nil, email: "user@example.com", want: true},
+		{name: "ドメインが一致", domains: []string{"example.ac.jp"}, email: "user@example.ac.jp", want: true},
+		{name: "大文字小文字の違い", domains: []string{"example.ac.jp"}, email: "user@EXAMPLE.ac.jp", want: true},
+		{name: "ドメインが異なる", domains: []string{"example.ac.jp"}, email: "user@example.com", want: false},
+		{name: "サブドメイン", domains: []string{"example.ac.jp"}, email: "user@mail.example.ac.jp", want: false},
+		{name: "メールアドレスではない", domains: []string{"example.ac.jp"}, email: "user", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := oidc.ProviderConfig{AllowedDomains: tt.domains}
+			assert.Equal(t, tt.want, c.AllowsEmail(tt.email))
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 Appendix B の例
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
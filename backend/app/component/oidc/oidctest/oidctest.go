@@ -0,0 +1,308 @@
+// Package oidctest はテストとローカルでの開発に使う、最小限の OpenID Connect のプロバイダーを提供します。
+// 認可のリクエストではログインの画面を表示せず、設定したユーザーとしてすぐに認可コードを発行します。
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc"
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// codeLifetime は認可コードの有効期間です。
+	codeLifetime = time.Minute
+	// idTokenLifetime は ID トークンの有効期間です。
+	idTokenLifetime = time.Hour
+	// keyID は ID トークンの署名の鍵の kid です。
+	keyID = "oidctest"
+)
+
+// User は認可のリクエストでサインインしたものとするユーザーを表す構造体です。
+type User struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// authorization は発行した認可コードに紐付く認可のリクエストを表す構造体です。
+type authorization struct {
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	user          User
+	expiresAt     time.Time
+}
+
+// Provider はモックの OpenID Connect のプロバイダーを表す構造体です。
+// ClientSecret を指定した場合はトークンのリクエストでクライアントの認証を求めます。
+// 認可のリクエストに login_hint のメールアドレスを指定した場合は、User の代わりにそのメールアドレスのユーザーとしてサインインします。
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	mu    sync.Mutex
+	user  User
+	key   *rsa.PrivateKey
+	codes map[string]authorization
+}
+
+// New は Provider を生成します。
+func New(issuer, clientID, clientSecret string, user User) (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		user:         user,
+		key:          key,
+		codes:        map[string]authorization{},
+	}, nil
+}
+
+// SetUser は次の認可のリクエストでサインインしたものとするユーザーを変更します。
+func (p *Provider) SetUser(user User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+// ServeHTTP はディスカバリー、認可、トークンと JWKS のエンドポイントを提供します。
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		p.discovery(w, r)
+	case "/authorize":
+		p.authorize(w, r)
+	case "/token":
+		p.token(w, r)
+	case "/jwks":
+		p.jwks(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// discovery はディスカバリーの情報を返します。
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                p.Issuer,
+		"authorization_endpoint":                p.Issuer + "/authorize",
+		"token_endpoint":                        p.Issuer + "/token",
+		"jwks_uri":                              p.Issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// authorize は認可のリクエストを検証し、認可コードを付けて redirect_uri にリダイレクトします。
+func (p *Provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	switch {
+	case q.Get("client_id") != p.ClientID:
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	case redirectURI == "":
+		http.Error(w, "redirect_uri is required", http.StatusBadRequest)
+		return
+	case q.Get("response_type") != "code":
+		http.Error(w, "unsupported response_type", http.StatusBadRequest)
+		return
+	case !strings.Contains(" "+q.Get("scope")+" ", " openid "):
+		http.Error(w, "openid scope is required", http.StatusBadRequest)
+		return
+	case q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256":
+		http.Error(w, "pkce with S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	code, err := oidc.NewRandomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	p.mu.Lock()
+	user := p.user
+	if hint := q.Get("login_hint"); hint != "" {
+		user = User{Subject: "sub-" + hint, Email: hint, EmailVerified: true, Name: user.Name}
+	}
+	p.codes[code] = authorization{
+		redirectURI:   redirectURI,
+		nonce:         q.Get("nonce"),
+		codeChallenge: q.Get("code_challenge"),
+		user:          user,
+		expiresAt:     time.Now().Add(codeLifetime),
+	}
+	p.mu.Unlock()
+
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	rq := u.Query()
+	rq.Set("code", code)
+	rq.Set("state", q.Get("state"))
+	u.RawQuery = rq.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+// token は認可コードを検証し、ID トークンを返します。認可コードは1回のみ使えます。
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.ClientID || (p.ClientSecret != "" && clientSecret != p.ClientSecret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	p.mu.Lock()
+	code := r.PostForm.Get("code")
+	a, found := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !found || time.Now().After(a.expiresAt) || a.redirectURI != r.PostForm.Get("redirect_uri") || oidc.CodeChallenge(r.PostForm.Get("code_verifier")) != a.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := p.IssueIDToken(p.Claims(a.user, a.nonce, time.Now()))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "oidctest-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenLifetime.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+// jwks は ID トークンの署名を検証する公開鍵を返します。
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+// Claims は user の now の時点の ID トークンの claims を返します。
+func (p *Provider) Claims(user User, nonce string, now time.Time) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":            p.Issuer,
+		"sub":            user.Subject,
+		"aud":            p.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(idTokenLifetime).Unix(),
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	return claims
+}
+
+// IssueIDToken は claims に署名して ID トークンを発行します。検証の失敗を試すために claims を書き換えて使えます。
+func (p *Provider) IssueIDToken(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(p.key)
+}
+
+// writeJSON は v を JSON で書き出します。
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Server はテストのために httptest.Server で起動した Provider を表す構造体です。
+type Server struct {
+	*Provider
+	*httptest.Server
+}
+
+// NewServer は Provider をローカルのポートで起動します。発行者は起動したサーバーの URL です。
+// 使い終わったら Close で停止します。
+func NewServer(clientID, clientSecret string, user User) (*Server, error) {
+	p, err := New("", clientID, clientSecret, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s := httptest.NewServer(p)
+	p.Issuer = s.URL
+	return &Server{Provider: p, Server: s}, nil
+}
+
+// Authorize はブラウザの代わりに認可のリクエストの URL を開き、リダイレクト先の認可コードと state を返します。
+func (s *Server) Authorize(authorizationURL string) (code, state string, err error) {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	res, err := client.Get(authorizationURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusFound {
+		return "", "", fmt.Errorf("authorize returned %d", res.StatusCode)
+	}
+	u, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+	return u.Query().Get("code"), u.Query().Get("state"), nil
+}
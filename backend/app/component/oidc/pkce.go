@@ -0,0 +1,31 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// randomBytes は state、nonce と PKCE のコード検証値のランダムなバイト数です。
+const randomBytes = 32
+
+// NewRandomString は state や nonce に使う推測できないランダムな文字列を生成します。
+func NewRandomString() (string, error) {
+	b := make([]byte, randomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewCodeVerifier は PKCE のコード検証値 (RFC 7636) を生成します。
+func NewCodeVerifier() (string, error) {
+	return NewRandomString()
+}
+
+// CodeChallenge はコード検証値から S256 のコードチャレンジを計算します。
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,48 @@
+package search
+
+import "strings"
+
+// Matcher は検索語に一致するかどうかを判定する構造体です。
+// 検索語は空白で区切られた語のすべてを含む場合に一致とします。
+type Matcher struct {
+	terms []string
+}
+
+// NewMatcher は検索語から Matcher を生成します。
+func NewMatcher(query string) *Matcher {
+	return &Matcher{terms: strings.Fields(Normalize(query))}
+}
+
+// Empty は検索語が空かどうかを返します。
+func (m *Matcher) Empty() bool {
+	return len(m.terms) == 0
+}
+
+// Match は texts が検索語に一致するかどうかを返します。
+// 各語は texts のいずれかに含まれていれば一致とします。
+func (m *Matcher) Match(texts ...string) bool {
+	if m.Empty() {
+		return false
+	}
+
+	normalized := make([]string, 0, len(texts))
+	for _, t := range texts {
+		normalized = append(normalized, Normalize(t))
+	}
+
+	for _, term := range m.terms {
+		if !containsAny(normalized, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(texts []string, term string) bool {
+	for _, t := range texts {
+		if strings.Contains(t, term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		texts []string
+		want  bool
+	}{
+		{name: "部分一致", query: "統計", texts: []string{"統計学 第6回"}, want: true},
+		{name: "全角数字の検索語で半角数字に一致", query: "第６回", texts: []string{"統計学 第6回"}, want: true},
+		{name: "ひらがなの検索語でカタカナに一致", query: "でーた", texts: []string{"データサイエンス入門"}, want: true},
+		{name: "半角カナの名前に一致", query: "プログラミング", texts: []string{"ﾌﾟﾛｸﾞﾗﾐﾝｸﾞ基礎"}, want: true},
+		{name: "大文字小文字を区別しない", query: "python", texts: []string{"Python入門"}, want: true},
+		{name: "複数の語はすべて含む場合に一致", query: "統計学 第6回", texts: []string{"統計学第6回"}, want: true},
+		{name: "複数の語の一部しか含まない場合は不一致", query: "統計学 第7回", texts: []string{"統計学 第6回"}, want: false},
+		{name: "語は複数の文字列にまたがって一致", query: "統計学 red", texts: []string{"統計学 第6回", "red"}, want: true},
+		{name: "含まない場合は不一致", query: "英語", texts: []string{"統計学 第6回"}, want: false},
+		{name: "空の検索語は不一致", query: "　", texts: []string{"統計学 第6回"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatcher(tt.query)
+			assert.Equal(t, tt.want, m.Match(tt.texts...))
+		})
+	}
+}
+
+func TestMatcher_Empty(t *testing.T) {
+	assert.True(t, NewMatcher("").Empty())
+	assert.True(t, NewMatcher("　 ").Empty())
+	assert.False(t, NewMatcher("統計").Empty())
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// halfwidthKana は半角カナ（U+FF61～U+FF9F）に対応する全角文字です。
+var halfwidthKana = []rune("。「」、・ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン゛゜")
+
+// voicedKana は濁点、semiVoicedKana は半濁点を付けたカタカナへの対応です。
+var (
+	voicedKana     = kanaPairs("カキクケコサシスセソタチツテトハヒフヘホウ", "ガギグゲゴザジズゼゾダヂヅデドバビブベボヴ")
+	semiVoicedKana = kanaPairs("ハヒフヘホ", "パピプペポ")
+)
+
+func kanaPairs(from, to string) map[rune]rune {
+	f, t := []rune(from), []rune(to)
+	m := make(map[rune]rune, len(f))
+	for i := range f {
+		m[f[i]] = t[i]
+	}
+	return m
+}
+
+// Normalize は検索で比較するために文字列を正規化します。
+// 全角英数記号を半角に、半角カナを全角に、ひらがなをカタカナに揃え、英字は小文字に変換します。
+// 連続する空白は1つの半角スペースにまとめ、前後の空白は取り除きます。
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	var prev rune = -1
+	flush := func() {
+		if prev >= 0 {
+			b.WriteRune(prev)
+		}
+		prev = -1
+	}
+
+	for _, r := range s {
+		r = foldWidth(r)
+
+		switch r {
+		case '゛', '゙':
+			if v, ok := voicedKana[prev]; ok {
+				prev = v
+				continue
+			}
+		case '゜', '゚':
+			if v, ok := semiVoicedKana[prev]; ok {
+				prev = v
+				continue
+			}
+		}
+
+		flush()
+		prev = unicode.ToLower(foldKana(r))
+	}
+	flush()
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// foldWidth は全角英数記号と全角スペースを半角に、半角カナを全角に変換します。
+func foldWidth(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case r >= '！' && r <= '～':
+		return r - 0xFEE0
+	case r >= '｡' && r <= 'ﾟ':
+		return halfwidthKana[r-0xFF61]
+	default:
+		return r
+	}
+}
+
+// foldKana はひらがなをカタカナに変換します。
+func foldKana(r rune) rune {
+	if (r >= 'ぁ' && r <= 'ゖ') || r == 'ゝ' || r == 'ゞ' {
+		return r + 0x60
+	}
+	return r
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "全角英数字を半角にする", in: "ＡＢＣ１２３", want: "abc123"},
+		{name: "英字を小文字にする", in: "Statistics", want: "statistics"},
+		{name: "ひらがなをカタカナにする", in: "とうけいがく", want: "トウケイガク"},
+		{name: "半角カナを全角にする", in: "ﾄｳｹｲ", want: "トウケイ"},
+		{name: "半角カナの濁点を結合する", in: "ｶﾞｸ", want: "ガク"},
+		{name: "半角カナの半濁点を結合する", in: "ﾌﾟﾛｸﾞﾗﾐﾝｸﾞ", want: "プログラミング"},
+		{name: "ヴを結合する", in: "ｳﾞ", want: "ヴ"},
+		{name: "結合文字の濁点を結合する", in: "が", want: "ガ"},
+		{name: "結合できない濁点はそのまま残す", in: "ｱﾞ", want: "ア゛"},
+		{name: "全角スペースと連続する空白をまとめる", in: "　統計学　 第６回  ", want: "統計学 第6回"},
+		{name: "漢字はそのまま", in: "統計学", want: "統計学"},
+		{name: "空文字", in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.in))
+		})
+	}
+}
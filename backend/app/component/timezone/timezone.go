@@ -0,0 +1,121 @@
+// Package timezone はユーザーのタイムゾーンを基準にした日時の変換を提供します。
+// リクエストの解析、日付ごとのまとめ、レスポンスの整形はすべてこのパッケージを経由します。
+package timezone
+
+import (
+	"fmt"
+	"time"
+
+	// Lambda の実行環境にはタイムゾーンのデータベースが含まれないため、バイナリに埋め込みます。
+	_ "time/tzdata"
+)
+
+// DefaultName はタイムゾーンが設定されていないユーザーに適用するタイムゾーンの名前です。
+const DefaultName = "Asia/Tokyo"
+
+// DateFormat は日付の形式です。
+const DateFormat = "2006-01-02"
+
+// DateTimeFormat はレスポンスで返す日時の形式です。
+// オフセットを含めることで日時を一意に表します。
+const DateTimeFormat = time.RFC3339
+
+// Zone はタイムゾーンを表す構造体です。
+// ゼロ値は DefaultName のタイムゾーンとして扱います。
+type Zone struct {
+	loc *time.Location
+}
+
+// Load は IANA タイムゾーン名から Zone を生成します。
+// 名前が空の場合は DefaultName のタイムゾーンを返します。
+func Load(name string) (Zone, error) {
+	if name == "" {
+		return Default(), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return Zone{}, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+
+	return Zone{loc: loc}, nil
+}
+
+// LoadOrDefault は IANA タイムゾーン名から Zone を生成します。
+// 名前が不正な場合は DefaultName のタイムゾーンを返します。
+func LoadOrDefault(name string) Zone {
+	z, err := Load(name)
+	if err != nil {
+		return Default()
+	}
+	return z
+}
+
+// Default は DefaultName のタイムゾーンを返します。
+func Default() Zone {
+	loc, err := time.LoadLocation(DefaultName)
+	if err != nil {
+		panic(err)
+	}
+	return Zone{loc: loc}
+}
+
+// UTC は UTC のタイムゾーンを返します。
+func UTC() Zone {
+	return Zone{loc: time.UTC}
+}
+
+// Valid はタイムゾーン名が有効かどうかを返します。
+func Valid(name string) bool {
+	_, err := Load(name)
+	return err == nil
+}
+
+// Name はタイムゾーンの名前を返します。
+func (z Zone) Name() string {
+	return z.Location().String()
+}
+
+// Location はタイムゾーンの time.Location を返します。
+func (z Zone) Location() *time.Location {
+	if z.loc == nil {
+		return Default().loc
+	}
+	return z.loc
+}
+
+// In は t をタイムゾーンの時刻に変換します。
+func (z Zone) In(t time.Time) time.Time {
+	return t.In(z.Location())
+}
+
+// Now はタイムゾーンの現在時刻を返します。
+func (z Zone) Now() time.Time {
+	return z.In(time.Now())
+}
+
+// ParseDateTime は日時の文字列を解析します。
+// オフセットを含む RFC 3339 の形式はそのオフセットで、
+// オフセットを含まない "2006-01-02 15:04:05" の形式はタイムゾーンの時刻として解釈します。
+func (z Zone) ParseDateTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return z.In(t), nil
+	}
+
+	return time.ParseInLocation(time.DateTime, s, z.Location())
+}
+
+// ParseDate は日付の文字列をタイムゾーンのその日の 0 時として解析します。
+func (z Zone) ParseDate(s string) (time.Time, error) {
+	return time.ParseInLocation(DateFormat, s, z.Location())
+}
+
+// FormatDateTime は t をタイムゾーンのオフセット付きの日時の文字列に変換します。
+func (z Zone) FormatDateTime(t time.Time) string {
+	return z.In(t).Format(DateTimeFormat)
+}
+
+// FormatDate は t をタイムゾーンの日付の文字列に変換します。
+func (z Zone) FormatDate(t time.Time) string {
+	return z.In(t).Format(DateFormat)
+}
@@ -0,0 +1,109 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "IANA タイムゾーン名", input: "America/New_York", want: "America/New_York"},
+		{name: "空の場合は既定のタイムゾーン", input: "", want: DefaultName},
+		{name: "不正なタイムゾーン名", input: "Asia/Nowhere", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, err := Load(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, z.Name())
+		})
+	}
+}
+
+func TestZone_ParseDateTime(t *testing.T) {
+	tokyo := LoadOrDefault("Asia/Tokyo")
+	newYork := LoadOrDefault("America/New_York")
+
+	tests := []struct {
+		name    string
+		zone    Zone
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "オフセットなしはタイムゾーンの時刻として解釈する",
+			zone:  tokyo,
+			input: "2024-01-01 00:30:00",
+			want:  time.Date(2023, 12, 31, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "オフセットなしは夏時間を考慮する",
+			zone:  newYork,
+			input: "2024-07-01 23:30:00",
+			want:  time.Date(2024, 7, 2, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "オフセット付きはそのオフセットで解釈する",
+			zone:  tokyo,
+			input: "2024-01-01T00:30:00-05:00",
+			want:  time.Date(2024, 1, 1, 5, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "不正な形式",
+			zone:    tokyo,
+			input:   "2024/01/01 00:30",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.zone.ParseDateTime(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got))
+			assert.Equal(t, tt.zone.Location(), got.Location())
+		})
+	}
+}
+
+func TestZone_Format(t *testing.T) {
+	tm := time.Date(2023, 12, 31, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		zone         Zone
+		wantDateTime string
+		wantDate     string
+	}{
+		{name: "日本時間では翌日になる", zone: LoadOrDefault("Asia/Tokyo"), wantDateTime: "2024-01-01T00:30:00+09:00", wantDate: "2024-01-01"},
+		{name: "UTC", zone: UTC(), wantDateTime: "2023-12-31T15:30:00Z", wantDate: "2023-12-31"},
+		{name: "ゼロ値は既定のタイムゾーン", zone: Zone{}, wantDateTime: "2024-01-01T00:30:00+09:00", wantDate: "2024-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantDateTime, tt.zone.FormatDateTime(tm))
+			assert.Equal(t, tt.wantDate, tt.zone.FormatDate(tm))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/cbor"
+)
+
+// SoftwareAuthenticator はテストで使うソフトウェアの認証器です。
+// ES256 の鍵でパスキーを1つ作成し、ブラウザと認証器の代わりに登録と認証の応答を生成します。
+type SoftwareAuthenticator struct {
+	RPID         string
+	Origin       string
+	CredentialID []byte
+	UserHandle   []byte
+	SignCount    uint32
+	// Flags は応答に含めるフラグです。NewSoftwareAuthenticator はユーザーの存在の確認と検証のフラグを立てます。
+	Flags byte
+
+	key *ecdsa.PrivateKey
+}
+
+// NewSoftwareAuthenticator は rp のパスキーを作成するソフトウェアの認証器を生成します。
+func NewSoftwareAuthenticator(rp RelyingParty) (*SoftwareAuthenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	return &SoftwareAuthenticator{
+		RPID:         rp.ID,
+		Origin:       rp.Origin,
+		CredentialID: id,
+		Flags:        FlagUserPresent | FlagUserVerified,
+		key:          key,
+	}, nil
+}
+
+// Create は登録の応答のクライアントデータと構成証明を生成します。構成証明の形式は none です。
+func (a *SoftwareAuthenticator) Create(challenge string, userHandle []byte) (clientDataJSON, attestationObject []byte, err error) {
+	a.UserHandle = userHandle
+
+	clientDataJSON, err = a.clientData(ClientDataTypeCreate, challenge)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	point, err := a.key.PublicKey.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKey, err := cbor.Marshal(map[interface{}]interface{}{
+		int64(coseKeyType):   int64(coseKeyTypeEC2),
+		int64(coseAlgorithm): int64(AlgES256),
+		int64(coseCurve):     int64(coseCurveP256),
+		int64(coseX):         point[1:33],
+		int64(coseY):         point[33:],
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authData := a.authenticatorData(a.Flags | FlagAttestedCredentialData)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.CredentialID)))
+	authData = append(authData, a.CredentialID...)
+	authData = append(authData, publicKey...)
+
+	attestationObject, err = cbor.Marshal(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": authData,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientDataJSON, attestationObject, nil
+}
+
+// Get は認証の応答のクライアントデータ、認証器のデータ、署名を生成します。生成するたびに署名の回数を増やします。
+func (a *SoftwareAuthenticator) Get(challenge string) (clientDataJSON, authenticatorData, signature []byte, err error) {
+	clientDataJSON, err = a.clientData(ClientDataTypeGet, challenge)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	a.SignCount++
+	authenticatorData = a.authenticatorData(a.Flags)
+
+	hash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authenticatorData...), hash[:]...))
+	signature, err = ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return clientDataJSON, authenticatorData, signature, nil
+}
+
+func (a *SoftwareAuthenticator) clientData(typ, challenge string) ([]byte, error) {
+	return json.Marshal(ClientData{Type: typ, Challenge: challenge, Origin: a.Origin})
+}
+
+func (a *SoftwareAuthenticator) authenticatorData(flags byte) []byte {
+	hash := sha256.Sum256([]byte(a.RPID))
+	data := append([]byte{}, hash[:]...)
+	data = append(data, flags)
+	return binary.BigEndian.AppendUint32(data, a.SignCount)
+}
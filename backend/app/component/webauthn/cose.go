@@ -0,0 +1,119 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/cbor"
+)
+
+// 受け付ける署名のアルゴリズムの COSE の識別子です。
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+// Algorithms は登録で受け付ける署名のアルゴリズムを優先する順に並べたものです。
+var Algorithms = []int{AlgEdDSA, AlgES256, AlgRS256}
+
+// COSE の鍵のパラメーターです。
+const (
+	coseKeyType      = 1
+	coseAlgorithm    = 3
+	coseCurve        = -1
+	coseX            = -2
+	coseY            = -3
+	coseRSAModulus   = -1
+	coseRSAExponent  = -2
+	coseKeyTypeOKP   = 1
+	coseKeyTypeEC2   = 2
+	coseKeyTypeRSA   = 3
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	// minRSABits は受け付ける RSA の鍵の最小のビット数です。
+	minRSABits = 2048
+)
+
+// PublicKey は COSE の形式から読み取ったパスキーの公開鍵を表す構造体です。
+type PublicKey struct {
+	Algorithm int
+	Key       crypto.PublicKey
+}
+
+// ParsePublicKey は COSE の形式の公開鍵を読み取ります。ES256、EdDSA (Ed25519)、RS256 のみ受け付けます。
+func ParsePublicKey(cose []byte) (*PublicKey, error) {
+	v, err := cbor.Unmarshal(cose)
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key: %v", ErrVerification, err)
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: public key is not a map", ErrVerification)
+	}
+
+	kty, _ := m[int64(coseKeyType)].(int64)
+	alg, _ := m[int64(coseAlgorithm)].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == AlgES256:
+		crv, _ := m[int64(coseCurve)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		y, _ := m[int64(coseY)].([]byte)
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("%w: invalid ES256 key", ErrVerification)
+		}
+		key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return nil, fmt.Errorf("%w: ES256 key is not on the curve", ErrVerification)
+		}
+		return &PublicKey{Algorithm: AlgES256, Key: key}, nil
+	case kty == coseKeyTypeOKP && alg == AlgEdDSA:
+		crv, _ := m[int64(coseCurve)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid EdDSA key", ErrVerification)
+		}
+		return &PublicKey{Algorithm: AlgEdDSA, Key: ed25519.PublicKey(x)}, nil
+	case kty == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := m[int64(coseRSAModulus)].([]byte)
+		e, _ := m[int64(coseRSAExponent)].([]byte)
+		if len(n)*8 < minRSABits || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("%w: invalid RS256 key", ErrVerification)
+		}
+		exp := 0
+		for _, b := range e {
+			exp = exp<<8 | int(b)
+		}
+		return &PublicKey{Algorithm: AlgRS256, Key: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exp}}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported key type %d and algorithm %d", ErrVerification, kty, alg)
+	}
+}
+
+// Verify は data の署名を検証します。
+func (k *PublicKey) Verify(data, signature []byte) error {
+	ok := false
+	switch key := k.Key.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(data)
+		ok = ecdsa.VerifyASN1(key, hash[:], signature)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, data, signature)
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(data)
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: invalid signature", ErrVerification)
+	}
+	return nil
+}
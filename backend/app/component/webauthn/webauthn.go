@@ -0,0 +1,251 @@
+// Package webauthn はパスキー (WebAuthn Level 2) の登録と認証の応答を検証します。
+// 構成証明 (attestation) は要求しないため、認証器の種類は確認せずに公開鍵のみを取り出します。
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/cbor"
+)
+
+// クライアントデータの種類です。
+const (
+	ClientDataTypeCreate = "webauthn.create"
+	ClientDataTypeGet    = "webauthn.get"
+)
+
+// 認証器のデータのフラグです。
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagBackupEligible         byte = 0x08
+	FlagBackedUp               byte = 0x10
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+// ErrVerification は応答の検証に失敗した場合のエラーです。
+var ErrVerification = errors.New("webauthn: verification failed")
+
+// Encoding は WebAuthn のバイト列を JSON でやり取りするためのパディングなしの Base64URL です。
+var Encoding = base64.RawURLEncoding
+
+// RelyingParty は検証する側のサービスを表す構造体です。
+// ID はパスキーを紐付けるドメインで、Origin は応答を受け付けるフロントエンドのオリジンです。
+type RelyingParty struct {
+	ID     string
+	Name   string
+	Origin string
+}
+
+// ClientData はブラウザが署名の対象に含めるクライアントデータを表す構造体です。
+type ClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// ParseClientData はクライアントデータの JSON を読み取ります。
+func ParseClientData(clientDataJSON []byte) (*ClientData, error) {
+	var c ClientData
+	if err := json.Unmarshal(clientDataJSON, &c); err != nil {
+		return nil, fmt.Errorf("%w: client data: %v", ErrVerification, err)
+	}
+	return &c, nil
+}
+
+// verify はクライアントデータの種類、チャレンジ、オリジンを確認します。
+func (c *ClientData) verify(typ, challenge, origin string) error {
+	if c.Type != typ {
+		return fmt.Errorf("%w: client data type %q", ErrVerification, c.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrVerification)
+	}
+	if c.Origin != origin || c.CrossOrigin {
+		return fmt.Errorf("%w: origin %q", ErrVerification, c.Origin)
+	}
+	return nil
+}
+
+// AuthenticatorData は認証器のデータを表す構造体です。
+// CredentialID と PublicKey は登録のときのみ含まれ、PublicKey は COSE の形式の公開鍵です。
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+// ParseAuthenticatorData は認証器のデータを読み取ります。
+func ParseAuthenticatorData(data []byte) (*AuthenticatorData, error) {
+	if len(data) < 37 {
+		return nil, fmt.Errorf("%w: authenticator data too short", ErrVerification)
+	}
+
+	a := &AuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if a.Has(FlagAttestedCredentialData) {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("%w: attested credential data too short", ErrVerification)
+		}
+		a.AAGUID = rest[:16]
+		n := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if n == 0 || len(rest) < n {
+			return nil, fmt.Errorf("%w: invalid credential id length", ErrVerification)
+		}
+		a.CredentialID = rest[:n]
+		rest = rest[n:]
+
+		_, after, err := cbor.UnmarshalPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: credential public key: %v", ErrVerification, err)
+		}
+		a.PublicKey = rest[:len(rest)-len(after)]
+		rest = after
+	}
+
+	if a.Has(FlagExtensionData) {
+		_, after, err := cbor.UnmarshalPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: extensions: %v", ErrVerification, err)
+		}
+		rest = after
+	}
+
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: trailing authenticator data", ErrVerification)
+	}
+
+	return a, nil
+}
+
+// Has はフラグが立っているかどうかを返します。
+func (a *AuthenticatorData) Has(flag byte) bool {
+	return a.Flags&flag == flag
+}
+
+// verify は RP ID のハッシュとユーザーの存在の確認、ユーザーの検証を確認します。
+func (a *AuthenticatorData) verify(rpID string) error {
+	hash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(a.RPIDHash, hash[:]) {
+		return fmt.Errorf("%w: rp id hash mismatch", ErrVerification)
+	}
+	// パスワードの代わりに使うため、端末のロックの解除などでユーザーを検証したパスキーのみ受け付ける
+	if !a.Has(FlagUserPresent) || !a.Has(FlagUserVerified) {
+		return fmt.Errorf("%w: user not verified", ErrVerification)
+	}
+	if a.Has(FlagBackedUp) && !a.Has(FlagBackupEligible) {
+		return fmt.Errorf("%w: invalid backup flags", ErrVerification)
+	}
+	return nil
+}
+
+// Credential は登録で作成されたパスキーを表す構造体です。
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte
+	Algorithm      int
+	SignCount      uint32
+	BackupEligible bool
+	BackedUp       bool
+}
+
+// VerifyRegistration は登録の応答を検証し、作成されたパスキーを返します。
+// challenge は登録の開始で発行したチャレンジの Base64URL です。
+func (rp RelyingParty) VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	c, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verify(ClientDataTypeCreate, challenge, rp.Origin); err != nil {
+		return nil, err
+	}
+
+	v, err := cbor.Unmarshal(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: attestation object: %v", ErrVerification, err)
+	}
+	obj, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: attestation object is not a map", ErrVerification)
+	}
+	raw, ok := obj["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: attestation object has no authData", ErrVerification)
+	}
+
+	a, err := ParseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.verify(rp.ID); err != nil {
+		return nil, err
+	}
+	if !a.Has(FlagAttestedCredentialData) {
+		return nil, fmt.Errorf("%w: no attested credential data", ErrVerification)
+	}
+
+	key, err := ParsePublicKey(a.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:             a.CredentialID,
+		PublicKey:      a.PublicKey,
+		Algorithm:      key.Algorithm,
+		SignCount:      a.SignCount,
+		BackupEligible: a.Has(FlagBackupEligible),
+		BackedUp:       a.Has(FlagBackedUp),
+	}, nil
+}
+
+// VerifyAssertion は認証の応答の署名を登録済みのパスキーの公開鍵で検証し、認証器のデータを返します。
+// 署名の回数による複製の検出は呼び出す側で行います。
+func (rp RelyingParty) VerifyAssertion(challenge string, publicKey, clientDataJSON, authenticatorData, signature []byte) (*AuthenticatorData, error) {
+	c, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verify(ClientDataTypeGet, challenge, rp.Origin); err != nil {
+		return nil, err
+	}
+
+	a, err := ParseAuthenticatorData(authenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.verify(rp.ID); err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorData...), hash[:]...)
+	if err := key.Verify(signed, signature); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
@@ -0,0 +1,160 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRP = RelyingParty{ID: "example.com", Name: "受講計画", Origin: "https://example.com"}
+
+func TestVerifyRegistration(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	a, err := NewSoftwareAuthenticator(testRP)
+	require.NoError(err)
+	a.Flags |= FlagBackupEligible | FlagBackedUp
+
+	clientDataJSON, attestationObject, err := a.Create("test-challenge", []byte("test-user-id"))
+	require.NoError(err)
+
+	c, err := testRP.VerifyRegistration("test-challenge", clientDataJSON, attestationObject)
+	require.NoError(err)
+	assert.Equal(a.CredentialID, c.ID)
+	assert.Equal(AlgES256, c.Algorithm)
+	assert.Equal(uint32(0), c.SignCount)
+	assert.True(c.BackupEligible)
+	assert.True(c.BackedUp)
+
+	_, err = ParsePublicKey(c.PublicKey)
+	assert.NoError(err)
+}
+
+func TestVerifyRegistration_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		rp        RelyingParty
+		challenge string
+		flags     byte
+	}{
+		{name: "チャレンジが異なる", rp: testRP, challenge: "test-other-challenge", flags: FlagUserPresent | FlagUserVerified},
+		{name: "オリジンが異なる", rp: RelyingParty{ID: testRP.ID, Origin: "https://evil.example.com"}, challenge: "test-challenge", flags: FlagUserPresent | FlagUserVerified},
+		{name: "RP ID が異なる", rp: RelyingParty{ID: "evil.example.com", Origin: testRP.Origin}, challenge: "test-challenge", flags: FlagUserPresent | FlagUserVerified},
+		{name: "ユーザーを検証していない", rp: testRP, challenge: "test-challenge", flags: FlagUserPresent},
+		{name: "バックアップできないのにバックアップ済み", rp: testRP, challenge: "test-challenge", flags: FlagUserPresent | FlagUserVerified | FlagBackedUp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewSoftwareAuthenticator(testRP)
+			require.NoError(t, err)
+			a.Flags = tt.flags
+
+			clientDataJSON, attestationObject, err := a.Create("test-challenge", []byte("test-user-id"))
+			require.NoError(t, err)
+
+			_, err = tt.rp.VerifyRegistration(tt.challenge, clientDataJSON, attestationObject)
+			assert.ErrorIs(t, err, ErrVerification)
+		})
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	a, err := NewSoftwareAuthenticator(testRP)
+	require.NoError(t, err)
+	clientDataJSON, attestationObject, err := a.Create("test-challenge", []byte("test-user-id"))
+	require.NoError(t, err)
+	c, err := testRP.VerifyRegistration("test-challenge", clientDataJSON, attestationObject)
+	require.NoError(t, err)
+
+	clientDataJSON, authData, signature, err := a.Get("test-challenge-2")
+	require.NoError(t, err)
+
+	got, err := testRP.VerifyAssertion("test-challenge-2", c.PublicKey, clientDataJSON, authData, signature)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), got.SignCount)
+
+	t.Run("登録のクライアントデータは受け付けない", func(t *testing.T) {
+		_, err := testRP.VerifyAssertion("test-challenge", c.PublicKey, []byte(`{"type":"webauthn.create","challenge":"test-challenge","origin":"https://example.com"}`), authData, signature)
+		assert.ErrorIs(t, err, ErrVerification)
+	})
+
+	t.Run("改ざんされた認証器のデータは受け付けない", func(t *testing.T) {
+		tampered := append([]byte{}, authData...)
+		tampered[len(tampered)-1]++
+		_, err := testRP.VerifyAssertion("test-challenge-2", c.PublicKey, clientDataJSON, tampered, signature)
+		assert.ErrorIs(t, err, ErrVerification)
+	})
+
+	t.Run("別のパスキーの公開鍵では検証できない", func(t *testing.T) {
+		other, err := NewSoftwareAuthenticator(testRP)
+		require.NoError(t, err)
+		cd, ao, err := other.Create("test-challenge", []byte("test-user-id"))
+		require.NoError(t, err)
+		oc, err := testRP.VerifyRegistration("test-challenge", cd, ao)
+		require.NoError(t, err)
+
+		_, err = testRP.VerifyAssertion("test-challenge-2", oc.PublicKey, clientDataJSON, authData, signature)
+		assert.ErrorIs(t, err, ErrVerification)
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	data := []byte("test-data")
+
+	t.Run("EdDSA", func(t *testing.T) {
+		require := require.New(t)
+
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(err)
+		cose, err := cbor.Marshal(map[interface{}]interface{}{
+			int64(coseKeyType): int64(coseKeyTypeOKP), int64(coseAlgorithm): int64(AlgEdDSA),
+			int64(coseCurve): int64(coseCurveEd25519), int64(coseX): []byte(pub),
+		})
+		require.NoError(err)
+
+		key, err := ParsePublicKey(cose)
+		require.NoError(err)
+		assert.NoError(t, key.Verify(data, ed25519.Sign(priv, data)))
+		assert.Error(t, key.Verify([]byte("test-other-data"), ed25519.Sign(priv, data)))
+	})
+
+	t.Run("RS256", func(t *testing.T) {
+		require := require.New(t)
+
+		priv, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(err)
+		cose, err := cbor.Marshal(map[interface{}]interface{}{
+			int64(coseKeyType): int64(coseKeyTypeRSA), int64(coseAlgorithm): int64(AlgRS256),
+			int64(coseRSAModulus): priv.N.Bytes(), int64(coseRSAExponent): big.NewInt(int64(priv.E)).Bytes(),
+		})
+		require.NoError(err)
+
+		hash := sha256.Sum256(data)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, hash[:])
+		require.NoError(err)
+
+		key, err := ParsePublicKey(cose)
+		require.NoError(err)
+		assert.NoError(t, key.Verify(data, signature))
+	})
+
+	t.Run("対応していないアルゴリズム", func(t *testing.T) {
+		cose, err := cbor.Marshal(map[interface{}]interface{}{
+			int64(coseKeyType): int64(coseKeyTypeEC2), int64(coseAlgorithm): int64(-35),
+		})
+		require.NoError(t, err)
+
+		_, err = ParsePublicKey(cose)
+		assert.ErrorIs(t, err, ErrVerification)
+	})
+}
@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// GetAvailability は受講可能日の設定を取得します。
+func GetAvailability(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get availability")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToGetAvailabilityRequest(r)
+	if err := request.ValidateGetAvailabilityRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	op := presenter.NewAvailabilityPresenter()
+	interactor := usecase.NewAvailabilityInteractor(logger, zone, ar, br, op)
+	interactor.GetAvailability(port.GetAvailabilityInputData{UserID: userID})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get availability")
+
+	return res, nil
+}
+
+// PutAvailability は受講可能日の設定を更新します。
+func PutAvailability(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start put availability")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req, err := request.ToPutAvailabilityRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidatePutAvailabilityRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	op := presenter.NewAvailabilityPresenter()
+	interactor := usecase.NewAvailabilityInteractor(logger, zone, ar, br, op)
+	interactor.UpdateAvailability(port.UpdateAvailabilityInputData{
+		UserID:            userID,
+		Weekdays:          req.Weekdays,
+		MaxLecturesPerDay: req.MaxLecturesPerDay,
+	})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end put availability")
+
+	return res, nil
+}
+
+// PostBlackout は受講できない期間を作成します。
+func PostBlackout(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start post blackout")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req, err := request.ToPostBlackoutRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidatePostBlackoutRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	op := presenter.NewAvailabilityPresenter()
+	interactor := usecase.NewAvailabilityInteractor(logger, zone, ar, br, op)
+	interactor.CreateBlackout(port.CreateBlackoutInputData{
+		UserID:   userID,
+		Label:    req.Label,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end post blackout")
+
+	return res, nil
+}
+
+// PutBlackout は受講できない期間を更新します。
+func PutBlackout(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start put blackout")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req, err := request.ToPutBlackoutRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidatePutBlackoutRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	br := repository.NewBlackoutRepository(*db)
+
+	blackout, err := br.Read(req.BlackoutID)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusNotFound, port.ErrorCodeBlackoutNotFound, usecase.MsgBlackoutNotFound)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	if blackout.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", blackout.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ar := repository.NewAvailabilityRepository(*db)
+	op := presenter.NewAvailabilityPresenter()
+	interactor := usecase.NewAvailabilityInteractor(logger, zone, ar, br, op)
+	interactor.UpdateBlackout(port.UpdateBlackoutInputData{
+		ID:       req.BlackoutID,
+		Label:    req.Label,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end put blackout")
+
+	return res, nil
+}
+
+// DeleteBlackout は受講できない期間を削除します。
+func DeleteBlackout(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start delete blackout")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToDeleteBlackoutRequest(r)
+	if err := request.ValidateDeleteBlackoutRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	br := repository.NewBlackoutRepository(*db)
+
+	blackout, err := br.Read(req.BlackoutID)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			res := events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNoContent,
+				Headers:    response.CORSHeaders,
+			}
+
+			logger.Info("end delete blackout")
+
+			return res, nil
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	if blackout.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", blackout.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ar := repository.NewAvailabilityRepository(*db)
+	op := presenter.NewAvailabilityPresenter()
+	interactor := usecase.NewAvailabilityInteractor(logger, zone, ar, br, op)
+	interactor.DeleteBlackout(port.DeleteBlackoutInputData{BlackoutID: req.BlackoutID})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end delete blackout")
+
+	return res, nil
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// DispatchEmailOutbox は送信待ちのメールを送信します。スケジュールで定期的に実行します。
+func DispatchEmailOutbox(ctx context.Context, e events.EventBridgeEvent) error {
+	logger := infrastructure.NewLogger()
+	logger.Info("start dispatch email outbox")
+
+	config := infrastructure.GetConfig()
+	mt, err := infrastructure.NewMailTransport(ctx, config)
+	if err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	obr := repository.NewEmailOutboxRepository(*db)
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+
+	ep := presenter.NewEmailOutboxPresenter()
+	interactor := usecase.NewEmailOutboxInteractor(logger, ur, obr, mr, ep)
+	interactor.DispatchEmailOutbox(ctx, port.DispatchEmailOutboxInputData{})
+
+	statusCode, body := ep.GetResponse()
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("failed to dispatch email outbox: %s", body)
+	}
+
+	logger.Info("end dispatch email outbox", "result", body)
+
+	return nil
+}
+
+// GetFailedEmailOutboxes は送信に失敗したメールのリストを取得します。
+func GetFailedEmailOutboxes(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get failed email outboxes")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	ur := repository.NewUserRepository(*db)
+	obr := repository.NewEmailOutboxRepository(*db)
+
+	ep := presenter.NewEmailOutboxPresenter()
+	interactor := usecase.NewEmailOutboxInteractor(logger, ur, obr, nil, ep)
+	interactor.GetFailedEmailOutboxList(port.GetFailedEmailOutboxListInputData{RequesterUserID: userID})
+
+	statusCode, body := ep.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get failed email outboxes")
+
+	return res, nil
+}
+
+// ResendEmailOutbox は送信に失敗したメールを再送します。
+func ResendEmailOutbox(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start resend email outbox")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToResendEmailOutboxRequest(r)
+	if err := request.ValidateResendEmailOutboxRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	mt, err := infrastructure.NewMailTransport(ctx, config)
+	if err != nil {
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	obr := repository.NewEmailOutboxRepository(*db)
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+
+	ep := presenter.NewEmailOutboxPresenter()
+	interactor := usecase.NewEmailOutboxInteractor(logger, ur, obr, mr, ep)
+	interactor.ResendEmailOutbox(ctx, port.ResendEmailOutboxInputData{RequesterUserID: userID, EmailOutboxID: req.EmailOutboxID})
+
+	statusCode, body := ep.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end resend email outbox")
+
+	return res, nil
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// userLocaleResolver は認証トークンのユーザーが設定した言語を取得します。
+type userLocaleResolver struct{}
+
+// NewLocaleResolver は middleware.LocaleResolver を生成します。
+func NewLocaleResolver() middleware.LocaleResolver {
+	return &userLocaleResolver{}
+}
+
+// UserLocale はリクエストしたユーザーが設定した言語を返します。
+// 認証できない場合やユーザーを取得できない場合は空文字を返します。
+func (lr *userLocaleResolver) UserLocale(r events.APIGatewayProxyRequest) string {
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return ""
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		return ""
+	}
+
+	return user.Locale
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// oidcCallbackPath は ID プロバイダーから戻るフロントエンドのコールバックのパスです。
+const oidcCallbackPath = "/signin/oidc/callback"
+
+// newOIDCProviders は設定から ID プロバイダーを生成します。
+// リダイレクト先は BaseUrl のコールバックのページで、ID プロバイダーにも同じ URL を登録します。
+func newOIDCProviders(config infrastructure.Config) ([]*oidc.Provider, error) {
+	configs, err := oidc.ParseProviderConfigs(config.OIDCProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURI := strings.TrimSuffix(config.BaseUrl, "/") + oidcCallbackPath
+	providers := make([]*oidc.Provider, 0, len(configs))
+	for _, c := range configs {
+		providers = append(providers, oidc.NewProvider(c, redirectURI, nil))
+	}
+	return providers, nil
+}
+
+// GetOIDCProviderList はサインインに使える ID プロバイダーの一覧を取得します。
+func GetOIDCProviderList(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get oidc provider list")
+
+	providers, err := newOIDCProviders(infrastructure.GetConfig())
+	if err != nil {
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	op := presenter.NewOIDCPresenter()
+	interactor := usecase.NewOIDCInteractor(logger, providers, nil, nil, nil, nil, nil, nil, nil, op)
+
+	input := port.GetOIDCProviderListInputData{}
+	interactor.GetOIDCProviderList(input)
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get oidc provider list")
+
+	return res, nil
+}
+
+// BeginOIDCSignIn は認可のリクエストを発行して、外部アカウントでのサインインを開始します。
+func BeginOIDCSignIn(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start begin oidc sign in")
+
+	req := request.ToBeginOIDCSignInRequest(r)
+	if err := request.ValidateBeginOIDCSignInRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	providers, err := newOIDCProviders(infrastructure.GetConfig())
+	if err != nil {
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	db := infrastructure.NewDB()
+	osr := repository.NewOIDCStateRepository(*db)
+	op := presenter.NewOIDCPresenter()
+	interactor := usecase.NewOIDCInteractor(logger, providers, nil, nil, nil, nil, nil, nil, osr, op)
+
+	input := port.BeginOIDCSignInInputData{ProviderID: req.ProviderID}
+	interactor.BeginOIDCSignIn(ctx, input)
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end begin oidc sign in")
+
+	return res, nil
+}
+
+// FinishOIDCSignIn は ID プロバイダーから戻った認可コードで ID トークンを検証し、セッションを発行します。
+func FinishOIDCSignIn(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start finish oidc sign in")
+
+	req, err := request.ToFinishOIDCSignInRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateFinishOIDCSignInRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	providers, err := newOIDCProviders(config)
+	if err != nil {
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	tfr := repository.NewTwoFactorRepository(*db)
+	oir := repository.NewOIDCIdentityRepository(*db)
+	osr := repository.NewOIDCStateRepository(*db)
+	op := presenter.NewOIDCPresenter()
+	interactor := usecase.NewOIDCInteractor(logger, providers, ur, sr, rtr, lsr, tfr, oir, osr, op)
+
+	input := port.FinishOIDCSignInInputData{
+		State:     req.State,
+		Code:      req.Code,
+		UserAgent: request.UserAgent(r),
+		IPAddress: request.SourceIP(r),
+	}
+	interactor.FinishOIDCSignIn(ctx, input)
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end finish oidc sign in")
+
+	return res, nil
+}
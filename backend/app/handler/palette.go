@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// GetPalette は標準のパレットの色とユーザーが定義した色を取得します。
+func GetPalette(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get palette")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	cr := repository.NewCustomColorRepository(*db)
+	sr := repository.NewScheduleRepository(*db)
+	sbr := repository.NewSubjectRepository(*db)
+	op := presenter.NewPalettePresenter()
+	interactor := usecase.NewPaletteInteractor(logger, zone, cr, sr, sbr, op)
+	interactor.GetPalette(port.GetPaletteInputData{UserID: userID})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get palette")
+
+	return res, nil
+}
+
+// PostCustomColor はユーザー定義の色を作成します。
+func PostCustomColor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start post custom color")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req, err := request.ToPostCustomColorRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidatePostCustomColorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	cr := repository.NewCustomColorRepository(*db)
+	sr := repository.NewScheduleRepository(*db)
+	sbr := repository.NewSubjectRepository(*db)
+	op := presenter.NewPalettePresenter()
+	interactor := usecase.NewPaletteInteractor(logger, zone, cr, sr, sbr, op)
+	interactor.CreateCustomColor(port.CreateCustomColorInputData{
+		UserID: userID,
+		Name:   req.Name,
+		Hex:    req.Hex,
+		Style:  req.Style,
+	})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end post custom color")
+
+	return res, nil
+}
+
+// DeleteCustomColor はユーザー定義の色を削除します。
+func DeleteCustomColor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start delete custom color")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToDeleteCustomColorRequest(r)
+	if err := request.ValidateDeleteCustomColorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+
+	cr := repository.NewCustomColorRepository(*db)
+
+	customColor, err := cr.Read(req.ColorID)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			res := events.APIGatewayProxyResponse{
+				StatusCode: http.StatusNoContent,
+				Headers:    response.CORSHeaders,
+			}
+
+			logger.Info("end delete custom color")
+
+			return res, nil
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	if customColor.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", customColor.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	sr := repository.NewScheduleRepository(*db)
+	sbr := repository.NewSubjectRepository(*db)
+	op := presenter.NewPalettePresenter()
+	interactor := usecase.NewPaletteInteractor(logger, zone, cr, sr, sbr, op)
+	interactor.DeleteCustomColor(port.DeleteCustomColorInputData{UserID: userID, ColorID: req.ColorID})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end delete custom color")
+
+	return res, nil
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/webauthn"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// newRelyingParty は設定からパスキーの RP を生成します。
+func newRelyingParty(config infrastructure.Config) webauthn.RelyingParty {
+	return webauthn.RelyingParty{
+		ID:     config.WebAuthnRPID,
+		Name:   config.ServiceName,
+		Origin: config.WebAuthnOrigin,
+	}
+}
+
+// BeginPasskeyRegistration はチャレンジを発行して、パスキーの登録を開始します。
+func BeginPasskeyRegistration(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start begin passkey registration")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	am := middleware.NewAuthMiddleware(sr, ur, repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToBeginPasskeyRegistrationRequest(r)
+	if err := request.ValidateBeginPasskeyRegistrationRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	pr := repository.NewPasskeyRepository(*db)
+	cr := repository.NewWebAuthnChallengeRepository(*db)
+	pp := presenter.NewPasskeyPresenter()
+	interactor := usecase.NewPasskeyInteractor(logger, newRelyingParty(config), ur, nil, nil, nil, pr, cr, pp)
+
+	input := port.BeginPasskeyRegistrationInputData{UserID: req.UserID}
+	interactor.BeginPasskeyRegistration(input)
+
+	statusCode, body := pp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end begin passkey registration")
+
+	return res, nil
+}
+
+// FinishPasskeyRegistration は登録の応答を検証して、パスキーを登録します。
+func FinishPasskeyRegistration(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start finish passkey registration")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	am := middleware.NewAuthMiddleware(sr, ur, repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req, err := request.ToFinishPasskeyRegistrationRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateFinishPasskeyRegistrationRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	pr := repository.NewPasskeyRepository(*db)
+	cr := repository.NewWebAuthnChallengeRepository(*db)
+	pp := presenter.NewPasskeyPresenter()
+	interactor := usecase.NewPasskeyInteractor(logger, newRelyingParty(config), ur, nil, nil, nil, pr, cr, pp)
+
+	input := port.FinishPasskeyRegistrationInputData{
+		UserID:            req.UserID,
+		Name:              req.Name,
+		ClientDataJSON:    req.Credential.Response.ClientDataJSON,
+		AttestationObject: req.Credential.Response.AttestationObject,
+	}
+	interactor.FinishPasskeyRegistration(input)
+
+	statusCode, body := pp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end finish passkey registration")
+
+	return res, nil
+}
+
+// GetPasskeyList は登録したパスキーの一覧を取得します。
+func GetPasskeyList(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get passkey list")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	am := middleware.NewAuthMiddleware(sr, ur, repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToGetPasskeyListRequest(r)
+	if err := request.ValidateGetPasskeyListRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	pr := repository.NewPasskeyRepository(*db)
+	pp := presenter.NewPasskeyPresenter()
+	interactor := usecase.NewPasskeyInteractor(logger, newRelyingParty(config), ur, nil, nil, nil, pr, nil, pp)
+
+	input := port.GetPasskeyListInputData{UserID: req.UserID}
+	interactor.GetPasskeyList(input)
+
+	statusCode, body := pp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get passkey list")
+
+	return res, nil
+}
+
+// DeletePasskey は指定されたパスキーを削除します。
+func DeletePasskey(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start delete passkey")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	am := middleware.NewAuthMiddleware(sr, ur, repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToDeletePasskeyRequest(r)
+	if err := request.ValidateDeletePasskeyRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	pr := repository.NewPasskeyRepository(*db)
+	pp := presenter.NewPasskeyPresenter()
+	interactor := usecase.NewPasskeyInteractor(logger, newRelyingParty(config), ur, nil, nil, nil, pr, nil, pp)
+
+	input := port.DeletePasskeyInputData{UserID: req.UserID, PasskeyID: req.PasskeyID}
+	interactor.DeletePasskey(input)
+
+	statusCode, body := pp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end delete passkey")
+
+	return res, nil
+}
+
+// BeginPasskeySignIn はチャレンジを発行して、パスキーによるサインインを開始します。
+func BeginPasskeySignIn(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start begin passkey sign in")
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	cr := repository.NewWebAuthnChallengeRepository(*db)
+	pp := presenter.NewPasskeyPresenter()
+	interactor := usecase.NewPasskeyInteractor(logger, newRelyingParty(config), nil, nil, nil, nil, nil, cr, pp)
+
+	input := port.BeginPasskeySignInInputData{}
+	interactor.BeginPasskeySignIn(input)
+
+	statusCode, body := pp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end begin passkey sign in")
+
+	return res, nil
+}
+
+// FinishPasskeySignIn は認証の応答を登録済みのパスキーで検証し、セッションを発行します。
+func FinishPasskeySignIn(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start finish passkey sign in")
+
+	req, err := request.ToFinishPasskeySignInRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateFinishPasskeySignInRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	pr := repository.NewPasskeyRepository(*db)
+	cr := repository.NewWebAuthnChallengeRepository(*db)
+	pp := presenter.NewPasskeyPresenter()
+	interactor := usecase.NewPasskeyInteractor(logger, newRelyingParty(config), ur, sr, rtr, lsr, pr, cr, pp)
+
+	input := port.FinishPasskeySignInInputData{
+		CredentialID:      req.Credential.ID,
+		ClientDataJSON:    req.Credential.Response.ClientDataJSON,
+		AuthenticatorData: req.Credential.Response.AuthenticatorData,
+		Signature:         req.Credential.Response.Signature,
+		UserHandle:        req.Credential.Response.UserHandle,
+		UserAgent:         request.UserAgent(r),
+		IPAddress:         request.SourceIP(r),
+	}
+	interactor.FinishPasskeySignIn(input)
+
+	statusCode, body := pp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end finish passkey sign in")
+
+	return res, nil
+}
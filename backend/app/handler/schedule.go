@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/middleware"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/datsukan/attendance-plan/backend/app/presenter"
@@ -21,12 +23,13 @@ func GetScheduleList(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	logger.Info("start get schedule list")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -34,31 +37,54 @@ func GetScheduleList(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	req := request.ToGetScheduleListRequest(r)
 	if err := request.ValidateGetScheduleListRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	if req.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", req.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
-
-	input := port.GetScheduleListInputData{UserID: req.UserID}
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
+
+	input := port.GetScheduleListInputData{
+		UserID:       req.UserID,
+		Type:         req.Type,
+		Color:        req.Color,
+		TagID:        req.TagID,
+		NamePrefix:   req.NamePrefix,
+		UpdatedSince: req.UpdatedSince,
+		From:         req.From,
+		To:           req.To,
+		Flat:         req.IsFlat(),
+		Fields:       req.Fields,
+	}
 	interactor.GetScheduleList(input)
 
 	statusCode, body := op.GetResponse()
@@ -79,12 +105,13 @@ func GetSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	logger.Info("start get schedule")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -92,41 +119,53 @@ func GetSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	req := request.ToGetScheduleRequest(r)
 	if err := request.ValidateGetScheduleRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
 
 	schedule, err := sr.Read(req.ScheduleID)
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusNotFound, usecase.MsgScheduleNotFound)
+			return response.NewError(http.StatusNotFound, port.ErrorCodeScheduleNotFound, usecase.MsgScheduleNotFound)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
 	if schedule.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", schedule.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
-	}
-
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
 
 	input := port.GetScheduleInputData{ScheduleID: req.ScheduleID}
 	interactor.GetSchedule(input)
@@ -149,11 +188,12 @@ func PostSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespon
 	logger.Info("start post schedule")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -161,39 +201,57 @@ func PostSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespon
 	req, err := request.ToPostScheduleRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePostScheduleRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
 
 	input := port.CreateScheduleInputData{
 		Schedule: port.CreateScheduleData{
-			UserID:   userID,
-			Name:     req.Name,
-			StartsAt: req.StartsAt,
-			EndsAt:   req.EndsAt,
-			Color:    req.Color,
-			Type:     req.Type,
-			Order:    req.Order,
+			UserID:        userID,
+			Name:          req.Name,
+			StartsAt:      req.StartsAt,
+			EndsAt:        req.EndsAt,
+			Timed:         req.Timed,
+			Color:         req.Color,
+			Type:          req.Type,
+			Order:         req.Order,
+			LectureNumber: req.LectureNumber,
+			Note:          req.Note,
+			Links:         req.Links,
+			Memo:          req.Memo,
+			TagIDs:        req.TagIDs,
 		},
 	}
 	interactor.CreateSchedule(input)
@@ -216,11 +274,12 @@ func PostBulkSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRe
 	logger.Info("start post bulk schedule")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -228,44 +287,62 @@ func PostBulkSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRe
 	req, err := request.ToPostBulkScheduleRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePostBulkScheduleRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
 
 	schedules := make([]port.CreateScheduleData, len(req.Schedules))
 	for i, s := range req.Schedules {
 		schedules[i] = port.CreateScheduleData{
-			UserID:   userID,
-			Name:     s.Name,
-			StartsAt: s.StartsAt,
-			EndsAt:   s.EndsAt,
-			Color:    s.Color,
-			Type:     s.Type,
-			Order:    s.Order,
+			UserID:        userID,
+			Name:          s.Name,
+			StartsAt:      s.StartsAt,
+			EndsAt:        s.EndsAt,
+			Timed:         s.Timed,
+			Color:         s.Color,
+			Type:          s.Type,
+			Order:         s.Order,
+			LectureNumber: s.LectureNumber,
+			Note:          s.Note,
+			Links:         s.Links,
+			Memo:          s.Memo,
+			TagIDs:        s.TagIDs,
 		}
 	}
 
 	input := port.CreateBulkScheduleInputData{Schedules: schedules}
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
 	interactor.CreateBulkSchedule(input)
 
 	statusCode, body := op.GetResponse()
@@ -286,11 +363,12 @@ func PutSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	logger.Info("start put schedule")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -298,56 +376,74 @@ func PutSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	req, err := request.ToPutScheduleRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePutScheduleRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
 
 	schedule, err := sr.Read(req.ScheduleID)
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusNotFound, usecase.MsgScheduleNotFound)
+			return response.NewError(http.StatusNotFound, port.ErrorCodeScheduleNotFound, usecase.MsgScheduleNotFound)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
 	if schedule.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", schedule.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
-	}
-
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
 
 	input := port.UpdateScheduleInputData{
 		Schedule: port.UpdateScheduleData{
-			ID:       req.ScheduleID,
-			Name:     req.Name,
-			StartsAt: req.StartsAt,
-			EndsAt:   req.EndsAt,
-			Color:    req.Color,
-			Type:     req.Type,
-			Order:    req.Order,
+			ID:            req.ScheduleID,
+			Name:          req.Name,
+			StartsAt:      req.StartsAt,
+			EndsAt:        req.EndsAt,
+			Timed:         req.Timed,
+			Color:         req.Color,
+			Type:          req.Type,
+			Order:         req.Order,
+			LectureNumber: req.LectureNumber,
+			Note:          req.Note,
+			Links:         req.Links,
+			Memo:          req.Memo,
+			TagIDs:        req.TagIDs,
 		},
 	}
 	interactor.UpdateSchedule(input)
@@ -370,11 +466,12 @@ func PutBulkSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	logger.Info("start put bulk schedule")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -382,26 +479,29 @@ func PutBulkSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	req, err := request.ToPutBulkScheduleRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePutBulkScheduleRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
 
 	schedules := make([]port.UpdateScheduleData, len(req.Schedules))
@@ -410,32 +510,47 @@ func PutBulkSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 		if err != nil {
 			if errors.Is(err, repository.NewNotFoundError()) {
 				logger.Warn(err.Error())
-				return response.NewError(http.StatusNotFound, usecase.MsgScheduleNotFound)
+				return response.NewError(http.StatusNotFound, port.ErrorCodeScheduleNotFound, usecase.MsgScheduleNotFound)
 			}
 
 			logger.Error(err.Error())
-			return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+			return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 		}
 
 		if schedule.UserID != userID {
 			logger.Warn("forbidden", "request_user_id", schedule.UserID)
-			return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+			return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 		}
 
 		schedules[i] = port.UpdateScheduleData{
-			ID:       s.ScheduleID,
-			Name:     s.Name,
-			StartsAt: s.StartsAt,
-			EndsAt:   s.EndsAt,
-			Color:    s.Color,
-			Type:     s.Type,
-			Order:    s.Order,
+			ID:            s.ScheduleID,
+			Name:          s.Name,
+			StartsAt:      s.StartsAt,
+			EndsAt:        s.EndsAt,
+			Timed:         s.Timed,
+			Color:         s.Color,
+			Type:          s.Type,
+			Order:         s.Order,
+			LectureNumber: s.LectureNumber,
+			Note:          s.Note,
+			Links:         s.Links,
+			Memo:          s.Memo,
+			TagIDs:        s.TagIDs,
 		}
 	}
 
 	input := port.UpdateBulkScheduleInputData{Schedules: schedules}
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
 	interactor.UpdateBulkSchedule(input)
 
 	statusCode, body := op.GetResponse()
@@ -456,12 +571,13 @@ func DeleteSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResp
 	logger.Info("start delete schedule")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -469,21 +585,24 @@ func DeleteSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResp
 	req := request.ToDeleteScheduleRequest(r)
 	if err := request.ValidateDeleteScheduleRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
 	sr := repository.NewScheduleRepository(*db)
 
 	schedule, err := sr.Read(req.ScheduleID)
@@ -501,16 +620,25 @@ func DeleteSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResp
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
 	if schedule.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", schedule.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
-	}
-
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSchedulePresenter()
-	interactor := usecase.NewScheduleInteractor(logger, sr, op)
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
 
 	input := port.DeleteScheduleInputData{ScheduleID: req.ScheduleID}
 	interactor.DeleteSchedule(input)
@@ -526,3 +654,123 @@ func DeleteSchedule(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResp
 
 	return res, nil
 }
+
+// GetScheduleLint はスケジュールの警告一覧を取得します。
+func GetScheduleLint(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get schedule lint")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
+	sr := repository.NewScheduleRepository(*db)
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
+	op := presenter.NewSchedulePresenter()
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
+	interactor.LintSchedule(port.LintScheduleInputData{UserID: userID})
+
+	statusCode, body := op.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get schedule lint")
+
+	return res, nil
+}
+
+// GetScheduleExport はユーザーのスケジュールを iCalendar 形式でエクスポートします。
+func GetScheduleExport(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get schedule export")
+
+	config := infrastructure.GetConfig()
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	ur := repository.NewUserRepository(*db)
+	user, err := ur.Read(userID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			logger.Warn(err.Error())
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+		}
+
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+	locale := i18n.Resolve(user.Locale, request.AcceptLanguage(r))
+
+	sr := repository.NewScheduleRepository(*db)
+	ar := repository.NewAvailabilityRepository(*db)
+	br := repository.NewBlackoutRepository(*db)
+	daq := usecase.NewDayAvailabilityQuery(ar, br, sr)
+	linter := usecase.NewScheduleLinter(zone, locale, sr, ur, daq)
+	tr := repository.NewTagRepository(*db)
+	str := repository.NewScheduleTagRepository(*db)
+	tagger := usecase.NewScheduleTagger(tr, str)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
+	op := presenter.NewSchedulePresenter()
+	interactor := usecase.NewScheduleInteractor(logger, zone, sr, linter, tagger, cv, op)
+	interactor.ExportSchedule(port.ExportScheduleInputData{UserID: userID})
+
+	statusCode, body := op.GetResponse()
+	headers := response.CORSHeaders
+	if statusCode == http.StatusOK {
+		headers = response.ICalendarHeaders
+	}
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    headers,
+	}
+
+	logger.Info("end get schedule export")
+
+	return res, nil
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// RefreshSession はリフレッシュトークンでアクセストークンを再発行します。
+func RefreshSession(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start refresh session")
+
+	req, err := request.ToRefreshSessionRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateRefreshSessionRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	sp := presenter.NewSessionPresenter()
+	interactor := usecase.NewSessionInteractor(logger, ur, sr, rtr, lsr, sp)
+
+	input := port.RefreshSessionInputData{
+		RefreshToken: req.RefreshToken,
+		UserAgent:    request.UserAgent(r),
+		IPAddress:    request.SourceIP(r),
+	}
+	interactor.RefreshSession(input)
+
+	statusCode, body := sp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end refresh session")
+
+	return res, nil
+}
+
+// SignOut はリフレッシュトークンを無効にしてサインアウトします。
+func SignOut(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start sign out")
+
+	req, err := request.ToSignOutRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateSignOutRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	db := infrastructure.NewDB()
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	sp := presenter.NewSessionPresenter()
+	interactor := usecase.NewSessionInteractor(logger, nil, nil, rtr, lsr, sp)
+
+	input := port.SignOutInputData{RefreshToken: req.RefreshToken}
+	interactor.SignOut(input)
+
+	statusCode, body := sp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end sign out")
+
+	return res, nil
+}
+
+// GetLoginSessions はサインインしている端末のログインセッションのリストを取得します。
+func GetLoginSessions(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get login sessions")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToGetLoginSessionListRequest(r)
+	if err := request.ValidateGetLoginSessionListRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	sp := presenter.NewSessionPresenter()
+	interactor := usecase.NewSessionInteractor(logger, ur, nil, nil, lsr, sp)
+
+	input := port.GetLoginSessionListInputData{UserID: req.UserID}
+	interactor.GetLoginSessionList(input)
+
+	statusCode, body := sp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get login sessions")
+
+	return res, nil
+}
+
+// RevokeLoginSession は指定されたログインセッションを無効にします。
+func RevokeLoginSession(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start revoke login session")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToRevokeLoginSessionRequest(r)
+	if err := request.ValidateRevokeLoginSessionRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	sp := presenter.NewSessionPresenter()
+	interactor := usecase.NewSessionInteractor(logger, nil, nil, rtr, lsr, sp)
+
+	input := port.RevokeLoginSessionInputData{UserID: req.UserID, SessionID: req.SessionID}
+	interactor.RevokeLoginSession(input)
+
+	statusCode, body := sp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end revoke login session")
+
+	return res, nil
+}
+
+// RevokeAllLoginSessions はすべての端末のログインセッションを無効にします。
+func RevokeAllLoginSessions(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start revoke all login sessions")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToRevokeAllLoginSessionsRequest(r)
+	if err := request.ValidateRevokeAllLoginSessionsRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	sp := presenter.NewSessionPresenter()
+	interactor := usecase.NewSessionInteractor(logger, ur, nil, rtr, lsr, sp)
+
+	input := port.RevokeAllLoginSessionsInputData{UserID: req.UserID}
+	interactor.RevokeAllLoginSessions(input)
+
+	statusCode, body := sp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end revoke all login sessions")
+
+	return res, nil
+}
+
+// GetJWKS はトークンの検証に使える公開鍵の一覧を JWKS の形式で取得します。
+func GetJWKS(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get jwks")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	sp := presenter.NewSessionPresenter()
+	interactor := usecase.NewSessionInteractor(logger, nil, sr, nil, nil, sp)
+
+	interactor.GetJWKS(port.GetJWKSInputData{})
+
+	statusCode, body := sp.GetResponse()
+	headers := response.CORSHeaders
+	if statusCode == http.StatusOK {
+		headers = response.JWKSHeaders
+	}
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    headers,
+	}
+
+	logger.Info("end get jwks")
+
+	return res, nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/middleware"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/datsukan/attendance-plan/backend/app/presenter"
@@ -21,12 +22,13 @@ func GetSubjectList(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResp
 	logger.Info("start get subject list")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -34,29 +36,33 @@ func GetSubjectList(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResp
 	req := request.ToGetSubjectListRequest(r)
 	if err := request.ValidateGetSubjectListRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	if req.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", req.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+
 	sr := repository.NewSubjectRepository(*db)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSubjectPresenter()
-	interactor := usecase.NewSubjectInteractor(logger, sr, op)
+	interactor := usecase.NewSubjectInteractor(logger, zone, sr, cv, op)
 	interactor.GetSubjectList(port.GetSubjectListInputData{UserID: userID})
 
 	statusCode, body := op.GetResponse()
@@ -77,12 +83,13 @@ func PostSubject(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	logger.Info("start post subject")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -90,29 +97,33 @@ func PostSubject(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	req, err := request.ToPostSubjectRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, err.Error())
 	}
 
 	if err := request.ValidatePostSubjectRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+
 	sr := repository.NewSubjectRepository(*db)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSubjectPresenter()
-	interactor := usecase.NewSubjectInteractor(logger, sr, op)
+	interactor := usecase.NewSubjectInteractor(logger, zone, sr, cv, op)
 	interactor.CreateSubject(port.CreateSubjectInputData{
 		UserID: userID,
 		Name:   req.Name,
@@ -137,12 +148,13 @@ func DeleteSubject(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespo
 	logger.Info("start delete subject")
 
 	config := infrastructure.GetConfig()
-	ssRepo := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(ssRepo)
+	ssRepo := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(ssRepo, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -150,24 +162,28 @@ func DeleteSubject(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespo
 	req := request.ToDeleteSubjectRequest(r)
 	if err := request.ValidateDeleteSubjectRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	if _, err := ur.Read(userID, true); err != nil {
+	user, err := ur.Read(userID, true)
+	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			logger.Warn(err.Error())
-			return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+			return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 		}
 
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
+	zone := timezone.LoadOrDefault(user.Timezone)
+
 	sr := repository.NewSubjectRepository(*db)
+	cr := repository.NewCustomColorRepository(*db)
+	cv := usecase.NewColorValidator(cr)
 	op := presenter.NewSubjectPresenter()
-	interactor := usecase.NewSubjectInteractor(logger, sr, op)
+	interactor := usecase.NewSubjectInteractor(logger, zone, sr, cv, op)
 	interactor.DeleteSubject(port.DeleteSubjectInputData{SubjectID: req.SubjectID})
 
 	statusCode, body := op.GetResponse()
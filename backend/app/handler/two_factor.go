@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/presenter"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/datsukan/attendance-plan/backend/app/usecase"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// GetTwoFactor は二段階認証の設定を取得します。
+func GetTwoFactor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start get two factor")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToGetTwoFactorRequest(r)
+	if err := request.ValidateGetTwoFactorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	tfr := repository.NewTwoFactorRepository(*db)
+	tp := presenter.NewTwoFactorPresenter()
+	interactor := usecase.NewTwoFactorInteractor(logger, nil, nil, nil, nil, nil, nil, tfr, tp)
+
+	input := port.GetTwoFactorInputData{UserID: req.UserID}
+	interactor.GetTwoFactor(input)
+
+	statusCode, body := tp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end get two factor")
+
+	return res, nil
+}
+
+// EnrollTwoFactor は二段階認証の設定を開始し、認証アプリに登録する otpauth URI を返します。
+func EnrollTwoFactor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start enroll two factor")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToEnrollTwoFactorRequest(r)
+	if err := request.ValidateEnrollTwoFactorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	tfr := repository.NewTwoFactorRepository(*db)
+	tp := presenter.NewTwoFactorPresenter()
+	interactor := usecase.NewTwoFactorInteractor(logger, ur, nil, nil, nil, nil, nil, tfr, tp)
+
+	input := port.EnrollTwoFactorInputData{UserID: req.UserID}
+	interactor.EnrollTwoFactor(input)
+
+	statusCode, body := tp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end enroll two factor")
+
+	return res, nil
+}
+
+// ActivateTwoFactor は認証コードを確認して二段階認証を有効にし、リカバリーコードを返します。
+func ActivateTwoFactor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start activate two factor")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req, err := request.ToActivateTwoFactorRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateActivateTwoFactorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	if req.UserID != userID {
+		logger.Warn("forbidden", "request_user_id", req.UserID)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
+	}
+
+	tfr := repository.NewTwoFactorRepository(*db)
+	tp := presenter.NewTwoFactorPresenter()
+	interactor := usecase.NewTwoFactorInteractor(logger, nil, nil, nil, nil, nil, nil, tfr, tp)
+
+	input := port.ActivateTwoFactorInputData{UserID: req.UserID, Code: req.Code}
+	interactor.ActivateTwoFactor(input)
+
+	statusCode, body := tp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end activate two factor")
+
+	return res, nil
+}
+
+// ResetTwoFactor は指定されたユーザーの二段階認証をリセットします。管理者のみ実行できます。
+func ResetTwoFactor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start reset two factor")
+
+	config := infrastructure.GetConfig()
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
+	userID, err := am.Auth(r)
+	if err != nil {
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
+	}
+
+	logger.With("user_id", userID)
+
+	req := request.ToResetTwoFactorRequest(r)
+	if err := request.ValidateResetTwoFactorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	ur := repository.NewUserRepository(*db)
+	tfr := repository.NewTwoFactorRepository(*db)
+	tp := presenter.NewTwoFactorPresenter()
+	interactor := usecase.NewTwoFactorInteractor(logger, ur, nil, nil, nil, nil, nil, tfr, tp)
+
+	input := port.ResetTwoFactorInputData{RequesterUserID: userID, UserID: req.UserID}
+	interactor.ResetTwoFactor(input)
+
+	statusCode, body := tp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end reset two factor")
+
+	return res, nil
+}
+
+// VerifyTwoFactor はサインインで返したトークンと認証コードを確認し、サインインを完了します。
+func VerifyTwoFactor(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start verify two factor")
+
+	req, err := request.ToVerifyTwoFactorRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateVerifyTwoFactorRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	utr := repository.NewUsedTokenRepository(*db)
+	rlr := repository.NewRateLimitRepository(*db)
+	tfr := repository.NewTwoFactorRepository(*db)
+	tp := presenter.NewTwoFactorPresenter()
+	interactor := usecase.NewTwoFactorInteractor(logger, ur, sr, rtr, lsr, utr, rlr, tfr, tp)
+
+	input := port.VerifyTwoFactorInputData{
+		TwoFactorToken: req.TwoFactorToken,
+		Code:           req.Code,
+		RecoveryCode:   req.RecoveryCode,
+		UserAgent:      request.UserAgent(r),
+		IPAddress:      request.SourceIP(r),
+	}
+	interactor.VerifyTwoFactor(input)
+
+	statusCode, body := tp.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end verify two factor")
+
+	return response.WithRetryAfter(res), nil
+}
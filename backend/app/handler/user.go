@@ -16,30 +16,50 @@ import (
 )
 
 // SignIn はサインインを行います。
-func SignIn(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+func SignIn(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	logger := infrastructure.NewLogger()
 	logger.Info("start sign in")
 
 	req, err := request.ToSignInRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidateSignInRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	config := infrastructure.GetConfig()
 	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	rlr := repository.NewRateLimitRepository(*db)
+	tfr := repository.NewTwoFactorRepository(*db)
+
+	// ロックした場合にロックを解除するメールを送信する
+	mt, err := infrastructure.NewMailTransport(ctx, config)
+	if err != nil {
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+	obr := repository.NewEmailOutboxRepository(*db)
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, up)
+	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, obr, nil, rtr, lsr, rlr, tfr, up)
 
-	input := port.SignInInputData{Email: req.Email, Password: req.Password}
-	interactor.SignIn(input)
+	input := port.SignInInputData{
+		Email:          req.Email,
+		Password:       req.Password,
+		UserAgent:      request.UserAgent(r),
+		IPAddress:      request.SourceIP(r),
+		AcceptLanguage: req.AcceptLanguage,
+	}
+	interactor.SignIn(ctx, input)
 
 	statusCode, body := up.GetResponse()
 	res := events.APIGatewayProxyResponse{
@@ -50,7 +70,7 @@ func SignIn(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, er
 
 	logger.Info("end sign in")
 
-	return res, nil
+	return response.WithRetryAfter(res), nil
 }
 
 // SignUp はサインアップを行います。
@@ -61,30 +81,32 @@ func SignUp(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGat
 	req, err := request.ToSignUpRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidateSignUpRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	config := infrastructure.GetConfig()
 	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
 
-	mc, err := infrastructure.NewMailClient(ctx, config.SESRegion)
+	mt, err := infrastructure.NewMailTransport(ctx, config)
 	if err != nil {
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
-	mr := repository.NewEmailRepository(mc, config.SenderEmail, config.SenderName)
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+	obr := repository.NewEmailOutboxRepository(*db)
+	rlr := repository.NewRateLimitRepository(*db)
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, up)
+	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, obr, nil, nil, nil, rlr, nil, up)
 
-	input := port.SignUpInputData{Email: req.Email}
+	input := port.SignUpInputData{Email: req.Email, AcceptLanguage: req.AcceptLanguage}
 	interactor.SignUp(ctx, input)
 
 	statusCode, body := up.GetResponse()
@@ -96,7 +118,7 @@ func SignUp(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGat
 
 	logger.Info("end sign up")
 
-	return res, nil
+	return response.WithRetryAfter(res), nil
 }
 
 // PasswordReset はパスワードリセットを行います。
@@ -107,30 +129,32 @@ func PasswordReset(ctx context.Context, r events.APIGatewayProxyRequest) (events
 	req, err := request.ToPasswordResetRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePasswordResetRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	config := infrastructure.GetConfig()
 	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
 
-	mc, err := infrastructure.NewMailClient(ctx, config.SESRegion)
+	mt, err := infrastructure.NewMailTransport(ctx, config)
 	if err != nil {
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
-	mr := repository.NewEmailRepository(mc, config.SenderEmail, config.SenderName)
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+	obr := repository.NewEmailOutboxRepository(*db)
+	rlr := repository.NewRateLimitRepository(*db)
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, up)
+	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, obr, nil, nil, nil, rlr, nil, up)
 
-	input := port.PasswordResetInputData{Email: req.Email}
+	input := port.PasswordResetInputData{Email: req.Email, AcceptLanguage: req.AcceptLanguage}
 	interactor.PasswordReset(ctx, input)
 
 	statusCode, body := up.GetResponse()
@@ -142,7 +166,7 @@ func PasswordReset(ctx context.Context, r events.APIGatewayProxyRequest) (events
 
 	logger.Info("end sign up")
 
-	return res, nil
+	return response.WithRetryAfter(res), nil
 }
 
 // PasswordSet はパスワード設定を行います。
@@ -153,20 +177,21 @@ func PasswordSet(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespons
 	req, err := request.ToPasswordSetRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePasswordSetRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	config := infrastructure.GetConfig()
 	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	utr := repository.NewUsedTokenRepository(*db)
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, up)
+	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, nil, utr, nil, nil, nil, nil, up)
 
 	input := port.PasswordSetInputData{Token: req.Token, Password: req.Password}
 	interactor.PasswordSet(input)
@@ -189,11 +214,12 @@ func GetUser(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, e
 	logger.Info("start get user")
 
 	config := infrastructure.GetConfig()
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(sr)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -201,19 +227,18 @@ func GetUser(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, e
 	req := request.ToGetUserRequest(r)
 	if err := request.ValidateGetUserRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	if req.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", req.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
 
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, nil, nil, up)
+	interactor := usecase.NewUserInteractor(logger, ur, nil, nil, nil, nil, nil, nil, nil, nil, up)
 
 	input := port.GetUserInputData{UserID: req.UserID}
 	interactor.GetUser(input)
@@ -236,11 +261,12 @@ func PutUser(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, e
 	logger.Info("start update user")
 
 	config := infrastructure.GetConfig()
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(sr)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -248,28 +274,30 @@ func PutUser(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, e
 	req, err := request.ToPutUserRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidatePutUserRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	if req.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", req.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
 
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, nil, nil, up)
+	interactor := usecase.NewUserInteractor(logger, ur, nil, nil, nil, nil, nil, nil, nil, nil, up)
 
 	input := port.UpdateUserInputData{
-		UserID: req.UserID,
-		Name:   req.Name,
+		UserID:             req.UserID,
+		Name:               req.Name,
+		Timezone:           req.Timezone,
+		Locale:             req.Locale,
+		SequenceStrictness: req.SequenceStrictness,
 	}
 	interactor.UpdateUser(input)
 
@@ -291,11 +319,12 @@ func DeleteUser(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse
 	logger.Info("start delete user")
 
 	config := infrastructure.GetConfig()
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(sr)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -303,19 +332,18 @@ func DeleteUser(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse
 	req := request.ToDeleteUserRequest(r)
 	if err := request.ValidateDeleteUserRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	if req.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", req.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
 
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, nil, nil, up)
+	interactor := usecase.NewUserInteractor(logger, ur, nil, nil, nil, nil, nil, nil, nil, nil, up)
 
 	input := port.DeleteUserInputData{UserID: req.UserID}
 	interactor.DeleteUser(input)
@@ -338,11 +366,12 @@ func ResetEmail(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse
 	logger.Info("start reset email")
 
 	config := infrastructure.GetConfig()
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(sr)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -350,33 +379,33 @@ func ResetEmail(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse
 	req, err := request.ToResetEmailRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidateResetEmailRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
 	if req.UserID != userID {
 		logger.Warn("forbidden", "request_user_id", req.UserID)
-		return response.NewError(http.StatusForbidden, usecase.MsgUserNotFound)
+		return response.NewError(http.StatusForbidden, port.ErrorCodeAuthForbidden, usecase.MsgUserNotFound)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
 
-	mc, err := infrastructure.NewMailClient(context.Background(), config.SESRegion)
+	mt, err := infrastructure.NewMailTransport(context.Background(), config)
 	if err != nil {
 		logger.Error(err.Error())
-		return response.NewError(http.StatusInternalServerError, usecase.MsgInternalServerError)
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
 	}
 
-	mr := repository.NewEmailRepository(mc, config.SenderEmail, config.SenderName)
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+	obr := repository.NewEmailOutboxRepository(*db)
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, up)
+	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, obr, nil, nil, nil, nil, nil, up)
 
-	input := port.ResetEmailInputData{UserID: req.UserID, Email: req.Email}
+	input := port.ResetEmailInputData{UserID: req.UserID, Email: req.Email, AcceptLanguage: req.AcceptLanguage}
 	interactor.ResetEmail(input)
 
 	statusCode, body := up.GetResponse()
@@ -396,11 +425,12 @@ func SetEmail(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse,
 	logger.Info("start set email")
 
 	config := infrastructure.GetConfig()
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(sr)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
@@ -408,20 +438,20 @@ func SetEmail(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse,
 	req, err := request.ToSetEmailRequest(r)
 	if err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, usecase.MsgRequestFormatInvalid)
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
 	}
 
 	if err := request.ValidateSetEmailRequest(req); err != nil {
 		logger.Warn(err.Error())
-		return response.NewError(http.StatusBadRequest, err.Error())
+		return response.NewBadRequestError(err)
 	}
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
+	utr := repository.NewUsedTokenRepository(*db)
 	up := presenter.NewUserPresenter()
-	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, up)
+	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, nil, utr, nil, nil, nil, nil, up)
 
-	input := port.SetEmailInputData{UserIDToken: req.UserIDToken, EmailToken: req.EmailToken}
+	input := port.SetEmailInputData{UserID: userID, Token: req.Token}
 	interactor.SetEmail(input)
 
 	statusCode, body := up.GetResponse()
@@ -435,3 +465,138 @@ func SetEmail(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse,
 
 	return res, nil
 }
+
+// UnlockAccount はサインインの失敗によるロックを解除します。
+func UnlockAccount(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start unlock account")
+
+	req, err := request.ToUnlockAccountRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateUnlockAccountRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	utr := repository.NewUsedTokenRepository(*db)
+	rlr := repository.NewRateLimitRepository(*db)
+	up := presenter.NewUserPresenter()
+	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, nil, utr, nil, nil, rlr, nil, up)
+
+	input := port.UnlockAccountInputData{Token: req.Token}
+	interactor.UnlockAccount(input)
+
+	statusCode, body := up.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end unlock account")
+
+	return res, nil
+}
+
+// RequestMagicLink はパスワードを使わずにサインインするリンクをメールで送信します。
+func RequestMagicLink(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start request magic link")
+
+	req, err := request.ToRequestMagicLinkRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateRequestMagicLinkRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+
+	mt, err := infrastructure.NewMailTransport(ctx, config)
+	if err != nil {
+		logger.Error(err.Error())
+		return response.NewError(http.StatusInternalServerError, port.ErrorCodeInternal, usecase.MsgInternalServerError)
+	}
+
+	mr := repository.NewEmailRepository(mt, config.SenderEmail, config.SenderName)
+	obr := repository.NewEmailOutboxRepository(*db)
+	rlr := repository.NewRateLimitRepository(*db)
+	up := presenter.NewUserPresenter()
+	interactor := usecase.NewUserInteractor(logger, ur, sr, mr, obr, nil, nil, nil, rlr, nil, up)
+
+	input := port.RequestMagicLinkInputData{Email: req.Email, AcceptLanguage: req.AcceptLanguage}
+	interactor.RequestMagicLink(ctx, input)
+
+	statusCode, body := up.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end request magic link")
+
+	return response.WithRetryAfter(res), nil
+}
+
+// SignInMagicLink はサインインのリンクのトークンでサインインします。
+func SignInMagicLink(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	logger := infrastructure.NewLogger()
+	logger.Info("start sign in magic link")
+
+	req, err := request.ToSignInMagicLinkRequest(r)
+	if err != nil {
+		logger.Warn(err.Error())
+		return response.NewError(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, usecase.MsgRequestFormatInvalid)
+	}
+
+	if err := request.ValidateSignInMagicLinkRequest(req); err != nil {
+		logger.Warn(err.Error())
+		return response.NewBadRequestError(err)
+	}
+
+	config := infrastructure.GetConfig()
+	db := infrastructure.NewDB()
+	ur := repository.NewUserRepository(*db)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	utr := repository.NewUsedTokenRepository(*db)
+	rtr := repository.NewRefreshTokenRepository(*db)
+	lsr := repository.NewLoginSessionRepository(*db)
+	tfr := repository.NewTwoFactorRepository(*db)
+	up := presenter.NewUserPresenter()
+	interactor := usecase.NewUserInteractor(logger, ur, sr, nil, nil, utr, rtr, lsr, nil, tfr, up)
+
+	input := port.SignInMagicLinkInputData{
+		Token:     req.Token,
+		Nonce:     req.Nonce,
+		UserAgent: request.UserAgent(r),
+		IPAddress: request.SourceIP(r),
+	}
+	interactor.SignInMagicLink(input)
+
+	statusCode, body := up.GetResponse()
+	res := events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    response.CORSHeaders,
+	}
+
+	logger.Info("end sign in magic link")
+
+	return res, nil
+}
@@ -19,16 +19,16 @@ func GetUserUsages(r events.APIGatewayProxyRequest) (events.APIGatewayProxyRespo
 	logger.Info("start get user usages")
 
 	config := infrastructure.GetConfig()
-	sr := repository.NewSessionRepository(config.SecretKey, config.TokenLifeDays)
-	am := middleware.NewAuthMiddleware(sr)
+	sr := repository.NewSessionRepository(config.SecretKey, config.SigningKeys, config.AccessTokenLifeMinutes)
+	db := infrastructure.NewDB()
+	am := middleware.NewAuthMiddleware(sr, repository.NewUserRepository(*db), repository.NewLoginSessionRepository(*db))
 	userID, err := am.Auth(r)
 	if err != nil {
-		return response.NewError(http.StatusUnauthorized, usecase.MsgUnauthorized)
+		return response.NewError(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, usecase.MsgUnauthorized)
 	}
 
 	logger.With("user_id", userID)
 
-	db := infrastructure.NewDB()
 	ur := repository.NewUserRepository(*db)
 	sbr := repository.NewSubjectRepository(*db)
 	scr := repository.NewScheduleRepository(*db)
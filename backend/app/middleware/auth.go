@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/datsukan/attendance-plan/backend/app/repository"
@@ -13,11 +14,17 @@ type AuthMiddleware interface {
 }
 
 type AuthMiddlewareImpl struct {
-	SessionRepository repository.SessionRepository
+	SessionRepository      repository.SessionRepository
+	UserRepository         repository.UserRepository
+	LoginSessionRepository repository.LoginSessionRepository
 }
 
-func NewAuthMiddleware(sessionRepository repository.SessionRepository) AuthMiddleware {
-	return &AuthMiddlewareImpl{SessionRepository: sessionRepository}
+func NewAuthMiddleware(sessionRepository repository.SessionRepository, userRepository repository.UserRepository, loginSessionRepository repository.LoginSessionRepository) AuthMiddleware {
+	return &AuthMiddlewareImpl{
+		SessionRepository:      sessionRepository,
+		UserRepository:         userRepository,
+		LoginSessionRepository: loginSessionRepository,
+	}
 }
 
 // Auth は認証処理を行います。
@@ -37,10 +44,30 @@ func (m *AuthMiddlewareImpl) Auth(r events.APIGatewayProxyRequest) (userID strin
 
 	sessionToken := strings.TrimPrefix(authorization, "Bearer ")
 
-	valid, userID := m.SessionRepository.IsValidToken(sessionToken)
-	if !valid {
+	// 有効期間の短いアクセストークンのみ受け付け、リフレッシュトークンやパスワード設定、メールアドレス変更のトークンは受け付けない
+	token, err := m.SessionRepository.ParseToken(sessionToken, repository.TokenPurposeSession)
+	if err != nil {
+		return "", errors.New("unauthorized")
+	}
+
+	// パスワードの設定やサインアウトで無効にしたセッションのアクセストークンは、有効期限内でも受け付けない
+	user, err := m.UserRepository.Read(token.Subject, true)
+	if err != nil {
+		return "", errors.New("unauthorized")
+	}
+
+	if user.TokenVersion != token.Version {
+		return "", errors.New("unauthorized")
+	}
+
+	session, err := m.LoginSessionRepository.Read(token.SessionID)
+	if err != nil {
+		return "", errors.New("unauthorized")
+	}
+
+	if !session.IsActive(user, time.Now()) {
 		return "", errors.New("unauthorized")
 	}
 
-	return userID, nil
+	return user.ID, nil
 }
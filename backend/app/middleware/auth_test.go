@@ -9,10 +9,18 @@ import (
 )
 
 func TestAuth(t *testing.T) {
+	sr := repository.NewSessionRepository("test-secret-key", "", 1)
+	passwordSetToken, err := sr.IssueToken(repository.TokenPurposePasswordSet, "user-id", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
 		name        string
 		req         events.APIGatewayProxyRequest
 		sessionRepo repository.SessionRepository
+		userRepo    repository.UserRepository
+		loginRepo   repository.LoginSessionRepository
 		wantError   string
 	}{
 		{
@@ -44,11 +52,38 @@ func TestAuth(t *testing.T) {
 			sessionRepo: NewStubFailSessionRepository(),
 			wantError:   "unauthorized",
 		},
+		{
+			name:        "未認証: パスワード設定のトークン",
+			req:         events.APIGatewayProxyRequest{Headers: map[string]string{"Authorization": "Bearer " + passwordSetToken}},
+			sessionRepo: sr,
+			wantError:   "unauthorized",
+		},
+		{
+			name:        "未認証: ユーザーのセッションの世代が上がった",
+			req:         events.APIGatewayProxyRequest{Headers: map[string]string{"Authorization": "Bearer token"}},
+			sessionRepo: NewStubSuccessSessionRepository(),
+			userRepo:    NewStubUserRepository(2),
+			wantError:   "unauthorized",
+		},
+		{
+			name:        "未認証: ログインセッションが無効",
+			req:         events.APIGatewayProxyRequest{Headers: map[string]string{"Authorization": "Bearer token"}},
+			sessionRepo: NewStubSuccessSessionRepository(),
+			loginRepo:   NewStubLoginSessionRepository(true),
+			wantError:   "unauthorized",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := NewAuthMiddleware(tt.sessionRepo)
+			if tt.userRepo == nil {
+				tt.userRepo = NewStubUserRepository(1)
+			}
+			if tt.loginRepo == nil {
+				tt.loginRepo = NewStubLoginSessionRepository(false)
+			}
+
+			m := NewAuthMiddleware(tt.sessionRepo, tt.userRepo, tt.loginRepo)
 			userID, err := m.Auth(tt.req)
 
 			assert := assert.New(t)
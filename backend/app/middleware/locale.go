@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/datsukan/attendance-plan/backend/app/request"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// LocaleResolver はリクエストしたユーザーが設定した言語を返すインターフェースです。
+// 認証されていない場合や言語を設定していない場合は空文字を返します。
+type LocaleResolver interface {
+	UserLocale(r events.APIGatewayProxyRequest) string
+}
+
+// Localize はエラーレスポンスのメッセージをユーザーが設定した言語または Accept-Language の言語に翻訳します。
+// ユーザーの設定はエラーレスポンスの場合のみ取得します。
+func Localize(resolver LocaleResolver, h HandlerFunc) HandlerFunc {
+	return func(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		res, err := h(r)
+		if err != nil {
+			return res, err
+		}
+		return localize(resolver, r, res), nil
+	}
+}
+
+// LocalizeContext はコンテキストを受け取るハンドラーのエラーレスポンスのメッセージを翻訳します。
+func LocalizeContext(resolver LocaleResolver, h ContextHandlerFunc) ContextHandlerFunc {
+	return func(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		res, err := h(ctx, r)
+		if err != nil {
+			return res, err
+		}
+		return localize(resolver, r, res), nil
+	}
+}
+
+func localize(resolver LocaleResolver, r events.APIGatewayProxyRequest, res events.APIGatewayProxyResponse) events.APIGatewayProxyResponse {
+	if res.StatusCode < http.StatusBadRequest {
+		return res
+	}
+
+	preference := ""
+	if resolver != nil {
+		preference = resolver.UserLocale(r)
+	}
+
+	locale := i18n.Resolve(preference, request.AcceptLanguage(r))
+	return response.LocalizeError(res, locale)
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLocaleResolver struct {
+	Locale string
+	Called bool
+}
+
+func (lr *stubLocaleResolver) UserLocale(r events.APIGatewayProxyRequest) string {
+	lr.Called = true
+	return lr.Locale
+}
+
+func TestLocalize(t *testing.T) {
+	ve := &model.ValidationError{}
+	ve.Add("name", "スケジュール名を入力してください")
+	ve.Add("color", "色を指定してください")
+	errorResponse, _ := response.NewBadRequestError(ve)
+
+	tests := []struct {
+		name           string
+		res            events.APIGatewayProxyResponse
+		preference     string
+		acceptLanguage string
+		wantLocale     string
+		wantMessages   []string
+	}{
+		{
+			name:           "Accept-Language が英語の場合は英語に翻訳する",
+			res:            errorResponse,
+			acceptLanguage: "en-US,en;q=0.9",
+			wantLocale:     "en",
+			wantMessages:   []string{"Please enter a schedule name.", "Please enter a schedule name.", "Please specify a color."},
+		},
+		{
+			name:           "ユーザーの設定を Accept-Language より優先する",
+			res:            errorResponse,
+			preference:     "ja",
+			acceptLanguage: "en",
+			wantLocale:     "ja",
+			wantMessages:   []string{"スケジュール名を入力してください", "スケジュール名を入力してください", "色を指定してください"},
+		},
+		{
+			name:       "ユーザーの設定が英語の場合は英語に翻訳する",
+			res:        errorResponse,
+			preference: "en",
+			wantLocale: "en",
+			wantMessages: []string{
+				"Please enter a schedule name.", "Please enter a schedule name.", "Please specify a color.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			lr := &stubLocaleResolver{Locale: tt.preference}
+			h := Localize(lr, func(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				return tt.res, nil
+			})
+
+			r := events.APIGatewayProxyRequest{Headers: map[string]string{"accept-language": tt.acceptLanguage}}
+			res, err := h(r)
+			require.NoError(err)
+
+			assert.Equal(tt.wantLocale, res.Headers["Content-Language"])
+
+			var body response.ErrorResponse
+			require.NoError(json.Unmarshal([]byte(res.Body), &body))
+			require.Len(body.Errors, 2)
+			assert.Equal(tt.wantMessages, []string{body.Message, body.Errors[0].Message, body.Errors[1].Message})
+		})
+	}
+
+	t.Run("成功時はユーザーの設定を取得せずレスポンスを変更しない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		lr := &stubLocaleResolver{Locale: "en"}
+		want := events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: `{"message":"ok"}`, Headers: response.CORSHeaders}
+		h := Localize(lr, func(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			return want, nil
+		})
+
+		res, err := h(events.APIGatewayProxyRequest{})
+		assert.NoError(err)
+		assert.Equal(want, res)
+		assert.False(lr.Called)
+	})
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// HandlerFunc は API Gateway のリクエストを処理する関数を表す型です。
+type HandlerFunc func(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// ContextHandlerFunc はコンテキストを受け取って API Gateway のリクエストを処理する関数を表す型です。
+type ContextHandlerFunc func(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// RequestID はレスポンスにリクエスト ID を付けます。
+func RequestID(h HandlerFunc) HandlerFunc {
+	return func(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		res, err := h(r)
+		if err != nil {
+			return res, err
+		}
+		return response.WithRequestID(res, requestID(r)), nil
+	}
+}
+
+// RequestIDContext はコンテキストを受け取るハンドラーのレスポンスにリクエスト ID を付けます。
+func RequestIDContext(h ContextHandlerFunc) ContextHandlerFunc {
+	return func(ctx context.Context, r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		res, err := h(ctx, r)
+		if err != nil {
+			return res, err
+		}
+		return response.WithRequestID(res, requestID(r)), nil
+	}
+}
+
+// requestID は API Gateway のリクエスト ID を返します。ない場合は新しく発行します。
+func requestID(r events.APIGatewayProxyRequest) string {
+	if r.RequestContext.RequestID != "" {
+		return r.RequestContext.RequestID
+	}
+	return id.NewID()
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name          string
+		res           events.APIGatewayProxyResponse
+		requestID     string
+		wantRequestID bool
+	}{
+		{
+			name:          "エラーレスポンスのボディにリクエスト ID を含める",
+			res:           events.APIGatewayProxyResponse{StatusCode: http.StatusNotFound, Body: response.ToErrorBody(port.ErrorCodeScheduleNotFound, "not found"), Headers: response.CORSHeaders},
+			requestID:     "test-request-id",
+			wantRequestID: true,
+		},
+		{
+			name:      "成功時はボディを変更しない",
+			res:       events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: `{"id":"test-id"}`, Headers: response.CORSHeaders},
+			requestID: "test-request-id",
+		},
+		{
+			name:          "API Gateway のリクエスト ID がない場合は発行する",
+			res:           events.APIGatewayProxyResponse{StatusCode: http.StatusBadRequest, Body: response.ToErrorBody(port.ErrorCodeRequestFormatInvalid, "invalid"), Headers: response.CORSHeaders},
+			wantRequestID: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			h := RequestID(func(r events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				return tt.res, nil
+			})
+
+			r := events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{RequestID: tt.requestID}}
+			res, err := h(r)
+			require.NoError(err)
+
+			requestID := res.Headers[response.RequestIDHeader]
+			assert.NotEmpty(requestID)
+			if tt.requestID != "" {
+				assert.Equal(tt.requestID, requestID)
+			}
+			assert.NotContains(response.CORSHeaders, response.RequestIDHeader)
+
+			if !tt.wantRequestID {
+				assert.Equal(tt.res.Body, res.Body)
+				return
+			}
+
+			var body response.ErrorResponse
+			require.NoError(json.Unmarshal([]byte(res.Body), &body))
+			assert.Equal(requestID, body.RequestID)
+			assert.NotEmpty(body.Code)
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package middleware
 
-import "github.com/datsukan/attendance-plan/backend/app/repository"
+import (
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
 
 type stubSuccessSessionRepositoryImpl struct{}
 
@@ -8,12 +13,24 @@ func NewStubSuccessSessionRepository() repository.SessionRepository {
 	return &stubSuccessSessionRepositoryImpl{}
 }
 
-func (r *stubSuccessSessionRepositoryImpl) GenerateToken(userID string) (string, error) {
+func (r *stubSuccessSessionRepositoryImpl) GenerateToken(userID, sessionID string, version int) (string, error) {
+	return "token", nil
+}
+
+func (r *stubSuccessSessionRepositoryImpl) IssueToken(purpose repository.TokenPurpose, subject, email string) (string, error) {
+	return "token", nil
+}
+
+func (r *stubSuccessSessionRepositoryImpl) IssueMagicLinkToken(subject, nonceHash string) (string, error) {
 	return "token", nil
 }
 
-func (r *stubSuccessSessionRepositoryImpl) IsValidToken(token string) (bool, string) {
-	return true, "user-id"
+func (r *stubSuccessSessionRepositoryImpl) ParseToken(token string, purpose repository.TokenPurpose) (*repository.Token, error) {
+	return &repository.Token{ID: "token-id", Purpose: purpose, Subject: "user-id", SessionID: "session-id", Version: 1}, nil
+}
+
+func (r *stubSuccessSessionRepositoryImpl) JWKS() ([]repository.JSONWebKey, error) {
+	return nil, nil
 }
 
 type stubFailSessionRepositoryImpl struct{}
@@ -22,10 +39,96 @@ func NewStubFailSessionRepository() repository.SessionRepository {
 	return &stubFailSessionRepositoryImpl{}
 }
 
-func (r *stubFailSessionRepositoryImpl) GenerateToken(userID string) (string, error) {
+func (r *stubFailSessionRepositoryImpl) GenerateToken(userID, sessionID string, version int) (string, error) {
+	return "", nil
+}
+
+func (r *stubFailSessionRepositoryImpl) IssueToken(purpose repository.TokenPurpose, subject, email string) (string, error) {
 	return "", nil
 }
 
-func (r *stubFailSessionRepositoryImpl) IsValidToken(token string) (bool, string) {
-	return false, ""
+func (r *stubFailSessionRepositoryImpl) IssueMagicLinkToken(subject, nonceHash string) (string, error) {
+	return "", nil
+}
+
+func (r *stubFailSessionRepositoryImpl) ParseToken(token string, purpose repository.TokenPurpose) (*repository.Token, error) {
+	return nil, repository.ErrTokenInvalid
+}
+
+func (r *stubFailSessionRepositoryImpl) JWKS() ([]repository.JSONWebKey, error) {
+	return nil, nil
+}
+
+type stubUserRepositoryImpl struct {
+	User *model.User
+}
+
+func NewStubUserRepository(tokenVersion int) repository.UserRepository {
+	return &stubUserRepositoryImpl{User: &model.User{ID: "user-id", Enabled: true, TokenVersion: tokenVersion}}
+}
+
+func (r *stubUserRepositoryImpl) ReadByEmail(email string, enabledOnly bool) (*model.User, error) {
+	return nil, repository.NewNotFoundError()
+}
+
+func (r *stubUserRepositoryImpl) Read(id string, enabledOnly bool) (*model.User, error) {
+	if id != r.User.ID {
+		return nil, repository.NewNotFoundError()
+	}
+	u := *r.User
+	return &u, nil
+}
+
+func (r *stubUserRepositoryImpl) ScanAll(enabledOnly bool) ([]model.User, error) {
+	return []model.User{*r.User}, nil
+}
+
+func (r *stubUserRepositoryImpl) Create(user *model.User) error {
+	return nil
+}
+
+func (r *stubUserRepositoryImpl) Update(user *model.User) error {
+	return nil
+}
+
+func (r *stubUserRepositoryImpl) Delete(id string) error {
+	return nil
+}
+
+func (r *stubUserRepositoryImpl) Exists(id string, enabledOnly bool) (bool, error) {
+	return id == r.User.ID, nil
+}
+
+func (r *stubUserRepositoryImpl) ExistsByEmail(email string, enabledOnly bool) (bool, error) {
+	return false, nil
+}
+
+type stubLoginSessionRepositoryImpl struct {
+	Session *model.LoginSession
+}
+
+func NewStubLoginSessionRepository(revoked bool) repository.LoginSessionRepository {
+	s := model.NewLoginSession("session-id", &model.User{ID: "user-id", TokenVersion: 1}, "", "", time.Hour, time.Now())
+	s.Revoked = revoked
+	return &stubLoginSessionRepositoryImpl{Session: s}
+}
+
+func (r *stubLoginSessionRepositoryImpl) Read(id string) (*model.LoginSession, error) {
+	if id != r.Session.ID {
+		return nil, repository.NewNotFoundError()
+	}
+	s := *r.Session
+	return &s, nil
+}
+
+func (r *stubLoginSessionRepositoryImpl) ReadByUserID(userID string) ([]model.LoginSession, error) {
+	return []model.LoginSession{*r.Session}, nil
+}
+
+func (r *stubLoginSessionRepositoryImpl) Create(session *model.LoginSession) error {
+	return nil
+}
+
+func (r *stubLoginSessionRepositoryImpl) Update(session *model.LoginSession) error {
+	return nil
 }
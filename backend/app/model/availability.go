@@ -0,0 +1,151 @@
+package model
+
+import "time"
+
+// Availability はユーザーの受講可能な曜日と1日あたりの受講上限を表す構造体です。
+type Availability struct {
+	UserID            string
+	Weekdays          []time.Weekday
+	MaxLecturesPerDay int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// AllWeekdays は日曜日から土曜日までの全曜日です。
+var AllWeekdays = []time.Weekday{
+	time.Sunday,
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+}
+
+// NewDefaultAvailability は全曜日受講可能で上限なしの Availability を生成します。
+// ユーザーが受講可能日を設定していない場合に使用します。
+func NewDefaultAvailability(userID string) *Availability {
+	weekdays := make([]time.Weekday, len(AllWeekdays))
+	copy(weekdays, AllWeekdays)
+
+	return &Availability{
+		UserID:   userID,
+		Weekdays: weekdays,
+	}
+}
+
+// IsAvailableWeekday は指定された曜日が受講可能かどうかを返します。
+func (a Availability) IsAvailableWeekday(w time.Weekday) bool {
+	for _, aw := range a.Weekdays {
+		if aw == w {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLimit は1日あたりの受講上限が設定されているかどうかを返します。
+func (a Availability) HasLimit() bool {
+	return a.MaxLecturesPerDay > 0
+}
+
+// DayAvailability は指定された日の受講可否を返します。
+// scheduled にはその日にすでに予定されている受講スケジュールの件数を指定します。
+func (a Availability) DayAvailability(date time.Time, blackouts BlackoutList, scheduled int) DayAvailability {
+	da := DayAvailability{
+		Date:        date,
+		Available:   true,
+		MaxLectures: a.MaxLecturesPerDay,
+		Scheduled:   scheduled,
+	}
+
+	if b, ok := blackouts.Find(date); ok {
+		da.Available = false
+		da.Reason = UnavailableReasonBlackout
+		da.BlackoutLabel = b.Label
+		return da
+	}
+
+	if !a.IsAvailableWeekday(date.Weekday()) {
+		da.Available = false
+		da.Reason = UnavailableReasonWeekday
+		return da
+	}
+
+	return da
+}
+
+// DayAvailabilityMap は schedules に含まれる受講スケジュールの日ごとの受講可否を返します。
+// 各日の受講済みの件数には、その日に開始する受講スケジュールの件数を使用します。
+func (a Availability) DayAvailabilityMap(schedules ScheduleList, blackouts BlackoutList) DayAvailabilityMap {
+	res := DayAvailabilityMap{}
+	for _, di := range schedules.FilterByType(ScheduleTypeCustom).ToDateItemList() {
+		res[di.Date.Format(DateFormat)] = a.DayAvailability(di.Date, blackouts, len(di.Schedules))
+	}
+	return res
+}
+
+// UnavailableReason は受講できない理由を表す型です。
+type UnavailableReason string
+
+const (
+	UnavailableReasonWeekday  UnavailableReason = "weekday"  // 受講しない曜日
+	UnavailableReasonBlackout UnavailableReason = "blackout" // 受講できない期間
+)
+
+// String は UnavailableReason を文字列に変換します。
+func (r UnavailableReason) String() string {
+	return string(r)
+}
+
+// DayAvailability はある日の受講可否と残りの受講可能数を表す構造体です。
+type DayAvailability struct {
+	Date          time.Time
+	Available     bool
+	Reason        UnavailableReason
+	BlackoutLabel string
+	MaxLectures   int
+	Scheduled     int
+}
+
+// Unlimited は受講可能数に上限がないことを表す Remaining の値です。
+const Unlimited = -1
+
+// Remaining はその日にあと何件受講を追加できるかを返します。
+// 受講できない日は 0 を、上限がない場合は Unlimited を返します。
+func (d DayAvailability) Remaining() int {
+	if !d.Available {
+		return 0
+	}
+
+	if d.MaxLectures <= 0 {
+		return Unlimited
+	}
+
+	if d.Scheduled >= d.MaxLectures {
+		return 0
+	}
+
+	return d.MaxLectures - d.Scheduled
+}
+
+// HasCapacity はその日にさらに受講を追加できるかどうかを返します。
+func (d DayAvailability) HasCapacity() bool {
+	return d.Remaining() != 0
+}
+
+// Exceeded はその日の受講の件数が上限を超えているかどうかを返します。
+// 上限がない場合は false を返します。
+func (d DayAvailability) Exceeded() bool {
+	return d.MaxLectures > 0 && d.Scheduled > d.MaxLectures
+}
+
+// DayAvailabilityMap は日付ごとの受講可否を表す型です。
+// キーには DateFormat 形式の日付を使用します。
+type DayAvailabilityMap map[string]DayAvailability
+
+// Find は指定された日の受講可否を返します。
+func (m DayAvailabilityMap) Find(date time.Time) (DayAvailability, bool) {
+	da, ok := m[date.Format(DateFormat)]
+	return da, ok
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailability_DayAvailability(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	blackouts := BlackoutList{
+		{
+			Label:    "出張",
+			StartsAt: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		availability  Availability
+		date          time.Time
+		scheduled     int
+		wantAvailable bool
+		wantReason    UnavailableReason
+		wantLabel     string
+		wantRemaining int
+	}{
+		{
+			name:          "受講する曜日で上限なし",
+			availability:  Availability{Weekdays: weekdays},
+			date:          time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			scheduled:     5,
+			wantAvailable: true,
+			wantRemaining: Unlimited,
+		},
+		{
+			name:          "受講する曜日で上限まで余裕あり",
+			availability:  Availability{Weekdays: weekdays, MaxLecturesPerDay: 3},
+			date:          time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			scheduled:     1,
+			wantAvailable: true,
+			wantRemaining: 2,
+		},
+		{
+			name:          "受講する曜日で上限に達している",
+			availability:  Availability{Weekdays: weekdays, MaxLecturesPerDay: 3},
+			date:          time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			scheduled:     4,
+			wantAvailable: true,
+			wantRemaining: 0,
+		},
+		{
+			name:          "受講しない曜日",
+			availability:  Availability{Weekdays: weekdays, MaxLecturesPerDay: 3},
+			date:          time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+			wantAvailable: false,
+			wantReason:    UnavailableReasonWeekday,
+			wantRemaining: 0,
+		},
+		{
+			name:          "受講できない期間の初日",
+			availability:  Availability{Weekdays: weekdays},
+			date:          time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+			wantAvailable: false,
+			wantReason:    UnavailableReasonBlackout,
+			wantLabel:     "出張",
+			wantRemaining: 0,
+		},
+		{
+			name:          "受講できない期間の最終日",
+			availability:  Availability{Weekdays: weekdays},
+			date:          time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC),
+			wantAvailable: false,
+			wantReason:    UnavailableReasonBlackout,
+			wantLabel:     "出張",
+			wantRemaining: 0,
+		},
+		{
+			name:          "受講できない期間の翌日",
+			availability:  Availability{Weekdays: weekdays},
+			date:          time.Date(2024, 6, 13, 0, 0, 0, 0, time.UTC),
+			wantAvailable: true,
+			wantRemaining: Unlimited,
+		},
+		{
+			name:          "デフォルトは全曜日受講可能",
+			availability:  *NewDefaultAvailability("test-user-id"),
+			date:          time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+			wantAvailable: true,
+			wantRemaining: Unlimited,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := tt.availability.DayAvailability(tt.date, blackouts, tt.scheduled)
+			assert.Equal(tt.wantAvailable, got.Available)
+			assert.Equal(tt.wantReason, got.Reason)
+			assert.Equal(tt.wantLabel, got.BlackoutLabel)
+			assert.Equal(tt.wantRemaining, got.Remaining())
+			assert.Equal(tt.wantRemaining != 0, got.HasCapacity())
+		})
+	}
+}
+
+func TestAvailability_DayAvailabilityMap(t *testing.T) {
+	assert := assert.New(t)
+
+	mon := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	sun := time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC)
+	schedules := ScheduleList{
+		{ID: "a", StartsAt: mon, Type: ScheduleTypeCustom},
+		{ID: "b", StartsAt: mon, Type: ScheduleTypeCustom},
+		{ID: "c", StartsAt: mon, Type: ScheduleTypeCustom},
+		{ID: "d", StartsAt: mon, Type: ScheduleTypeMaster},
+		{ID: "e", StartsAt: sun, Type: ScheduleTypeCustom},
+	}
+
+	a := Availability{Weekdays: []time.Weekday{time.Monday}, MaxLecturesPerDay: 2}
+	got := a.DayAvailabilityMap(schedules, nil)
+
+	assert.Len(got, 2)
+
+	da, ok := got.Find(mon)
+	if assert.True(ok) {
+		assert.True(da.Available)
+		assert.Equal(3, da.Scheduled)
+		assert.True(da.Exceeded())
+	}
+
+	da, ok = got.Find(sun)
+	if assert.True(ok) {
+		assert.False(da.Available)
+		assert.Equal(UnavailableReasonWeekday, da.Reason)
+		assert.False(da.Exceeded())
+	}
+
+	_, ok = got.Find(time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC))
+	assert.False(ok)
+}
+
+func TestBlackoutList_Sort(t *testing.T) {
+	d1 := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+	d3 := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	bl := BlackoutList{{ID: "3", StartsAt: d3}, {ID: "1", StartsAt: d1}, {ID: "2", StartsAt: d2}}
+	bl.Sort()
+
+	assert.Equal(t, BlackoutList{{ID: "1", StartsAt: d1}, {ID: "2", StartsAt: d2}, {ID: "3", StartsAt: d3}}, bl)
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Blackout は出張などで受講できない期間を表す構造体です。
+// StartsAt と EndsAt は日付のみを扱い、両端の日を含みます。
+type Blackout struct {
+	ID        string
+	UserID    string
+	Label     string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Contains は指定された日が受講できない期間に含まれるかどうかを返します。
+func (b Blackout) Contains(date time.Time) bool {
+	d := date.Format(DateFormat)
+	return b.StartsAt.Format(DateFormat) <= d && d <= b.EndsAt.Format(DateFormat)
+}
+
+// BlackoutList は受講できない期間のリストを表す構造体です。
+type BlackoutList []Blackout
+
+// Find は指定された日を含む受講できない期間を返します。
+func (bl BlackoutList) Find(date time.Time) (*Blackout, bool) {
+	for _, b := range bl {
+		if b.Contains(date) {
+			return &b, true
+		}
+	}
+	return nil, false
+}
+
+// Sort は受講できない期間を開始日の昇順で並び替えます。
+func (bl BlackoutList) Sort() {
+	for i := 0; i < len(bl); i++ {
+		for j := i + 1; j < len(bl); j++ {
+			if bl[i].StartsAt.After(bl[j].StartsAt) {
+				bl[i], bl[j] = bl[j], bl[i]
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Color はスケジュールや科目に設定する色のキーを表す型です。
+// 標準のパレットのキーか、ユーザーが定義した色のキー（custom_ に色の ID を続けたもの）を取ります。
+type Color string
+
+const (
+	ColorWhite         Color = "white"
+	ColorGray          Color = "gray"
+	ColorOrange        Color = "orange"
+	ColorBlue          Color = "blue"
+	ColorRed           Color = "red"
+	ColorGreen         Color = "green"
+	ColorYellow        Color = "yellow"
+	ColorOutlineOrange Color = "outline_orange"
+	ColorOutlineBlue   Color = "outline_blue"
+	ColorOutlineRed    Color = "outline_red"
+	ColorOutlineGreen  Color = "outline_green"
+	ColorOutlineYellow Color = "outline_yellow"
+)
+
+// customColorPrefix はユーザーが定義した色のキーの接頭辞です。
+const customColorPrefix = "custom_"
+
+// String は色のキーを文字列で返します。
+func (c Color) String() string {
+	return string(c)
+}
+
+// IsPalette は標準のパレットの色かどうかを返します。
+func (c Color) IsPalette() bool {
+	for _, pc := range Palette() {
+		if pc.Key == c {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCustom はユーザーが定義した色のキーの形式かどうかを返します。
+// 色が存在するかどうかは判定しません。
+func (c Color) IsCustom() bool {
+	return c.CustomColorID() != ""
+}
+
+// CustomColorID はユーザーが定義した色の ID を返します。
+// ユーザーが定義した色のキーでない場合は空文字を返します。
+func (c Color) CustomColorID() string {
+	id, ok := strings.CutPrefix(string(c), customColorPrefix)
+	if !ok {
+		return ""
+	}
+	return id
+}
+
+// Valid は標準のパレットの色か、ユーザーが定義した色のキーの形式かどうかを返します。
+func (c Color) Valid() bool {
+	return c.IsPalette() || c.IsCustom()
+}
+
+// ColorStyle は色の表示方法を表す型です。
+type ColorStyle string
+
+const (
+	// ColorStyleFill は背景を塗りつぶす表示方法です。
+	ColorStyleFill ColorStyle = "fill"
+	// ColorStyleOutline は枠線と文字のみに色を付ける表示方法です。
+	ColorStyleOutline ColorStyle = "outline"
+)
+
+// String は表示方法を文字列で返します。
+func (cs ColorStyle) String() string {
+	return string(cs)
+}
+
+// ToColorStyle は文字列を ColorStyle に変換します。
+// 該当しない場合は空文字の ColorStyle を返します。
+func ToColorStyle(s string) ColorStyle {
+	switch ColorStyle(s) {
+	case ColorStyleFill, ColorStyleOutline:
+		return ColorStyle(s)
+	default:
+		return ""
+	}
+}
+
+// PaletteColor はパレットの色を表す構造体です。
+type PaletteColor struct {
+	Key   Color
+	Name  string
+	Hex   string
+	Style ColorStyle
+}
+
+// Palette は標準のパレットの色をフロントエンドの表示順で返します。
+func Palette() []PaletteColor {
+	return []PaletteColor{
+		{Key: ColorWhite, Name: "白", Hex: "#ffffff", Style: ColorStyleFill},
+		{Key: ColorGray, Name: "グレー", Hex: "#6b7280", Style: ColorStyleFill},
+		{Key: ColorOrange, Name: "オレンジ", Hex: "#f97316", Style: ColorStyleFill},
+		{Key: ColorBlue, Name: "青", Hex: "#3b82f6", Style: ColorStyleFill},
+		{Key: ColorRed, Name: "赤", Hex: "#ef4444", Style: ColorStyleFill},
+		{Key: ColorGreen, Name: "緑", Hex: "#22c55e", Style: ColorStyleFill},
+		{Key: ColorYellow, Name: "黄", Hex: "#eab308", Style: ColorStyleFill},
+		{Key: ColorOutlineOrange, Name: "オレンジ（枠線のみ）", Hex: "#f97316", Style: ColorStyleOutline},
+		{Key: ColorOutlineBlue, Name: "青（枠線のみ）", Hex: "#3b82f6", Style: ColorStyleOutline},
+		{Key: ColorOutlineRed, Name: "赤（枠線のみ）", Hex: "#ef4444", Style: ColorStyleOutline},
+		{Key: ColorOutlineGreen, Name: "緑（枠線のみ）", Hex: "#22c55e", Style: ColorStyleOutline},
+		{Key: ColorOutlineYellow, Name: "黄（枠線のみ）", Hex: "#eab308", Style: ColorStyleOutline},
+	}
+}
+
+// hexColorPattern は #rrggbb 形式の色コードに一致する正規表現です。
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidHex は #rrggbb 形式の色コードかどうかを返します。
+func ValidHex(hex string) bool {
+	return hexColorPattern.MatchString(hex)
+}
+
+// CustomColor はユーザーが定義した色を表す構造体です。
+// Hex は #rrggbb 形式の小文字の色コードで保持します。
+type CustomColor struct {
+	ID        string
+	UserID    string
+	Name      string
+	Hex       string
+	Style     ColorStyle
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Key はスケジュールや科目に設定する色のキーを返します。
+func (cc CustomColor) Key() Color {
+	return Color(customColorPrefix + cc.ID)
+}
+
+// PaletteColor はパレットの色として返します。
+func (cc CustomColor) PaletteColor() PaletteColor {
+	return PaletteColor{Key: cc.Key(), Name: cc.Name, Hex: cc.Hex, Style: cc.Style}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPalette(t *testing.T) {
+	assert := assert.New(t)
+
+	p := Palette()
+	assert.Len(p, 12)
+	assert.Equal(ColorWhite, p[0].Key)
+
+	for _, pc := range p {
+		assert.True(ValidHex(pc.Hex), pc.Key)
+		assert.NotEmpty(ToColorStyle(pc.Style.String()), pc.Key)
+	}
+}
+
+func TestColor_Valid(t *testing.T) {
+	tests := []struct {
+		name          string
+		color         Color
+		wantPalette   bool
+		wantCustom    bool
+		wantCustomID  string
+		wantValidated bool
+	}{
+		{name: "パレットの色", color: ColorOutlineBlue, wantPalette: true, wantValidated: true},
+		{name: "ユーザーが定義した色", color: "custom_01J0000000000000000000000", wantCustom: true, wantCustomID: "01J0000000000000000000000", wantValidated: true},
+		{name: "ID のないユーザー定義の色", color: "custom_"},
+		{name: "パレットにない色", color: "purple"},
+		{name: "空文字", color: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			assert.Equal(tt.wantPalette, tt.color.IsPalette())
+			assert.Equal(tt.wantCustom, tt.color.IsCustom())
+			assert.Equal(tt.wantCustomID, tt.color.CustomColorID())
+			assert.Equal(tt.wantValidated, tt.color.Valid())
+		})
+	}
+}
+
+func TestValidHex(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		want bool
+	}{
+		{name: "小文字", hex: "#1a2b3c", want: true},
+		{name: "大文字", hex: "#1A2B3C", want: true},
+		{name: "# がない", hex: "1a2b3c", want: false},
+		{name: "3桁", hex: "#abc", want: false},
+		{name: "16進数でない", hex: "#12345g", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidHex(tt.hex))
+		})
+	}
+}
+
+func TestCustomColor_Key(t *testing.T) {
+	cc := CustomColor{ID: "test-color-id", Name: "藤色", Hex: "#a78bfa", Style: ColorStyleOutline}
+
+	assert.Equal(t, Color("custom_test-color-id"), cc.Key())
+	assert.Equal(t, "test-color-id", cc.Key().CustomColorID())
+	assert.Equal(t, PaletteColor{Key: "custom_test-color-id", Name: "藤色", Hex: "#a78bfa", Style: ColorStyleOutline}, cc.PaletteColor())
+}
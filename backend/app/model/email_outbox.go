@@ -0,0 +1,148 @@
+package model
+
+import "time"
+
+// EmailOutboxStatus はメールの送信状況を表す型です。
+type EmailOutboxStatus string
+
+const (
+	// EmailOutboxStatusPending は送信待ちです。送信に失敗した場合も再試行するまではこの状態です。
+	EmailOutboxStatusPending EmailOutboxStatus = "pending"
+	// EmailOutboxStatusSent は送信済みです。
+	EmailOutboxStatusSent EmailOutboxStatus = "sent"
+	// EmailOutboxStatusFailed は再試行の上限に達して送信を諦めた状態です。
+	EmailOutboxStatusFailed EmailOutboxStatus = "failed"
+	// EmailOutboxStatusSuppressed は送信しないメールです。
+	// 宛先のアカウントの有無を推測されないよう、アカウントが存在しない場合も同じ処理を行って保存します。
+	EmailOutboxStatusSuppressed EmailOutboxStatus = "suppressed"
+)
+
+const (
+	// emailOutboxBaseBackoff は1回目の失敗後に再試行するまでの間隔です。失敗するごとに倍になります。
+	emailOutboxBaseBackoff = time.Minute
+	// emailOutboxMaxBackoff は再試行するまでの間隔の上限です。
+	emailOutboxMaxBackoff = time.Hour
+	// emailOutboxLease は送信を試行する処理がメールを確保しておく期間です。
+	// 試行の結果を記録できずに終わった場合は、この期間の後に他の処理が再試行します。
+	emailOutboxLease = time.Minute
+)
+
+// EmailOutbox は送信するメールを表す構造体です。
+// ユースケースはメールを直接送信せずに送信待ちとして保存し、送信の結果を記録します。
+// Failures は送信待ちになってからの連続した失敗回数で、Attempts はすべての試行の記録です。
+// Version は保存するごとに増える版で、同時に更新された場合に後の更新を失敗させるために使います。
+type EmailOutbox struct {
+	ID            string
+	To            string
+	Subject       string
+	Text          string
+	HTML          string
+	Status        EmailOutboxStatus
+	Failures      int
+	Attempts      []EmailDeliveryAttempt
+	NextAttemptAt time.Time
+	MessageID     string
+	SentAt        time.Time
+	Version       int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// EmailDeliveryAttempt はメールの送信の試行を表す構造体です。
+type EmailDeliveryAttempt struct {
+	AttemptedAt time.Time
+	MessageID   string
+	Error       string
+}
+
+// NewEmailOutbox は送信待ちのメールを生成します。すぐに送信を試行できる状態にします。
+func NewEmailOutbox(id, to, subject, text, html string, now time.Time) *EmailOutbox {
+	return &EmailOutbox{
+		ID:            id,
+		To:            to,
+		Subject:       subject,
+		Text:          text,
+		HTML:          html,
+		Status:        EmailOutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Suppress はメールを送信しないことを記録します。
+func (o *EmailOutbox) Suppress(now time.Time) {
+	o.Status = EmailOutboxStatusSuppressed
+	o.UpdatedAt = now
+}
+
+// IsDue は指定された日時に送信を試行するべきかどうかを返します。
+func (o *EmailOutbox) IsDue(now time.Time) bool {
+	return o.Status == EmailOutboxStatusPending && !o.NextAttemptAt.After(now)
+}
+
+// Claim は送信を試行するためにメールを確保します。
+// 確保している間は IsDue が false になるため、他の処理は同じメールを送信しません。
+func (o *EmailOutbox) Claim(now time.Time) {
+	o.NextAttemptAt = now.Add(emailOutboxLease)
+	o.UpdatedAt = now
+}
+
+// MarkSent は送信に成功したことを記録します。
+func (o *EmailOutbox) MarkSent(messageID string, now time.Time) {
+	o.Attempts = append(o.Attempts, EmailDeliveryAttempt{AttemptedAt: now, MessageID: messageID})
+	o.Status = EmailOutboxStatusSent
+	o.MessageID = messageID
+	o.SentAt = now
+	o.UpdatedAt = now
+}
+
+// MarkFailed は送信に失敗したことを記録します。
+// 失敗回数が maxAttempts に達した場合は送信を諦め、そうでない場合は指数的に間隔を空けて再試行します。
+func (o *EmailOutbox) MarkFailed(cause error, maxAttempts int, now time.Time) {
+	o.Attempts = append(o.Attempts, EmailDeliveryAttempt{AttemptedAt: now, Error: cause.Error()})
+	o.UpdatedAt = now
+
+	o.Failures++
+	if o.Failures >= maxAttempts {
+		o.Status = EmailOutboxStatusFailed
+		return
+	}
+
+	o.NextAttemptAt = now.Add(EmailOutboxBackoff(o.Failures))
+}
+
+// Resend は送信を諦めたメールを送信待ちに戻します。
+// 失敗回数は再送した時点から数え直しますが、それまでの試行の記録は残します。
+func (o *EmailOutbox) Resend(now time.Time) {
+	o.Status = EmailOutboxStatusPending
+	o.Failures = 0
+	o.NextAttemptAt = now
+	o.UpdatedAt = now
+}
+
+// LastError は最後に失敗した試行のエラーを返します。
+func (o *EmailOutbox) LastError() string {
+	for i := len(o.Attempts) - 1; i >= 0; i-- {
+		if o.Attempts[i].Error != "" {
+			return o.Attempts[i].Error
+		}
+	}
+	return ""
+}
+
+// EmailOutboxBackoff は failures 回失敗した後に再試行するまでの間隔を返します。
+func EmailOutboxBackoff(failures int) time.Duration {
+	if failures < 1 {
+		return 0
+	}
+
+	d := emailOutboxBaseBackoff
+	for i := 1; i < failures; i++ {
+		d *= 2
+		if d >= emailOutboxMaxBackoff {
+			return emailOutboxMaxBackoff
+		}
+	}
+	return d
+}
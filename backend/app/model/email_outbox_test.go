@@ -0,0 +1,107 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{name: "失敗していない", failures: 0, want: 0},
+		{name: "1回目の失敗", failures: 1, want: time.Minute},
+		{name: "2回目の失敗は倍", failures: 2, want: 2 * time.Minute},
+		{name: "4回目の失敗", failures: 4, want: 8 * time.Minute},
+		{name: "上限を超える場合は上限", failures: 10, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EmailOutboxBackoff(tt.failures))
+		})
+	}
+}
+
+func TestEmailOutbox_MarkFailed(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		failures    int
+		maxAttempts int
+		wantStatus  EmailOutboxStatus
+		wantNext    time.Time
+	}{
+		{name: "上限に達するまでは間隔を空けて再試行する", failures: 0, maxAttempts: 3, wantStatus: EmailOutboxStatusPending, wantNext: now.Add(time.Minute)},
+		{name: "失敗するごとに間隔を倍にする", failures: 1, maxAttempts: 3, wantStatus: EmailOutboxStatusPending, wantNext: now.Add(2 * time.Minute)},
+		{name: "上限に達した場合は送信を諦める", failures: 2, maxAttempts: 3, wantStatus: EmailOutboxStatusFailed, wantNext: now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			o := NewEmailOutbox("test-id", "to@example.com", "subject", "text", "", now)
+			o.Failures = tt.failures
+			o.MarkFailed(errors.New("send error"), tt.maxAttempts, now)
+
+			assert.Equal(tt.wantStatus, o.Status)
+			assert.Equal(tt.wantNext, o.NextAttemptAt)
+			assert.Equal(tt.failures+1, o.Failures)
+			assert.Len(o.Attempts, 1)
+			assert.Equal("send error", o.LastError())
+		})
+	}
+}
+
+func TestEmailOutbox_Resend(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	o := NewEmailOutbox("test-id", "to@example.com", "subject", "text", "", now)
+	o.MarkFailed(errors.New("send error"), 1, now)
+	assert.Equal(EmailOutboxStatusFailed, o.Status)
+	assert.False(o.IsDue(now))
+
+	later := now.Add(time.Hour)
+	o.Resend(later)
+	assert.Equal(EmailOutboxStatusPending, o.Status)
+	assert.Zero(o.Failures)
+	assert.Len(o.Attempts, 1)
+	assert.True(o.IsDue(later))
+
+	o.MarkSent("test-message-id", later)
+	assert.Equal(EmailOutboxStatusSent, o.Status)
+	assert.Equal("test-message-id", o.MessageID)
+	assert.Equal(later, o.SentAt)
+	assert.False(o.IsDue(later))
+}
+
+func TestEmailOutbox_Claim(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	o := NewEmailOutbox("test-id", "to@example.com", "subject", "text", "", now)
+	assert.True(o.IsDue(now))
+
+	o.Claim(now)
+	assert.False(o.IsDue(now))
+	assert.True(o.IsDue(now.Add(time.Minute)))
+}
+
+func TestEmailOutbox_Suppress(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	o := NewEmailOutbox("test-id", "to@example.com", "subject", "text", "", now)
+	o.Suppress(now)
+	assert.Equal(EmailOutboxStatusSuppressed, o.Status)
+	assert.False(o.IsDue(now))
+	assert.False(o.IsDue(now.Add(time.Hour)))
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// lectureNumberPatterns は講義回にマッチする正規表現です。先頭のものから優先して使用します。
+var lectureNumberPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`第\s*([0-9０-９]+)\s*回`), // 第N回
+	regexp.MustCompile(`[#＃]\s*([0-9０-９]+)`),  // #N
+	regexp.MustCompile(`([0-9０-９]+)\s*$`),     // 末尾の数字
+}
+
+// lectureNameTrimChars は講義回を取り除いた後の科目名の前後から取り除く文字です。
+const lectureNameTrimChars = " 　-_:：・/／"
+
+// MaxLectureNumber は講義回として扱う数の上限です。
+const MaxLectureNumber = 999
+
+// ParseLectureName は受講スケジュールの名前から科目名と講義回を取り出します。
+// 講義回は「第N回」「#N」「末尾の数字」の順に探します。
+// 講義回が見つからない場合は名前全体を科目名として返し、ok に false を返します。
+func ParseLectureName(name string) (subject string, number int, ok bool) {
+	for _, p := range lectureNumberPatterns {
+		loc := p.FindStringSubmatchIndex(name)
+		if loc == nil {
+			continue
+		}
+
+		n, err := strconv.Atoi(toHalfWidthDigits(name[loc[2]:loc[3]]))
+		if err != nil || n <= 0 || n > MaxLectureNumber {
+			continue
+		}
+
+		subject = strings.Trim(name[:loc[0]]+" "+name[loc[1]:], lectureNameTrimChars)
+		if subject == "" {
+			// 数字だけの名前は科目名がないため講義回として扱わない
+			continue
+		}
+
+		return subject, n, true
+	}
+
+	return strings.Trim(name, lectureNameTrimChars), 0, false
+}
+
+// toHalfWidthDigits は全角数字を半角数字に変換します。
+func toHalfWidthDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if '０' <= r && r <= '９' {
+			return r - '０' + '0'
+		}
+		return r
+	}, s)
+}
+
+// SequenceStrictness は講義回の順番が逆転する変更をどう扱うかを表す型です。
+type SequenceStrictness string
+
+const (
+	SequenceStrictnessOff    SequenceStrictness = "off"    // 確認しない
+	SequenceStrictnessWarn   SequenceStrictness = "warn"   // 警告する
+	SequenceStrictnessReject SequenceStrictness = "reject" // 変更を拒否する
+)
+
+// DefaultSequenceStrictness は設定されていない場合の SequenceStrictness です。
+const DefaultSequenceStrictness = SequenceStrictnessWarn
+
+// String は SequenceStrictness を文字列に変換します。
+func (s SequenceStrictness) String() string {
+	return string(s)
+}
+
+// Valid は SequenceStrictness が定義済みの値かどうかを返します。
+func (s SequenceStrictness) Valid() bool {
+	switch s {
+	case SequenceStrictnessOff, SequenceStrictnessWarn, SequenceStrictnessReject:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToSequenceStrictness は文字列を SequenceStrictness に変換します。
+// 空文字の場合は DefaultSequenceStrictness を返します。
+func ToSequenceStrictness(s string) SequenceStrictness {
+	if s == "" {
+		return DefaultSequenceStrictness
+	}
+	return SequenceStrictness(s)
+}
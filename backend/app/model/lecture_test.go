@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLectureName(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantSubject string
+		wantNumber  int
+		wantOK      bool
+	}{
+		{name: "科目名の後ろに講義回", input: "統計学 第3回", wantSubject: "統計学", wantNumber: 3, wantOK: true},
+		{name: "科目名の前に講義回", input: "第12回 統計学", wantSubject: "統計学", wantNumber: 12, wantOK: true},
+		{name: "全角数字の講義回", input: "統計学　第５回", wantSubject: "統計学", wantNumber: 5, wantOK: true},
+		{name: "区切り文字付き", input: "統計学 - 第1回", wantSubject: "統計学", wantNumber: 1, wantOK: true},
+		{name: "#N 形式", input: "統計学 #4", wantSubject: "統計学", wantNumber: 4, wantOK: true},
+		{name: "全角の＃N 形式", input: "統計学＃４", wantSubject: "統計学", wantNumber: 4, wantOK: true},
+		{name: "末尾の数字", input: "統計学 7", wantSubject: "統計学", wantNumber: 7, wantOK: true},
+		{name: "第N回を末尾の数字より優先する", input: "英語2 第3回", wantSubject: "英語2", wantNumber: 3, wantOK: true},
+		{name: "数字だけの名前は講義回として扱わない", input: "15", wantSubject: "15", wantNumber: 0, wantOK: false},
+		{name: "講義回なし", input: "統計学 レポート提出", wantSubject: "統計学 レポート提出", wantNumber: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, number, ok := ParseLectureName(tt.input)
+			assert.Equal(t, tt.wantSubject, subject)
+			assert.Equal(t, tt.wantNumber, number)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestSchedule_Lecture(t *testing.T) {
+	t.Run("LectureNumber を名前より優先する", func(t *testing.T) {
+		subject, number, ok := Schedule{Name: "統計学 第3回", LectureNumber: 5}.Lecture()
+		assert.Equal(t, "統計学", subject)
+		assert.Equal(t, 5, number)
+		assert.True(t, ok)
+	})
+
+	t.Run("LectureNumber が未設定の場合は名前から判定する", func(t *testing.T) {
+		subject, number, ok := Schedule{Name: "統計学 第3回"}.Lecture()
+		assert.Equal(t, "統計学", subject)
+		assert.Equal(t, 3, number)
+		assert.True(t, ok)
+	})
+}
+
+func TestToSequenceStrictness(t *testing.T) {
+	assert.Equal(t, SequenceStrictnessWarn, ToSequenceStrictness(""))
+	assert.Equal(t, SequenceStrictnessReject, ToSequenceStrictness("reject"))
+	assert.True(t, ToSequenceStrictness("off").Valid())
+	assert.False(t, ToSequenceStrictness("strict").Valid())
+}
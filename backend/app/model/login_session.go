@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// loginSessionUserAgentMaxLength は保存する User-Agent の文字数の上限です。
+const loginSessionUserAgentMaxLength = 512
+
+// LoginSession はサインインしている端末ごとのセッションを表す構造体です。
+// ID はリフレッシュトークンの系列の ID と同じで、サインインしてからアクセストークンを再発行するたびに LastSeenAt と有効期限を延ばします。
+// TokenVersion は作成したときのユーザーのセッションの世代で、ユーザーの世代と異なる場合は無効です。
+// TTL は DynamoDB の TTL で有効期限が切れたセッションを削除するための UNIX 時間です。
+type LoginSession struct {
+	ID           string
+	UserID       string
+	UserAgent    string
+	IPAddress    string
+	TokenVersion int
+	Revoked      bool
+	CreatedAt    time.Time
+	LastSeenAt   time.Time
+	ExpiresAt    time.Time
+	TTL          int64
+}
+
+// NewLoginSession はログインセッションを生成します。
+func NewLoginSession(id string, user *User, userAgent, ipAddress string, lifetime time.Duration, now time.Time) *LoginSession {
+	s := &LoginSession{
+		ID:           id,
+		UserID:       user.ID,
+		TokenVersion: user.TokenVersion,
+		CreatedAt:    now,
+	}
+	s.Touch(userAgent, ipAddress, lifetime, now)
+	return s
+}
+
+// Touch はアクセストークンを再発行したときの端末の情報を記録し、有効期限を延ばします。
+func (s *LoginSession) Touch(userAgent, ipAddress string, lifetime time.Duration, now time.Time) {
+	// 文字の途中で切って不正な UTF-8 にならないよう、文字単位で切り詰める
+	if utf8.RuneCountInString(userAgent) > loginSessionUserAgentMaxLength {
+		userAgent = string([]rune(userAgent)[:loginSessionUserAgentMaxLength])
+	}
+
+	s.UserAgent = userAgent
+	s.IPAddress = ipAddress
+	s.LastSeenAt = now
+	s.ExpiresAt = now.Add(lifetime)
+	s.TTL = s.ExpiresAt.Unix()
+}
+
+// IsActive は指定されたユーザーのセッションとして有効かどうかを返します。
+func (s *LoginSession) IsActive(user *User, now time.Time) bool {
+	return !s.Revoked && s.UserID == user.ID && s.TokenVersion == user.TokenVersion && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginSession_IsActive(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	user := &User{ID: "test-user-id", TokenVersion: 2}
+
+	tests := []struct {
+		name   string
+		modify func(s *LoginSession, u *User)
+		want   bool
+	}{
+		{name: "有効なセッション", modify: func(s *LoginSession, u *User) {}, want: true},
+		{name: "無効にされたセッション", modify: func(s *LoginSession, u *User) { s.Revoked = true }, want: false},
+		{name: "有効期限切れのセッション", modify: func(s *LoginSession, u *User) { s.ExpiresAt = now }, want: false},
+		{name: "ユーザーのセッションの世代が上がった", modify: func(s *LoginSession, u *User) { u.TokenVersion++ }, want: false},
+		{name: "別のユーザーのセッション", modify: func(s *LoginSession, u *User) { u.ID = "test-other-user-id" }, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := *user
+			s := NewLoginSession("test-session-id", &u, "test-agent", "192.0.2.1", time.Hour, now)
+			tt.modify(s, &u)
+			assert.Equal(t, tt.want, s.IsActive(&u, now))
+		})
+	}
+}
+
+func TestLoginSession_Touch(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	s := NewLoginSession("test-session-id", &User{ID: "test-user-id"}, "test-agent", "192.0.2.1", time.Hour, now)
+
+	later := now.Add(30 * time.Minute)
+	s.Touch("test-other-agent", "192.0.2.2", time.Hour, later)
+
+	assert.Equal(now, s.CreatedAt)
+	assert.Equal(later, s.LastSeenAt)
+	assert.Equal(later.Add(time.Hour), s.ExpiresAt)
+	assert.Equal(s.ExpiresAt.Unix(), s.TTL)
+	assert.Equal("test-other-agent", s.UserAgent)
+	assert.Equal("192.0.2.2", s.IPAddress)
+}
+
+func TestLoginSession_Touch_TruncateUserAgent(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	s := NewLoginSession("test-session-id", &User{ID: "test-user-id"}, "test-agent", "192.0.2.1", time.Hour, now)
+
+	// 1文字が3バイトの文字で上限を超える User-Agent
+	s.Touch(strings.Repeat("端", loginSessionUserAgentMaxLength+1), "192.0.2.2", time.Hour, now)
+
+	assert.True(utf8.ValidString(s.UserAgent))
+	assert.Equal(strings.Repeat("端", loginSessionUserAgentMaxLength), s.UserAgent)
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// magicLinkNonceBytes はサインインのリンクの nonce のランダムなバイト数です。
+const magicLinkNonceBytes = 32
+
+// NewMagicLinkNonce はサインインのリンクをリクエストしたブラウザに渡す nonce を生成します。
+// リンクのトークンには nonce のハッシュのみを含め、メールを盗み見てもリンクを使えないようにします。
+func NewMagicLinkNonce() (string, error) {
+	b := make([]byte, magicLinkNonceBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashMagicLinkNonce は nonce のハッシュを返します。
+func HashMagicLinkNonce(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifyMagicLinkNonce は nonce がトークンに含めたハッシュと一致するかどうかを返します。
+func VerifyMagicLinkNonce(hash, nonce string) bool {
+	if hash == "" || nonce == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(HashMagicLinkNonce(nonce))) == 1
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyMagicLinkNonce(t *testing.T) {
+	nonce, err := NewMagicLinkNonce()
+	require.NoError(t, err)
+	assert.Len(t, nonce, 43)
+
+	tests := []struct {
+		name  string
+		hash  string
+		nonce string
+		want  bool
+	}{
+		{name: "一致する", hash: HashMagicLinkNonce(nonce), nonce: nonce, want: true},
+		{name: "別のブラウザの nonce", hash: HashMagicLinkNonce(nonce), nonce: "other-nonce", want: false},
+		{name: "nonce が空", hash: HashMagicLinkNonce(""), nonce: "", want: false},
+		{name: "ハッシュが空", hash: "", nonce: nonce, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifyMagicLinkNonce(tt.hash, tt.nonce))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc"
+)
+
+// OIDCStateLifetime は外部アカウントでのサインインを始めてからコールバックまでの有効期間です。
+const OIDCStateLifetime = 10 * time.Minute
+
+// OIDCState は外部アカウントでのサインインの認可のリクエストを表す構造体です。
+// State はコールバックで認可のリクエストを照合する値で、1回のみ使えます。
+// Nonce は ID トークンに含めさせる値、CodeVerifier は PKCE のコード検証値で、どちらもブラウザには渡しません。
+// TTL は DynamoDB の TTL で有効期限が切れたリクエストを削除するための UNIX 時間です。
+type OIDCState struct {
+	State        string
+	ProviderID   string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	TTL          int64
+	CreatedAt    time.Time
+}
+
+// NewOIDCState は providerID のプロバイダーの認可のリクエストを生成します。
+func NewOIDCState(providerID string, now time.Time) (*OIDCState, error) {
+	state, err := oidc.NewRandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.NewRandomString()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(OIDCStateLifetime)
+	return &OIDCState{
+		State:        state,
+		ProviderID:   providerID,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    expiresAt,
+		TTL:          expiresAt.Unix(),
+		CreatedAt:    now,
+	}, nil
+}
+
+// IsValid は now の時点で使えるかどうかを返します。
+func (s *OIDCState) IsValid(now time.Time) bool {
+	return now.Before(s.ExpiresAt)
+}
+
+// OIDCIdentity はユーザーに紐付けた外部アカウントを表す構造体です。
+// ID は発行者とプロバイダーの中で一意なユーザーの ID (sub) の組み合わせで、メールアドレスが変わっても同じアカウントを識別します。
+// Email は最後にサインインした時点の外部アカウントのメールアドレスです。
+type OIDCIdentity struct {
+	ID         string
+	Issuer     string
+	Subject    string
+	ProviderID string
+	UserID     string
+	Email      string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// OIDCIdentityID は発行者と sub から外部アカウントの ID を返します。
+func OIDCIdentityID(issuer, subject string) string {
+	return issuer + "#" + subject
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCState_IsValid(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	s, err := NewOIDCState("test-provider", now)
+	require.NoError(err)
+	assert.Len(s.State, 43)
+	assert.Len(s.Nonce, 43)
+	assert.Len(s.CodeVerifier, 43)
+	assert.NotEqual(s.State, s.Nonce)
+	assert.Equal(now.Add(OIDCStateLifetime).Unix(), s.TTL)
+
+	assert.True(s.IsValid(now.Add(time.Minute)))
+	assert.False(s.IsValid(now.Add(OIDCStateLifetime)))
+}
+
+func TestOIDCIdentityID(t *testing.T) {
+	assert.Equal(t, "https://accounts.google.com#1234", OIDCIdentityID("https://accounts.google.com", "1234"))
+}
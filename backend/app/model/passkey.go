@@ -0,0 +1,91 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+)
+
+const (
+	// PasskeyDefaultName は名前を指定せずに登録したパスキーの名前です。
+	PasskeyDefaultName = "パスキー"
+	// PasskeyNameMaxLength はパスキーの名前の最大文字数です。
+	PasskeyNameMaxLength = 50
+	// PasskeyMaxCount はユーザーごとに登録できるパスキーの数の上限です。
+	PasskeyMaxCount = 10
+	// WebAuthnChallengeLifetime はパスキーの登録と認証のチャレンジの有効期間です。
+	WebAuthnChallengeLifetime = 5 * time.Minute
+	// webAuthnChallengeBytes はチャレンジのランダムなバイト数です。
+	webAuthnChallengeBytes = 32
+)
+
+// Passkey はユーザーが登録したパスキー (WebAuthn の公開鍵の資格情報) を表す構造体です。
+// ID は認証器が発行した資格情報の ID の Base64URL で、PublicKey は COSE の形式の公開鍵です。
+// SignCount は認証器の署名の回数で、回数が増えない署名は複製された認証器によるものとして拒否します。
+// BackupEligible と BackedUp はパスキーが端末の間で同期できるか、同期されているかを表します。
+type Passkey struct {
+	ID             string
+	UserID         string
+	Name           string
+	PublicKey      []byte
+	Algorithm      int
+	SignCount      int64
+	BackupEligible bool
+	BackedUp       bool
+	CreatedAt      time.Time
+	LastUsedAt     time.Time
+}
+
+// IsValidSignCount は認証器の署名の回数が前回より増えているかどうかを返します。
+// 署名の回数を数えない認証器は常に 0 を返すため、前回と今回の両方が 0 の場合は確認しません。
+func (p *Passkey) IsValidSignCount(signCount int64) bool {
+	if p.SignCount == 0 && signCount == 0 {
+		return true
+	}
+	return signCount > p.SignCount
+}
+
+// WebAuthnChallengePurpose はチャレンジの用途を表す型です。
+type WebAuthnChallengePurpose string
+
+const (
+	// WebAuthnChallengePurposeRegistration はパスキーの登録のチャレンジです。
+	WebAuthnChallengePurposeRegistration WebAuthnChallengePurpose = "registration"
+	// WebAuthnChallengePurposeAuthentication はパスキーによるサインインのチャレンジです。
+	WebAuthnChallengePurposeAuthentication WebAuthnChallengePurpose = "authentication"
+)
+
+// WebAuthnChallenge はパスキーの登録と認証で認証器に署名させるチャレンジを表す構造体です。
+// 応答の再送を防ぐため、チャレンジは1回のみ使えます。登録のチャレンジは UserID のユーザーのみ使えます。
+// TTL は DynamoDB の TTL で有効期限が切れたチャレンジを削除するための UNIX 時間です。
+type WebAuthnChallenge struct {
+	Challenge string
+	Purpose   WebAuthnChallengePurpose
+	UserID    string
+	ExpiresAt time.Time
+	TTL       int64
+	CreatedAt time.Time
+}
+
+// NewWebAuthnChallenge はチャレンジを生成します。
+func NewWebAuthnChallenge(purpose WebAuthnChallengePurpose, userID string, now time.Time) (*WebAuthnChallenge, error) {
+	b := make([]byte, webAuthnChallengeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(WebAuthnChallengeLifetime)
+	return &WebAuthnChallenge{
+		Challenge: base64.RawURLEncoding.EncodeToString(b),
+		Purpose:   purpose,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		TTL:       expiresAt.Unix(),
+		CreatedAt: now,
+	}, nil
+}
+
+// IsValid は now の時点で purpose の用途に使えるかどうかを返します。
+func (c *WebAuthnChallenge) IsValid(purpose WebAuthnChallengePurpose, now time.Time) bool {
+	return c.Purpose == purpose && now.Before(c.ExpiresAt)
+}
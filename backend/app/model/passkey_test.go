@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasskey_IsValidSignCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    int64
+		signCount int64
+		want      bool
+	}{
+		{name: "前回より増えている", stored: 5, signCount: 6, want: true},
+		{name: "前回と同じ", stored: 5, signCount: 5, want: false},
+		{name: "前回より減っている", stored: 5, signCount: 1, want: false},
+		{name: "回数を数えない認証器", stored: 0, signCount: 0, want: true},
+		{name: "数え始めた認証器", stored: 0, signCount: 1, want: true},
+		{name: "数えていた認証器が 0 を返す", stored: 5, signCount: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Passkey{SignCount: tt.stored}
+			assert.Equal(t, tt.want, p.IsValidSignCount(tt.signCount))
+		})
+	}
+}
+
+func TestWebAuthnChallenge_IsValid(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	c, err := NewWebAuthnChallenge(WebAuthnChallengePurposeRegistration, "test-user-id", now)
+	require.NoError(err)
+	assert.Len(c.Challenge, 43)
+	assert.Equal(now.Add(WebAuthnChallengeLifetime).Unix(), c.TTL)
+
+	assert.True(c.IsValid(WebAuthnChallengePurposeRegistration, now.Add(time.Minute)))
+	assert.False(c.IsValid(WebAuthnChallengePurposeAuthentication, now.Add(time.Minute)))
+	assert.False(c.IsValid(WebAuthnChallengePurposeRegistration, now.Add(WebAuthnChallengeLifetime)))
+}
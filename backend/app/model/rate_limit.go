@@ -0,0 +1,137 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// RateLimitKind は回数を制限する操作の種類を表す型です。
+type RateLimitKind string
+
+const (
+	// RateLimitKindSignInEmail はメールアドレスごとのサインインの失敗です。
+	RateLimitKindSignInEmail RateLimitKind = "signin_email"
+	// RateLimitKindSignInIP は送信元の IP アドレスごとのサインインの失敗です。
+	RateLimitKindSignInIP RateLimitKind = "signin_ip"
+	// RateLimitKindPasswordResetMail はメールアドレスごとのパスワードリセットのメールの送信です。
+	RateLimitKindPasswordResetMail RateLimitKind = "password_reset_mail"
+	// RateLimitKindSignUpMail はメールアドレスごとのサインアップのメールの送信です。
+	RateLimitKindSignUpMail RateLimitKind = "signup_mail"
+	// RateLimitKindMagicLinkMail はメールアドレスごとのサインインのリンクのメールの送信です。
+	RateLimitKindMagicLinkMail RateLimitKind = "magic_link_mail"
+	// RateLimitKindTwoFactor はユーザーごとの二段階認証の認証コードの失敗です。
+	RateLimitKindTwoFactor RateLimitKind = "two_factor"
+)
+
+// MailCooldown は同じメールアドレスにパスワードリセットやサインアップのメールを再び送信できるまでの間隔です。
+const MailCooldown = time.Minute
+
+// RateLimitKey は操作の種類と対象から回数の制限のキーを生成します。
+// メールアドレスは大文字と小文字を区別せずに数えます。
+func RateLimitKey(kind RateLimitKind, subject string) string {
+	return string(kind) + "#" + strings.ToLower(strings.TrimSpace(subject))
+}
+
+// RateLimitPolicy は失敗の回数による制限の方針を表す構造体です。
+// DelayAfter 回失敗すると次の試行まで待つ時間を1秒から倍にしながら MaxDelay まで延ばし、
+// LockAfter 回失敗すると LockDuration の間はロックします。
+// 失敗の回数は最後の失敗から Window が過ぎるとリセットします。
+type RateLimitPolicy struct {
+	Window       time.Duration
+	DelayAfter   int
+	MaxDelay     time.Duration
+	LockAfter    int
+	LockDuration time.Duration
+}
+
+var (
+	// SignInEmailPolicy はメールアドレスごとのサインインの失敗の制限です。
+	SignInEmailPolicy = RateLimitPolicy{
+		Window:       15 * time.Minute,
+		DelayAfter:   3,
+		MaxDelay:     time.Minute,
+		LockAfter:    10,
+		LockDuration: 15 * time.Minute,
+	}
+	// SignInIPPolicy は送信元の IP アドレスごとのサインインの失敗の制限です。
+	// 同じ IP アドレスを複数のユーザーで共有する場合を考慮して、メールアドレスごとより緩くします。
+	SignInIPPolicy = RateLimitPolicy{
+		Window:       15 * time.Minute,
+		DelayAfter:   10,
+		MaxDelay:     time.Minute,
+		LockAfter:    50,
+		LockDuration: 15 * time.Minute,
+	}
+	// TwoFactorPolicy はユーザーごとの二段階認証の認証コードの失敗の制限です。
+	// パスワードを知っている相手が認証コードを総当たりできないよう、サインインより早くロックします。
+	TwoFactorPolicy = RateLimitPolicy{
+		Window:       15 * time.Minute,
+		DelayAfter:   3,
+		MaxDelay:     time.Minute,
+		LockAfter:    5,
+		LockDuration: 15 * time.Minute,
+	}
+)
+
+// Delay は failures 回失敗した後に次の試行まで待つ時間を返します。
+func (p RateLimitPolicy) Delay(failures int) time.Duration {
+	if failures < p.DelayAfter {
+		return 0
+	}
+
+	d := time.Second
+	for n := p.DelayAfter; n < failures && d < p.MaxDelay; n++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	return d
+}
+
+// RateLimit は回数の制限のカウンターを表す構造体です。
+// Key は RateLimitKey で生成したキーで、TTL は DynamoDB の TTL でカウンターを削除するための UNIX 時間です。
+// TTL を過ぎたカウンターは削除される前でも存在しないものとして扱います。
+type RateLimit struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+	TTL           int64
+	UpdatedAt     time.Time
+}
+
+// IsExpired は now の時点でカウンターの期限が切れているかどうかを返します。
+func (r *RateLimit) IsExpired(now time.Time) bool {
+	return r.TTL <= now.Unix()
+}
+
+// IsLocked は now の時点でロックされているかどうかを返します。
+func (r *RateLimit) IsLocked(now time.Time) bool {
+	return now.Before(r.LockedUntil)
+}
+
+// RetryAfter は now の時点で次に試行できるまでの時間を返します。すぐに試行できる場合は 0 を返します。
+func (r *RateLimit) RetryAfter(policy RateLimitPolicy, now time.Time) time.Duration {
+	if r.IsExpired(now) {
+		return 0
+	}
+
+	if r.IsLocked(now) {
+		return r.LockedUntil.Sub(now)
+	}
+
+	next := r.LastFailureAt.Add(policy.Delay(r.Failures))
+	if next.After(now) {
+		return next.Sub(now)
+	}
+
+	return 0
+}
+
+// ShouldLock は失敗の回数がロックする回数にちょうど達したかどうかを返します。
+// 失敗の回数は atomic に加算するため、同時に失敗した場合も true になるのは1回のみです。
+func (r *RateLimit) ShouldLock(policy RateLimitPolicy) bool {
+	return r.Failures == policy.LockAfter
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitKey(t *testing.T) {
+	assert.Equal(t, "signin_email#test-email@example.com", RateLimitKey(RateLimitKindSignInEmail, " Test-Email@Example.com "))
+}
+
+func TestRateLimitPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{name: "待つ回数に達していない場合は待たない", failures: 2, want: 0},
+		{name: "待つ回数に達した場合は1秒待つ", failures: 3, want: time.Second},
+		{name: "失敗が続く度に待つ時間を倍にする", failures: 5, want: 4 * time.Second},
+		{name: "待つ時間は上限を超えない", failures: 20, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SignInEmailPolicy.Delay(tt.failures))
+		})
+	}
+}
+
+func TestRateLimit_RetryAfter(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	ttl := now.Add(time.Hour).Unix()
+
+	tests := []struct {
+		name string
+		rl   RateLimit
+		want time.Duration
+	}{
+		{name: "失敗が少ない場合はすぐに試行できる", rl: RateLimit{Failures: 1, LastFailureAt: now, TTL: ttl}, want: 0},
+		{name: "失敗が続いた場合は待つ", rl: RateLimit{Failures: 4, LastFailureAt: now.Add(-time.Second), TTL: ttl}, want: time.Second},
+		{name: "待つ時間が過ぎた場合はすぐに試行できる", rl: RateLimit{Failures: 4, LastFailureAt: now.Add(-time.Minute), TTL: ttl}, want: 0},
+		{name: "ロックされている場合はロックが解除されるまで待つ", rl: RateLimit{Failures: 10, LastFailureAt: now, LockedUntil: now.Add(15 * time.Minute), TTL: ttl}, want: 15 * time.Minute},
+		{name: "期限が切れている場合はすぐに試行できる", rl: RateLimit{Failures: 10, LastFailureAt: now, LockedUntil: now.Add(15 * time.Minute), TTL: now.Unix()}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rl.RetryAfter(SignInEmailPolicy, now))
+		})
+	}
+}
+
+func TestRateLimit_ShouldLock(t *testing.T) {
+	assert.False(t, (&RateLimit{Failures: 9}).ShouldLock(SignInEmailPolicy))
+	assert.True(t, (&RateLimit{Failures: 10}).ShouldLock(SignInEmailPolicy))
+	assert.False(t, (&RateLimit{Failures: 11}).ShouldLock(SignInEmailPolicy))
+}
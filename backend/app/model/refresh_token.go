@@ -0,0 +1,78 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshTokenStatus はリフレッシュトークンの状態を表す型です。
+type RefreshTokenStatus string
+
+const (
+	// RefreshTokenStatusActive はアクセストークンの再発行に使える状態です。
+	RefreshTokenStatusActive RefreshTokenStatus = "active"
+	// RefreshTokenStatusRotated は再発行に使われ、新しいリフレッシュトークンに置き換えられた状態です。
+	RefreshTokenStatusRotated RefreshTokenStatus = "rotated"
+	// RefreshTokenStatusRevoked はサインアウトや再利用の検知で無効にされた状態です。
+	RefreshTokenStatusRevoked RefreshTokenStatus = "revoked"
+)
+
+// refreshTokenBytes はリフレッシュトークンのランダムなバイト数です。
+const refreshTokenBytes = 32
+
+// RefreshToken はリフレッシュトークンを表す構造体です。
+// トークンそのものは保存せず、ハッシュを ID として保存します。
+// FamilyID はサインインで発行してから再発行で引き継いだトークンの系列の ID で、再利用を検知した場合は系列ごと無効にします。
+// TTL は DynamoDB の TTL で有効期限が切れたトークンを削除するための UNIX 時間です。
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	UserID    string
+	Status    RefreshTokenStatus
+	ExpiresAt time.Time
+	TTL       int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewRefreshToken はリフレッシュトークンを生成します。
+// 戻り値の文字列はクライアントに渡すトークンで、保存するのはそのハッシュのみです。
+func NewRefreshToken(userID, familyID string, lifetime time.Duration, now time.Time) (*RefreshToken, string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	expiresAt := now.Add(lifetime)
+	return &RefreshToken{
+		ID:        HashRefreshToken(token),
+		FamilyID:  familyID,
+		UserID:    userID,
+		Status:    RefreshTokenStatusActive,
+		ExpiresAt: expiresAt,
+		TTL:       expiresAt.Unix(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, token, nil
+}
+
+// HashRefreshToken はリフレッシュトークンを保存するためのハッシュに変換します。
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsable はアクセストークンの再発行に使えるかどうかを返します。
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.Status == RefreshTokenStatusActive && now.Before(t.ExpiresAt)
+}
+
+// IsReused は再発行に使われたトークンがもう一度使われたかどうかを返します。
+// 漏洩したトークンが使われた可能性があるため、系列ごと無効にする必要があります。
+func (t *RefreshToken) IsReused() bool {
+	return t.Status == RefreshTokenStatusRotated
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	rt, token, err := NewRefreshToken("test-user-id", "test-family-id", 24*time.Hour, now)
+	require.NoError(err)
+
+	assert.NotEmpty(token)
+	assert.NotEqual(token, rt.ID)
+	assert.Equal(HashRefreshToken(token), rt.ID)
+	assert.Equal(RefreshTokenStatusActive, rt.Status)
+	assert.Equal(now.Add(24*time.Hour), rt.ExpiresAt)
+	assert.Equal(rt.ExpiresAt.Unix(), rt.TTL)
+
+	_, other, err := NewRefreshToken("test-user-id", "test-family-id", 24*time.Hour, now)
+	require.NoError(err)
+	assert.NotEqual(token, other)
+}
+
+func TestRefreshToken_IsUsable(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    RefreshTokenStatus
+		expiresAt time.Time
+		want      bool
+	}{
+		{name: "有効なトークン", status: RefreshTokenStatusActive, expiresAt: now.Add(time.Minute), want: true},
+		{name: "有効期限切れのトークン", status: RefreshTokenStatusActive, expiresAt: now, want: false},
+		{name: "再発行に使われたトークン", status: RefreshTokenStatusRotated, expiresAt: now.Add(time.Minute), want: false},
+		{name: "無効にされたトークン", status: RefreshTokenStatusRevoked, expiresAt: now.Add(time.Minute), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &RefreshToken{Status: tt.status, ExpiresAt: tt.expiresAt}
+			assert.Equal(t, tt.want, rt.IsUsable(now))
+		})
+	}
+}
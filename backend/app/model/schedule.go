@@ -3,17 +3,140 @@ package model
 import "time"
 
 // Schedule はスケジュールの model を表す構造体です。
+// LectureNumber が 0 の場合、講義回は名前から判定します。
+// Note は Markdown 形式のノート、Links は LMS の講義ページなどの URL、Memo は短いメモです。
+// TagIDs にはスケジュールに付いたタグの ID を保持します。
+// Timed が false の場合は終日のスケジュールとして StartsAt と EndsAt の日付のみを扱い、
+// true の場合は時刻まで指定した時間指定のスケジュールとして扱います。
 type Schedule struct {
-	ID        string
-	UserID    string
-	Name      string
-	StartsAt  time.Time
-	EndsAt    time.Time
-	Color     string
-	Type      ScheduleType
-	Order     Order
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            string
+	UserID        string
+	Name          string
+	StartsAt      time.Time
+	EndsAt        time.Time
+	Timed         bool
+	Color         string
+	Type          ScheduleType
+	Order         Order
+	LectureNumber int
+	Note          string
+	Links         []string
+	Memo          string
+	TagIDs        []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Lecture は受講スケジュールの科目名と講義回を返します。
+// LectureNumber が設定されている場合は名前から判定した講義回より優先します。
+func (s Schedule) Lecture() (subject string, number int, ok bool) {
+	subject, number, ok = ParseLectureName(s.Name)
+	if s.LectureNumber > 0 {
+		return subject, s.LectureNumber, true
+	}
+	return subject, number, ok
+}
+
+// HasTag はスケジュールに指定されたタグが付いているかどうかを返します。
+func (s Schedule) HasTag(tagID string) bool {
+	for _, id := range s.TagIDs {
+		if id == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag はスケジュールにタグを付けます。すでに付いている場合は false を返します。
+func (s *Schedule) AddTag(tagID string) bool {
+	if s.HasTag(tagID) {
+		return false
+	}
+	s.TagIDs = append(s.TagIDs, tagID)
+	return true
+}
+
+// RemoveTag はスケジュールからタグを外します。付いていない場合は false を返します。
+func (s *Schedule) RemoveTag(tagID string) bool {
+	if !s.HasTag(tagID) {
+		return false
+	}
+
+	tagIDs := make([]string, 0, len(s.TagIDs)-1)
+	for _, id := range s.TagIDs {
+		if id != tagID {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+	s.TagIDs = tagIDs
+	return true
+}
+
+// In はスケジュールの日時を loc の時刻に変換したスケジュールを返します。
+// 日付の判定はスケジュールの日時のタイムゾーンで行うため、ユーザーのタイムゾーンに変換してから使用します。
+func (s Schedule) In(loc *time.Location) Schedule {
+	s.StartsAt = s.StartsAt.In(loc)
+	s.EndsAt = s.EndsAt.In(loc)
+	s.CreatedAt = s.CreatedAt.In(loc)
+	s.UpdatedAt = s.UpdatedAt.In(loc)
+	return s
+}
+
+// ValidTimeRange は開始日時と終了日時の前後関係が正しいかどうかを返します。
+// 終日のスケジュールは終了日が開始日以降、時間指定のスケジュールは終了日時が開始日時より後の場合に正しいとします。
+// 時間指定のスケジュールは日をまたいでも構いません。
+func (s Schedule) ValidTimeRange() bool {
+	if s.Timed {
+		return s.EndsAt.After(s.StartsAt)
+	}
+	return s.EndsAt.Format(DateFormat) >= s.StartsAt.Format(DateFormat)
+}
+
+// sortsBefore は同じ日のスケジュールの中で s が other より前に並ぶかどうかを返します。
+// 終日のスケジュールを時間指定のスケジュールより前に並べ、時間指定のスケジュール同士は開始時刻の早い順に並べます。
+// それ以外は Order で比較します。
+func (s Schedule) sortsBefore(other Schedule) bool {
+	if s.Timed != other.Timed {
+		return !s.Timed
+	}
+	if s.Timed && !s.StartsAt.Equal(other.StartsAt) {
+		return s.StartsAt.Before(other.StartsAt)
+	}
+	return s.Order < other.Order
+}
+
+// RebaseLegacyDate はタイムゾーン対応前に保存された終日のスケジュールの日時を loc の日付として解釈し直します。
+// タイムゾーン対応前は入力された日付を UTC の 0 時として保存していたため、
+// UTC の 0 時にある終日のスケジュールのうち、loc のその日のオフセットが 0 でないものを対象とします。
+// 対象外のスケジュールはそのまま返し、ok は false になります。
+func (s Schedule) RebaseLegacyDate(loc *time.Location) (rebased Schedule, ok bool) {
+	if s.Timed {
+		return s, false
+	}
+
+	start := s.StartsAt.UTC()
+	if start.Hour() != 0 || start.Minute() != 0 || start.Second() != 0 || start.Nanosecond() != 0 {
+		return s, false
+	}
+
+	if _, offset := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc).Zone(); offset == 0 {
+		return s, false
+	}
+
+	s.StartsAt = rebaseWallClock(start, loc)
+	s.EndsAt = rebaseWallClock(s.EndsAt.UTC(), loc)
+	return s, true
+}
+
+// rebaseWallClock は t の日付と時刻をそのまま loc の時刻として解釈し直します。
+func rebaseWallClock(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// IsCompleted は指定された日時の時点でスケジュールが完了済みかどうかを返します。
+// 終了日が now の日付より前の場合に完了済みとします。
+func (s Schedule) IsCompleted(now time.Time) bool {
+	return s.EndsAt.Format(DateFormat) < now.Format(DateFormat)
 }
 
 // ScheduleType はスケジュールの種類を表す構造体です。
@@ -35,8 +158,9 @@ func (s ScheduleType) String() string {
 }
 
 // ToScheduleType は文字列を ScheduleType に変換します。
+// 該当しない場合は空文字の ScheduleType を返します。
 func ToScheduleType(s string) ScheduleType {
-	return ScheduleType(s)
+	return ScheduleType(ScheduleType(s).String())
 }
 
 // Order はスケジュールの順番を表す構造体です。
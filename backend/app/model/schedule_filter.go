@@ -0,0 +1,60 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ScheduleFilter はスケジュールの絞り込み条件を表す構造体です。
+// ゼロ値の項目は条件に含めません。From と To は日付のみを扱い、両端の日を含みます。
+// TagID は指定されたタグが付いたスケジュールを、NamePrefix は名前の前方一致、UpdatedSince は指定日時以降に更新されたスケジュールを対象とします。
+// Completed を指定した場合、終了日が Now より前の日のスケジュールを完了済みとして扱います。
+type ScheduleFilter struct {
+	Type         ScheduleType
+	Color        string
+	TagID        string
+	NamePrefix   string
+	UpdatedSince time.Time
+	From         time.Time
+	To           time.Time
+	Completed    *bool
+	Now          time.Time
+}
+
+// Match はスケジュールが絞り込み条件に一致するかどうかを返します。
+func (f ScheduleFilter) Match(s Schedule) bool {
+	if f.Type != "" && s.Type != f.Type {
+		return false
+	}
+
+	if f.Color != "" && s.Color != f.Color {
+		return false
+	}
+
+	if f.TagID != "" && !s.HasTag(f.TagID) {
+		return false
+	}
+
+	if f.NamePrefix != "" && !strings.HasPrefix(s.Name, f.NamePrefix) {
+		return false
+	}
+
+	if !f.UpdatedSince.IsZero() && s.UpdatedAt.Before(f.UpdatedSince) {
+		return false
+	}
+
+	date := s.StartsAt.Format(DateFormat)
+	if !f.From.IsZero() && date < f.From.Format(DateFormat) {
+		return false
+	}
+
+	if !f.To.IsZero() && date > f.To.Format(DateFormat) {
+		return false
+	}
+
+	if f.Completed != nil && s.IsCompleted(f.Now) != *f.Completed {
+		return false
+	}
+
+	return true
+}
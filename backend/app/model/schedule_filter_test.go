@@ -0,0 +1,64 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleFilter_Match(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	schedule := Schedule{
+		ID:        "test-id",
+		Name:      "統計学 第6回",
+		StartsAt:  time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC),
+		EndsAt:    time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC),
+		Color:     "red",
+		Type:      ScheduleTypeCustom,
+		UpdatedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+	}
+	completed := true
+	notCompleted := false
+
+	tests := []struct {
+		name   string
+		filter ScheduleFilter
+		want   bool
+	}{
+		{name: "条件なし", filter: ScheduleFilter{}, want: true},
+		{name: "種類が一致", filter: ScheduleFilter{Type: ScheduleTypeCustom}, want: true},
+		{name: "種類が不一致", filter: ScheduleFilter{Type: ScheduleTypeMaster}, want: false},
+		{name: "色が一致", filter: ScheduleFilter{Color: "red"}, want: true},
+		{name: "色が不一致", filter: ScheduleFilter{Color: "blue"}, want: false},
+		{name: "名前の前方一致", filter: ScheduleFilter{NamePrefix: "統計学"}, want: true},
+		{name: "名前の途中のみ一致", filter: ScheduleFilter{NamePrefix: "第6回"}, want: false},
+		{name: "更新日時と同じ日時以降", filter: ScheduleFilter{UpdatedSince: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)}, want: true},
+		{name: "更新日時より後", filter: ScheduleFilter{UpdatedSince: time.Date(2024, 6, 1, 9, 0, 1, 0, time.UTC)}, want: false},
+		{name: "期間の初日と同じ日", filter: ScheduleFilter{From: time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)}, want: true},
+		{name: "期間の初日より前", filter: ScheduleFilter{From: time.Date(2024, 6, 6, 0, 0, 0, 0, time.UTC)}, want: false},
+		{name: "期間の最終日と同じ日", filter: ScheduleFilter{To: time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)}, want: true},
+		{name: "期間の最終日より後", filter: ScheduleFilter{To: time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC)}, want: false},
+		{name: "完了済みを指定", filter: ScheduleFilter{Completed: &completed, Now: now}, want: true},
+		{name: "未完了を指定", filter: ScheduleFilter{Completed: &notCompleted, Now: now}, want: false},
+		{name: "当日は未完了", filter: ScheduleFilter{Completed: &notCompleted, Now: time.Date(2024, 6, 5, 23, 0, 0, 0, time.UTC)}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(schedule))
+		})
+	}
+}
+
+func TestScheduleList_Filter(t *testing.T) {
+	sl := ScheduleList{
+		{ID: "1", Color: "red", Type: ScheduleTypeCustom},
+		{ID: "2", Color: "blue", Type: ScheduleTypeCustom},
+		{ID: "3", Color: "red", Type: ScheduleTypeMaster},
+	}
+
+	got := sl.Filter(ScheduleFilter{Color: "red", Type: ScheduleTypeCustom})
+	assert.Equal(t, ScheduleList{{ID: "1", Color: "red", Type: ScheduleTypeCustom}}, got)
+	assert.Equal(t, ScheduleList{}, sl.Filter(ScheduleFilter{Color: "green"}))
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // ScheduleList はスケジュールのリストを表す構造体です。
 type ScheduleList []Schedule
 
@@ -14,11 +16,71 @@ func (sl ScheduleList) FilterByType(t ScheduleType) ScheduleList {
 	return schedules
 }
 
-// Sort はスケジュールを Order の昇順で並び替えます。
+// In はすべてのスケジュールの日時を loc の時刻に変換したリストを返します。
+func (sl ScheduleList) In(loc *time.Location) ScheduleList {
+	res := make(ScheduleList, 0, len(sl))
+	for _, s := range sl {
+		res = append(res, s.In(loc))
+	}
+	return res
+}
+
+// Filter は絞り込み条件に一致するスケジュールのリストを返します。
+func (sl ScheduleList) Filter(f ScheduleFilter) ScheduleList {
+	res := ScheduleList{}
+	for _, s := range sl {
+		if f.Match(s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// Replace は同じ ID のスケジュールを changed の内容に置き換えたリストを返します。
+// changed のうちリストに存在しないスケジュールは末尾に追加します。
+func (sl ScheduleList) Replace(changed ScheduleList) ScheduleList {
+	cm := make(map[string]Schedule, len(changed))
+	for _, c := range changed {
+		cm[c.ID] = c
+	}
+
+	res := make(ScheduleList, 0, len(sl)+len(changed))
+	for _, s := range sl {
+		if c, ok := cm[s.ID]; ok {
+			res = append(res, c)
+			delete(cm, s.ID)
+			continue
+		}
+		res = append(res, s)
+	}
+
+	for _, c := range changed {
+		if _, ok := cm[c.ID]; ok {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// Sort は同じ日のスケジュールを並び替えます。
+// 終日のスケジュールを Order の昇順で先に並べ、時間指定のスケジュールを開始時刻の早い順に続けます。
 func (sl ScheduleList) Sort() {
 	for i := 0; i < len(sl); i++ {
 		for j := i + 1; j < len(sl); j++ {
-			if sl[i].Order > sl[j].Order {
+			if sl[j].sortsBefore(sl[i]) {
+				sl[i], sl[j] = sl[j], sl[i]
+			}
+		}
+	}
+}
+
+// SortByStartsAt はスケジュールを予定の早い順に並び替えます。
+// 同じ日の場合は Sort と同じ順に並び替えます。
+func (sl ScheduleList) SortByStartsAt() {
+	for i := 0; i < len(sl); i++ {
+		for j := i + 1; j < len(sl); j++ {
+			if scheduledBefore(sl[j], sl[i]) {
 				sl[i], sl[j] = sl[j], sl[i]
 			}
 		}
@@ -42,7 +104,8 @@ func (sl ScheduleList) NextOrder() Order {
 }
 
 // ToDateItemList はスケジュールリストを日付ごとのリストに変換します。
-// リストは日付の昇順、スケジュールの Order の昇順で並び替えられます。
+// リストは日付の昇順、日付の中は Sort の順で並び替えられます。
+// 日付は StartsAt のタイムゾーンで判定します。
 func (sl ScheduleList) ToDateItemList() DateItemList {
 	dataMap := make(map[ScheduleType]map[string]DateItem)
 	for _, s := range sl {
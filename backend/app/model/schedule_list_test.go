@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,3 +94,86 @@ func TestScheduleList_Sort(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleList_Replace(t *testing.T) {
+	sl := ScheduleList{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}
+
+	got := sl.Replace(ScheduleList{{ID: "2", Name: "changed"}, {ID: "3", Name: "new"}})
+
+	assert.Equal(t, ScheduleList{{ID: "1", Name: "a"}, {ID: "2", Name: "changed"}, {ID: "3", Name: "new"}}, got)
+	assert.Equal(t, "b", sl[1].Name)
+}
+
+func TestScheduleList_SortByStartsAt(t *testing.T) {
+	d1 := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	sl := ScheduleList{
+		{ID: "3", StartsAt: d2, Order: 1},
+		{ID: "2", StartsAt: d1, Order: 2},
+		{ID: "1", StartsAt: d1, Order: 1},
+	}
+	sl.SortByStartsAt()
+
+	assert.Equal(t, []string{"1", "2", "3"}, []string{sl[0].ID, sl[1].ID, sl[2].ID})
+}
+
+func TestScheduleList_In(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	sl := ScheduleList{
+		{ID: "1", Type: ScheduleTypeCustom, StartsAt: time.Date(2023, 12, 31, 15, 30, 0, 0, time.UTC), Order: 1},
+		{ID: "2", Type: ScheduleTypeCustom, StartsAt: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), Order: 2},
+	}
+
+	assert.Len(t, sl.ToDateItemList(), 2)
+
+	got := sl.In(tokyo).ToDateItemList()
+	require.Len(t, got, 1)
+	assert.Equal(t, "2024-01-01", got[0].Date.Format(DateFormat))
+	assert.Len(t, got[0].Schedules, 2)
+	assert.Equal(t, time.UTC, sl[0].StartsAt.Location())
+}
+
+func TestScheduleList_Sort_Timed(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2024, 6, 1, h, m, 0, 0, time.UTC) }
+
+	sl := ScheduleList{
+		{ID: "study", Timed: true, StartsAt: at(20, 0), Order: 1},
+		{ID: "all-day-2", StartsAt: at(0, 0), Order: 2},
+		{ID: "lecture", Timed: true, StartsAt: at(9, 0), Order: 3},
+		{ID: "all-day-1", StartsAt: at(0, 0), Order: 1},
+		{ID: "review", Timed: true, StartsAt: at(9, 0), Order: 1},
+	}
+	sl.Sort()
+
+	var ids []string
+	for _, s := range sl {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []string{"all-day-1", "all-day-2", "review", "lecture", "study"}, ids)
+}
+
+func TestScheduleList_In_RebaseLegacyDate(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	legacy := Schedule{ID: "1", Type: ScheduleTypeCustom, StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Order: 1}
+	zoned := Schedule{ID: "2", Type: ScheduleTypeCustom, StartsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo).UTC(), EndsAt: time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo).UTC(), Order: 2}
+
+	assert.False(t, legacy.StartsAt.Equal(zoned.StartsAt))
+
+	rebased, ok := legacy.RebaseLegacyDate(tokyo)
+	require.True(t, ok)
+	_, ok = zoned.RebaseLegacyDate(tokyo)
+	require.False(t, ok)
+
+	assert.True(t, rebased.StartsAt.Equal(zoned.StartsAt))
+
+	got := ScheduleList{rebased, zoned}.In(tokyo).ToDateItemList()
+	require.Len(t, got, 1)
+	assert.Equal(t, "2024-01-01", got[0].Date.Format(DateFormat))
+	assert.Len(t, got[0].Schedules, 2)
+	assert.Equal(t, Order(3), ScheduleList{rebased, zoned}.NextOrder())
+}
@@ -0,0 +1,348 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+)
+
+// DefaultMaxLecturesPerDay は1日あたりの受講上限が設定されていない場合に過密と判定する件数です。
+const DefaultMaxLecturesPerDay = 6
+
+// DeadlineKeywords は受講の期限となる学事スケジュールの名前に含まれるキーワードです。
+var DeadlineKeywords = []string{"単位認定試験"}
+
+// WarningCode はスケジュールの警告の種類を表す型です。
+type WarningCode string
+
+const (
+	WarningCodeAfterDeadline  WarningCode = "after_deadline"  // 期限より後の受講
+	WarningCodeOverload       WarningCode = "overload"        // 1日の受講数の超過
+	WarningCodeSequence       WarningCode = "sequence"        // 講義回の順番の逆転
+	WarningCodeUnavailableDay WarningCode = "unavailable_day" // 受講できない日の受講
+)
+
+// String は WarningCode を文字列に変換します。
+func (c WarningCode) String() string {
+	return string(c)
+}
+
+// Warning はスケジュールの警告を表す構造体です。
+// 警告は登録や更新を妨げるものではなく、利用者に見直しを促すためのものです。
+type Warning struct {
+	Code        WarningCode
+	Message     string
+	Date        time.Time
+	ScheduleIDs []string
+}
+
+// HasScheduleID は警告が指定されたスケジュールに関するものかどうかを返します。
+func (w Warning) HasScheduleID(id string) bool {
+	for _, sid := range w.ScheduleIDs {
+		if sid == id {
+			return true
+		}
+	}
+	return false
+}
+
+// WarningList は警告のリストを表す構造体です。
+type WarningList []Warning
+
+// FilterByScheduleIDs は指定されたスケジュールのいずれかに関する警告でフィルタリングします。
+func (wl WarningList) FilterByScheduleIDs(ids ...string) WarningList {
+	res := WarningList{}
+	for _, w := range wl {
+		for _, id := range ids {
+			if w.HasScheduleID(id) {
+				res = append(res, w)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// Difference は before に含まれない警告を返します。
+// 変更によって新たに発生した警告を取り出すために使用します。
+func (wl WarningList) Difference(before WarningList) WarningList {
+	exists := make(map[string]bool)
+	for _, w := range before {
+		exists[w.key()] = true
+	}
+
+	res := WarningList{}
+	for _, w := range wl {
+		if !exists[w.key()] {
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+// key は警告を同一視するためのキーを返します。
+func (w Warning) key() string {
+	return w.Code.String() + ":" + strings.Join(w.ScheduleIDs, ",")
+}
+
+// Sort は警告を日付の昇順、同じ日付の場合は警告の種類の昇順で並び替えます。
+func (wl WarningList) Sort() {
+	for i := 0; i < len(wl); i++ {
+		for j := i + 1; j < len(wl); j++ {
+			di, dj := wl[i].Date.Format(DateFormat), wl[j].Date.Format(DateFormat)
+			if di > dj || (di == dj && wl[i].Code > wl[j].Code) {
+				wl[i], wl[j] = wl[j], wl[i]
+			}
+		}
+	}
+}
+
+// RuleContext はルールの評価に使用するユーザーの設定を表す構造体です。
+// Days には評価するスケジュールの日ごとの受講可否を保持します。
+// Locale は警告のメッセージの言語で、空の場合は日本語とします。
+type RuleContext struct {
+	SequenceStrictness SequenceStrictness
+	Days               DayAvailabilityMap
+	Locale             i18n.Locale
+}
+
+// Rule はスケジュールを評価して警告を返すルールを表すインターフェースです。
+type Rule interface {
+	Evaluate(schedules ScheduleList, ctx RuleContext) WarningList
+}
+
+// RuleSet は複数のルールをまとめて評価するルールエンジンを表す構造体です。
+type RuleSet []Rule
+
+// NewDefaultRuleSet は標準のルールをすべて含む RuleSet を生成します。
+func NewDefaultRuleSet() RuleSet {
+	return RuleSet{
+		DeadlineRule{Keywords: DeadlineKeywords},
+		OverloadRule{DefaultLimit: DefaultMaxLecturesPerDay},
+		SequenceRule{},
+		UnavailableDayRule{},
+	}
+}
+
+// Evaluate はすべてのルールでスケジュールを評価し、日付順に並べた警告を返します。
+func (rs RuleSet) Evaluate(schedules ScheduleList, ctx RuleContext) WarningList {
+	res := WarningList{}
+	for _, r := range rs {
+		res = append(res, r.Evaluate(schedules, ctx)...)
+	}
+	res.Sort()
+	return res
+}
+
+// DeadlineRule は単位認定試験などの期限より後に予定された受講を検出するルールです。
+// 科目ごとに最初の受講日以降で最も近い期限を、その科目の期限とみなします。
+type DeadlineRule struct {
+	Keywords []string
+}
+
+// Evaluate はスケジュールを評価します。
+func (r DeadlineRule) Evaluate(schedules ScheduleList, ctx RuleContext) WarningList {
+	var deadlines ScheduleList
+	for _, s := range schedules.FilterByType(ScheduleTypeMaster) {
+		if r.isDeadline(s) {
+			deadlines = append(deadlines, s)
+		}
+	}
+	if len(deadlines) == 0 {
+		return nil
+	}
+	deadlines.SortByStartsAt()
+
+	var res WarningList
+	for _, lectures := range groupBySubject(schedules.FilterByType(ScheduleTypeCustom)) {
+		first := lectures[0].StartsAt.Format(DateFormat)
+
+		var deadline *Schedule
+		for i := range deadlines {
+			if deadlines[i].StartsAt.Format(DateFormat) >= first {
+				deadline = &deadlines[i]
+				break
+			}
+		}
+		if deadline == nil {
+			continue
+		}
+
+		for _, l := range lectures {
+			if l.StartsAt.Format(DateFormat) <= deadline.StartsAt.Format(DateFormat) {
+				continue
+			}
+
+			res = append(res, Warning{
+				Code:        WarningCodeAfterDeadline,
+				Message:     i18n.Sprintf(ctx.Locale, "「%s」が「%s」（%s）より後に予定されています", l.Name, deadline.Name, deadline.StartsAt.Format(DateFormat)),
+				Date:        l.StartsAt,
+				ScheduleIDs: []string{l.ID, deadline.ID},
+			})
+		}
+	}
+
+	return res
+}
+
+func (r DeadlineRule) isDeadline(s Schedule) bool {
+	for _, k := range r.Keywords {
+		if strings.Contains(s.Name, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverloadRule は1日の受講数が上限を超えている日を検出するルールです。
+// ユーザーが1日あたりの受講上限を設定していない場合は DefaultLimit を上限とします。
+type OverloadRule struct {
+	DefaultLimit int
+}
+
+// Evaluate はスケジュールを評価します。
+func (r OverloadRule) Evaluate(schedules ScheduleList, ctx RuleContext) WarningList {
+	var res WarningList
+	for _, di := range schedules.FilterByType(ScheduleTypeCustom).ToDateItemList() {
+		da, ok := ctx.Days.Find(di.Date)
+		if !ok {
+			da = DayAvailability{Date: di.Date, Available: true, Scheduled: len(di.Schedules)}
+		}
+		if da.MaxLectures <= 0 {
+			da.MaxLectures = r.DefaultLimit
+		}
+		if !da.Exceeded() {
+			continue
+		}
+
+		ids := make([]string, 0, len(di.Schedules))
+		for _, s := range di.Schedules {
+			ids = append(ids, s.ID)
+		}
+
+		res = append(res, Warning{
+			Code:        WarningCodeOverload,
+			Message:     i18n.Sprintf(ctx.Locale, "%s に%d件の受講が予定されています（上限%d件）", di.Date.Format(DateFormat), da.Scheduled, da.MaxLectures),
+			Date:        di.Date,
+			ScheduleIDs: ids,
+		})
+	}
+
+	return res
+}
+
+// SequenceRule は同じ科目の講義回が逆順に予定されている箇所を検出するルールです。
+// ユーザーの SequenceStrictness が off の場合は検出しません。
+type SequenceRule struct{}
+
+// Evaluate はスケジュールを評価します。
+func (r SequenceRule) Evaluate(schedules ScheduleList, ctx RuleContext) WarningList {
+	if ctx.SequenceStrictness == SequenceStrictnessOff {
+		return nil
+	}
+
+	var res WarningList
+	for _, lectures := range groupBySubject(schedules.FilterByType(ScheduleTypeCustom)) {
+		type numbered struct {
+			Schedule
+			number int
+		}
+
+		var nl []numbered
+		for _, l := range lectures {
+			if _, n, ok := l.Lecture(); ok {
+				nl = append(nl, numbered{Schedule: l, number: n})
+			}
+		}
+
+		for i := 0; i < len(nl); i++ {
+			for j := i + 1; j < len(nl); j++ {
+				if nl[i].number > nl[j].number {
+					nl[i], nl[j] = nl[j], nl[i]
+				}
+			}
+		}
+
+		for i := 1; i < len(nl); i++ {
+			prev, cur := nl[i-1], nl[i]
+			if prev.number == cur.number || !scheduledBefore(cur.Schedule, prev.Schedule) {
+				continue
+			}
+
+			res = append(res, Warning{
+				Code:        WarningCodeSequence,
+				Message:     i18n.Sprintf(ctx.Locale, "「%s」が「%s」より前に予定されています", cur.Name, prev.Name),
+				Date:        cur.StartsAt,
+				ScheduleIDs: []string{cur.ID, prev.ID},
+			})
+		}
+	}
+
+	return res
+}
+
+// UnavailableDayRule は受講しない曜日や受講できない期間に予定された受講を検出するルールです。
+// 受講可否は RuleContext の Days で判定します。
+type UnavailableDayRule struct{}
+
+// Evaluate はスケジュールを評価します。
+func (r UnavailableDayRule) Evaluate(schedules ScheduleList, ctx RuleContext) WarningList {
+	var res WarningList
+	for _, s := range schedules.FilterByType(ScheduleTypeCustom) {
+		da, ok := ctx.Days.Find(s.StartsAt)
+		if !ok || da.Available {
+			continue
+		}
+
+		var msg string
+		switch da.Reason {
+		case UnavailableReasonBlackout:
+			msg = i18n.Sprintf(ctx.Locale, "「%s」が受講できない期間「%s」に予定されています", s.Name, da.BlackoutLabel)
+		default:
+			msg = i18n.Sprintf(ctx.Locale, "「%s」が受講しない曜日（%s曜日）に予定されています", s.Name, i18n.Translate(ctx.Locale, weekdayNames[s.StartsAt.Weekday()]))
+		}
+
+		res = append(res, Warning{
+			Code:        WarningCodeUnavailableDay,
+			Message:     msg,
+			Date:        s.StartsAt,
+			ScheduleIDs: []string{s.ID},
+		})
+	}
+
+	return res
+}
+
+// weekdayNames は曜日の日本語表記です。他の言語の表記はメッセージカタログで翻訳します。
+var weekdayNames = [...]string{"日", "月", "火", "水", "木", "金", "土"}
+
+// groupBySubject は受講スケジュールを科目名ごとにまとめ、それぞれ予定の早い順に並べて返します。
+func groupBySubject(schedules ScheduleList) []ScheduleList {
+	var keys []string
+	m := make(map[string]ScheduleList)
+	for _, s := range schedules {
+		subject, _, _ := s.Lecture()
+		if _, ok := m[subject]; !ok {
+			keys = append(keys, subject)
+		}
+		m[subject] = append(m[subject], s)
+	}
+
+	res := make([]ScheduleList, 0, len(keys))
+	for _, k := range keys {
+		m[k].SortByStartsAt()
+		res = append(res, m[k])
+	}
+	return res
+}
+
+// scheduledBefore は a が b より前に予定されているかどうかを返します。
+// 同じ日の場合は時間指定の開始時刻と Order で比較します。
+func scheduledBefore(a, b Schedule) bool {
+	da, db := a.StartsAt.Format(DateFormat), b.StartsAt.Format(DateFormat)
+	if da != db {
+		return da < db
+	}
+	return a.sortsBefore(b)
+}
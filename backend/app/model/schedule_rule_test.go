@@ -0,0 +1,208 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadlineRule_Evaluate(t *testing.T) {
+	d := func(day int) time.Time { return time.Date(2024, 7, day, 0, 0, 0, 0, time.UTC) }
+
+	schedules := ScheduleList{
+		{ID: "exam", Name: "単位認定試験", StartsAt: d(20), Type: ScheduleTypeMaster},
+		{ID: "l4", Name: "統計学 第4回", StartsAt: d(19), Type: ScheduleTypeCustom},
+		{ID: "l5", Name: "統計学 第5回", StartsAt: d(21), Type: ScheduleTypeCustom},
+		{ID: "other", Name: "英語 第1回", StartsAt: d(25), Type: ScheduleTypeCustom},
+	}
+
+	got := DeadlineRule{Keywords: DeadlineKeywords}.Evaluate(schedules, RuleContext{})
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, WarningCodeAfterDeadline, got[0].Code)
+		assert.Equal(t, []string{"l5", "exam"}, got[0].ScheduleIDs)
+		assert.Equal(t, "「統計学 第5回」が「単位認定試験」（2024-07-20）より後に予定されています", got[0].Message)
+	}
+}
+
+func TestOverloadRule_Evaluate(t *testing.T) {
+	date := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	var schedules ScheduleList
+	for i := 1; i <= 7; i++ {
+		schedules = append(schedules, Schedule{ID: string(rune('a' + i)), StartsAt: date, Type: ScheduleTypeCustom, Order: Order(i)})
+	}
+
+	days := func(max int) DayAvailabilityMap {
+		return Availability{Weekdays: AllWeekdays, MaxLecturesPerDay: max}.DayAvailabilityMap(schedules, nil)
+	}
+
+	tests := []struct {
+		name      string
+		ctx       RuleContext
+		wantCount int
+	}{
+		{name: "上限未設定の場合は既定の上限で判定する", ctx: RuleContext{Days: days(0)}, wantCount: 1},
+		{name: "ユーザーの上限を超えている", ctx: RuleContext{Days: days(3)}, wantCount: 1},
+		{name: "ユーザーの上限以内", ctx: RuleContext{Days: days(7)}, wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OverloadRule{DefaultLimit: DefaultMaxLecturesPerDay}.Evaluate(schedules, tt.ctx)
+			assert.Len(t, got, tt.wantCount)
+		})
+	}
+}
+
+func TestSequenceRule_Evaluate(t *testing.T) {
+	d := func(day int) time.Time { return time.Date(2024, 7, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		schedules ScheduleList
+		wantIDs   [][]string
+	}{
+		{
+			name: "講義回の順番どおり",
+			schedules: ScheduleList{
+				{ID: "l3", Name: "統計学 第3回", StartsAt: d(1), Type: ScheduleTypeCustom},
+				{ID: "l4", Name: "統計学 第4回", StartsAt: d(2), Type: ScheduleTypeCustom},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "第4回が第3回より前",
+			schedules: ScheduleList{
+				{ID: "l3", Name: "統計学 第3回", StartsAt: d(2), Type: ScheduleTypeCustom},
+				{ID: "l4", Name: "統計学 第4回", StartsAt: d(1), Type: ScheduleTypeCustom},
+			},
+			wantIDs: [][]string{{"l4", "l3"}},
+		},
+		{
+			name: "同じ日は Order で判定する",
+			schedules: ScheduleList{
+				{ID: "l3", Name: "統計学 第3回", StartsAt: d(1), Type: ScheduleTypeCustom, Order: 2},
+				{ID: "l4", Name: "統計学 第4回", StartsAt: d(1), Type: ScheduleTypeCustom, Order: 1},
+			},
+			wantIDs: [][]string{{"l4", "l3"}},
+		},
+		{
+			name: "LectureNumber で判定する",
+			schedules: ScheduleList{
+				{ID: "l3", Name: "統計学 復習", LectureNumber: 3, StartsAt: d(2), Type: ScheduleTypeCustom},
+				{ID: "l4", Name: "統計学 演習", LectureNumber: 4, StartsAt: d(1), Type: ScheduleTypeCustom},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "別の科目は比較しない",
+			schedules: ScheduleList{
+				{ID: "a3", Name: "統計学 第3回", StartsAt: d(2), Type: ScheduleTypeCustom},
+				{ID: "b4", Name: "英語 第4回", StartsAt: d(1), Type: ScheduleTypeCustom},
+			},
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIDs [][]string
+			for _, w := range (SequenceRule{}).Evaluate(tt.schedules, RuleContext{}) {
+				assert.Equal(t, WarningCodeSequence, w.Code)
+				gotIDs = append(gotIDs, w.ScheduleIDs)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestUnavailableDayRule_Evaluate(t *testing.T) {
+	availability := Availability{Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}
+	blackouts := BlackoutList{
+		{Label: "出張", StartsAt: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	schedules := ScheduleList{
+		{ID: "mon", Name: "月曜の受講", StartsAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Type: ScheduleTypeCustom},
+		{ID: "tue", Name: "出張中の受講", StartsAt: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), Type: ScheduleTypeCustom},
+		{ID: "sun", Name: "日曜の受講", StartsAt: time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC), Type: ScheduleTypeCustom},
+		{ID: "master", Name: "日曜の学事", StartsAt: time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC), Type: ScheduleTypeMaster},
+	}
+
+	ctx := RuleContext{Days: availability.DayAvailabilityMap(schedules, blackouts)}
+
+	got := UnavailableDayRule{}.Evaluate(schedules, ctx)
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "「出張中の受講」が受講できない期間「出張」に予定されています", got[0].Message)
+		assert.Equal(t, "「日曜の受講」が受講しない曜日（日曜日）に予定されています", got[1].Message)
+	}
+
+	ctx.Locale = i18n.English
+	got = UnavailableDayRule{}.Evaluate(schedules, ctx)
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "\"出張中の受講\" is scheduled during the blackout period \"出張\".", got[0].Message)
+		assert.Equal(t, "\"日曜の受講\" is scheduled on a day you do not attend (Sun).", got[1].Message)
+	}
+}
+
+func TestRuleSet_Evaluate(t *testing.T) {
+	d := func(day int) time.Time { return time.Date(2024, 7, day, 0, 0, 0, 0, time.UTC) }
+
+	schedules := ScheduleList{
+		{ID: "exam", Name: "単位認定試験", StartsAt: d(20), Type: ScheduleTypeMaster},
+		{ID: "l3", Name: "統計学 第3回", StartsAt: d(22), Type: ScheduleTypeCustom},
+		{ID: "l4", Name: "統計学 第4回", StartsAt: d(10), Type: ScheduleTypeCustom},
+	}
+
+	got := NewDefaultRuleSet().Evaluate(schedules, RuleContext{})
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, WarningCodeSequence, got[0].Code)
+		assert.Equal(t, WarningCodeAfterDeadline, got[1].Code)
+	}
+
+	assert.Len(t, got.FilterByScheduleIDs("l4"), 1)
+	assert.Len(t, got.FilterByScheduleIDs("exam"), 1)
+	assert.Empty(t, got.FilterByScheduleIDs("unknown"))
+}
+
+func TestSequenceRule_Evaluate_Strictness(t *testing.T) {
+	schedules := ScheduleList{
+		{ID: "l3", Name: "統計学 第3回", StartsAt: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), Type: ScheduleTypeCustom},
+		{ID: "l4", Name: "統計学 第4回", StartsAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Type: ScheduleTypeCustom},
+	}
+
+	tests := []struct {
+		name       string
+		strictness SequenceStrictness
+		wantCount  int
+	}{
+		{name: "未設定の場合は検出する", strictness: "", wantCount: 1},
+		{name: "reject の場合は検出する", strictness: SequenceStrictnessReject, wantCount: 1},
+		{name: "off の場合は検出しない", strictness: SequenceStrictnessOff, wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := RuleContext{SequenceStrictness: tt.strictness}
+			assert.Len(t, SequenceRule{}.Evaluate(schedules, ctx), tt.wantCount)
+		})
+	}
+}
+
+func TestWarningList_Difference(t *testing.T) {
+	before := WarningList{
+		{Code: WarningCodeSequence, ScheduleIDs: []string{"a", "b"}},
+	}
+	after := WarningList{
+		{Code: WarningCodeSequence, ScheduleIDs: []string{"a", "b"}},
+		{Code: WarningCodeSequence, ScheduleIDs: []string{"c", "b"}},
+	}
+
+	assert.Equal(t, WarningList{{Code: WarningCodeSequence, ScheduleIDs: []string{"c", "b"}}}, after.Difference(before))
+}
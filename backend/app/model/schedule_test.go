@@ -2,8 +2,10 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestScheduleType_String(t *testing.T) {
@@ -40,3 +42,100 @@ func TestScheduleType_String(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedule_ValidTimeRange(t *testing.T) {
+	at := func(d, h, m int) time.Time { return time.Date(2024, 6, d, h, m, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     bool
+	}{
+		{name: "終日 同じ日", schedule: Schedule{StartsAt: at(1, 0, 0), EndsAt: at(1, 0, 0)}, want: true},
+		{name: "終日 終了日が開始日より前", schedule: Schedule{StartsAt: at(2, 0, 0), EndsAt: at(1, 0, 0)}, want: false},
+		{name: "終日 同じ日の時刻は比較しない", schedule: Schedule{StartsAt: at(1, 12, 0), EndsAt: at(1, 9, 0)}, want: true},
+		{name: "時間指定 同じ日", schedule: Schedule{Timed: true, StartsAt: at(1, 20, 0), EndsAt: at(1, 21, 30)}, want: true},
+		{name: "時間指定 日をまたぐ", schedule: Schedule{Timed: true, StartsAt: at(1, 23, 0), EndsAt: at(2, 1, 0)}, want: true},
+		{name: "時間指定 開始と終了が同じ", schedule: Schedule{Timed: true, StartsAt: at(1, 20, 0), EndsAt: at(1, 20, 0)}, want: false},
+		{name: "時間指定 終了が開始より前", schedule: Schedule{Timed: true, StartsAt: at(1, 21, 0), EndsAt: at(1, 20, 0)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.schedule.ValidTimeRange())
+		})
+	}
+}
+
+func TestSchedule_RebaseLegacyDate(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	utcMidnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokyoMidnight := time.Date(2024, 1, 1, 0, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name      string
+		schedule  Schedule
+		loc       *time.Location
+		wantOK    bool
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "UTC の 0 時の終日のスケジュールを東側のタイムゾーンの日付に変換する",
+			schedule:  Schedule{StartsAt: utcMidnight, EndsAt: utcMidnight.AddDate(0, 0, 2)},
+			loc:       tokyo,
+			wantOK:    true,
+			wantStart: time.Date(2023, 12, 31, 15, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "UTC の 0 時の終日のスケジュールを西側のタイムゾーンの日付に変換する",
+			schedule:  Schedule{StartsAt: utcMidnight, EndsAt: utcMidnight},
+			loc:       newYork,
+			wantOK:    true,
+			wantStart: time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "タイムゾーン対応後のスケジュールは変換しない",
+			schedule:  Schedule{StartsAt: tokyoMidnight, EndsAt: tokyoMidnight},
+			loc:       tokyo,
+			wantOK:    false,
+			wantStart: tokyoMidnight,
+			wantEnd:   tokyoMidnight,
+		},
+		{
+			name:      "オフセットが 0 のタイムゾーンでは変換しない",
+			schedule:  Schedule{StartsAt: utcMidnight, EndsAt: utcMidnight},
+			loc:       time.UTC,
+			wantOK:    false,
+			wantStart: utcMidnight,
+			wantEnd:   utcMidnight,
+		},
+		{
+			name:      "時間指定のスケジュールは変換しない",
+			schedule:  Schedule{Timed: true, StartsAt: utcMidnight, EndsAt: utcMidnight.Add(time.Hour)},
+			loc:       tokyo,
+			wantOK:    false,
+			wantStart: utcMidnight,
+			wantEnd:   utcMidnight.Add(time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.schedule.RebaseLegacyDate(tt.loc)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.wantStart.Equal(got.StartsAt), got.StartsAt)
+			assert.True(t, tt.wantEnd.Equal(got.EndsAt), got.EndsAt)
+
+			again, ok := got.RebaseLegacyDate(tt.loc)
+			assert.False(t, ok)
+			assert.True(t, got.StartsAt.Equal(again.StartsAt))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+)
+
+const (
+	// ScheduleNameMaxLength はスケジュール名の文字数の上限です。
+	ScheduleNameMaxLength = 50
+	// ScheduleNoteMaxLength はノートの文字数の上限です。
+	ScheduleNoteMaxLength = 2000
+	// ScheduleMemoMaxLength はメモの文字数の上限です。
+	ScheduleMemoMaxLength = 100
+	// ScheduleLinksMax はリンクの件数の上限です。
+	ScheduleLinksMax = 5
+	// ScheduleLinkMaxLength はリンクの URL の長さの上限です。
+	ScheduleLinkMaxLength = 2048
+	// ScheduleTagsMax はスケジュールに付けるタグの件数の上限です。
+	ScheduleTagsMax = 10
+)
+
+// Validate はスケジュールの不変条件を検証し、すべての違反を ValidationError で返します。
+// 項目のパスはリクエストの JSON のキーに合わせます。
+// ユーザーが定義した色が存在するかどうかは判定しません。
+func (s Schedule) Validate() error {
+	ve := &ValidationError{}
+
+	// name が空文字
+	if s.Name == "" {
+		ve.Add("name", "スケジュール名を入力してください")
+	}
+
+	// name が上限の文字数より多い
+	if utf8.RuneCountInString(s.Name) > ScheduleNameMaxLength {
+		ve.Add("name", fmt.Sprintf("スケジュール名は%d文字以内で入力してください", ScheduleNameMaxLength))
+	}
+
+	// starts_at が未指定
+	if s.StartsAt.IsZero() {
+		ve.Add("starts_at", "開始日を入力してください")
+	}
+
+	// ends_at が未指定
+	if s.EndsAt.IsZero() {
+		ve.Add("ends_at", "終了日を入力してください")
+	}
+
+	if !s.StartsAt.IsZero() && !s.EndsAt.IsZero() {
+		switch {
+		// ends_at が starts_at より前
+		case s.EndsAt.Before(s.StartsAt):
+			ve.Add("ends_at", "終了日は開始日以降の日付を入力してください")
+		// 時間指定で ends_at が starts_at より後でない
+		case !s.ValidTimeRange():
+			ve.Add("ends_at", "時間指定のスケジュールは終了日時を開始日時より後にしてください")
+		}
+	}
+
+	// color が空文字
+	if s.Color == "" {
+		ve.Add("color", "色を指定してください")
+	} else if !Color(s.Color).Valid() {
+		ve.Add("color", "色はパレットの色またはユーザーが定義した色を指定してください")
+	}
+
+	// type が空文字
+	if s.Type == "" {
+		ve.Add("type", "スケジュールの種類を指定してください")
+	} else if s.Type.String() == "" {
+		ve.Add("type", fmt.Sprintf("スケジュールの種類は %s または %s を指定してください", ScheduleTypeMaster, ScheduleTypeCustom))
+	}
+
+	// lecture_number が範囲外
+	if s.LectureNumber < 0 || s.LectureNumber > MaxLectureNumber {
+		ve.Add("lecture_number", fmt.Sprintf("講義回は1～%dで指定してください", MaxLectureNumber))
+	}
+
+	s.validateDetail(ve)
+	validateTagIDs(s.TagIDs, ve)
+
+	return ve.Err()
+}
+
+// validateDetail はノート、リンク、メモの不変条件を検証します。
+func (s Schedule) validateDetail(ve *ValidationError) {
+	// note が上限の文字数より多い
+	if utf8.RuneCountInString(s.Note) > ScheduleNoteMaxLength {
+		ve.Add("note", fmt.Sprintf("ノートは%d文字以内で入力してください", ScheduleNoteMaxLength))
+	}
+
+	// memo が上限の文字数より多い
+	if utf8.RuneCountInString(s.Memo) > ScheduleMemoMaxLength {
+		ve.Add("memo", fmt.Sprintf("メモは%d文字以内で入力してください", ScheduleMemoMaxLength))
+	}
+
+	// links が上限の件数より多い
+	if len(s.Links) > ScheduleLinksMax {
+		ve.Add("links", fmt.Sprintf("リンクは%d件以内で指定してください", ScheduleLinksMax))
+	}
+
+	// links が http または https の URL でない
+	for i, link := range s.Links {
+		field := IndexedField("links", i)
+		if len(link) > ScheduleLinkMaxLength {
+			ve.Add(field, fmt.Sprintf("リンクは%d文字以内で入力してください", ScheduleLinkMaxLength))
+			continue
+		}
+
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			ve.Add(field, "リンクは http または https の URL を入力してください")
+		}
+	}
+}
+
+// validateTagIDs はスケジュールに付けるタグ ID の不変条件を検証します。
+func validateTagIDs(tagIDs []string, ve *ValidationError) {
+	// tag_ids が上限の件数より多い
+	if len(tagIDs) > ScheduleTagsMax {
+		ve.Add("tag_ids", fmt.Sprintf("タグは%d件以内で指定してください", ScheduleTagsMax))
+	}
+
+	// tag_ids に空文字が含まれる
+	for i, id := range tagIDs {
+		if id == "" {
+			ve.Add(IndexedField("tag_ids", i), "タグIDを指定してください")
+		}
+	}
+}
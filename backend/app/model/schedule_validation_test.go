@@ -0,0 +1,155 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToScheduleType(t *testing.T) {
+	assert.Equal(t, ScheduleTypeMaster, ToScheduleType("master"))
+	assert.Equal(t, ScheduleTypeCustom, ToScheduleType("custom"))
+	assert.Equal(t, ScheduleType(""), ToScheduleType("unknown"))
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() Schedule {
+		return Schedule{Name: "統計学 第1回", StartsAt: date, EndsAt: date, Color: "white", Type: ScheduleTypeCustom}
+	}
+
+	tests := []struct {
+		name     string
+		schedule func() Schedule
+		want     []FieldError
+	}{
+		{
+			name:     "正常系",
+			schedule: valid,
+		},
+		{
+			name: "正常系: 詳細とタグを上限まで指定",
+			schedule: func() Schedule {
+				s := valid()
+				s.Note = strings.Repeat("あ", 2000)
+				s.Memo = strings.Repeat("あ", 100)
+				s.Links = []string{"https://a.example.com", "http://b.example.com/path?q=1", "https://c.example.com", "https://d.example.com", "https://e.example.com"}
+				s.TagIDs = strings.Split("a,b,c,d,e,f,g,h,i,j", ",")
+				return s
+			},
+		},
+		{
+			name:     "異常系: すべての違反を返す",
+			schedule: func() Schedule { return Schedule{Type: "unknown", LectureNumber: -1} },
+			want: []FieldError{
+				{Field: "name", Message: "スケジュール名を入力してください"},
+				{Field: "starts_at", Message: "開始日を入力してください"},
+				{Field: "ends_at", Message: "終了日を入力してください"},
+				{Field: "color", Message: "色を指定してください"},
+				{Field: "type", Message: "スケジュールの種類は master または custom を指定してください"},
+				{Field: "lecture_number", Message: "講義回は1～999で指定してください"},
+			},
+		},
+		{
+			name: "異常系: name が50文字より多い",
+			schedule: func() Schedule {
+				s := valid()
+				s.Name = strings.Repeat("あ", 51)
+				return s
+			},
+			want: []FieldError{{Field: "name", Message: "スケジュール名は50文字以内で入力してください"}},
+		},
+		{
+			name: "異常系: 終了日が開始日より前",
+			schedule: func() Schedule {
+				s := valid()
+				s.EndsAt = date.Add(-time.Second)
+				return s
+			},
+			want: []FieldError{{Field: "ends_at", Message: "終了日は開始日以降の日付を入力してください"}},
+		},
+		{
+			name: "異常系: 時間指定で終了日時が開始日時と同じ",
+			schedule: func() Schedule {
+				s := valid()
+				s.Timed = true
+				return s
+			},
+			want: []FieldError{{Field: "ends_at", Message: "時間指定のスケジュールは終了日時を開始日時より後にしてください"}},
+		},
+		{
+			name: "異常系: パレットにない色",
+			schedule: func() Schedule {
+				s := valid()
+				s.Color = "purple"
+				return s
+			},
+			want: []FieldError{{Field: "color", Message: "色はパレットの色またはユーザーが定義した色を指定してください"}},
+		},
+		{
+			name: "異常系: ノートとメモが長すぎる",
+			schedule: func() Schedule {
+				s := valid()
+				s.Note = strings.Repeat("あ", 2001)
+				s.Memo = strings.Repeat("あ", 101)
+				return s
+			},
+			want: []FieldError{
+				{Field: "note", Message: "ノートは2000文字以内で入力してください"},
+				{Field: "memo", Message: "メモは100文字以内で入力してください"},
+			},
+		},
+		{
+			name: "異常系: リンクが多すぎる",
+			schedule: func() Schedule {
+				s := valid()
+				s.Links = []string{"https://a.example.com", "https://b.example.com", "https://c.example.com", "https://d.example.com", "https://e.example.com", "https://f.example.com"}
+				return s
+			},
+			want: []FieldError{{Field: "links", Message: "リンクは5件以内で指定してください"}},
+		},
+		{
+			name: "異常系: リンクの URL が不正",
+			schedule: func() Schedule {
+				s := valid()
+				s.Links = []string{"https://example.com/" + strings.Repeat("a", 2048), "javascript:alert(1)", "https://"}
+				return s
+			},
+			want: []FieldError{
+				{Field: "links[0]", Message: "リンクは2048文字以内で入力してください"},
+				{Field: "links[1]", Message: "リンクは http または https の URL を入力してください"},
+				{Field: "links[2]", Message: "リンクは http または https の URL を入力してください"},
+			},
+		},
+		{
+			name: "異常系: タグが多すぎて空の ID を含む",
+			schedule: func() Schedule {
+				s := valid()
+				s.TagIDs = strings.Split("a,b,c,d,e,f,g,h,i,j,", ",")
+				return s
+			},
+			want: []FieldError{
+				{Field: "tag_ids", Message: "タグは10件以内で指定してください"},
+				{Field: "tag_ids[10]", Message: "タグIDを指定してください"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := tt.schedule().Validate()
+			if tt.want == nil {
+				assert.NoError(err)
+				return
+			}
+
+			ve, ok := AsValidationError(err)
+			assert.True(ok)
+			assert.Equal(tt.want, ve.Errors)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
 
 // Subject は科目の model を表す構造体です。
 type Subject struct {
@@ -11,3 +15,31 @@ type Subject struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// SubjectNameMaxLength は科目名の文字数の上限です。
+const SubjectNameMaxLength = 50
+
+// Validate は科目の不変条件を検証し、すべての違反を ValidationError で返します。
+// ユーザーが定義した色が存在するかどうかは判定しません。
+func (s Subject) Validate() error {
+	ve := &ValidationError{}
+
+	// name が空文字
+	if s.Name == "" {
+		ve.Add("name", "科目名を入力してください")
+	}
+
+	// name が上限の文字数より多い
+	if utf8.RuneCountInString(s.Name) > SubjectNameMaxLength {
+		ve.Add("name", fmt.Sprintf("科目名は%d文字以内で入力してください", SubjectNameMaxLength))
+	}
+
+	// color が空文字
+	if s.Color == "" {
+		ve.Add("color", "色を指定してください")
+	} else if !Color(s.Color).Valid() {
+		ve.Add("color", "色はパレットの色またはユーザーが定義した色を指定してください")
+	}
+
+	return ve.Err()
+}
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+// Tag はユーザーが定義するスケジュールのタグを表す構造体です。
+type Tag struct {
+	ID        string
+	UserID    string
+	Name      string
+	Color     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TagList はタグのリストを表す構造体です。
+type TagList []Tag
+
+// FindByName は指定された名前のタグを返します。
+func (tl TagList) FindByName(name string) (*Tag, bool) {
+	for _, t := range tl {
+		if t.Name == name {
+			return &t, true
+		}
+	}
+	return nil, false
+}
+
+// Sort はタグを名前の昇順で並び替えます。
+func (tl TagList) Sort() {
+	for i := 0; i < len(tl); i++ {
+		for j := i + 1; j < len(tl); j++ {
+			if tl[i].Name > tl[j].Name {
+				tl[i], tl[j] = tl[j], tl[i]
+			}
+		}
+	}
+}
+
+// ScheduleTag はスケジュールとタグの対応を表す構造体です。
+// タグが付いたスケジュールをユーザーの全スケジュールを読まずに取得するために保持します。
+type ScheduleTag struct {
+	TagID      string
+	ScheduleID string
+	UserID     string
+	CreatedAt  time.Time
+}
+
+// UniqueTagIDs は重複と空文字を除いたタグ ID のリストを返します。
+func UniqueTagIDs(tagIDs []string) []string {
+	res := []string{}
+	seen := make(map[string]bool, len(tagIDs))
+	for _, id := range tagIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, id)
+	}
+	return res
+}
+
+// DiffTagIDs は before から after への変更で追加されたタグ ID と削除されたタグ ID を返します。
+func DiffTagIDs(before, after []string) (added, removed []string) {
+	bm := make(map[string]bool, len(before))
+	for _, id := range before {
+		bm[id] = true
+	}
+	am := make(map[string]bool, len(after))
+	for _, id := range after {
+		am[id] = true
+		if !bm[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range before {
+		if !am[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
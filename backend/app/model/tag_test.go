@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueTagIDs(t *testing.T) {
+	tests := []struct {
+		name   string
+		tagIDs []string
+		want   []string
+	}{
+		{name: "nil", tagIDs: nil, want: []string{}},
+		{name: "重複と空文字を除く", tagIDs: []string{"a", "", "b", "a"}, want: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UniqueTagIDs(tt.tagIDs))
+		})
+	}
+}
+
+func TestDiffTagIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      []string
+		after       []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{name: "変更なし", before: []string{"a"}, after: []string{"a"}, wantAdded: nil, wantRemoved: nil},
+		{name: "追加のみ", before: nil, after: []string{"a", "b"}, wantAdded: []string{"a", "b"}, wantRemoved: nil},
+		{name: "削除のみ", before: []string{"a", "b"}, after: nil, wantAdded: nil, wantRemoved: []string{"a", "b"}},
+		{name: "置き換え", before: []string{"a", "b"}, after: []string{"b", "c"}, wantAdded: []string{"c"}, wantRemoved: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			added, removed := DiffTagIDs(tt.before, tt.after)
+			assert.Equal(tt.wantAdded, added)
+			assert.Equal(tt.wantRemoved, removed)
+		})
+	}
+}
+
+func TestSchedule_AddTag_RemoveTag(t *testing.T) {
+	assert := assert.New(t)
+
+	s := Schedule{TagIDs: []string{"a"}}
+
+	assert.False(s.AddTag("a"))
+	assert.True(s.AddTag("b"))
+	assert.Equal([]string{"a", "b"}, s.TagIDs)
+
+	assert.True(s.RemoveTag("a"))
+	assert.False(s.RemoveTag("a"))
+	assert.Equal([]string{"b"}, s.TagIDs)
+	assert.True(s.HasTag("b"))
+	assert.False(s.HasTag("a"))
+}
+
+func TestTagList_Sort(t *testing.T) {
+	tl := TagList{{ID: "2", Name: "試験"}, {ID: "1", Name: "レポート"}, {ID: "3", Name: "課題"}}
+	tl.Sort()
+
+	assert.Equal(t, []string{"1", "2", "3"}, []string{tl[0].ID, tl[1].ID, tl[2].ID})
+}
@@ -0,0 +1,193 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpDigits は TOTP の認証コードの桁数です。
+	totpDigits = 6
+	// totpPeriod は TOTP の認証コードが切り替わる間隔です。
+	totpPeriod = 30 * time.Second
+	// totpSkew は端末の時計のずれを許容する前後の間隔の数です。
+	totpSkew = 1
+	// totpSecretBytes は TOTP の秘密鍵のバイト数です。RFC 4226 が推奨する160ビットにします。
+	totpSecretBytes = 20
+	// RecoveryCodeCount は発行するリカバリーコードの数です。
+	RecoveryCodeCount = 10
+	// recoveryCodeBytes はリカバリーコードのランダムなバイト数です。
+	recoveryCodeBytes = 5
+)
+
+// totpEncoding は TOTP の秘密鍵を認証アプリに渡すためのパディングなしの Base32 です。
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactor はユーザーの TOTP による二段階認証の設定を表す構造体です。
+// Secret は Base32 の秘密鍵で、認証コードで確認するまでは Enabled が false のままサインインには使いません。
+// LastUsedStep は最後に使われた認証コードの間隔の番号で、同じ認証コードの再利用を防ぎます。
+// RecoveryCodes は未使用のリカバリーコードのハッシュです。
+type TwoFactor struct {
+	UserID        string
+	Secret        string
+	Enabled       bool
+	LastUsedStep  int64
+	RecoveryCodes []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewTwoFactor は新しい秘密鍵で確認前の二段階認証の設定を生成します。
+func NewTwoFactor(userID string, now time.Time) (*TwoFactor, error) {
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactor{
+		UserID:    userID,
+		Secret:    totpEncoding.EncodeToString(b),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// URI は認証アプリに登録するための otpauth URI を返します。
+func (tf *TwoFactor) URI(issuer, account string) string {
+	v := url.Values{}
+	v.Set("secret", tf.Secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// Verify は now の時点で認証コードが正しいかどうかを確認し、正しい場合は認証コードの間隔の番号を返します。
+// 端末の時計のずれを考慮して前後の間隔の認証コードも受け付けますが、LastUsedStep 以前の間隔の認証コードは受け付けません。
+func (tf *TwoFactor) Verify(code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(tf.Secret)
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.TrimSpace(code)
+	current := totpStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= tf.LastUsedStep {
+			continue
+		}
+		want := hotp(key, uint64(step), totpDigits)
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// Code は now の時点の認証コードを返します。
+func (tf *TwoFactor) Code(now time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(tf.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, uint64(totpStep(now)), totpDigits), nil
+}
+
+// Activate は認証コードで確認した二段階認証を有効にし、リカバリーコードを発行します。
+// 戻り値はユーザーに1回のみ表示するリカバリーコードで、保存するのはそのハッシュのみです。
+func (tf *TwoFactor) Activate(step int64, now time.Time) ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for n := 0; n < RecoveryCodeCount; n++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+
+	tf.Enabled = true
+	tf.LastUsedStep = step
+	tf.RecoveryCodes = hashes
+	tf.UpdatedAt = now
+
+	return codes, nil
+}
+
+// RecoveryCodeIndex は未使用のリカバリーコードのうち code に一致するものの位置を返します。一致しない場合は -1 を返します。
+func (tf *TwoFactor) RecoveryCodeIndex(code string) int {
+	hash := HashRecoveryCode(code)
+	for n, h := range tf.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			return n
+		}
+	}
+
+	return -1
+}
+
+// HashRecoveryCode はリカバリーコードを保存するためのハッシュを返します。
+// 入力しやすいように大文字と小文字、区切りのハイフンと空白を区別しません。
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRecoveryCode は「xxxx-xxxx」の形式のリカバリーコードを生成します。
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	s := strings.ToLower(totpEncoding.EncodeToString(b))
+	return s[:4] + "-" + s[4:], nil
+}
+
+// totpStep は t の時点の認証コードの間隔の番号を返します。
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod.Seconds())
+}
+
+// hotp は RFC 4226 の HOTP で counter の認証コードを生成します。
+func hotp(key []byte, counter uint64, digits int) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for n := 0; n < digits; n++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 Appendix B の SHA1 のテストベクター
+func TestHOTP_RFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "59秒", unix: 59, want: "94287082"},
+		{name: "1111111109秒", unix: 1111111109, want: "07081804"},
+		{name: "1111111111秒", unix: 1111111111, want: "14050471"},
+		{name: "1234567890秒", unix: 1234567890, want: "89005924"},
+		{name: "2000000000秒", unix: 2000000000, want: "69279037"},
+		{name: "20000000000秒", unix: 20000000000, want: "65353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := totpStep(time.Unix(tt.unix, 0))
+			assert.Equal(t, tt.want, hotp(key, uint64(step), 8))
+		})
+	}
+}
+
+func TestTwoFactor_Verify(t *testing.T) {
+	tf := &TwoFactor{Secret: totpEncoding.EncodeToString([]byte("12345678901234567890"))}
+	now := time.Unix(1111111111, 0)
+	step := totpStep(now)
+
+	tests := []struct {
+		name         string
+		code         string
+		lastUsedStep int64
+		wantStep     int64
+		wantOK       bool
+	}{
+		// RFC 6238 のテストベクターの下6桁
+		{name: "現在の間隔の認証コードを受け付ける", code: "050471", wantStep: step, wantOK: true},
+		{name: "1つ前の間隔の認証コードを受け付ける", code: "081804", wantStep: step - 1, wantOK: true},
+		{name: "誤った認証コードは受け付けない", code: "000000", wantOK: false},
+		{name: "使用済みの間隔の認証コードは受け付けない", code: "050471", lastUsedStep: step, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf.LastUsedStep = tt.lastUsedStep
+			got, ok := tf.Verify(tt.code, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantStep, got)
+		})
+	}
+}
+
+func TestTwoFactor_Code(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	tf := &TwoFactor{Secret: totpEncoding.EncodeToString([]byte("12345678901234567890"))}
+	now := time.Unix(1234567890, 0)
+
+	code, err := tf.Code(now)
+	require.NoError(err)
+	assert.Equal("005924", code)
+
+	step, ok := tf.Verify(code, now)
+	assert.True(ok)
+	assert.Equal(totpStep(now), step)
+}
+
+func TestTwoFactor_URI(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	tf, err := NewTwoFactor("test-user-id", time.Now())
+	require.NoError(err)
+	assert.Len(tf.Secret, 32)
+	assert.False(tf.Enabled)
+
+	u, err := url.Parse(tf.URI("受講計画", "test-email@example.com"))
+	require.NoError(err)
+	assert.Equal("otpauth", u.Scheme)
+	assert.Equal("totp", u.Host)
+	assert.Equal("/受講計画:test-email@example.com", u.Path)
+	assert.Equal(tf.Secret, u.Query().Get("secret"))
+	assert.Equal("受講計画", u.Query().Get("issuer"))
+	assert.Equal("6", u.Query().Get("digits"))
+	assert.Equal("30", u.Query().Get("period"))
+}
+
+func TestTwoFactor_Activate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	tf, err := NewTwoFactor("test-user-id", time.Now())
+	require.NoError(err)
+
+	codes, err := tf.Activate(100, time.Now())
+	require.NoError(err)
+	assert.True(tf.Enabled)
+	assert.Equal(int64(100), tf.LastUsedStep)
+	require.Len(codes, RecoveryCodeCount)
+	require.Len(tf.RecoveryCodes, RecoveryCodeCount)
+
+	for n, code := range codes {
+		assert.Regexp(`^[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		assert.NotContains(tf.RecoveryCodes, code)
+		assert.Equal(HashRecoveryCode(code), tf.RecoveryCodes[n])
+	}
+
+	// 大文字と区切りの違いは区別しない
+	assert.Equal(3, tf.RecoveryCodeIndex(strings.ToUpper(strings.ReplaceAll(codes[3], "-", " "))))
+	assert.Equal(-1, tf.RecoveryCodeIndex("test-code"))
+}
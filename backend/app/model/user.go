@@ -1,16 +1,92 @@
 package model
 
 import (
+	"fmt"
+	"regexp"
 	"time"
+	"unicode/utf8"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
 )
 
 // User はユーザーの model を表す構造体です。
+// Timezone は IANA タイムゾーン名で、空の場合は既定のタイムゾーンを使用します。
+// Locale はメッセージの言語で、空の場合は Accept-Language の言語を使用します。
+// SequenceStrictness は講義回の順番が逆転する変更の扱いで、空の場合は DefaultSequenceStrictness とします。
+// TokenVersion はセッションの世代で、上げると発行済みのアクセストークンとログインセッションがすべて無効になります。
 type User struct {
-	ID        string
-	Email     string
-	Password  string
-	Name      string
-	Enabled   bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 string
+	Email              string
+	Password           string
+	Name               string
+	Timezone           string
+	Locale             string
+	SequenceStrictness SequenceStrictness
+	Enabled            bool
+	TokenVersion       int
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+const (
+	// UserEmailMaxLength はメールアドレスの長さの上限です。
+	UserEmailMaxLength = 254
+	// UserNameMaxLength は名前の文字数の上限です。
+	UserNameMaxLength = 50
+)
+
+// emailPattern はメールアドレスとして扱う形式に一致する正規表現です。
+// 送信できるかどうかは確認メールで判定するため、@ の前後が空でないことのみを確認します。
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// Validate はユーザーの不変条件を検証し、すべての違反を ValidationError で返します。
+// パスワードはハッシュ化して保持するため、Password.Validate で別に検証します。
+func (u User) Validate() error {
+	ve := &ValidationError{}
+
+	// email が空文字
+	if u.Email == "" {
+		ve.Add("email", "メールアドレスを入力してください")
+	} else if len(u.Email) > UserEmailMaxLength || !emailPattern.MatchString(u.Email) {
+		// email の形式が正しくない
+		ve.Add("email", "メールアドレスの形式が正しくありません")
+	}
+
+	ve.Merge("", u.ValidateProfile())
+
+	return ve.Err()
+}
+
+// ValidateProfile はユーザーが変更できる名前、タイムゾーン、言語、講義回の順番の確認の不変条件を検証し、すべての違反を ValidationError で返します。
+func (u User) ValidateProfile() error {
+	ve := &ValidationError{}
+
+	// name が上限の文字数より多い
+	if utf8.RuneCountInString(u.Name) > UserNameMaxLength {
+		ve.Add("name", fmt.Sprintf("名前は%d文字以内で入力してください", UserNameMaxLength))
+	}
+
+	// timezone が IANA タイムゾーン名でない
+	if u.Timezone != "" {
+		if _, err := time.LoadLocation(u.Timezone); err != nil {
+			ve.Add("timezone", "タイムゾーンは IANA タイムゾーン名（例: Asia/Tokyo）で指定してください")
+		}
+	}
+
+	// locale が対応している言語でない
+	if u.Locale != "" && !i18n.Locale(u.Locale).Valid() {
+		ve.Add("locale", fmt.Sprintf("言語は %s または %s を指定してください", i18n.Japanese, i18n.English))
+	}
+
+	// sequence_strictness が定義済みの値でない
+	if !u.Strictness().Valid() {
+		ve.Add("sequence_strictness", fmt.Sprintf("講義回の順番の確認は %s、%s、%s のいずれかを指定してください", SequenceStrictnessOff, SequenceStrictnessWarn, SequenceStrictnessReject))
+	}
+
+	return ve.Err()
+}
+
+// Strictness は講義回の順番が逆転する変更の扱いを返します。
+func (u User) Strictness() SequenceStrictness {
+	return ToSequenceStrictness(u.SequenceStrictness.String())
 }
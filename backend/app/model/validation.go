@@ -0,0 +1,104 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FieldError は項目ごとの不変条件の違反を表す構造体です。
+// Field はリクエストの JSON のキーに合わせた項目のパスで、一括処理では schedules[0].name のように要素の位置を含みます。
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError は不変条件の違反をまとめたエラーを表す構造体です。
+// 最初の違反で止めず、すべての違反を Errors に保持します。
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error は先頭の違反のメッセージを返します。
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	return e.Errors[0].Message
+}
+
+// Add は違反を追加します。
+func (e *ValidationError) Add(field, message string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: message})
+}
+
+// Set は指定された項目の違反を置き換えます。項目の違反がない場合は追加します。
+func (e *ValidationError) Set(field, message string) {
+	for i, fe := range e.Errors {
+		if fe.Field == field {
+			e.Errors[i].Message = message
+			return
+		}
+	}
+	e.Add(field, message)
+}
+
+// Has は指定された項目の違反があるかどうかを返します。
+func (e *ValidationError) Has(field string) bool {
+	for _, fe := range e.Errors {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge は err の違反を項目のパスの前に prefix を付けて追加します。
+// err が ValidationError でない場合は prefix を項目として err のメッセージを追加します。
+func (e *ValidationError) Merge(prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	ve, ok := AsValidationError(err)
+	if !ok {
+		e.Add(prefix, err.Error())
+		return
+	}
+
+	for _, fe := range ve.Errors {
+		e.Add(JoinFieldPath(prefix, fe.Field), fe.Message)
+	}
+}
+
+// Err は違反がある場合に自身を、ない場合に nil を返します。
+func (e *ValidationError) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// AsValidationError は err が ValidationError の場合に取り出します。
+func AsValidationError(err error) (*ValidationError, bool) {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return ve, true
+	}
+	return nil, false
+}
+
+// IndexedField は一括処理の要素の項目のパスを返します。
+func IndexedField(field string, index int) string {
+	return fmt.Sprintf("%s[%d]", field, index)
+}
+
+// JoinFieldPath は項目のパスを連結します。どちらかが空の場合はもう一方を返します。
+func JoinFieldPath(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	if field == "" {
+		return prefix
+	}
+	return prefix + "." + field
+}
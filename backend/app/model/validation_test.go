@@ -0,0 +1,99 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	ve := &ValidationError{}
+	assert.NoError(ve.Err())
+	assert.Equal("", ve.Error())
+
+	inner := &ValidationError{}
+	inner.Add("name", "スケジュール名を入力してください")
+	inner.Add("links[0]", "リンクは http または https の URL を入力してください")
+
+	ve.Merge(IndexedField("schedules", 1), inner)
+	ve.Merge("id", errors.New("スケジュールIDを指定してください"))
+	ve.Merge("ignored", nil)
+	ve.Set("id", "IDが不正です")
+
+	require.Error(ve.Err())
+	assert.Equal("スケジュール名を入力してください", ve.Error())
+	assert.True(ve.Has("schedules[1].links[0]"))
+	assert.False(ve.Has("ignored"))
+	assert.Equal([]FieldError{
+		{Field: "schedules[1].name", Message: "スケジュール名を入力してください"},
+		{Field: "schedules[1].links[0]", Message: "リンクは http または https の URL を入力してください"},
+		{Field: "id", Message: "IDが不正です"},
+	}, ve.Errors)
+
+	got, ok := AsValidationError(errors.Join(errors.New("wrap"), ve))
+	require.True(ok)
+	assert.Same(ve, got)
+
+	_, ok = AsValidationError(errors.New("plain"))
+	assert.False(ok)
+}
+
+func TestSubject_Validate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(Subject{Name: "統計学", Color: "custom_test-color-id"}.Validate())
+
+	ve, ok := AsValidationError(Subject{Color: "purple"}.Validate())
+	assert.True(ok)
+	assert.Equal([]FieldError{
+		{Field: "name", Message: "科目名を入力してください"},
+		{Field: "color", Message: "色はパレットの色またはユーザーが定義した色を指定してください"},
+	}, ve.Errors)
+}
+
+func TestUser_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		want []FieldError
+	}{
+		{name: "正常系", user: User{Email: "test@example.com", Name: "test name", Timezone: "Asia/Tokyo", Locale: "en"}},
+		{name: "正常系: 名前とタイムゾーンが未設定", user: User{Email: "test@example.com"}},
+		{
+			name: "異常系: すべての違反を返す",
+			user: User{Email: "test.example.com", Name: "あいうえおかきくけこあいうえおかきくけこあいうえおかきくけこあいうえおかきくけこあいうえおかきくけこあ", Timezone: "Asia/Nowhere", Locale: "fr"},
+			want: []FieldError{
+				{Field: "email", Message: "メールアドレスの形式が正しくありません"},
+				{Field: "name", Message: "名前は50文字以内で入力してください"},
+				{Field: "timezone", Message: "タイムゾーンは IANA タイムゾーン名（例: Asia/Tokyo）で指定してください"},
+				{Field: "locale", Message: "言語は ja または en を指定してください"},
+			},
+		},
+		{
+			name: "異常系: email が空",
+			user: User{},
+			want: []FieldError{{Field: "email", Message: "メールアドレスを入力してください"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := tt.user.Validate()
+			if tt.want == nil {
+				assert.NoError(err)
+				return
+			}
+
+			ve, ok := AsValidationError(err)
+			assert.True(ok)
+			assert.Equal(tt.want, ve.Errors)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package port
+
+// BaseBlackoutData は受講できない期間の基本データを表す構造体です。
+type BaseBlackoutData struct {
+	ID        string
+	UserID    string
+	Label     string
+	StartsAt  string
+	EndsAt    string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// BaseAvailabilityData は受講可能日の設定の基本データを表す構造体です。
+type BaseAvailabilityData struct {
+	UserID            string
+	Weekdays          []int
+	MaxLecturesPerDay int
+	Blackouts         []BaseBlackoutData
+}
+
+// GetAvailabilityInputData は受講可能日の設定取得の入力データを表す構造体です。
+type GetAvailabilityInputData struct {
+	UserID string
+}
+
+// GetAvailabilityOutputData は受講可能日の設定取得の出力データを表す構造体です。
+type GetAvailabilityOutputData struct {
+	Availability BaseAvailabilityData
+}
+
+// UpdateAvailabilityInputData は受講可能日の設定更新の入力データを表す構造体です。
+type UpdateAvailabilityInputData struct {
+	UserID            string
+	Weekdays          []int
+	MaxLecturesPerDay int
+}
+
+// UpdateAvailabilityOutputData は受講可能日の設定更新の出力データを表す構造体です。
+type UpdateAvailabilityOutputData struct {
+	Availability BaseAvailabilityData
+}
+
+// CreateBlackoutInputData は受講できない期間作成の入力データを表す構造体です。
+type CreateBlackoutInputData struct {
+	UserID   string
+	Label    string
+	StartsAt string
+	EndsAt   string
+}
+
+// CreateBlackoutOutputData は受講できない期間作成の出力データを表す構造体です。
+type CreateBlackoutOutputData struct {
+	Blackout BaseBlackoutData
+}
+
+// UpdateBlackoutInputData は受講できない期間更新の入力データを表す構造体です。
+type UpdateBlackoutInputData struct {
+	ID       string
+	Label    string
+	StartsAt string
+	EndsAt   string
+}
+
+// UpdateBlackoutOutputData は受講できない期間更新の出力データを表す構造体です。
+type UpdateBlackoutOutputData struct {
+	Blackout BaseBlackoutData
+}
+
+// DeleteBlackoutInputData は受講できない期間削除の入力データを表す構造体です。
+type DeleteBlackoutInputData struct {
+	BlackoutID string
+}
+
+// DeleteBlackoutOutputData は受講できない期間削除の出力データを表す構造体です。
+type DeleteBlackoutOutputData struct{}
+
+// AvailabilityInputPort は受講可能日のユースケースを表すインターフェースです。
+type AvailabilityInputPort interface {
+	GetAvailability(input GetAvailabilityInputData)
+	UpdateAvailability(input UpdateAvailabilityInputData)
+	CreateBlackout(input CreateBlackoutInputData)
+	UpdateBlackout(input UpdateBlackoutInputData)
+	DeleteBlackout(input DeleteBlackoutInputData)
+}
+
+// AvailabilityOutputPort は受講可能日のユースケースの外部出力を表すインターフェースです。
+type AvailabilityOutputPort interface {
+	GetResponse() (int, string)
+	SetResponseGetAvailability(output *GetAvailabilityOutputData, result Result)
+	SetResponseUpdateAvailability(output *UpdateAvailabilityOutputData, result Result)
+	SetResponseCreateBlackout(output *CreateBlackoutOutputData, result Result)
+	SetResponseUpdateBlackout(output *UpdateBlackoutOutputData, result Result)
+	SetResponseDeleteBlackout(output *DeleteBlackoutOutputData, result Result)
+}
@@ -0,0 +1,72 @@
+package port
+
+import "context"
+
+// EmailDeliveryAttemptData はメールの送信の試行のデータを表す構造体です。
+type EmailDeliveryAttemptData struct {
+	AttemptedAt string
+	MessageID   string
+	Error       string
+}
+
+// EmailOutboxData は送信するメールのデータを表す構造体です。
+type EmailOutboxData struct {
+	ID            string
+	To            string
+	Subject       string
+	Status        string
+	Failures      int
+	LastError     string
+	Attempts      []EmailDeliveryAttemptData
+	NextAttemptAt string
+	MessageID     string
+	CreatedAt     string
+	UpdatedAt     string
+}
+
+// DispatchEmailOutboxInputData は送信待ちのメールの送信の入力データを表す構造体です。
+type DispatchEmailOutboxInputData struct{}
+
+// DispatchEmailOutboxOutputData は送信待ちのメールの送信の出力データを表す構造体です。
+type DispatchEmailOutboxOutputData struct {
+	Sent     int
+	Retrying int
+	Failed   int
+}
+
+// GetFailedEmailOutboxListInputData は送信に失敗したメールのリスト取得の入力データを表す構造体です。
+type GetFailedEmailOutboxListInputData struct {
+	RequesterUserID string
+}
+
+// GetFailedEmailOutboxListOutputData は送信に失敗したメールのリスト取得の出力データを表す構造体です。
+type GetFailedEmailOutboxListOutputData struct {
+	Total      int
+	Deliveries []EmailOutboxData
+}
+
+// ResendEmailOutboxInputData は送信に失敗したメールの再送の入力データを表す構造体です。
+type ResendEmailOutboxInputData struct {
+	RequesterUserID string
+	EmailOutboxID   string
+}
+
+// ResendEmailOutboxOutputData は送信に失敗したメールの再送の出力データを表す構造体です。
+type ResendEmailOutboxOutputData struct {
+	Delivery EmailOutboxData
+}
+
+// EmailOutboxInputPort は送信するメールのユースケースを表すインターフェースです。
+type EmailOutboxInputPort interface {
+	DispatchEmailOutbox(ctx context.Context, inputData DispatchEmailOutboxInputData)
+	GetFailedEmailOutboxList(inputData GetFailedEmailOutboxListInputData)
+	ResendEmailOutbox(ctx context.Context, inputData ResendEmailOutboxInputData)
+}
+
+// EmailOutboxOutputPort は送信するメールのユースケースの外部出力を表すインターフェースです。
+type EmailOutboxOutputPort interface {
+	GetResponse() (int, string)
+	SetResponseDispatchEmailOutbox(outputData *DispatchEmailOutboxOutputData, result Result)
+	SetResponseGetFailedEmailOutboxList(outputData *GetFailedEmailOutboxListOutputData, result Result)
+	SetResponseResendEmailOutbox(outputData *ResendEmailOutboxOutputData, result Result)
+}
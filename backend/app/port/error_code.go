@@ -0,0 +1,108 @@
+package port
+
+// ErrorCode はクライアントがエラーの種類を判定するための安定したコードを表す型です。
+// メッセージは文言の変更や翻訳で変わるため、クライアントはコードで判定します。
+type ErrorCode string
+
+const (
+	ErrorCodeInternal                 ErrorCode = "internal.server_error"
+	ErrorCodeRequestFormatInvalid     ErrorCode = "request.format_invalid"
+	ErrorCodeValidationFailed         ErrorCode = "validation.failed"
+	ErrorCodeAuthUnauthorized         ErrorCode = "auth.unauthorized"
+	ErrorCodeAuthForbidden            ErrorCode = "auth.forbidden"
+	ErrorCodeAuthCredentialsInvalid   ErrorCode = "auth.credentials_invalid"
+	ErrorCodeAuthTokenInvalid         ErrorCode = "auth.token_invalid"
+	ErrorCodeUserEmailAlreadyExists   ErrorCode = "user.email_already_exists"
+	ErrorCodeUserEmailNotFound        ErrorCode = "user.email_not_found"
+	ErrorCodeUserEmailIsSame          ErrorCode = "user.email_is_same"
+	ErrorCodeScheduleNotFound         ErrorCode = "schedule.not_found"
+	ErrorCodeScheduleSequenceBroken   ErrorCode = "schedule.lecture_sequence_broken"
+	ErrorCodeBlackoutNotFound         ErrorCode = "blackout.not_found"
+	ErrorCodeTagNotFound              ErrorCode = "tag.not_found"
+	ErrorCodeTagNameDuplicated        ErrorCode = "tag.name_duplicated"
+	ErrorCodeColorNotFound            ErrorCode = "color.not_found"
+	ErrorCodeCustomColorLimitExceeded ErrorCode = "color.custom_limit_exceeded"
+	ErrorCodeCustomColorInUse         ErrorCode = "color.custom_in_use"
+	ErrorCodeEmailOutboxNotFound      ErrorCode = "email.outbox_not_found"
+	ErrorCodeEmailOutboxNotFailed     ErrorCode = "email.outbox_not_failed"
+	ErrorCodeSessionNotFound          ErrorCode = "session.not_found"
+	ErrorCodeAuthTooManyAttempts      ErrorCode = "auth.too_many_attempts"
+	ErrorCodeEmailCooldown            ErrorCode = "email.cooldown"
+	ErrorCodeMFAAlreadyEnabled        ErrorCode = "mfa.already_enabled"
+	ErrorCodeMFANotEnrolled           ErrorCode = "mfa.not_enrolled"
+	ErrorCodeMFACodeInvalid           ErrorCode = "mfa.code_invalid"
+	ErrorCodePasskeyChallengeInvalid  ErrorCode = "passkey.challenge_invalid"
+	ErrorCodePasskeyInvalid           ErrorCode = "passkey.invalid"
+	ErrorCodePasskeyAlreadyRegistered ErrorCode = "passkey.already_registered"
+	ErrorCodePasskeyNotFound          ErrorCode = "passkey.not_found"
+	ErrorCodePasskeyLimitExceeded     ErrorCode = "passkey.limit_exceeded"
+	ErrorCodeAuthBrowserMismatch      ErrorCode = "auth.browser_mismatch"
+	ErrorCodeOIDCProviderNotFound     ErrorCode = "oidc.provider_not_found"
+	ErrorCodeOIDCStateInvalid         ErrorCode = "oidc.state_invalid"
+	ErrorCodeOIDCLoginFailed          ErrorCode = "oidc.login_failed"
+	ErrorCodeOIDCEmailNotVerified     ErrorCode = "oidc.email_not_verified"
+	ErrorCodeOIDCDomainNotAllowed     ErrorCode = "oidc.domain_not_allowed"
+)
+
+// errorCodes はすべてのエラーコードの一覧です。コードを追加した場合はここにも追加します。
+var errorCodes = []ErrorCode{
+	ErrorCodeInternal,
+	ErrorCodeRequestFormatInvalid,
+	ErrorCodeValidationFailed,
+	ErrorCodeAuthUnauthorized,
+	ErrorCodeAuthForbidden,
+	ErrorCodeAuthCredentialsInvalid,
+	ErrorCodeAuthTokenInvalid,
+	ErrorCodeUserEmailAlreadyExists,
+	ErrorCodeUserEmailNotFound,
+	ErrorCodeUserEmailIsSame,
+	ErrorCodeScheduleNotFound,
+	ErrorCodeScheduleSequenceBroken,
+	ErrorCodeBlackoutNotFound,
+	ErrorCodeTagNotFound,
+	ErrorCodeTagNameDuplicated,
+	ErrorCodeColorNotFound,
+	ErrorCodeCustomColorLimitExceeded,
+	ErrorCodeCustomColorInUse,
+	ErrorCodeEmailOutboxNotFound,
+	ErrorCodeEmailOutboxNotFailed,
+	ErrorCodeSessionNotFound,
+	ErrorCodeAuthTooManyAttempts,
+	ErrorCodeEmailCooldown,
+	ErrorCodeMFAAlreadyEnabled,
+	ErrorCodeMFANotEnrolled,
+	ErrorCodeMFACodeInvalid,
+	ErrorCodePasskeyChallengeInvalid,
+	ErrorCodePasskeyInvalid,
+	ErrorCodePasskeyAlreadyRegistered,
+	ErrorCodePasskeyNotFound,
+	ErrorCodePasskeyLimitExceeded,
+	ErrorCodeAuthBrowserMismatch,
+	ErrorCodeOIDCProviderNotFound,
+	ErrorCodeOIDCStateInvalid,
+	ErrorCodeOIDCLoginFailed,
+	ErrorCodeOIDCEmailNotVerified,
+	ErrorCodeOIDCDomainNotAllowed,
+}
+
+// ErrorCodes はすべてのエラーコードを返します。
+func ErrorCodes() []ErrorCode {
+	codes := make([]ErrorCode, len(errorCodes))
+	copy(codes, errorCodes)
+	return codes
+}
+
+// Valid はエラーコードが一覧に含まれるかどうかを返します。
+func (c ErrorCode) Valid() bool {
+	for _, code := range errorCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// String はエラーコードを文字列で返します。
+func (c ErrorCode) String() string {
+	return string(c)
+}
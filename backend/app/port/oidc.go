@@ -0,0 +1,55 @@
+package port
+
+import "context"
+
+// OIDCProviderData は外部アカウントでのサインインに使える ID プロバイダーのデータを表す構造体です。
+type OIDCProviderData struct {
+	ID   string
+	Name string
+}
+
+// GetOIDCProviderListInputData は ID プロバイダーの一覧の取得の入力データを表す構造体です。
+type GetOIDCProviderListInputData struct{}
+
+// GetOIDCProviderListOutputData は ID プロバイダーの一覧の取得の出力データを表す構造体です。
+type GetOIDCProviderListOutputData struct {
+	Providers []OIDCProviderData
+}
+
+// BeginOIDCSignInInputData は外部アカウントでのサインインの開始の入力データを表す構造体です。
+type BeginOIDCSignInInputData struct {
+	ProviderID string
+}
+
+// BeginOIDCSignInOutputData は外部アカウントでのサインインの開始の出力データを表す構造体です。
+// AuthorizationURL はブラウザを遷移させる ID プロバイダーの認可のリクエストの URL です。
+// State はコールバックで同じブラウザから戻ってきたことを確かめるため、ブラウザに保存させる値です。
+type BeginOIDCSignInOutputData struct {
+	AuthorizationURL string
+	State            string
+}
+
+// FinishOIDCSignInInputData は外部アカウントでのサインインの完了の入力データを表す構造体です。
+// State と Code は ID プロバイダーからコールバックに渡された値です。
+// UserAgent と IPAddress はログインセッションの端末の情報として記録します。
+type FinishOIDCSignInInputData struct {
+	State     string
+	Code      string
+	UserAgent string
+	IPAddress string
+}
+
+// OIDCInputPort は外部アカウントでのサインインのユースケースを表すインターフェースです。
+type OIDCInputPort interface {
+	GetOIDCProviderList(input GetOIDCProviderListInputData)
+	BeginOIDCSignIn(ctx context.Context, input BeginOIDCSignInInputData)
+	FinishOIDCSignIn(ctx context.Context, input FinishOIDCSignInInputData)
+}
+
+// OIDCOutputPort は外部アカウントでのサインインのユースケースの外部出力を表すインターフェースです。
+type OIDCOutputPort interface {
+	GetResponse() (statusCode int, body string)
+	SetResponseGetOIDCProviderList(output *GetOIDCProviderListOutputData, result Result)
+	SetResponseBeginOIDCSignIn(output *BeginOIDCSignInOutputData, result Result)
+	SetResponseFinishOIDCSignIn(output *SignInOutputData, result Result)
+}
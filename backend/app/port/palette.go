@@ -0,0 +1,70 @@
+package port
+
+// BasePaletteColorData はパレットの色の基本データを表す構造体です。
+// Custom はユーザーが定義した色の場合に true となります。
+type BasePaletteColorData struct {
+	Key    string
+	Name   string
+	Hex    string
+	Style  string
+	Custom bool
+}
+
+// BaseCustomColorData はユーザー定義の色の基本データを表す構造体です。
+type BaseCustomColorData struct {
+	ID        string
+	UserID    string
+	Key       string
+	Name      string
+	Hex       string
+	Style     string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// GetPaletteInputData はパレット取得の入力データを表す構造体です。
+type GetPaletteInputData struct {
+	UserID string
+}
+
+// GetPaletteOutputData はパレット取得の出力データを表す構造体です。
+type GetPaletteOutputData struct {
+	Colors []BasePaletteColorData
+}
+
+// CreateCustomColorInputData はユーザー定義の色の作成の入力データを表す構造体です。
+type CreateCustomColorInputData struct {
+	UserID string
+	Name   string
+	Hex    string
+	Style  string
+}
+
+// CreateCustomColorOutputData はユーザー定義の色の作成の出力データを表す構造体です。
+type CreateCustomColorOutputData struct {
+	CustomColor BaseCustomColorData
+}
+
+// DeleteCustomColorInputData はユーザー定義の色の削除の入力データを表す構造体です。
+type DeleteCustomColorInputData struct {
+	UserID  string
+	ColorID string
+}
+
+// DeleteCustomColorOutputData はユーザー定義の色の削除の出力データを表す構造体です。
+type DeleteCustomColorOutputData struct{}
+
+// PaletteInputPort はパレットのユースケースを表すインターフェースです。
+type PaletteInputPort interface {
+	GetPalette(input GetPaletteInputData)
+	CreateCustomColor(input CreateCustomColorInputData)
+	DeleteCustomColor(input DeleteCustomColorInputData)
+}
+
+// PaletteOutputPort はパレットのユースケースの外部出力を表すインターフェースです。
+type PaletteOutputPort interface {
+	GetResponse() (int, string)
+	SetResponseGetPalette(output *GetPaletteOutputData, result Result)
+	SetResponseCreateCustomColor(output *CreateCustomColorOutputData, result Result)
+	SetResponseDeleteCustomColor(output *DeleteCustomColorOutputData, result Result)
+}
@@ -0,0 +1,116 @@
+package port
+
+// PasskeyData はパスキーのデータを表す構造体です。
+// BackedUp はパスキーが端末の間で同期されているかどうかです。LastUsedAt は一度も使っていない場合は空です。
+type PasskeyData struct {
+	ID         string
+	Name       string
+	BackedUp   bool
+	CreatedAt  string
+	LastUsedAt string
+}
+
+// BeginPasskeyRegistrationInputData はパスキーの登録の開始の入力データを表す構造体です。
+type BeginPasskeyRegistrationInputData struct {
+	UserID string
+}
+
+// BeginPasskeyRegistrationOutputData はパスキーの登録の開始の出力データを表す構造体です。
+// ブラウザの navigator.credentials.create に渡すオプションで、バイト列はすべて Base64URL です。
+// ExcludeCredentialIDs は同じ認証器で重ねて登録しないよう、登録済みのパスキーの ID を並べたものです。
+type BeginPasskeyRegistrationOutputData struct {
+	Challenge            string
+	RPID                 string
+	RPName               string
+	UserHandle           string
+	UserName             string
+	UserDisplayName      string
+	Algorithms           []int
+	ExcludeCredentialIDs []string
+	TimeoutMilliseconds  int64
+}
+
+// FinishPasskeyRegistrationInputData はパスキーの登録の完了の入力データを表す構造体です。
+// ClientDataJSON と AttestationObject はブラウザの応答の Base64URL です。
+type FinishPasskeyRegistrationInputData struct {
+	UserID            string
+	Name              string
+	ClientDataJSON    string
+	AttestationObject string
+}
+
+// FinishPasskeyRegistrationOutputData はパスキーの登録の完了の出力データを表す構造体です。
+type FinishPasskeyRegistrationOutputData struct {
+	PasskeyData
+}
+
+// GetPasskeyListInputData はパスキーの一覧の取得の入力データを表す構造体です。
+type GetPasskeyListInputData struct {
+	UserID string
+}
+
+// GetPasskeyListOutputData はパスキーの一覧の取得の出力データを表す構造体です。
+type GetPasskeyListOutputData struct {
+	Passkeys []PasskeyData
+}
+
+// DeletePasskeyInputData はパスキーの削除の入力データを表す構造体です。
+type DeletePasskeyInputData struct {
+	UserID    string
+	PasskeyID string
+}
+
+// DeletePasskeyOutputData はパスキーの削除の出力データを表す構造体です。
+type DeletePasskeyOutputData struct{}
+
+// BeginPasskeySignInInputData はパスキーのサインインの開始の入力データを表す構造体です。
+type BeginPasskeySignInInputData struct{}
+
+// BeginPasskeySignInOutputData はパスキーのサインインの開始の出力データを表す構造体です。
+// ブラウザの navigator.credentials.get に渡すオプションです。
+// メールアドレスを入力せずにサインインできるよう、使えるパスキーは指定せずに認証器に選ばせます。
+type BeginPasskeySignInOutputData struct {
+	Challenge           string
+	RPID                string
+	TimeoutMilliseconds int64
+}
+
+// FinishPasskeySignInInputData はパスキーのサインインの完了の入力データを表す構造体です。
+// CredentialID、ClientDataJSON、AuthenticatorData、Signature、UserHandle はブラウザの応答の Base64URL です。
+// UserAgent と IPAddress はログインセッションの端末の情報として記録します。
+type FinishPasskeySignInInputData struct {
+	CredentialID      string
+	ClientDataJSON    string
+	AuthenticatorData string
+	Signature         string
+	UserHandle        string
+	UserAgent         string
+	IPAddress         string
+}
+
+// FinishPasskeySignInOutputData はパスキーのサインインの完了の出力データを表す構造体です。
+type FinishPasskeySignInOutputData struct {
+	BaseUserData
+	SessionTokenData
+}
+
+// PasskeyInputPort はパスキーのユースケースを表すインターフェースです。
+type PasskeyInputPort interface {
+	BeginPasskeyRegistration(input BeginPasskeyRegistrationInputData)
+	FinishPasskeyRegistration(input FinishPasskeyRegistrationInputData)
+	GetPasskeyList(input GetPasskeyListInputData)
+	DeletePasskey(input DeletePasskeyInputData)
+	BeginPasskeySignIn(input BeginPasskeySignInInputData)
+	FinishPasskeySignIn(input FinishPasskeySignInInputData)
+}
+
+// PasskeyOutputPort はパスキーのユースケースの外部出力を表すインターフェースです。
+type PasskeyOutputPort interface {
+	GetResponse() (statusCode int, body string)
+	SetResponseBeginPasskeyRegistration(output *BeginPasskeyRegistrationOutputData, result Result)
+	SetResponseFinishPasskeyRegistration(output *FinishPasskeyRegistrationOutputData, result Result)
+	SetResponseGetPasskeyList(output *GetPasskeyListOutputData, result Result)
+	SetResponseDeletePasskey(output *DeletePasskeyOutputData, result Result)
+	SetResponseBeginPasskeySignIn(output *BeginPasskeySignInOutputData, result Result)
+	SetResponseFinishPasskeySignIn(output *FinishPasskeySignInOutputData, result Result)
+}
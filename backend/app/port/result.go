@@ -1,10 +1,23 @@
 package port
 
+import "time"
+
 // Result は処理結果を表す構造体です。
+// ErrorCode にはクライアントが判定に使うエラーコードを、Errors には不変条件の違反を項目ごとに格納します。
+// RetryAfter は回数の制限でエラーになった場合に、再試行できるまでの時間です。
 type Result struct {
 	StatusCode   int
 	HasError     bool
+	ErrorCode    ErrorCode
 	ErrorMessage string
+	Errors       []FieldError
+	RetryAfter   time.Duration
+}
+
+// FieldError は項目ごとのエラーを表す構造体です。
+type FieldError struct {
+	Field   string
+	Message string
 }
 
 // NewSuccessResult は成功時の Result を生成します。
@@ -13,10 +26,32 @@ func NewSuccessResult(statusCode int) Result {
 }
 
 // NewErrorResult はエラー時の Result を生成します。
-func NewErrorResult(statusCode int, errorMessage string) Result {
+func NewErrorResult(statusCode int, errorCode ErrorCode, errorMessage string) Result {
 	return Result{
 		StatusCode:   statusCode,
 		HasError:     true,
+		ErrorCode:    errorCode,
 		ErrorMessage: errorMessage,
 	}
 }
+
+// WithRetryAfter は再試行できるまでの時間を設定した Result を返します。
+func (r Result) WithRetryAfter(d time.Duration) Result {
+	r.RetryAfter = d
+	return r
+}
+
+// NewValidationErrorResult は不変条件の違反があった場合の Result を生成します。
+// ErrorMessage には先頭の違反のメッセージを格納します。
+func NewValidationErrorResult(statusCode int, errors []FieldError) Result {
+	r := Result{
+		StatusCode: statusCode,
+		HasError:   true,
+		ErrorCode:  ErrorCodeValidationFailed,
+		Errors:     errors,
+	}
+	if len(errors) > 0 {
+		r.ErrorMessage = errors[0].Message
+	}
+	return r
+}
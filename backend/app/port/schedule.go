@@ -2,16 +2,30 @@ package port
 
 // BaseScheduleData はスケジュールの基本データを表す構造体です。
 type BaseScheduleData struct {
-	ID        string
-	UserID    string
-	Name      string
-	StartsAt  string
-	EndsAt    string
-	Color     string
-	Type      string
-	Order     int
-	CreatedAt string
-	UpdatedAt string
+	ID            string
+	UserID        string
+	Name          string
+	StartsAt      string
+	EndsAt        string
+	Timed         bool
+	Color         string
+	Type          string
+	Order         int
+	LectureNumber int
+	Note          string
+	Links         []string
+	Memo          string
+	TagIDs        []string
+	CreatedAt     string
+	UpdatedAt     string
+}
+
+// BaseWarningData はスケジュールの警告の基本データを表す構造体です。
+type BaseWarningData struct {
+	Code        string
+	Message     string
+	Date        string
+	ScheduleIDs []string
 }
 
 type BaseDateItemData struct {
@@ -21,14 +35,29 @@ type BaseDateItemData struct {
 }
 
 // GetScheduleListInputData はスケジュールリスト取得の入力データを表す構造体です。
+// 空の絞り込み条件は指定なしとして扱います。UpdatedSince は日時、From と To は日付の文字列です。
+// Flat が true の場合は日付ごとにまとめずに返し、Fields を指定した場合は指定された項目のみを返します。
 type GetScheduleListInputData struct {
-	UserID string
+	UserID       string
+	Type         string
+	Color        string
+	TagID        string
+	NamePrefix   string
+	UpdatedSince string
+	From         string
+	To           string
+	Flat         bool
+	Fields       []string
 }
 
 // GetScheduleListOutputData はスケジュールリスト取得の出力データを表す構造体です。
+// Flat が true の場合は Schedules に、false の場合は MasterSchedules と CustomSchedules に結果を格納します。
 type GetScheduleListOutputData struct {
 	MasterSchedules []BaseDateItemData
 	CustomSchedules []BaseDateItemData
+	Schedules       []BaseScheduleData
+	Flat            bool
+	Fields          []string
 }
 
 // GetScheduleInputData はスケジュール取得の入力データを表す構造体です。
@@ -42,14 +71,21 @@ type GetScheduleOutputData struct {
 }
 
 // CreateScheduleData はスケジュール作成のスケジュールデータを表す構造体です。
+// Timed が false の場合は終日のスケジュールとして作成します。
 type CreateScheduleData struct {
-	UserID   string
-	Name     string
-	StartsAt string
-	EndsAt   string
-	Color    string
-	Type     string
-	Order    int
+	UserID        string
+	Name          string
+	StartsAt      string
+	EndsAt        string
+	Timed         bool
+	Color         string
+	Type          string
+	Order         int
+	LectureNumber int
+	Note          string
+	Links         []string
+	Memo          string
+	TagIDs        []string
 }
 
 // CreateScheduleData はスケジュール作成のデータを表す構造体です。
@@ -60,6 +96,7 @@ type CreateScheduleInputData struct {
 // CreateScheduleOutputData はスケジュール作成の出力データを表す構造体です。
 type CreateScheduleOutputData struct {
 	Schedule BaseScheduleData
+	Warnings []BaseWarningData
 }
 
 // CreateBulkScheduleInputData はスケジュール一括作成の入力データを表す構造体です。
@@ -70,17 +107,25 @@ type CreateBulkScheduleInputData struct {
 // CreateBulkScheduleOutputData はスケジュール一括作成の出力データを表す構造体です。
 type CreateBulkScheduleOutputData struct {
 	Schedules []BaseScheduleData
+	Warnings  []BaseWarningData
 }
 
 // UpdateScheduleData はスケジュール更新のスケジュールデータを表す構造体です。
+// Timed、LectureNumber、Note、Links、Memo、TagIDs が nil の場合は既存の値を変更しません。
 type UpdateScheduleData struct {
-	ID       string
-	Name     string
-	StartsAt string
-	EndsAt   string
-	Color    string
-	Type     string
-	Order    int
+	ID            string
+	Name          string
+	StartsAt      string
+	EndsAt        string
+	Timed         *bool
+	Color         string
+	Type          string
+	Order         int
+	LectureNumber *int
+	Note          *string
+	Links         []string
+	Memo          *string
+	TagIDs        []string
 }
 
 // UpdateScheduleInputData はスケジュール更新の入力データを表す構造体です。
@@ -91,6 +136,7 @@ type UpdateScheduleInputData struct {
 // UpdateScheduleOutputData はスケジュール更新の出力データを表す構造体です。
 type UpdateScheduleOutputData struct {
 	Schedule BaseScheduleData
+	Warnings []BaseWarningData
 }
 
 // UpdateBulkScheduleInputData はスケジュール一括更新の入力データを表す構造体です。
@@ -101,6 +147,7 @@ type UpdateBulkScheduleInputData struct {
 // UpdateBulkScheduleOutputData はスケジュール一括更新の出力データを表す構造体です。
 type UpdateBulkScheduleOutputData struct {
 	Schedules []BaseScheduleData
+	Warnings  []BaseWarningData
 }
 
 // DeleteScheduleInputData はスケジュール削除の入力データを表す構造体です。
@@ -113,6 +160,27 @@ type DeleteScheduleOutputData struct {
 	ScheduleID string
 }
 
+// LintScheduleInputData はスケジュールの警告一覧取得の入力データを表す構造体です。
+type LintScheduleInputData struct {
+	UserID string
+}
+
+// LintScheduleOutputData はスケジュールの警告一覧取得の出力データを表す構造体です。
+type LintScheduleOutputData struct {
+	Warnings []BaseWarningData
+}
+
+// ExportScheduleInputData はスケジュールのエクスポートの入力データを表す構造体です。
+type ExportScheduleInputData struct {
+	UserID string
+}
+
+// ExportScheduleOutputData はスケジュールのエクスポートの出力データを表す構造体です。
+// Calendar は iCalendar 形式の文字列です。
+type ExportScheduleOutputData struct {
+	Calendar string
+}
+
 // ScheduleInputPort はスケジュールのユースケースを表すインターフェースです。
 type ScheduleInputPort interface {
 	GetScheduleList(input GetScheduleListInputData)
@@ -122,6 +190,8 @@ type ScheduleInputPort interface {
 	UpdateSchedule(input UpdateScheduleInputData)
 	UpdateBulkSchedule(input UpdateBulkScheduleInputData)
 	DeleteSchedule(input DeleteScheduleInputData)
+	LintSchedule(input LintScheduleInputData)
+	ExportSchedule(input ExportScheduleInputData)
 }
 
 // ScheduleOutputPort はスケジュールのユースケースの外部出力を表すインターフェースです。
@@ -134,4 +204,6 @@ type ScheduleOutputPort interface {
 	SetResponseUpdateSchedule(output *UpdateScheduleOutputData, result Result)
 	SetResponseUpdateBulkSchedule(output *UpdateBulkScheduleOutputData, result Result)
 	SetResponseDeleteSchedule(output *DeleteScheduleOutputData, result Result)
+	SetResponseLintSchedule(output *LintScheduleOutputData, result Result)
+	SetResponseExportSchedule(output *ExportScheduleOutputData, result Result)
 }
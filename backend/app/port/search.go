@@ -0,0 +1,32 @@
+package port
+
+// SearchInputData は検索の入力データを表す構造体です。
+// From と To は日付（YYYY-MM-DD）で、空の場合は期間で絞り込みません。
+// Completed が nil の場合は完了状態で絞り込みません。
+type SearchInputData struct {
+	UserID    string
+	Query     string
+	Type      string
+	Color     string
+	TagID     string
+	From      string
+	To        string
+	Completed *bool
+}
+
+// SearchOutputData は検索の出力データを表す構造体です。
+type SearchOutputData struct {
+	Schedules []BaseDateItemData
+	Subjects  []BaseSubjectData
+}
+
+// SearchInputPort は検索のユースケースを表すインターフェースです。
+type SearchInputPort interface {
+	Search(input SearchInputData)
+}
+
+// SearchOutputPort は検索のユースケースの外部出力を表すインターフェースです。
+type SearchOutputPort interface {
+	GetResponse() (int, string)
+	SetResponseSearch(output *SearchOutputData, result Result)
+}
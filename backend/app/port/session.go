@@ -0,0 +1,110 @@
+package port
+
+// SessionTokenData はセッションのトークンを表す構造体です。
+// SessionToken は API の認証に使う有効期間の短いアクセストークンで、RefreshToken はその再発行に使うトークンです。
+// ExpiresIn はアクセストークンの有効期間の秒数です。
+type SessionTokenData struct {
+	SessionToken string
+	RefreshToken string
+	ExpiresIn    int
+}
+
+// LoginSessionData はログインセッションのデータを表す構造体です。
+// Device は端末を表す User-Agent で、LastSeenAt は最後にアクセストークンを再発行した日時です。
+type LoginSessionData struct {
+	ID         string
+	Device     string
+	IPAddress  string
+	CreatedAt  string
+	LastSeenAt string
+}
+
+// RefreshSessionInputData はアクセストークンの再発行の入力データを表す構造体です。
+// UserAgent と IPAddress はログインセッションの端末の情報として記録します。
+type RefreshSessionInputData struct {
+	RefreshToken string
+	UserAgent    string
+	IPAddress    string
+}
+
+// RefreshSessionOutputData はアクセストークンの再発行の出力データを表す構造体です。
+type RefreshSessionOutputData struct {
+	SessionTokenData
+}
+
+// SignOutInputData はサインアウトの入力データを表す構造体です。
+type SignOutInputData struct {
+	RefreshToken string
+}
+
+// SignOutOutputData はサインアウトの出力データを表す構造体です。
+type SignOutOutputData struct{}
+
+// GetLoginSessionListInputData はログインセッションのリスト取得の入力データを表す構造体です。
+type GetLoginSessionListInputData struct {
+	UserID string
+}
+
+// GetLoginSessionListOutputData はログインセッションのリスト取得の出力データを表す構造体です。
+type GetLoginSessionListOutputData struct {
+	Sessions []LoginSessionData
+}
+
+// RevokeLoginSessionInputData はログインセッションの無効化の入力データを表す構造体です。
+type RevokeLoginSessionInputData struct {
+	UserID    string
+	SessionID string
+}
+
+// RevokeLoginSessionOutputData はログインセッションの無効化の出力データを表す構造体です。
+type RevokeLoginSessionOutputData struct{}
+
+// RevokeAllLoginSessionsInputData はすべてのログインセッションの無効化の入力データを表す構造体です。
+type RevokeAllLoginSessionsInputData struct {
+	UserID string
+}
+
+// RevokeAllLoginSessionsOutputData はすべてのログインセッションの無効化の出力データを表す構造体です。
+type RevokeAllLoginSessionsOutputData struct{}
+
+// JSONWebKeyData は JWKS で公開する公開鍵のデータを表す構造体です。
+// N と E は RSA の鍵、Curve と X は Ed25519 の鍵のみが持ちます。
+type JSONWebKeyData struct {
+	KeyType   string
+	Curve     string
+	Algorithm string
+	Use       string
+	KeyID     string
+	N         string
+	E         string
+	X         string
+}
+
+// GetJWKSInputData は JWKS の取得の入力データを表す構造体です。
+type GetJWKSInputData struct{}
+
+// GetJWKSOutputData は JWKS の取得の出力データを表す構造体です。
+type GetJWKSOutputData struct {
+	Keys []JSONWebKeyData
+}
+
+// SessionInputPort はセッションのユースケースを表すインターフェースです。
+type SessionInputPort interface {
+	RefreshSession(input RefreshSessionInputData)
+	SignOut(input SignOutInputData)
+	GetLoginSessionList(input GetLoginSessionListInputData)
+	RevokeLoginSession(input RevokeLoginSessionInputData)
+	RevokeAllLoginSessions(input RevokeAllLoginSessionsInputData)
+	GetJWKS(input GetJWKSInputData)
+}
+
+// SessionOutputPort はセッションのユースケースの外部出力を表すインターフェースです。
+type SessionOutputPort interface {
+	GetResponse() (statusCode int, body string)
+	SetResponseRefreshSession(output *RefreshSessionOutputData, result Result)
+	SetResponseSignOut(output *SignOutOutputData, result Result)
+	SetResponseGetLoginSessionList(output *GetLoginSessionListOutputData, result Result)
+	SetResponseRevokeLoginSession(output *RevokeLoginSessionOutputData, result Result)
+	SetResponseRevokeAllLoginSessions(output *RevokeAllLoginSessionsOutputData, result Result)
+	SetResponseGetJWKS(output *GetJWKSOutputData, result Result)
+}
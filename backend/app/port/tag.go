@@ -0,0 +1,101 @@
+package port
+
+// BaseTagData はタグの基本データを表す構造体です。
+type BaseTagData struct {
+	ID        string
+	UserID    string
+	Name      string
+	Color     string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// GetTagListInputData はタグリスト取得の入力データを表す構造体です。
+type GetTagListInputData struct {
+	UserID string
+}
+
+// GetTagListOutputData はタグリスト取得の出力データを表す構造体です。
+type GetTagListOutputData struct {
+	Tags []BaseTagData
+}
+
+// CreateTagInputData はタグ作成の入力データを表す構造体です。
+type CreateTagInputData struct {
+	UserID string
+	Name   string
+	Color  string
+}
+
+// CreateTagOutputData はタグ作成の出力データを表す構造体です。
+type CreateTagOutputData struct {
+	Tag BaseTagData
+}
+
+// UpdateTagInputData はタグ更新の入力データを表す構造体です。
+type UpdateTagInputData struct {
+	ID    string
+	Name  string
+	Color string
+}
+
+// UpdateTagOutputData はタグ更新の出力データを表す構造体です。
+type UpdateTagOutputData struct {
+	Tag BaseTagData
+}
+
+// DeleteTagInputData はタグ削除の入力データを表す構造体です。
+type DeleteTagInputData struct {
+	TagID string
+}
+
+// DeleteTagOutputData はタグ削除の出力データを表す構造体です。
+type DeleteTagOutputData struct{}
+
+// MergeTagInputData はタグ統合の入力データを表す構造体です。
+// SourceID のタグを TargetID のタグに統合し、SourceID のタグを削除します。
+type MergeTagInputData struct {
+	SourceID string
+	TargetID string
+}
+
+// MergeTagOutputData はタグ統合の出力データを表す構造体です。
+type MergeTagOutputData struct {
+	Tag         BaseTagData
+	ScheduleIDs []string
+}
+
+// AssignTagInputData はタグを付けるスケジュールの一括変更の入力データを表す構造体です。
+type AssignTagInputData struct {
+	TagID  string
+	Add    []string
+	Remove []string
+}
+
+// AssignTagOutputData はタグを付けるスケジュールの一括変更の出力データを表す構造体です。
+// ScheduleIDs には変更後にタグが付いているスケジュールの ID を格納します。
+type AssignTagOutputData struct {
+	TagID       string
+	ScheduleIDs []string
+}
+
+// TagInputPort はタグのユースケースを表すインターフェースです。
+type TagInputPort interface {
+	GetTagList(input GetTagListInputData)
+	CreateTag(input CreateTagInputData)
+	UpdateTag(input UpdateTagInputData)
+	DeleteTag(input DeleteTagInputData)
+	MergeTag(input MergeTagInputData)
+	AssignTag(input AssignTagInputData)
+}
+
+// TagOutputPort はタグのユースケースの外部出力を表すインターフェースです。
+type TagOutputPort interface {
+	GetResponse() (int, string)
+	SetResponseGetTagList(output *GetTagListOutputData, result Result)
+	SetResponseCreateTag(output *CreateTagOutputData, result Result)
+	SetResponseUpdateTag(output *UpdateTagOutputData, result Result)
+	SetResponseDeleteTag(output *DeleteTagOutputData, result Result)
+	SetResponseMergeTag(output *MergeTagOutputData, result Result)
+	SetResponseAssignTag(output *AssignTagOutputData, result Result)
+}
@@ -0,0 +1,83 @@
+package port
+
+// GetTwoFactorInputData は二段階認証の設定の取得の入力データを表す構造体です。
+type GetTwoFactorInputData struct {
+	UserID string
+}
+
+// GetTwoFactorOutputData は二段階認証の設定の取得の出力データを表す構造体です。
+// RecoveryCodesRemaining は未使用のリカバリーコードの数です。
+type GetTwoFactorOutputData struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+// EnrollTwoFactorInputData は二段階認証の設定の開始の入力データを表す構造体です。
+type EnrollTwoFactorInputData struct {
+	UserID string
+}
+
+// EnrollTwoFactorOutputData は二段階認証の設定の開始の出力データを表す構造体です。
+// URI は認証アプリに登録するための otpauth URI で、Secret は手動で登録するための Base32 の秘密鍵です。
+type EnrollTwoFactorOutputData struct {
+	Secret string
+	URI    string
+}
+
+// ActivateTwoFactorInputData は二段階認証の有効化の入力データを表す構造体です。
+type ActivateTwoFactorInputData struct {
+	UserID string
+	Code   string
+}
+
+// ActivateTwoFactorOutputData は二段階認証の有効化の出力データを表す構造体です。
+// RecoveryCodes はこのときにのみ返すリカバリーコードです。
+type ActivateTwoFactorOutputData struct {
+	RecoveryCodes []string
+}
+
+// ResetTwoFactorInputData は二段階認証のリセットの入力データを表す構造体です。
+// RequesterUserID はリセットする管理者のユーザー ID で、UserID は二段階認証をリセットするユーザーの ID です。
+type ResetTwoFactorInputData struct {
+	RequesterUserID string
+	UserID          string
+}
+
+// ResetTwoFactorOutputData は二段階認証のリセットの出力データを表す構造体です。
+type ResetTwoFactorOutputData struct{}
+
+// VerifyTwoFactorInputData は二段階認証のサインインの入力データを表す構造体です。
+// Code と RecoveryCode のいずれかを指定します。
+// UserAgent と IPAddress はログインセッションの端末の情報として記録します。
+type VerifyTwoFactorInputData struct {
+	TwoFactorToken string
+	Code           string
+	RecoveryCode   string
+	UserAgent      string
+	IPAddress      string
+}
+
+// VerifyTwoFactorOutputData は二段階認証のサインインの出力データを表す構造体です。
+type VerifyTwoFactorOutputData struct {
+	BaseUserData
+	SessionTokenData
+}
+
+// TwoFactorInputPort は二段階認証のユースケースを表すインターフェースです。
+type TwoFactorInputPort interface {
+	GetTwoFactor(input GetTwoFactorInputData)
+	EnrollTwoFactor(input EnrollTwoFactorInputData)
+	ActivateTwoFactor(input ActivateTwoFactorInputData)
+	ResetTwoFactor(input ResetTwoFactorInputData)
+	VerifyTwoFactor(input VerifyTwoFactorInputData)
+}
+
+// TwoFactorOutputPort は二段階認証のユースケースの外部出力を表すインターフェースです。
+type TwoFactorOutputPort interface {
+	GetResponse() (statusCode int, body string)
+	SetResponseGetTwoFactor(output *GetTwoFactorOutputData, result Result)
+	SetResponseEnrollTwoFactor(output *EnrollTwoFactorOutputData, result Result)
+	SetResponseActivateTwoFactor(output *ActivateTwoFactorOutputData, result Result)
+	SetResponseResetTwoFactor(output *ResetTwoFactorOutputData, result Result)
+	SetResponseVerifyTwoFactor(output *VerifyTwoFactorOutputData, result Result)
+}
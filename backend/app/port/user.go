@@ -4,36 +4,50 @@ import "context"
 
 // BaseUserData はユーザーの基本データを表す構造体です。
 type BaseUserData struct {
-	ID        string
-	Email     string
-	Name      string
-	CreatedAt string
-	UpdatedAt string
+	ID                 string
+	Email              string
+	Name               string
+	Timezone           string
+	Locale             string
+	SequenceStrictness string
+	CreatedAt          string
+	UpdatedAt          string
 }
 
 // SignInInputData はサインインの入力データを表す構造体です。
+// UserAgent と IPAddress はログインセッションの端末の情報として記録し、IPAddress は失敗の回数の制限にも使います。
+// AcceptLanguage はロックを解除するメールの言語を決めるために使います。
 type SignInInputData struct {
-	Email    string
-	Password string
+	Email          string
+	Password       string
+	UserAgent      string
+	IPAddress      string
+	AcceptLanguage string
 }
 
 // SignInOutputData はサインインの出力データを表す構造体です。
+// 二段階認証が有効なユーザーの場合はセッションを発行せず、TwoFactorToken のみを返します。
 type SignInOutputData struct {
 	BaseUserData
-	SessionToken string
+	SessionTokenData
+	TwoFactorToken string
 }
 
 // SignUpInputData はサインアップの入力データを表す構造体です。
+// AcceptLanguage はメールの言語を決めるためのリクエストの Accept-Language ヘッダーの値です。
 type SignUpInputData struct {
-	Email string
+	Email          string
+	AcceptLanguage string
 }
 
 // SignUpOutputData はサインアップの出力データを表す構造体です。
 type SignUpOutputData struct{}
 
 // PasswordResetInputData はパスワードリセットの入力データを表す構造体です。
+// AcceptLanguage はユーザーが言語を設定していない場合にメールの言語を決めるために使います。
 type PasswordResetInputData struct {
-	Email string
+	Email          string
+	AcceptLanguage string
 }
 
 // PasswordResetOutputData はパスワードリセットの出力データを表す構造体です。
@@ -59,9 +73,13 @@ type GetUserOutputData struct {
 }
 
 // UpdateUserInputData はユーザー情報更新の入力データを表す構造体です。
+// Timezone、Locale、SequenceStrictness が空の場合は現在の設定を維持します。
 type UpdateUserInputData struct {
-	UserID string
-	Name   string
+	UserID             string
+	Name               string
+	Timezone           string
+	Locale             string
+	SequenceStrictness string
 }
 
 // UpdateUserOutputData はユーザー情報更新の出力データを表す構造体です。
@@ -78,9 +96,11 @@ type DeleteUserInputData struct {
 type DeleteUserOutputData struct{}
 
 // ResetEmailInputData はメールアドレスリセットの入力データを表す構造体です。
+// AcceptLanguage はユーザーが言語を設定していない場合にメールの言語を決めるために使います。
 type ResetEmailInputData struct {
-	UserID string
-	Email  string
+	UserID         string
+	Email          string
+	AcceptLanguage string
 }
 
 // ResetEmailOutputData はメールアドレスリセットの出力データを表す構造体です。
@@ -88,16 +108,48 @@ type ResetEmailOutputData struct{}
 
 // SetEmailInputData はメールアドレス設定の入力データを表す構造体です。
 type SetEmailInputData struct {
-	UserIDToken string
-	EmailToken  string
+	UserID string
+	Token  string
 }
 
 // SetEmailOutputData はメールアドレス設定の出力データを表す構造体です。
 type SetEmailOutputData struct{}
 
+// UnlockAccountInputData はロックの解除の入力データを表す構造体です。
+type UnlockAccountInputData struct {
+	Token string
+}
+
+// UnlockAccountOutputData はロックの解除の出力データを表す構造体です。
+type UnlockAccountOutputData struct{}
+
+// RequestMagicLinkInputData はサインインのリンクのリクエストの入力データを表す構造体です。
+// AcceptLanguage はユーザーが言語を設定していない場合にメールの言語を決めるために使います。
+type RequestMagicLinkInputData struct {
+	Email          string
+	AcceptLanguage string
+}
+
+// RequestMagicLinkOutputData はサインインのリンクのリクエストの出力データを表す構造体です。
+// Nonce はリンクを開くときに送り返す、リクエストしたブラウザを確認するための値です。
+// メールアドレスが登録されているかどうかを判別できないよう、登録されていない場合も返します。
+type RequestMagicLinkOutputData struct {
+	Nonce string
+}
+
+// SignInMagicLinkInputData はサインインのリンクによるサインインの入力データを表す構造体です。
+// Nonce はリンクをリクエストしたときに受け取った値です。
+// UserAgent と IPAddress はログインセッションの端末の情報として記録します。
+type SignInMagicLinkInputData struct {
+	Token     string
+	Nonce     string
+	UserAgent string
+	IPAddress string
+}
+
 // UserInputPort はユーザーのユースケースを表すインターフェースです。
 type UserInputPort interface {
-	SignIn(input SignInInputData)
+	SignIn(ctx context.Context, input SignInInputData)
 	SignUp(ctx context.Context, input SignUpInputData)
 	PasswordReset(ctx context.Context, input PasswordResetInputData)
 	PasswordSet(input PasswordSetInputData)
@@ -106,6 +158,9 @@ type UserInputPort interface {
 	DeleteUser(input DeleteUserInputData)
 	ResetEmail(input ResetEmailInputData)
 	SetEmail(input SetEmailInputData)
+	UnlockAccount(input UnlockAccountInputData)
+	RequestMagicLink(ctx context.Context, input RequestMagicLinkInputData)
+	SignInMagicLink(input SignInMagicLinkInputData)
 }
 
 // UserOutputPort はユーザーのユースケースの外部出力を表すインターフェースです。
@@ -120,4 +175,7 @@ type UserOutputPort interface {
 	SetResponseDeleteUser(output *DeleteUserOutputData, result Result)
 	SetResponseResetEmail(output *ResetEmailOutputData, result Result)
 	SetResponseSetEmail(output *SetEmailOutputData, result Result)
+	SetResponseUnlockAccount(output *UnlockAccountOutputData, result Result)
+	SetResponseRequestMagicLink(output *RequestMagicLinkOutputData, result Result)
+	SetResponseSignInMagicLink(output *SignInOutputData, result Result)
 }
@@ -0,0 +1,117 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// AvailabilityPresenter は受講可能日の presenter を表す構造体です。
+type AvailabilityPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewAvailabilityPresenter は AvailabilityOutputPort を生成します。
+func NewAvailabilityPresenter() port.AvailabilityOutputPort {
+	return &AvailabilityPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *AvailabilityPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseGetAvailability は受講可能日の設定を取得するレスポンスをセットします。
+func (p *AvailabilityPresenter) SetResponseGetAvailability(output *port.GetAvailabilityOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetAvailabilityResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseUpdateAvailability は受講可能日の設定を更新するレスポンスをセットします。
+func (p *AvailabilityPresenter) SetResponseUpdateAvailability(output *port.UpdateAvailabilityOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToPutAvailabilityResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseCreateBlackout は受講できない期間を作成するレスポンスをセットします。
+func (p *AvailabilityPresenter) SetResponseCreateBlackout(output *port.CreateBlackoutOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToPostBlackoutResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseUpdateBlackout は受講できない期間を更新するレスポンスをセットします。
+func (p *AvailabilityPresenter) SetResponseUpdateBlackout(output *port.UpdateBlackoutOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToPutBlackoutResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseDeleteBlackout は受講できない期間を削除するレスポンスをセットします。
+func (p *AvailabilityPresenter) SetResponseDeleteBlackout(output *port.DeleteBlackoutOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 削除成功時はレスポンスボディを空にする
+}
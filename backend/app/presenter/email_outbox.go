@@ -0,0 +1,85 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// EmailOutboxPresenter は送信するメールの presenter を表す構造体です。
+type EmailOutboxPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewEmailOutboxPresenter は EmailOutboxOutputPort を生成します。
+func NewEmailOutboxPresenter() port.EmailOutboxOutputPort {
+	return &EmailOutboxPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *EmailOutboxPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseDispatchEmailOutbox は送信待ちのメールを送信するレスポンスをセットします。
+func (p *EmailOutboxPresenter) SetResponseDispatchEmailOutbox(output *port.DispatchEmailOutboxOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToDispatchEmailOutboxResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseGetFailedEmailOutboxList は送信に失敗したメールのリストを取得するレスポンスをセットします。
+func (p *EmailOutboxPresenter) SetResponseGetFailedEmailOutboxList(output *port.GetFailedEmailOutboxListOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetFailedEmailOutboxListResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseResendEmailOutbox は送信に失敗したメールを再送するレスポンスをセットします。
+func (p *EmailOutboxPresenter) SetResponseResendEmailOutbox(output *port.ResendEmailOutboxOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToResendEmailOutboxResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
@@ -0,0 +1,73 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// OIDCPresenter は外部アカウントでのサインインの presenter を表す構造体です。
+type OIDCPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewOIDCPresenter は OIDCOutputPort を生成します。
+func NewOIDCPresenter() port.OIDCOutputPort {
+	return &OIDCPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *OIDCPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseGetOIDCProviderList は ID プロバイダーの一覧の取得のレスポンスをセットします。
+func (p *OIDCPresenter) SetResponseGetOIDCProviderList(output *port.GetOIDCProviderListOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetOIDCProviderListResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseBeginOIDCSignIn は外部アカウントでのサインインの開始のレスポンスをセットします。
+func (p *OIDCPresenter) SetResponseBeginOIDCSignIn(output *port.BeginOIDCSignInOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToBeginOIDCSignInResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseFinishOIDCSignIn は外部アカウントでのサインインの完了のレスポンスをセットします。
+// レスポンスはパスワードによるサインインと同じ形式です。
+func (p *OIDCPresenter) SetResponseFinishOIDCSignIn(output *port.SignInOutputData, result port.Result) {
+	up := &UserPresenter{}
+	up.SetResponseSignIn(output, result)
+	p.StatusCode, p.Body = up.GetResponse()
+}
@@ -0,0 +1,77 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// PalettePresenter はパレットの presenter を表す構造体です。
+type PalettePresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewPalettePresenter は PaletteOutputPort を生成します。
+func NewPalettePresenter() port.PaletteOutputPort {
+	return &PalettePresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *PalettePresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseGetPalette はパレットを取得するレスポンスをセットします。
+func (p *PalettePresenter) SetResponseGetPalette(output *port.GetPaletteOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetPaletteResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseCreateCustomColor はユーザー定義の色を作成するレスポンスをセットします。
+func (p *PalettePresenter) SetResponseCreateCustomColor(output *port.CreateCustomColorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToPostCustomColorResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseDeleteCustomColor はユーザー定義の色を削除するレスポンスをセットします。
+func (p *PalettePresenter) SetResponseDeleteCustomColor(output *port.DeleteCustomColorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 削除成功時はレスポンスボディを空にする
+}
@@ -0,0 +1,137 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// PasskeyPresenter はパスキーの presenter を表す構造体です。
+type PasskeyPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewPasskeyPresenter は PasskeyOutputPort を生成します。
+func NewPasskeyPresenter() port.PasskeyOutputPort {
+	return &PasskeyPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *PasskeyPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseBeginPasskeyRegistration はパスキーの登録の開始のレスポンスをセットします。
+func (p *PasskeyPresenter) SetResponseBeginPasskeyRegistration(output *port.BeginPasskeyRegistrationOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToBeginPasskeyRegistrationResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseFinishPasskeyRegistration はパスキーの登録の完了のレスポンスをセットします。
+func (p *PasskeyPresenter) SetResponseFinishPasskeyRegistration(output *port.FinishPasskeyRegistrationOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToFinishPasskeyRegistrationResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseGetPasskeyList はパスキーの一覧の取得のレスポンスをセットします。
+func (p *PasskeyPresenter) SetResponseGetPasskeyList(output *port.GetPasskeyListOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetPasskeyListResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseDeletePasskey はパスキーの削除のレスポンスをセットします。
+func (p *PasskeyPresenter) SetResponseDeletePasskey(output *port.DeletePasskeyOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 成功時はレスポンスボディを空にする
+}
+
+// SetResponseBeginPasskeySignIn はパスキーのサインインの開始のレスポンスをセットします。
+func (p *PasskeyPresenter) SetResponseBeginPasskeySignIn(output *port.BeginPasskeySignInOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToBeginPasskeySignInResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseFinishPasskeySignIn はパスキーのサインインの完了のレスポンスをセットします。
+func (p *PasskeyPresenter) SetResponseFinishPasskeySignIn(output *port.FinishPasskeySignInOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToFinishPasskeySignInResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
@@ -29,15 +29,19 @@ func (p *SchedulePresenter) SetResponseGetScheduleList(output *port.GetScheduleL
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
-	res := response.ToGetScheduleListResponse(output)
+	var res interface{} = response.ToGetScheduleListResponse(output)
+	if output != nil && output.Flat {
+		res = response.ToGetFlatScheduleListResponse(output)
+	}
+
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -49,7 +53,7 @@ func (p *SchedulePresenter) SetResponseGetSchedule(output *port.GetScheduleOutpu
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -57,7 +61,7 @@ func (p *SchedulePresenter) SetResponseGetSchedule(output *port.GetScheduleOutpu
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -69,7 +73,7 @@ func (p *SchedulePresenter) SetResponseCreateSchedule(output *port.CreateSchedul
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -77,7 +81,7 @@ func (p *SchedulePresenter) SetResponseCreateSchedule(output *port.CreateSchedul
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -89,7 +93,7 @@ func (p *SchedulePresenter) SetResponseCreateBulkSchedule(output *port.CreateBul
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -97,7 +101,7 @@ func (p *SchedulePresenter) SetResponseCreateBulkSchedule(output *port.CreateBul
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -109,7 +113,7 @@ func (p *SchedulePresenter) SetResponseUpdateSchedule(output *port.UpdateSchedul
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -117,7 +121,7 @@ func (p *SchedulePresenter) SetResponseUpdateSchedule(output *port.UpdateSchedul
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -129,7 +133,7 @@ func (p *SchedulePresenter) SetResponseUpdateBulkSchedule(output *port.UpdateBul
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -137,7 +141,7 @@ func (p *SchedulePresenter) SetResponseUpdateBulkSchedule(output *port.UpdateBul
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -149,9 +153,42 @@ func (p *SchedulePresenter) SetResponseDeleteSchedule(output *port.DeleteSchedul
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
 	// 削除成功時はレスポンスボディを空にする
 }
+
+// SetResponseExportSchedule はスケジュールをエクスポートするレスポンスをセットします。
+// 成功した場合のボディは JSON ではなく iCalendar 形式の文字列です。
+func (p *SchedulePresenter) SetResponseExportSchedule(output *port.ExportScheduleOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	p.Body = output.Calendar
+}
+
+// SetResponseLintSchedule はスケジュールの警告一覧を取得するレスポンスをセットします。
+func (p *SchedulePresenter) SetResponseLintSchedule(output *port.LintScheduleOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetScheduleLintResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// SearchPresenter は検索の presenter を表す構造体です。
+type SearchPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewSearchPresenter は SearchOutputPort を生成します。
+func NewSearchPresenter() port.SearchOutputPort {
+	return &SearchPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *SearchPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseSearch は検索結果のレスポンスをセットします。
+func (p *SearchPresenter) SetResponseSearch(output *port.SearchOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetSearchResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
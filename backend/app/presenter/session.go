@@ -0,0 +1,121 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// SessionPresenter はセッションの presenter を表す構造体です。
+type SessionPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewSessionPresenter は SessionOutputPort を生成します。
+func NewSessionPresenter() port.SessionOutputPort {
+	return &SessionPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *SessionPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseRefreshSession はアクセストークンの再発行のレスポンスをセットします。
+func (p *SessionPresenter) SetResponseRefreshSession(output *port.RefreshSessionOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToRefreshSessionResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseSignOut はサインアウトのレスポンスをセットします。
+func (p *SessionPresenter) SetResponseSignOut(output *port.SignOutOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 成功時はレスポンスボディを空にする
+}
+
+// SetResponseGetLoginSessionList はログインセッションのリスト取得のレスポンスをセットします。
+func (p *SessionPresenter) SetResponseGetLoginSessionList(output *port.GetLoginSessionListOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetLoginSessionListResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseRevokeLoginSession はログインセッションの無効化のレスポンスをセットします。
+func (p *SessionPresenter) SetResponseRevokeLoginSession(output *port.RevokeLoginSessionOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 成功時はレスポンスボディを空にする
+}
+
+// SetResponseRevokeAllLoginSessions はすべてのログインセッションの無効化のレスポンスをセットします。
+func (p *SessionPresenter) SetResponseRevokeAllLoginSessions(output *port.RevokeAllLoginSessionsOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 成功時はレスポンスボディを空にする
+}
+
+// SetResponseGetJWKS は JWKS の取得のレスポンスをセットします。
+func (p *SessionPresenter) SetResponseGetJWKS(output *port.GetJWKSOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetJWKSResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
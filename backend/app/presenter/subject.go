@@ -29,7 +29,7 @@ func (p *SubjectPresenter) SetResponseGetSubjectList(output *port.GetSubjectList
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -37,7 +37,7 @@ func (p *SubjectPresenter) SetResponseGetSubjectList(output *port.GetSubjectList
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -49,7 +49,7 @@ func (p *SubjectPresenter) SetResponseCreateSubject(output *port.CreateSubjectOu
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -57,7 +57,7 @@ func (p *SubjectPresenter) SetResponseCreateSubject(output *port.CreateSubjectOu
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -69,7 +69,7 @@ func (p *SubjectPresenter) SetResponseDeleteSubject(output *port.DeleteSubjectOu
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -0,0 +1,117 @@
+package presenter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/response"
+)
+
+// TwoFactorPresenter は二段階認証の presenter を表す構造体です。
+type TwoFactorPresenter struct {
+	StatusCode int
+	Body       string
+}
+
+// NewTwoFactorPresenter は TwoFactorOutputPort を生成します。
+func NewTwoFactorPresenter() port.TwoFactorOutputPort {
+	return &TwoFactorPresenter{}
+}
+
+// GetResponse はレスポンスのステータスコードとボディを取得します。
+func (p *TwoFactorPresenter) GetResponse() (int, string) {
+	return p.StatusCode, p.Body
+}
+
+// SetResponseGetTwoFactor は二段階認証の設定の取得のレスポンスをセットします。
+func (p *TwoFactorPresenter) SetResponseGetTwoFactor(output *port.GetTwoFactorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToGetTwoFactorResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseEnrollTwoFactor は二段階認証の設定の開始のレスポンスをセットします。
+func (p *TwoFactorPresenter) SetResponseEnrollTwoFactor(output *port.EnrollTwoFactorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToEnrollTwoFactorResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseActivateTwoFactor は二段階認証の有効化のレスポンスをセットします。
+func (p *TwoFactorPresenter) SetResponseActivateTwoFactor(output *port.ActivateTwoFactorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToActivateTwoFactorResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseResetTwoFactor は二段階認証のリセットのレスポンスをセットします。
+func (p *TwoFactorPresenter) SetResponseResetTwoFactor(output *port.ResetTwoFactorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 成功時はレスポンスボディを空にする
+}
+
+// SetResponseVerifyTwoFactor は二段階認証のサインインのレスポンスをセットします。
+func (p *TwoFactorPresenter) SetResponseVerifyTwoFactor(output *port.VerifyTwoFactorOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToVerifyTwoFactorResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
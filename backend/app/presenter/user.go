@@ -28,7 +28,21 @@ func (p *UserPresenter) SetResponseSignIn(output *port.SignInOutputData, result
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 二段階認証が有効なユーザーにはセッションの代わりに二段階認証のトークンを返す
+	if output != nil && output.TwoFactorToken != "" {
+		res := response.ToTwoFactorChallengeResponse(output)
+		b, err := json.Marshal(res)
+		if err != nil {
+			p.StatusCode = http.StatusInternalServerError
+			p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+			return
+		}
+
+		p.Body = string(b)
 		return
 	}
 
@@ -36,7 +50,7 @@ func (p *UserPresenter) SetResponseSignIn(output *port.SignInOutputData, result
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -48,7 +62,7 @@ func (p *UserPresenter) SetResponseSignUp(output *port.SignUpOutputData, result
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -60,7 +74,7 @@ func (p *UserPresenter) SetResponsePasswordReset(output *port.PasswordResetOutpu
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -72,7 +86,7 @@ func (p *UserPresenter) SetResponsePasswordSet(output *port.PasswordSetOutputDat
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -84,7 +98,7 @@ func (p *UserPresenter) SetResponseGetUser(output *port.GetUserOutputData, resul
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -92,7 +106,7 @@ func (p *UserPresenter) SetResponseGetUser(output *port.GetUserOutputData, resul
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -104,7 +118,7 @@ func (p *UserPresenter) SetResponseUpdateUser(output *port.UpdateUserOutputData,
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -112,7 +126,7 @@ func (p *UserPresenter) SetResponseUpdateUser(output *port.UpdateUserOutputData,
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
@@ -124,7 +138,7 @@ func (p *UserPresenter) SetResponseDeleteUser(output *port.DeleteUserOutputData,
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -136,7 +150,7 @@ func (p *UserPresenter) SetResponseResetEmail(output *port.ResetEmailOutputData,
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -148,9 +162,47 @@ func (p *UserPresenter) SetResponseSetEmail(output *port.SetEmailOutputData, res
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
 	// 成功時はレスポンスボディを空にする
 }
+
+// SetResponseUnlockAccount はロックの解除のレスポンスをセットします。
+func (p *UserPresenter) SetResponseUnlockAccount(output *port.UnlockAccountOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	// 成功時はレスポンスボディを空にする
+}
+
+// SetResponseRequestMagicLink はサインインのリンクのリクエストのレスポンスをセットします。
+func (p *UserPresenter) SetResponseRequestMagicLink(output *port.RequestMagicLinkOutputData, result port.Result) {
+	p.StatusCode = result.StatusCode
+
+	if result.HasError {
+		p.Body = response.ToResultErrorBody(result)
+		return
+	}
+
+	res := response.ToRequestMagicLinkResponse(output)
+	b, err := json.Marshal(res)
+	if err != nil {
+		p.StatusCode = http.StatusInternalServerError
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
+		return
+	}
+
+	p.Body = string(b)
+}
+
+// SetResponseSignInMagicLink はサインインのリンクによるサインインのレスポンスをセットします。
+// レスポンスはパスワードによるサインインと同じ形式です。
+func (p *UserPresenter) SetResponseSignInMagicLink(output *port.SignInOutputData, result port.Result) {
+	p.SetResponseSignIn(output, result)
+}
@@ -29,7 +29,7 @@ func (p *UserUsagePresenter) SetResponseGetUserUsageList(output *port.GetUserUsa
 	p.StatusCode = result.StatusCode
 
 	if result.HasError {
-		p.Body = response.ToErrorBody(result.ErrorMessage)
+		p.Body = response.ToResultErrorBody(result)
 		return
 	}
 
@@ -37,7 +37,7 @@ func (p *UserUsagePresenter) SetResponseGetUserUsageList(output *port.GetUserUsa
 	b, err := json.Marshal(res)
 	if err != nil {
 		p.StatusCode = http.StatusInternalServerError
-		p.Body = response.ToErrorBody(err.Error())
+		p.Body = response.ToErrorBody(port.ErrorCodeInternal, err.Error())
 		return
 	}
 
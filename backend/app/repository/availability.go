@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const availabilityTableName = "AttendancePlan_Availability"
+
+// AvailabilityRepository は受講可能日の設定の repository を表すインターフェースです。
+type AvailabilityRepository interface {
+	Read(userID string) (*model.Availability, error)
+	Update(availability *model.Availability) error
+	Delete(userID string) error
+}
+
+// AvailabilityRepositoryImpl は受講可能日の設定の repository の実装を表す構造体です。
+type AvailabilityRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewAvailabilityRepository は AvailabilityRepository を生成します。
+func NewAvailabilityRepository(db dynamo.DB) AvailabilityRepository {
+	return &AvailabilityRepositoryImpl{DB: db, Table: db.Table(availabilityTableName)}
+}
+
+// Read は指定されたユーザー ID の受講可能日の設定を取得します。
+func (r *AvailabilityRepositoryImpl) Read(userID string) (*model.Availability, error) {
+	var availability *model.Availability
+	err := r.Table.Get("UserID", userID).One(&availability)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return availability, nil
+}
+
+// Update は受講可能日の設定を保存します。
+func (r *AvailabilityRepositoryImpl) Update(availability *model.Availability) error {
+	return r.Table.Put(availability).Run()
+}
+
+// Delete は指定されたユーザー ID の受講可能日の設定を削除します。
+func (r *AvailabilityRepositoryImpl) Delete(userID string) error {
+	return r.Table.Delete("UserID", userID).Run()
+}
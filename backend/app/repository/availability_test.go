@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAvailabilitySetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(availabilityTableName)
+
+	var availabilities []model.Availability
+	err := table.Scan().All(&availabilities)
+	require.NoError(err)
+
+	for _, a := range availabilities {
+		err := table.Delete("UserID", a.UserID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestAvailability_Read(t *testing.T) {
+	availability := &model.Availability{
+		UserID:            "test-user-id",
+		Weekdays:          []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+		MaxLecturesPerDay: 3,
+		CreatedAt:         time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name         string
+		data         *model.Availability
+		wantHasError bool
+	}{
+		{name: "0件取得", data: nil, wantHasError: true},
+		{name: "1件取得", data: availability},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			db, table, err := testAvailabilitySetup(t)
+			require.NoError(err)
+
+			if tt.data != nil {
+				err := table.Put(tt.data).Run()
+				require.NoError(err)
+			}
+
+			repo := NewAvailabilityRepository(*db)
+			got, err := repo.Read("test-user-id")
+
+			if tt.wantHasError {
+				assert.Nil(got)
+				assert.True(IsNotFoundError(err))
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tt.data.UserID, got.UserID)
+			assert.Equal(tt.data.Weekdays, got.Weekdays)
+			assert.Equal(tt.data.MaxLecturesPerDay, got.MaxLecturesPerDay)
+		})
+	}
+}
+
+func TestAvailability_Update(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, table, err := testAvailabilitySetup(t)
+	require.NoError(err)
+
+	repo := NewAvailabilityRepository(*db)
+
+	availability := &model.Availability{
+		UserID:            "test-user-id",
+		Weekdays:          []time.Weekday{time.Saturday, time.Sunday},
+		MaxLecturesPerDay: 2,
+	}
+	require.NoError(repo.Update(availability))
+
+	availability.MaxLecturesPerDay = 4
+	require.NoError(repo.Update(availability))
+
+	var got model.Availability
+	require.NoError(table.Get("UserID", "test-user-id").One(&got))
+	assert.Equal(availability.Weekdays, got.Weekdays)
+	assert.Equal(4, got.MaxLecturesPerDay)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const blackoutTableName = "AttendancePlan_Blackout"
+
+// BlackoutRepository は受講できない期間の repository を表すインターフェースです。
+type BlackoutRepository interface {
+	Read(id string) (*model.Blackout, error)
+	ReadByUserID(userID string) ([]model.Blackout, error)
+	Create(blackout *model.Blackout) error
+	Update(blackout *model.Blackout) error
+	Delete(id string) error
+}
+
+// BlackoutRepositoryImpl は受講できない期間の repository の実装を表す構造体です。
+type BlackoutRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewBlackoutRepository は BlackoutRepository を生成します。
+func NewBlackoutRepository(db dynamo.DB) BlackoutRepository {
+	return &BlackoutRepositoryImpl{DB: db, Table: db.Table(blackoutTableName)}
+}
+
+// Read は指定された ID の受講できない期間を取得します。
+func (r *BlackoutRepositoryImpl) Read(id string) (*model.Blackout, error) {
+	var blackout *model.Blackout
+	err := r.Table.Get("ID", id).One(&blackout)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return blackout, nil
+}
+
+// ReadByUserID は指定されたユーザー ID の受講できない期間を開始日の昇順で取得します。
+func (r *BlackoutRepositoryImpl) ReadByUserID(userID string) ([]model.Blackout, error) {
+	blackouts := []model.Blackout{}
+	err := r.Table.Get("UserID", userID).Index("UserID-index").Order(dynamo.Ascending).All(&blackouts)
+	if err != nil {
+		return nil, err
+	}
+	return blackouts, nil
+}
+
+// Create は受講できない期間を保存します。
+func (r *BlackoutRepositoryImpl) Create(blackout *model.Blackout) error {
+	return r.Table.Put(blackout).Run()
+}
+
+// Update は受講できない期間を更新します。
+func (r *BlackoutRepositoryImpl) Update(blackout *model.Blackout) error {
+	return r.Table.Put(blackout).Run()
+}
+
+// Delete は指定された ID の受講できない期間を削除します。
+func (r *BlackoutRepositoryImpl) Delete(id string) error {
+	return r.Table.Delete("ID", id).Run()
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBlackoutSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(blackoutTableName)
+
+	var blackouts []model.Blackout
+	err := table.Scan().All(&blackouts)
+	require.NoError(err)
+
+	for _, b := range blackouts {
+		err := table.Delete("ID", b.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestBlackout_ReadByUserID(t *testing.T) {
+	var blackouts []model.Blackout
+	for i := 0; i < 3; i++ {
+		b := model.Blackout{
+			ID:        fmt.Sprintf("test-blackout-%d", i),
+			UserID:    "test-user-a",
+			Label:     fmt.Sprintf("test-label-%d", i),
+			StartsAt:  time.Date(2021, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			EndsAt:    time.Date(2021, 1, 2+i, 0, 0, 0, 0, time.UTC),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		blackouts = append(blackouts, b)
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		data   []model.Blackout
+		want   []model.Blackout
+	}{
+		{name: "0件取得", userID: "test-user-a", data: []model.Blackout{}, want: []model.Blackout{}},
+		{name: "3件取得", userID: "test-user-a", data: blackouts, want: blackouts},
+		{name: "異なるユーザーID", userID: "test-user-b", data: blackouts, want: []model.Blackout{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			db, table, err := testBlackoutSetup(t)
+			require.NoError(err)
+
+			for _, b := range tt.data {
+				err := table.Put(b).Run()
+				require.NoError(err)
+			}
+
+			repo := NewBlackoutRepository(*db)
+			got, err := repo.ReadByUserID(tt.userID)
+			require.NoError(err)
+
+			if !assert.Len(got, len(tt.want)) {
+				return
+			}
+
+			for i, want := range tt.want {
+				assert.Equal(want.ID, got[i].ID)
+				assert.Equal(want.Label, got[i].Label)
+				assert.Equal(want.StartsAt.Format(model.DateFormat), got[i].StartsAt.Format(model.DateFormat))
+				assert.Equal(want.EndsAt.Format(model.DateFormat), got[i].EndsAt.Format(model.DateFormat))
+			}
+		})
+	}
+}
+
+func TestBlackout_Delete(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, table, err := testBlackoutSetup(t)
+	require.NoError(err)
+
+	b := model.Blackout{
+		ID:       "test-blackout",
+		UserID:   "test-user-a",
+		Label:    "test-label",
+		StartsAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(table.Put(b).Run())
+
+	repo := NewBlackoutRepository(*db)
+	require.NoError(repo.Delete("test-blackout"))
+
+	_, err = repo.Read("test-blackout")
+	assert.True(IsNotFoundError(err))
+}
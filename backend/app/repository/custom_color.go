@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const customColorTableName = "AttendancePlan_CustomColor"
+
+// CustomColorRepository はユーザー定義の色の repository を表すインターフェースです。
+type CustomColorRepository interface {
+	Read(id string) (*model.CustomColor, error)
+	ReadByUserID(userID string) ([]model.CustomColor, error)
+	Create(customColor *model.CustomColor) error
+	Delete(id string) error
+}
+
+// CustomColorRepositoryImpl はユーザー定義の色の repository の実装を表す構造体です。
+type CustomColorRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewCustomColorRepository は CustomColorRepository を生成します。
+func NewCustomColorRepository(db dynamo.DB) CustomColorRepository {
+	return &CustomColorRepositoryImpl{DB: db, Table: db.Table(customColorTableName)}
+}
+
+// Read は指定された ID のユーザー定義の色を取得します。
+func (r *CustomColorRepositoryImpl) Read(id string) (*model.CustomColor, error) {
+	var customColor *model.CustomColor
+	err := r.Table.Get("ID", id).One(&customColor)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return customColor, nil
+}
+
+// ReadByUserID は指定されたユーザー ID のユーザー定義の色を作成日時の昇順で取得します。
+func (r *CustomColorRepositoryImpl) ReadByUserID(userID string) ([]model.CustomColor, error) {
+	customColors := []model.CustomColor{}
+	err := r.Table.Get("UserID", userID).Index("UserID-index").Order(dynamo.Ascending).All(&customColors)
+	if err != nil {
+		return nil, err
+	}
+	return customColors, nil
+}
+
+// Create はユーザー定義の色を保存します。
+func (r *CustomColorRepositoryImpl) Create(customColor *model.CustomColor) error {
+	return r.Table.Put(customColor).Run()
+}
+
+// Delete は指定された ID のユーザー定義の色を削除します。
+func (r *CustomColorRepositoryImpl) Delete(id string) error {
+	return r.Table.Delete("ID", id).Run()
+}
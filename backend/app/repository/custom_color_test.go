@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCustomColorSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(customColorTableName)
+
+	var customColors []model.CustomColor
+	err := table.Scan().All(&customColors)
+	require.NoError(err)
+
+	for _, cc := range customColors {
+		err := table.Delete("ID", cc.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestCustomColor_ReadByUserID(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	customColors := []model.CustomColor{
+		{ID: "test-color-2", UserID: "test-user-a", Name: "藤色", Hex: "#a78bfa", Style: model.ColorStyleFill, CreatedAt: date.AddDate(0, 0, 1), UpdatedAt: date},
+		{ID: "test-color-1", UserID: "test-user-a", Name: "若草色", Hex: "#84cc16", Style: model.ColorStyleOutline, CreatedAt: date, UpdatedAt: date},
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		data   []model.CustomColor
+		want   []string
+	}{
+		{name: "0件取得", userID: "test-user-a", data: []model.CustomColor{}, want: []string{}},
+		{name: "作成日時の昇順で取得", userID: "test-user-a", data: customColors, want: []string{"test-color-1", "test-color-2"}},
+		{name: "異なるユーザーID", userID: "test-user-b", data: customColors, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			db, table, err := testCustomColorSetup(t)
+			require.NoError(err)
+
+			for _, cc := range tt.data {
+				require.NoError(table.Put(cc).Run())
+			}
+
+			repo := NewCustomColorRepository(*db)
+			got, err := repo.ReadByUserID(tt.userID)
+			require.NoError(err)
+
+			ids := []string{}
+			for _, cc := range got {
+				ids = append(ids, cc.ID)
+			}
+			assert.Equal(tt.want, ids)
+		})
+	}
+}
+
+func TestCustomColor_Delete(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, table, err := testCustomColorSetup(t)
+	require.NoError(err)
+
+	cc := model.CustomColor{ID: "test-color", UserID: "test-user-a", Name: "藤色", Hex: "#a78bfa", Style: model.ColorStyleFill}
+	require.NoError(table.Put(cc).Run())
+
+	repo := NewCustomColorRepository(*db)
+	require.NoError(repo.Delete("test-color"))
+
+	_, err = repo.Read("test-color")
+	assert.True(IsNotFoundError(err))
+}
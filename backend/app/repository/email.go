@@ -5,37 +5,49 @@ import (
 	"fmt"
 	"net/mail"
 
-	"github.com/aws/aws-sdk-go-v2/service/sesv2"
-	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/datsukan/attendance-plan/backend/infrastructure"
 )
 
 // EmailRepository はメールの repository を表すインターフェースです。
 type EmailRepository interface {
 	Send(ctx context.Context, to, subject, body string) (string, error)
+	SendMessage(ctx context.Context, to string, msg EmailMessage) (string, error)
+}
+
+// EmailMessage は送信するメールを表す構造体です。
+// HTML を指定した場合はテキストと HTML を multipart/alternative として送信します。
+type EmailMessage struct {
+	Subject string
+	Text    string
+	HTML    string
 }
 
 // EmailRepositoryImpl はメールの repository の実装を表す構造体です。
 type EmailRepositoryImpl struct {
-	Client      infrastructure.MailClient
+	Transport   infrastructure.MailTransport
 	SenderEmail string
 	SenderName  string
 }
 
 // NewEmailRepository は EmailRepository を生成します。
-func NewEmailRepository(client infrastructure.MailClient, senderEmail, senderName string) EmailRepository {
+// 実際の送信は設定に応じて選択した MailTransport に委ねます。
+func NewEmailRepository(transport infrastructure.MailTransport, senderEmail, senderName string) EmailRepository {
 	return &EmailRepositoryImpl{
-		Client:      client,
+		Transport:   transport,
 		SenderEmail: senderEmail,
 		SenderName:  senderName,
 	}
 }
 
-// Send はメールを送信します。
+// Send はテキストのみのメールを送信します。
 func (r *EmailRepositoryImpl) Send(ctx context.Context, to, subject, body string) (string, error) {
-	if r.Client == nil {
-		return "", fmt.Errorf("client is nil")
+	return r.SendMessage(ctx, to, EmailMessage{Subject: subject, Text: body})
+}
+
+// SendMessage はメールを送信します。
+func (r *EmailRepositoryImpl) SendMessage(ctx context.Context, to string, msg EmailMessage) (string, error) {
+	if r.Transport == nil {
+		return "", fmt.Errorf("transport is nil")
 	}
 
 	if r.SenderEmail == "" {
@@ -46,46 +58,24 @@ func (r *EmailRepositoryImpl) Send(ctx context.Context, to, subject, body string
 		return "", fmt.Errorf("to is empty")
 	}
 
-	if subject == "" {
+	if msg.Subject == "" {
 		return "", fmt.Errorf("subject is empty")
 	}
 
-	if body == "" {
+	if msg.Text == "" {
 		return "", fmt.Errorf("body is empty")
 	}
 
-	address := mail.Address{
-		Name:    r.SenderName,
-		Address: r.SenderEmail,
-	}
-
-	input := &sesv2.SendEmailInput{
-		FromEmailAddress: aws.String(address.String()), // 送信元
-		Destination: &types.Destination{
-			ToAddresses: []string{to}, // 送信先
+	m := infrastructure.OutgoingMail{
+		From: mail.Address{
+			Name:    r.SenderName,
+			Address: r.SenderEmail,
 		},
-		Content: &types.EmailContent{
-			Simple: &types.Message{
-				Body: &types.Body{
-					Text: &types.Content{
-						Data: aws.String(body), // 本文
-					},
-				},
-				Subject: &types.Content{
-					Data: aws.String(subject), // 件名
-				},
-			},
-		},
-	}
-
-	res, err := r.Client.SendEmail(ctx, input)
-	if err != nil {
-		return "", err
-	}
-
-	if res == nil || res.MessageId == nil {
-		return "", fmt.Errorf("failed to send email")
+		To:      []string{to},
+		Subject: msg.Subject,
+		Text:    msg.Text,
+		HTML:    msg.HTML,
 	}
 
-	return *res.MessageId, nil
+	return r.Transport.Send(ctx, m)
 }
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const emailOutboxTableName = "AttendancePlan_EmailOutbox"
+
+var (
+	// ErrEmailOutboxConflict は送信するメールが読み取った後に他の処理によって更新された場合のエラーです。
+	ErrEmailOutboxConflict = errors.New("email outbox is updated concurrently")
+)
+
+// EmailOutboxRepository は送信するメールの repository を表すインターフェースです。
+type EmailOutboxRepository interface {
+	Read(id string) (*model.EmailOutbox, error)
+	ReadDue(now time.Time, limit int) ([]model.EmailOutbox, error)
+	ReadByStatus(status model.EmailOutboxStatus) ([]model.EmailOutbox, error)
+	Create(outbox *model.EmailOutbox) error
+	Update(outbox *model.EmailOutbox) error
+}
+
+// EmailOutboxRepositoryImpl は送信するメールの repository の実装を表す構造体です。
+type EmailOutboxRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewEmailOutboxRepository は EmailOutboxRepository を生成します。
+func NewEmailOutboxRepository(db dynamo.DB) EmailOutboxRepository {
+	return &EmailOutboxRepositoryImpl{DB: db, Table: db.Table(emailOutboxTableName)}
+}
+
+// Read は指定された ID の送信するメールを取得します。
+func (r *EmailOutboxRepositoryImpl) Read(id string) (*model.EmailOutbox, error) {
+	var outbox *model.EmailOutbox
+	err := r.Table.Get("ID", id).One(&outbox)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return outbox, nil
+}
+
+// ReadDue は指定された日時までに送信を試行するべき送信待ちのメールを、試行する日時の昇順で最大 limit 件取得します。
+func (r *EmailOutboxRepositoryImpl) ReadDue(now time.Time, limit int) ([]model.EmailOutbox, error) {
+	outboxes := []model.EmailOutbox{}
+	err := r.Table.Get("Status", model.EmailOutboxStatusPending).
+		Range("NextAttemptAt", dynamo.LessOrEqual, now.UTC()).
+		Index("Status-index").
+		Order(dynamo.Ascending).
+		Limit(int64(limit)).
+		All(&outboxes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 日時は文字列で比較されるため、秒未満の桁の違いで範囲に含まれたものを除く
+	due := make([]model.EmailOutbox, 0, len(outboxes))
+	for _, o := range outboxes {
+		if o.IsDue(now) {
+			due = append(due, o)
+		}
+	}
+	return due, nil
+}
+
+// ReadByStatus は指定された送信状況のメールを、試行する日時の降順で取得します。
+func (r *EmailOutboxRepositoryImpl) ReadByStatus(status model.EmailOutboxStatus) ([]model.EmailOutbox, error) {
+	outboxes := []model.EmailOutbox{}
+	err := r.Table.Get("Status", status).Index("Status-index").Order(dynamo.Descending).All(&outboxes)
+	if err != nil {
+		return nil, err
+	}
+	return outboxes, nil
+}
+
+// Create は送信するメールを保存します。
+func (r *EmailOutboxRepositoryImpl) Create(outbox *model.EmailOutbox) error {
+	return r.Table.Put(toStoredEmailOutbox(outbox)).Run()
+}
+
+// Update は送信するメールを更新し、Version を上げます。
+// 同じメールが同時に送信されないよう、Version が読み取った時点から変わっていない場合のみ更新し、
+// それ以外は ErrEmailOutboxConflict を返します。
+func (r *EmailOutboxRepositoryImpl) Update(outbox *model.EmailOutbox) error {
+	o := toStoredEmailOutbox(outbox)
+	o.Version++
+
+	err := r.Table.Put(o).If("attribute_not_exists('Version') OR 'Version' = ?", outbox.Version).Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrEmailOutboxConflict
+		}
+		return err
+	}
+
+	outbox.Version = o.Version
+	return nil
+}
+
+// toStoredEmailOutbox は試行する日時を UTC に揃えたコピーを返します。
+// 試行する日時はインデックスの範囲キーとして文字列で比較するため、タイムゾーンを揃えて保存します。
+func toStoredEmailOutbox(outbox *model.EmailOutbox) model.EmailOutbox {
+	o := *outbox
+	o.NextAttemptAt = o.NextAttemptAt.UTC()
+	return o
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEmailOutboxSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(emailOutboxTableName)
+
+	var outboxes []model.EmailOutbox
+	err := table.Scan().All(&outboxes)
+	require.NoError(err)
+
+	for _, o := range outboxes {
+		err := table.Delete("ID", o.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestEmailOutbox_ReadDue(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	sent := model.NewEmailOutbox("test-sent", "to@example.com", "subject", "text", "", now.Add(-2*time.Minute))
+	sent.MarkSent("test-message-id", now.Add(-2*time.Minute))
+	outboxes := []*model.EmailOutbox{
+		model.NewEmailOutbox("test-due-2", "to@example.com", "subject", "text", "", now.Add(-time.Minute)),
+		model.NewEmailOutbox("test-due-1", "to@example.com", "subject", "text", "", now.Add(-2*time.Minute)),
+		model.NewEmailOutbox("test-later", "to@example.com", "subject", "text", "", now.Add(time.Minute)),
+		sent,
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{name: "試行する日時の昇順で取得", limit: 10, want: []string{"test-due-1", "test-due-2"}},
+		{name: "上限の件数まで取得", limit: 1, want: []string{"test-due-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			db, _, err := testEmailOutboxSetup(t)
+			require.NoError(err)
+
+			repo := NewEmailOutboxRepository(*db)
+			for _, o := range outboxes {
+				require.NoError(repo.Create(o))
+			}
+
+			got, err := repo.ReadDue(now, tt.limit)
+			require.NoError(err)
+
+			ids := []string{}
+			for _, o := range got {
+				ids = append(ids, o.ID)
+			}
+			assert.Equal(tt.want, ids)
+		})
+	}
+}
+
+func TestEmailOutbox_Update(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testEmailOutboxSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	o := model.NewEmailOutbox("test-id", "to@example.com", "subject", "text", "<p>text</p>", now)
+
+	repo := NewEmailOutboxRepository(*db)
+	require.NoError(repo.Create(o))
+
+	o.MarkFailed(errors.New("send error"), 1, now)
+	require.NoError(repo.Update(o))
+
+	got, err := repo.Read("test-id")
+	require.NoError(err)
+	assert.Equal(model.EmailOutboxStatusFailed, got.Status)
+	assert.Equal(1, got.Failures)
+	assert.Equal("send error", got.LastError())
+
+	failed, err := repo.ReadByStatus(model.EmailOutboxStatusFailed)
+	require.NoError(err)
+	require.Len(failed, 1)
+	assert.Equal("test-id", failed[0].ID)
+
+	// 読み取った後に他の処理が更新した場合は更新しない
+	stale := *got
+	got.Claim(now)
+	require.NoError(repo.Update(got))
+	stale.Claim(now)
+	assert.ErrorIs(repo.Update(&stale), ErrEmailOutboxConflict)
+
+	_, err = repo.Read("test-not-found")
+	assert.True(IsNotFoundError(err))
+}
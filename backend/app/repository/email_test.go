@@ -3,27 +3,24 @@ package repository
 import (
 	"context"
 	"errors"
+	"net/mail"
 	"testing"
 
-	"github.com/aws/aws-sdk-go-v2/service/sesv2"
 	"github.com/datsukan/attendance-plan/backend/infrastructure"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-type stubMailClient struct{}
+type stubMailTransport struct{}
 
-func (s *stubMailClient) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
-	messageID := "test-message-id"
-	return &sesv2.SendEmailOutput{
-		MessageId: &messageID,
-	}, nil
+func (s *stubMailTransport) Send(ctx context.Context, m infrastructure.OutgoingMail) (string, error) {
+	return "test-message-id", nil
 }
 
-type stubErrorMailClient struct{}
+type stubErrorMailTransport struct{}
 
-func (s *stubErrorMailClient) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
-	return nil, errors.New("send email error")
+func (s *stubErrorMailTransport) Send(ctx context.Context, m infrastructure.OutgoingMail) (string, error) {
+	return "", errors.New("send email error")
 }
 
 func TestEmail_Send(t *testing.T) {
@@ -33,7 +30,7 @@ func TestEmail_Send(t *testing.T) {
 	body := "test body"
 
 	type fields struct {
-		client      infrastructure.MailClient
+		transport   infrastructure.MailTransport
 		senderEmail string
 	}
 	type args struct {
@@ -50,41 +47,41 @@ func TestEmail_Send(t *testing.T) {
 	}{
 		{
 			name:   "正常系",
-			fields: fields{&stubMailClient{}, senderEmail},
+			fields: fields{&stubMailTransport{}, senderEmail},
 			args:   args{to, subject, body},
 			want:   "test-message-id",
 		},
 		{
-			name:    "異常系: client が nil",
+			name:    "異常系: transport が nil",
 			fields:  fields{nil, senderEmail},
-			wantErr: errors.New("client is nil"),
+			wantErr: errors.New("transport is nil"),
 		},
 		{
 			name:    "異常系: senderEmail が空",
-			fields:  fields{&stubMailClient{}, ""},
+			fields:  fields{&stubMailTransport{}, ""},
 			wantErr: errors.New("sender email is empty"),
 		},
 		{
 			name:    "異常系: to が空",
-			fields:  fields{&stubMailClient{}, senderEmail},
+			fields:  fields{&stubMailTransport{}, senderEmail},
 			args:    args{"", subject, body},
 			wantErr: errors.New("to is empty"),
 		},
 		{
 			name:    "異常系: subject が空",
-			fields:  fields{&stubMailClient{}, senderEmail},
+			fields:  fields{&stubMailTransport{}, senderEmail},
 			args:    args{to, "", body},
 			wantErr: errors.New("subject is empty"),
 		},
 		{
 			name:    "異常系: body が空",
-			fields:  fields{&stubMailClient{}, senderEmail},
+			fields:  fields{&stubMailTransport{}, senderEmail},
 			args:    args{to, subject, ""},
 			wantErr: errors.New("body is empty"),
 		},
 		{
-			name:    "異常系: SendEmail がエラー",
-			fields:  fields{&stubErrorMailClient{}, senderEmail},
+			name:    "異常系: 送信がエラー",
+			fields:  fields{&stubErrorMailTransport{}, senderEmail},
 			args:    args{to, subject, body},
 			wantErr: errors.New("send email error"),
 		},
@@ -97,7 +94,7 @@ func TestEmail_Send(t *testing.T) {
 
 			ctx := context.Background()
 			r := &EmailRepositoryImpl{
-				Client:      tt.fields.client,
+				Transport:   tt.fields.transport,
 				SenderEmail: tt.fields.senderEmail,
 			}
 			got, err := r.Send(ctx, tt.args.to, tt.args.subject, tt.args.body)
@@ -113,3 +110,36 @@ func TestEmail_Send(t *testing.T) {
 		})
 	}
 }
+
+func TestEmail_SendMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  EmailMessage
+	}{
+		{name: "テキストと HTML を送信する", msg: EmailMessage{Subject: "test subject", Text: "test body", HTML: "<p>test body</p>"}},
+		{name: "HTML がない場合はテキストのみを送信する", msg: EmailMessage{Subject: "test subject", Text: "test body"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			c := infrastructure.NewCaptureTransport("")
+			r := &EmailRepositoryImpl{Transport: c, SenderEmail: "test@example.com", SenderName: "test sender"}
+
+			got, err := r.SendMessage(context.Background(), "test-to@example.com", tt.msg)
+			require.NoError(err)
+
+			messages := c.Messages()
+			require.Len(messages, 1)
+			m := messages[0]
+			assert.Equal(m.MessageID, got)
+			assert.Equal(mail.Address{Name: "test sender", Address: "test@example.com"}, m.From)
+			assert.Equal([]string{"test-to@example.com"}, m.To)
+			assert.Equal(tt.msg.Subject, m.Subject)
+			assert.Equal(tt.msg.Text, m.Text)
+			assert.Equal(tt.msg.HTML, m.HTML)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const loginSessionTableName = "AttendancePlan_LoginSession"
+
+// LoginSessionRepository はログインセッションの repository を表すインターフェースです。
+type LoginSessionRepository interface {
+	Read(id string) (*model.LoginSession, error)
+	ReadByUserID(userID string) ([]model.LoginSession, error)
+	Create(session *model.LoginSession) error
+	Update(session *model.LoginSession) error
+}
+
+// LoginSessionRepositoryImpl はログインセッションの repository の実装を表す構造体です。
+type LoginSessionRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewLoginSessionRepository は LoginSessionRepository を生成します。
+func NewLoginSessionRepository(db dynamo.DB) LoginSessionRepository {
+	return &LoginSessionRepositoryImpl{DB: db, Table: db.Table(loginSessionTableName)}
+}
+
+// Read は指定された ID のログインセッションを取得します。
+func (r *LoginSessionRepositoryImpl) Read(id string) (*model.LoginSession, error) {
+	var session *model.LoginSession
+	err := r.Table.Get("ID", id).One(&session)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return session, nil
+}
+
+// ReadByUserID は指定されたユーザーのログインセッションを取得します。無効なものも含みます。
+func (r *LoginSessionRepositoryImpl) ReadByUserID(userID string) ([]model.LoginSession, error) {
+	sessions := []model.LoginSession{}
+	err := r.Table.Get("UserID", userID).Index("UserID-index").All(&sessions)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Create はログインセッションを保存します。
+func (r *LoginSessionRepositoryImpl) Create(session *model.LoginSession) error {
+	return r.Table.Put(session).Run()
+}
+
+// Update はログインセッションを更新します。
+func (r *LoginSessionRepositoryImpl) Update(session *model.LoginSession) error {
+	return r.Table.Put(session).Run()
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLoginSessionSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(loginSessionTableName)
+
+	var sessions []model.LoginSession
+	err := table.Scan().All(&sessions)
+	require.NoError(err)
+
+	for _, s := range sessions {
+		err := table.Delete("ID", s.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestLoginSession_ReadByUserID(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testLoginSessionSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	user := &model.User{ID: "test-user-id"}
+	other := &model.User{ID: "test-other-user-id"}
+
+	repo := NewLoginSessionRepository(*db)
+	require.NoError(repo.Create(model.NewLoginSession("test-id-1", user, "test-agent", "192.0.2.1", time.Hour, now)))
+	require.NoError(repo.Create(model.NewLoginSession("test-id-2", user, "test-agent", "192.0.2.1", time.Hour, now)))
+	require.NoError(repo.Create(model.NewLoginSession("test-id-3", other, "test-agent", "192.0.2.1", time.Hour, now)))
+
+	got, err := repo.ReadByUserID("test-user-id")
+	require.NoError(err)
+	assert.Len(got, 2)
+
+	s, err := repo.Read("test-id-1")
+	require.NoError(err)
+	s.Revoked = true
+	require.NoError(repo.Update(s))
+
+	s, err = repo.Read("test-id-1")
+	require.NoError(err)
+	assert.True(s.Revoked)
+
+	_, err = repo.Read("test-not-found")
+	assert.True(IsNotFoundError(err))
+}
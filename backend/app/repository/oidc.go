@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const (
+	oidcStateTableName    = "AttendancePlan_OIDCState"
+	oidcIdentityTableName = "AttendancePlan_OIDCIdentity"
+)
+
+// ErrOIDCIdentityAlreadyLinked は同じ外部アカウントがすでにユーザーに紐付けられている場合のエラーです。
+var ErrOIDCIdentityAlreadyLinked = errors.New("oidc identity is already linked")
+
+// OIDCStateRepository は外部アカウントでのサインインの認可のリクエストの repository を表すインターフェースです。
+type OIDCStateRepository interface {
+	Create(state *model.OIDCState) error
+	Consume(state string) (*model.OIDCState, error)
+}
+
+// OIDCStateRepositoryImpl は認可のリクエストの repository の実装を表す構造体です。
+type OIDCStateRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewOIDCStateRepository は OIDCStateRepository を生成します。
+func NewOIDCStateRepository(db dynamo.DB) OIDCStateRepository {
+	return &OIDCStateRepositoryImpl{DB: db, Table: db.Table(oidcStateTableName)}
+}
+
+// Create は認可のリクエストを保存します。
+func (r *OIDCStateRepositoryImpl) Create(state *model.OIDCState) error {
+	return r.Table.Put(state).Run()
+}
+
+// Consume は認可のリクエストを削除し、削除したリクエストを返します。
+// リクエストがない場合やすでに使われている場合は NotFoundError を返します。
+func (r *OIDCStateRepositoryImpl) Consume(state string) (*model.OIDCState, error) {
+	var s *model.OIDCState
+	err := r.Table.Delete("State", state).If("attribute_exists('State')").OldValue(&s)
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return nil, NewNotFoundError()
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// OIDCIdentityRepository はユーザーに紐付けた外部アカウントの repository を表すインターフェースです。
+type OIDCIdentityRepository interface {
+	Read(id string) (*model.OIDCIdentity, error)
+	Create(identity *model.OIDCIdentity) error
+	Update(identity *model.OIDCIdentity) error
+	Delete(id string) error
+}
+
+// OIDCIdentityRepositoryImpl は外部アカウントの repository の実装を表す構造体です。
+type OIDCIdentityRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewOIDCIdentityRepository は OIDCIdentityRepository を生成します。
+func NewOIDCIdentityRepository(db dynamo.DB) OIDCIdentityRepository {
+	return &OIDCIdentityRepositoryImpl{DB: db, Table: db.Table(oidcIdentityTableName)}
+}
+
+// Read は指定された ID の外部アカウントを取得します。
+func (r *OIDCIdentityRepositoryImpl) Read(id string) (*model.OIDCIdentity, error) {
+	var identity *model.OIDCIdentity
+	err := r.Table.Get("ID", id).One(&identity)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return identity, nil
+}
+
+// Create は外部アカウントを保存します。
+// 同時に初めてサインインした場合に1つのユーザーのみに紐付けるよう、同じ ID の外部アカウントがすでにある場合は
+// ErrOIDCIdentityAlreadyLinked を返します。
+func (r *OIDCIdentityRepositoryImpl) Create(identity *model.OIDCIdentity) error {
+	err := r.Table.Put(identity).If("attribute_not_exists('ID')").Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrOIDCIdentityAlreadyLinked
+		}
+		return err
+	}
+	return nil
+}
+
+// Update は外部アカウントを更新します。
+func (r *OIDCIdentityRepositoryImpl) Update(identity *model.OIDCIdentity) error {
+	return r.Table.Put(identity).Run()
+}
+
+// Delete は指定された ID の外部アカウントを削除します。
+func (r *OIDCIdentityRepositoryImpl) Delete(id string) error {
+	return r.Table.Delete("ID", id).Run()
+}
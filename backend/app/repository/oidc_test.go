@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOIDCIdentitySetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(oidcIdentityTableName)
+
+	var identities []model.OIDCIdentity
+	err := table.Scan().All(&identities)
+	require.NoError(err)
+
+	for _, i := range identities {
+		err := table.Delete("ID", i.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestOIDCIdentity_Create(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testOIDCIdentitySetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewOIDCIdentityRepository(*db)
+
+	id := model.OIDCIdentityID("https://accounts.example.com", "test-subject")
+	identity := &model.OIDCIdentity{ID: id, Issuer: "https://accounts.example.com", Subject: "test-subject", ProviderID: "test", UserID: "test-user-id", Email: "test@example.com", CreatedAt: now, LastUsedAt: now}
+	require.NoError(repo.Create(identity))
+	assert.ErrorIs(repo.Create(&model.OIDCIdentity{ID: id, UserID: "other-user-id"}), ErrOIDCIdentityAlreadyLinked)
+
+	identity.LastUsedAt = now.Add(time.Hour)
+	require.NoError(repo.Update(identity))
+
+	got, err := repo.Read(id)
+	require.NoError(err)
+	assert.Equal("test-user-id", got.UserID)
+	assert.True(now.Add(time.Hour).Equal(got.LastUsedAt))
+
+	require.NoError(repo.Delete(id))
+	_, err = repo.Read(id)
+	assert.True(IsNotFoundError(err))
+}
+
+func TestOIDCState_Consume(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewOIDCStateRepository(*db)
+
+	s, err := model.NewOIDCState("test", now)
+	require.NoError(err)
+	require.NoError(repo.Create(s))
+
+	got, err := repo.Consume(s.State)
+	require.NoError(err)
+	assert.Equal(s.Nonce, got.Nonce)
+	assert.Equal(s.CodeVerifier, got.CodeVerifier)
+
+	// 使用済みのリクエストは使えない
+	_, err = repo.Consume(s.State)
+	assert.True(IsNotFoundError(err))
+}
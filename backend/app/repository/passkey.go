@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const (
+	passkeyTableName           = "AttendancePlan_Passkey"
+	webAuthnChallengeTableName = "AttendancePlan_WebAuthnChallenge"
+)
+
+var (
+	// ErrPasskeyAlreadyRegistered は同じ資格情報の ID のパスキーがすでに登録されている場合のエラーです。
+	ErrPasskeyAlreadyRegistered = errors.New("passkey is already registered")
+	// ErrPasskeySignCountConflict は署名の回数が同時に更新された場合のエラーです。
+	ErrPasskeySignCountConflict = errors.New("passkey sign count is updated concurrently")
+)
+
+// PasskeyRepository はパスキーの repository を表すインターフェースです。
+type PasskeyRepository interface {
+	Read(id string) (*model.Passkey, error)
+	ReadByUserID(userID string) ([]model.Passkey, error)
+	Create(passkey *model.Passkey) error
+	UpdateSignCount(passkey *model.Passkey, signCount int64, now time.Time) error
+	Delete(id string) error
+}
+
+// PasskeyRepositoryImpl はパスキーの repository の実装を表す構造体です。
+type PasskeyRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewPasskeyRepository は PasskeyRepository を生成します。
+func NewPasskeyRepository(db dynamo.DB) PasskeyRepository {
+	return &PasskeyRepositoryImpl{DB: db, Table: db.Table(passkeyTableName)}
+}
+
+// Read は指定された資格情報の ID のパスキーを取得します。
+func (r *PasskeyRepositoryImpl) Read(id string) (*model.Passkey, error) {
+	var passkey *model.Passkey
+	err := r.Table.Get("ID", id).One(&passkey)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return passkey, nil
+}
+
+// ReadByUserID は指定されたユーザーのパスキーを取得します。
+func (r *PasskeyRepositoryImpl) ReadByUserID(userID string) ([]model.Passkey, error) {
+	passkeys := []model.Passkey{}
+	err := r.Table.Get("UserID", userID).Index("UserID-index").All(&passkeys)
+	if err != nil {
+		return nil, err
+	}
+	return passkeys, nil
+}
+
+// Create はパスキーを保存します。
+// 同じ資格情報の ID のパスキーがすでにある場合は ErrPasskeyAlreadyRegistered を返します。
+func (r *PasskeyRepositoryImpl) Create(passkey *model.Passkey) error {
+	err := r.Table.Put(passkey).If("attribute_not_exists('ID')").Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrPasskeyAlreadyRegistered
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateSignCount はパスキーの署名の回数と最後に使った日時を更新します。
+// 同じ応答が同時に使われた場合に1回のみ成功するよう、署名の回数が読み取った時点から変わっていない場合のみ更新し、
+// それ以外は ErrPasskeySignCountConflict を返します。
+func (r *PasskeyRepositoryImpl) UpdateSignCount(passkey *model.Passkey, signCount int64, now time.Time) error {
+	err := r.Table.Update("ID", passkey.ID).
+		Set("SignCount", signCount).
+		Set("LastUsedAt", now).
+		If("'SignCount' = ?", passkey.SignCount).
+		Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrPasskeySignCountConflict
+		}
+		return err
+	}
+
+	passkey.SignCount = signCount
+	passkey.LastUsedAt = now
+	return nil
+}
+
+// Delete は指定された資格情報の ID のパスキーを削除します。
+func (r *PasskeyRepositoryImpl) Delete(id string) error {
+	return r.Table.Delete("ID", id).Run()
+}
+
+// WebAuthnChallengeRepository はパスキーの登録と認証のチャレンジの repository を表すインターフェースです。
+type WebAuthnChallengeRepository interface {
+	Create(challenge *model.WebAuthnChallenge) error
+	Consume(challenge string) (*model.WebAuthnChallenge, error)
+}
+
+// WebAuthnChallengeRepositoryImpl はチャレンジの repository の実装を表す構造体です。
+type WebAuthnChallengeRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewWebAuthnChallengeRepository は WebAuthnChallengeRepository を生成します。
+func NewWebAuthnChallengeRepository(db dynamo.DB) WebAuthnChallengeRepository {
+	return &WebAuthnChallengeRepositoryImpl{DB: db, Table: db.Table(webAuthnChallengeTableName)}
+}
+
+// Create はチャレンジを保存します。
+func (r *WebAuthnChallengeRepositoryImpl) Create(challenge *model.WebAuthnChallenge) error {
+	return r.Table.Put(challenge).Run()
+}
+
+// Consume はチャレンジを削除し、削除したチャレンジを返します。
+// チャレンジがない場合やすでに使われている場合は NotFoundError を返します。
+func (r *WebAuthnChallengeRepositoryImpl) Consume(challenge string) (*model.WebAuthnChallenge, error) {
+	var c *model.WebAuthnChallenge
+	err := r.Table.Delete("Challenge", challenge).If("attribute_exists('Challenge')").OldValue(&c)
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return nil, NewNotFoundError()
+		}
+		return nil, err
+	}
+	return c, nil
+}
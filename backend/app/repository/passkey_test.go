@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPasskeySetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(passkeyTableName)
+
+	var passkeys []model.Passkey
+	err := table.Scan().All(&passkeys)
+	require.NoError(err)
+
+	for _, p := range passkeys {
+		err := table.Delete("ID", p.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestPasskey_Create(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testPasskeySetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewPasskeyRepository(*db)
+
+	p := &model.Passkey{ID: "test-credential-id", UserID: "test-user-id", Name: "test-name", PublicKey: []byte("test-public-key"), CreatedAt: now}
+	require.NoError(repo.Create(p))
+	assert.ErrorIs(repo.Create(p), ErrPasskeyAlreadyRegistered)
+	require.NoError(repo.Create(&model.Passkey{ID: "test-credential-id-2", UserID: "test-user-id", CreatedAt: now}))
+
+	got, err := repo.Read("test-credential-id")
+	require.NoError(err)
+	assert.Equal(p.PublicKey, got.PublicKey)
+
+	passkeys, err := repo.ReadByUserID("test-user-id")
+	require.NoError(err)
+	assert.Len(passkeys, 2)
+
+	require.NoError(repo.Delete("test-credential-id"))
+	_, err = repo.Read("test-credential-id")
+	assert.True(IsNotFoundError(err))
+}
+
+func TestPasskey_UpdateSignCount(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testPasskeySetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewPasskeyRepository(*db)
+
+	p := &model.Passkey{ID: "test-credential-id", UserID: "test-user-id", SignCount: 1, CreatedAt: now}
+	require.NoError(repo.Create(p))
+
+	stale := *p
+	require.NoError(repo.UpdateSignCount(p, 2, now))
+	assert.Equal(int64(2), p.SignCount)
+
+	// 読み取った後に更新された場合は更新しない
+	assert.ErrorIs(repo.UpdateSignCount(&stale, 2, now), ErrPasskeySignCountConflict)
+}
+
+func TestWebAuthnChallenge_Consume(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewWebAuthnChallengeRepository(*db)
+
+	c, err := model.NewWebAuthnChallenge(model.WebAuthnChallengePurposeAuthentication, "", now)
+	require.NoError(err)
+	require.NoError(repo.Create(c))
+
+	got, err := repo.Consume(c.Challenge)
+	require.NoError(err)
+	assert.Equal(c.Purpose, got.Purpose)
+
+	// 使用済みのチャレンジは使えない
+	_, err = repo.Consume(c.Challenge)
+	assert.True(IsNotFoundError(err))
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const rateLimitTableName = "AttendancePlan_RateLimit"
+
+// RateLimitRepository は回数の制限の repository を表すインターフェースです。
+type RateLimitRepository interface {
+	Read(key string, now time.Time) (*model.RateLimit, error)
+	AddFailure(key string, window time.Duration, now time.Time) (*model.RateLimit, error)
+	Lock(key string, until time.Time, now time.Time) error
+	Delete(key string) error
+	Acquire(key string, cooldown time.Duration, now time.Time) (bool, error)
+}
+
+// RateLimitRepositoryImpl は回数の制限の repository の実装を表す構造体です。
+type RateLimitRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewRateLimitRepository は RateLimitRepository を生成します。
+func NewRateLimitRepository(db dynamo.DB) RateLimitRepository {
+	return &RateLimitRepositoryImpl{DB: db, Table: db.Table(rateLimitTableName)}
+}
+
+// Read は指定されたキーのカウンターを取得します。期限が切れたカウンターは存在しないものとして扱います。
+func (r *RateLimitRepositoryImpl) Read(key string, now time.Time) (*model.RateLimit, error) {
+	var rl *model.RateLimit
+	err := r.Table.Get("Key", key).One(&rl)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+
+	if rl.IsExpired(now) {
+		return nil, NewNotFoundError()
+	}
+	return rl, nil
+}
+
+// AddFailure は失敗の回数を atomic に1回加算し、加算後のカウンターを返します。
+// カウンターの期限は最後の失敗から window の間で、期限が切れている場合は1回目から数え直します。
+func (r *RateLimitRepositoryImpl) AddFailure(key string, window time.Duration, now time.Time) (*model.RateLimit, error) {
+	// 新しいカウンターを作成する処理と競合した場合は、作成されたカウンターに加算し直す
+	for attempt := 0; attempt < 2; attempt++ {
+		var rl model.RateLimit
+		err := r.Table.Update("Key", key).
+			Add("Failures", 1).
+			Set("LastFailureAt", now).
+			Set("TTL", now.Add(window).Unix()).
+			Set("UpdatedAt", now).
+			If("'TTL' > ?", now.Unix()).
+			Value(&rl)
+		if err == nil {
+			return &rl, nil
+		}
+		if !dynamo.IsCondCheckFailed(err) {
+			return nil, err
+		}
+
+		rl = model.RateLimit{
+			Key:           key,
+			Failures:      1,
+			LastFailureAt: now,
+			TTL:           now.Add(window).Unix(),
+			UpdatedAt:     now,
+		}
+		err = r.Table.Put(rl).If("attribute_not_exists('Key') OR 'TTL' <= ?", now.Unix()).Run()
+		if err == nil {
+			return &rl, nil
+		}
+		if !dynamo.IsCondCheckFailed(err) {
+			return nil, err
+		}
+	}
+
+	return nil, errors.New("rate limit counter is updated concurrently")
+}
+
+// Lock は指定された日時までカウンターをロックします。
+// ロックが解除されると同時にカウンターの期限も切れ、失敗の回数は1回目から数え直します。
+func (r *RateLimitRepositoryImpl) Lock(key string, until time.Time, now time.Time) error {
+	return r.Table.Update("Key", key).
+		Set("LockedUntil", until).
+		Set("TTL", until.Unix()).
+		Set("UpdatedAt", now).
+		Run()
+}
+
+// Delete は指定されたキーのカウンターを削除します。
+func (r *RateLimitRepositoryImpl) Delete(key string) error {
+	return r.Table.Delete("Key", key).Run()
+}
+
+// Acquire は cooldown の間に1回のみ成功する操作の実行権を取得します。
+// 前回の取得から cooldown が過ぎていない場合は false を返します。
+func (r *RateLimitRepositoryImpl) Acquire(key string, cooldown time.Duration, now time.Time) (bool, error) {
+	rl := model.RateLimit{
+		Key:       key,
+		TTL:       now.Add(cooldown).Unix(),
+		UpdatedAt: now,
+	}
+	err := r.Table.Put(rl).If("attribute_not_exists('Key') OR 'TTL' <= ?", now.Unix()).Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
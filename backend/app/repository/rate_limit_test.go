@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRateLimitSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(rateLimitTableName)
+
+	var limits []model.RateLimit
+	err := table.Scan().All(&limits)
+	require.NoError(err)
+
+	for _, l := range limits {
+		err := table.Delete("Key", l.Key).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestRateLimit_AddFailure(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testRateLimitSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	key := model.RateLimitKey(model.RateLimitKindSignInEmail, "test-email@example.com")
+	repo := NewRateLimitRepository(*db)
+
+	_, err = repo.Read(key, now)
+	assert.True(IsNotFoundError(err))
+
+	rl, err := repo.AddFailure(key, 15*time.Minute, now)
+	require.NoError(err)
+	assert.Equal(1, rl.Failures)
+
+	rl, err = repo.AddFailure(key, 15*time.Minute, now.Add(time.Minute))
+	require.NoError(err)
+	assert.Equal(2, rl.Failures)
+
+	// 期限が切れた後の失敗は1回目から数え直す
+	rl, err = repo.AddFailure(key, 15*time.Minute, now.Add(time.Hour))
+	require.NoError(err)
+	assert.Equal(1, rl.Failures)
+
+	require.NoError(repo.Lock(key, now.Add(time.Hour+15*time.Minute), now.Add(time.Hour)))
+	rl, err = repo.Read(key, now.Add(time.Hour))
+	require.NoError(err)
+	assert.True(rl.IsLocked(now.Add(time.Hour)))
+
+	require.NoError(repo.Delete(key))
+	_, err = repo.Read(key, now.Add(time.Hour))
+	assert.True(IsNotFoundError(err))
+}
+
+func TestRateLimit_Acquire(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testRateLimitSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	key := model.RateLimitKey(model.RateLimitKindPasswordResetMail, "test-email@example.com")
+	repo := NewRateLimitRepository(*db)
+
+	ok, err := repo.Acquire(key, time.Minute, now)
+	require.NoError(err)
+	assert.True(ok)
+
+	ok, err = repo.Acquire(key, time.Minute, now.Add(30*time.Second))
+	require.NoError(err)
+	assert.False(ok)
+
+	ok, err = repo.Acquire(key, time.Minute, now.Add(time.Minute))
+	require.NoError(err)
+	assert.True(ok)
+}
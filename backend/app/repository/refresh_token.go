@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const refreshTokenTableName = "AttendancePlan_RefreshToken"
+
+// RefreshTokenRepository はリフレッシュトークンの repository を表すインターフェースです。
+type RefreshTokenRepository interface {
+	Read(id string) (*model.RefreshToken, error)
+	Create(token *model.RefreshToken) error
+	Rotate(token *model.RefreshToken, now time.Time) error
+	RevokeFamily(familyID string, now time.Time) error
+}
+
+// RefreshTokenRepositoryImpl はリフレッシュトークンの repository の実装を表す構造体です。
+type RefreshTokenRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewRefreshTokenRepository は RefreshTokenRepository を生成します。
+func NewRefreshTokenRepository(db dynamo.DB) RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{DB: db, Table: db.Table(refreshTokenTableName)}
+}
+
+// Read は指定された ID（トークンのハッシュ）のリフレッシュトークンを取得します。
+func (r *RefreshTokenRepositoryImpl) Read(id string) (*model.RefreshToken, error) {
+	var token *model.RefreshToken
+	err := r.Table.Get("ID", id).One(&token)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return token, nil
+}
+
+// Create はリフレッシュトークンを保存します。
+func (r *RefreshTokenRepositoryImpl) Create(token *model.RefreshToken) error {
+	return r.Table.Put(token).Run()
+}
+
+// Rotate はリフレッシュトークンを再発行に使われた状態にします。
+// 同時に使われた場合に1回のみ成功するよう、有効な状態の場合のみ更新し、それ以外は ErrTokenAlreadyUsed を返します。
+func (r *RefreshTokenRepositoryImpl) Rotate(token *model.RefreshToken, now time.Time) error {
+	err := r.Table.Update("ID", token.ID).
+		Set("Status", model.RefreshTokenStatusRotated).
+		Set("UpdatedAt", now).
+		If("'Status' = ?", model.RefreshTokenStatusActive).
+		Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrTokenAlreadyUsed
+		}
+		return err
+	}
+
+	token.Status = model.RefreshTokenStatusRotated
+	token.UpdatedAt = now
+	return nil
+}
+
+// RevokeFamily は指定された系列のリフレッシュトークンをすべて無効にします。
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(familyID string, now time.Time) error {
+	tokens := []model.RefreshToken{}
+	if err := r.Table.Get("FamilyID", familyID).Index("FamilyID-index").All(&tokens); err != nil {
+		return err
+	}
+
+	for _, t := range tokens {
+		if t.Status == model.RefreshTokenStatusRevoked {
+			continue
+		}
+
+		err := r.Table.Update("ID", t.ID).
+			Set("Status", model.RefreshTokenStatusRevoked).
+			Set("UpdatedAt", now).
+			Run()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
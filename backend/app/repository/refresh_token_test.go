@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRefreshTokenSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(refreshTokenTableName)
+
+	var tokens []model.RefreshToken
+	err := table.Scan().All(&tokens)
+	require.NoError(err)
+
+	for _, rt := range tokens {
+		err := table.Delete("ID", rt.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestRefreshToken_Rotate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testRefreshTokenSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	rt, _, err := model.NewRefreshToken("test-user-id", "test-family-id", time.Hour, now)
+	require.NoError(err)
+
+	repo := NewRefreshTokenRepository(*db)
+	require.NoError(repo.Create(rt))
+
+	require.NoError(repo.Rotate(rt, now))
+	assert.ErrorIs(repo.Rotate(rt, now), ErrTokenAlreadyUsed)
+
+	got, err := repo.Read(rt.ID)
+	require.NoError(err)
+	assert.Equal(model.RefreshTokenStatusRotated, got.Status)
+}
+
+func TestRefreshToken_RevokeFamily(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testRefreshTokenSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewRefreshTokenRepository(*db)
+
+	first, _, err := model.NewRefreshToken("test-user-id", "test-family-id", time.Hour, now)
+	require.NoError(err)
+	second, _, err := model.NewRefreshToken("test-user-id", "test-family-id", time.Hour, now)
+	require.NoError(err)
+	other, _, err := model.NewRefreshToken("test-user-id", "test-other-family-id", time.Hour, now)
+	require.NoError(err)
+	for _, rt := range []*model.RefreshToken{first, second, other} {
+		require.NoError(repo.Create(rt))
+	}
+
+	require.NoError(repo.RevokeFamily("test-family-id", now))
+
+	for _, rt := range []*model.RefreshToken{first, second} {
+		got, err := repo.Read(rt.ID)
+		require.NoError(err)
+		assert.Equal(model.RefreshTokenStatusRevoked, got.Status)
+	}
+
+	got, err := repo.Read(other.ID)
+	require.NoError(err)
+	assert.Equal(model.RefreshTokenStatusActive, got.Status)
+}
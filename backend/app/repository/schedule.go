@@ -14,6 +14,8 @@ type ScheduleRepository interface {
 	Read(id string) (*model.Schedule, error)
 	ReadByUserID(userID string) ([]model.Schedule, error)
 	ReadByUserIDStartsAt(userID string, startsAt time.Time) ([]model.Schedule, error)
+	ReadByUserIDStartsAtBetween(userID string, from, to time.Time) ([]model.Schedule, error)
+	ReadByIDs(ids []string) ([]model.Schedule, error)
 	Create(schedule *model.Schedule) error
 	Update(schedule *model.Schedule) error
 	Delete(id string) error
@@ -58,21 +60,54 @@ func (r *ScheduleRepositoryImpl) ReadByUserID(userID string) ([]model.Schedule,
 // ReadByUserIDStartsAt は指定されたユーザー ID と開始日時に紐づくスケジュールを取得します。
 func (r *ScheduleRepositoryImpl) ReadByUserIDStartsAt(userID string, startsAt time.Time) ([]model.Schedule, error) {
 	var schedules []model.Schedule
-	err := r.Table.Get("UserID", userID).Range("StartsAt", dynamo.Equal, startsAt).Index("UserID-index").Order(dynamo.Ascending).All(&schedules)
+	err := r.Table.Get("UserID", userID).Range("StartsAt", dynamo.Equal, startsAt.UTC()).Index("UserID-index").Order(dynamo.Ascending).All(&schedules)
 	if err != nil {
 		return nil, err
 	}
 	return schedules, nil
 }
 
+// ReadByUserIDStartsAtBetween は指定されたユーザー ID に紐づき、開始日時が from から to の範囲（両端を含む）にあるスケジュールを取得します。
+func (r *ScheduleRepositoryImpl) ReadByUserIDStartsAtBetween(userID string, from, to time.Time) ([]model.Schedule, error) {
+	var schedules []model.Schedule
+	err := r.Table.Get("UserID", userID).Range("StartsAt", dynamo.Between, from.UTC(), to.UTC()).Index("UserID-index").Order(dynamo.Ascending).All(&schedules)
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// ReadByIDs は指定された ID のスケジュールをまとめて取得します。存在しない ID は無視します。
+func (r *ScheduleRepositoryImpl) ReadByIDs(ids []string) ([]model.Schedule, error) {
+	schedules := []model.Schedule{}
+	if len(ids) == 0 {
+		return schedules, nil
+	}
+
+	keys := make([]dynamo.Keyed, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, dynamo.Keys{id})
+	}
+
+	err := r.Table.Batch("ID").Get(keys...).All(&schedules)
+	if err != nil && err != dynamo.ErrNotFound {
+		return nil, err
+	}
+	return schedules, nil
+}
+
 // Create はスケジュールを保存します。
+// 日時は文字列として比較されるため、UTC に揃えて保存します。
 func (r *ScheduleRepositoryImpl) Create(schedule *model.Schedule) error {
-	return r.Table.Put(schedule).Run()
+	s := schedule.In(time.UTC)
+	return r.Table.Put(&s).Run()
 }
 
 // Update はスケジュールを更新します。
+// 日時は文字列として比較されるため、UTC に揃えて保存します。
 func (r *ScheduleRepositoryImpl) Update(schedule *model.Schedule) error {
-	return r.Table.Put(schedule).Run()
+	s := schedule.In(time.UTC)
+	return r.Table.Put(&s).Run()
 }
 
 // Delete はスケジュールを削除します。
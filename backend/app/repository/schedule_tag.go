@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const scheduleTagTableName = "AttendancePlan_ScheduleTag"
+
+// ScheduleTagRepository はスケジュールとタグの対応の repository を表すインターフェースです。
+type ScheduleTagRepository interface {
+	ReadByTagID(tagID string) ([]model.ScheduleTag, error)
+	ReadByScheduleID(scheduleID string) ([]model.ScheduleTag, error)
+	Create(scheduleTag *model.ScheduleTag) error
+	Delete(tagID, scheduleID string) error
+}
+
+// ScheduleTagRepositoryImpl はスケジュールとタグの対応の repository の実装を表す構造体です。
+type ScheduleTagRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewScheduleTagRepository は ScheduleTagRepository を生成します。
+func NewScheduleTagRepository(db dynamo.DB) ScheduleTagRepository {
+	return &ScheduleTagRepositoryImpl{DB: db, Table: db.Table(scheduleTagTableName)}
+}
+
+// ReadByTagID は指定されたタグ ID が付いたスケジュールとの対応を取得します。
+func (r *ScheduleTagRepositoryImpl) ReadByTagID(tagID string) ([]model.ScheduleTag, error) {
+	scheduleTags := []model.ScheduleTag{}
+	err := r.Table.Get("TagID", tagID).Order(dynamo.Ascending).All(&scheduleTags)
+	if err != nil {
+		return nil, err
+	}
+	return scheduleTags, nil
+}
+
+// ReadByScheduleID は指定されたスケジュール ID に付いたタグとの対応を取得します。
+func (r *ScheduleTagRepositoryImpl) ReadByScheduleID(scheduleID string) ([]model.ScheduleTag, error) {
+	scheduleTags := []model.ScheduleTag{}
+	err := r.Table.Get("ScheduleID", scheduleID).Index("ScheduleID-index").All(&scheduleTags)
+	if err != nil {
+		return nil, err
+	}
+	return scheduleTags, nil
+}
+
+// Create はスケジュールとタグの対応を保存します。
+func (r *ScheduleTagRepositoryImpl) Create(scheduleTag *model.ScheduleTag) error {
+	return r.Table.Put(scheduleTag).Run()
+}
+
+// Delete は指定されたタグ ID とスケジュール ID の対応を削除します。
+func (r *ScheduleTagRepositoryImpl) Delete(tagID, scheduleID string) error {
+	return r.Table.Delete("TagID", tagID).Range("ScheduleID", scheduleID).Run()
+}
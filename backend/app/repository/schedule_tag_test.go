@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScheduleTagSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(scheduleTagTableName)
+
+	var scheduleTags []model.ScheduleTag
+	err := table.Scan().All(&scheduleTags)
+	require.NoError(err)
+
+	for _, st := range scheduleTags {
+		err := table.Delete("TagID", st.TagID).Range("ScheduleID", st.ScheduleID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestScheduleTag(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testScheduleTagSetup(t)
+	require.NoError(err)
+
+	repo := NewScheduleTagRepository(*db)
+	for _, st := range []model.ScheduleTag{
+		{TagID: "test-tag-1", ScheduleID: "test-id-1", UserID: "test-user-a"},
+		{TagID: "test-tag-1", ScheduleID: "test-id-2", UserID: "test-user-a"},
+		{TagID: "test-tag-2", ScheduleID: "test-id-1", UserID: "test-user-a"},
+	} {
+		require.NoError(repo.Create(&st))
+	}
+
+	// タグが付いたスケジュールを取得する
+	byTag, err := repo.ReadByTagID("test-tag-1")
+	require.NoError(err)
+	require.Len(byTag, 2)
+	assert.Equal("test-id-1", byTag[0].ScheduleID)
+	assert.Equal("test-id-2", byTag[1].ScheduleID)
+
+	// スケジュールに付いたタグを取得する
+	bySchedule, err := repo.ReadByScheduleID("test-id-1")
+	require.NoError(err)
+	assert.Len(bySchedule, 2)
+
+	// 対応を削除する
+	require.NoError(repo.Delete("test-tag-1", "test-id-1"))
+
+	byTag, err = repo.ReadByTagID("test-tag-1")
+	require.NoError(err)
+	require.Len(byTag, 1)
+	assert.Equal("test-id-2", byTag[0].ScheduleID)
+}
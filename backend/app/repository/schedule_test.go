@@ -287,6 +287,57 @@ func TestSchedule_ReadByUserIDStartsAt(t *testing.T) {
 	}
 }
 
+func TestSchedule_ReadByIDs(t *testing.T) {
+	var schedules []model.Schedule
+	for i := 0; i < 3; i++ {
+		s := model.Schedule{
+			ID:       fmt.Sprintf("test-id-%d", i),
+			UserID:   "test-user-id",
+			Name:     fmt.Sprintf("test-name-%d", i),
+			StartsAt: time.Date(2021, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2021, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			Type:     model.ScheduleTypeCustom,
+			TagIDs:   []string{"test-tag-1"},
+		}
+		schedules = append(schedules, s)
+	}
+
+	tests := []struct {
+		name string
+		ids  []string
+		want []string
+	}{
+		{name: "ID 指定なし", ids: nil, want: []string{}},
+		{name: "2件取得", ids: []string{"test-id-0", "test-id-2"}, want: []string{"test-id-0", "test-id-2"}},
+		{name: "存在しない ID は無視する", ids: []string{"test-id-1", "test-id-x"}, want: []string{"test-id-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			db, table, err := testScheduleSetup(t)
+			require.NoError(err)
+
+			for _, s := range schedules {
+				require.NoError(table.Put(s).Run())
+			}
+
+			repo := NewScheduleRepository(*db)
+			got, err := repo.ReadByIDs(tt.ids)
+			require.NoError(err)
+
+			ids := []string{}
+			for _, s := range got {
+				ids = append(ids, s.ID)
+				assert.Equal([]string{"test-tag-1"}, s.TagIDs)
+			}
+			assert.ElementsMatch(tt.want, ids)
+		})
+	}
+}
+
 func TestSchedule_Create(t *testing.T) {
 	t.Run("正常に登録できること", func(t *testing.T) {
 		require := require.New(t)
@@ -1,41 +1,167 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
 	jwt "github.com/golang-jwt/jwt/v5"
 )
 
+// TokenPurpose はトークンの用途を表す型です。
+// 用途ごとに受け付ける audience と有効期間を分け、別の用途のトークンを使い回せないようにします。
+type TokenPurpose string
+
 const (
-	TokenKeyValue = "value"
-	TokenKeyExp   = "exp"
+	// TokenPurposeSession はログインセッションのアクセストークンです。有効期間が短く、リフレッシュトークンで再発行します。
+	TokenPurposeSession TokenPurpose = "session"
+	// TokenPurposePasswordSet はパスワード設定のリンクのトークンです。1回のみ使えます。
+	TokenPurposePasswordSet TokenPurpose = "password_set"
+	// TokenPurposeEmailChange はメールアドレス変更のリンクのトークンです。1回のみ使えます。
+	TokenPurposeEmailChange TokenPurpose = "email_change"
+	// TokenPurposeAccountUnlock はサインインの失敗でロックされたアカウントのロック解除のリンクのトークンです。1回のみ使えます。
+	TokenPurposeAccountUnlock TokenPurpose = "account_unlock"
+	// TokenPurposeTwoFactor はパスワードを確認した後に二段階認証の認証コードを送るためのトークンです。
+	// 認証コードを誤った場合は再び使えますが、サインインに成功すると使用済みになります。
+	TokenPurposeTwoFactor TokenPurpose = "two_factor"
+	// TokenPurposeMagicLink はパスワードを使わずにサインインするリンクのトークンです。1回のみ使えます。
+	// リンクをリクエストしたブラウザのみで使えるように、ブラウザに渡した nonce のハッシュを持ちます。
+	TokenPurposeMagicLink TokenPurpose = "magic_link"
 )
 
+const (
+	// tokenIssuer はトークンの発行者です。
+	tokenIssuer = "attendance-plan"
+	// passwordSetTokenLifetime はパスワード設定のトークンの有効期間です。
+	passwordSetTokenLifetime = time.Hour
+	// emailChangeTokenLifetime はメールアドレス変更のトークンの有効期間です。
+	emailChangeTokenLifetime = time.Hour
+	// accountUnlockTokenLifetime はアカウントのロック解除のトークンの有効期間です。
+	accountUnlockTokenLifetime = time.Hour
+	// twoFactorTokenLifetime は二段階認証のトークンの有効期間です。
+	twoFactorTokenLifetime = 5 * time.Minute
+	// magicLinkTokenLifetime はサインインのリンクのトークンの有効期間です。
+	magicLinkTokenLifetime = 15 * time.Minute
+)
+
+// Audience はトークンを受け付ける先を返します。
+func (p TokenPurpose) Audience() string {
+	switch p {
+	case TokenPurposePasswordSet:
+		return "attendance-plan/password-set"
+	case TokenPurposeEmailChange:
+		return "attendance-plan/email-set"
+	case TokenPurposeAccountUnlock:
+		return "attendance-plan/account-unlock"
+	case TokenPurposeTwoFactor:
+		return "attendance-plan/two-factor"
+	case TokenPurposeMagicLink:
+		return "attendance-plan/magic-link"
+	default:
+		return "attendance-plan/api"
+	}
+}
+
+// SingleUse は1回のみ使えるトークンかどうかを返します。
+func (p TokenPurpose) SingleUse() bool {
+	return p != TokenPurposeSession
+}
+
+// ErrTokenInvalid はトークンが無効もしくは期限切れ、または用途が異なる場合のエラーです。
+var ErrTokenInvalid = errors.New("token is invalid")
+
+// Token は検証したトークンの内容を表す構造体です。
+// SessionID と Version はアクセストークンのみが持つ、ログインセッションの ID とユーザーのセッションの世代です。
+// NonceHash はサインインのリンクのトークンのみが持つ、リンクをリクエストしたブラウザの nonce のハッシュです。
+type Token struct {
+	ID        string
+	Purpose   TokenPurpose
+	Subject   string
+	Email     string
+	SessionID string
+	Version   int
+	NonceHash string
+	ExpiresAt time.Time
+}
+
+// tokenClaims はトークンの claims を表す構造体です。
+type tokenClaims struct {
+	Purpose   TokenPurpose `json:"pur"`
+	Email     string       `json:"email,omitempty"`
+	SessionID string       `json:"sid,omitempty"`
+	Version   int          `json:"ver,omitempty"`
+	NonceHash string       `json:"nonce,omitempty"`
+	jwt.RegisteredClaims
+}
+
 // SessionRepository はセッションの repository を表すインターフェースです。
 type SessionRepository interface {
-	GenerateToken(value string) (token string, err error)
-	IsValidToken(token string) (valid bool, value string)
+	GenerateToken(userID, sessionID string, version int) (token string, err error)
+	IssueToken(purpose TokenPurpose, subject, email string) (token string, err error)
+	IssueMagicLinkToken(subject, nonceHash string) (token string, err error)
+	ParseToken(token string, purpose TokenPurpose) (*Token, error)
+	JWKS() ([]JSONWebKey, error)
 }
 
 // SessionRepositoryImpl はセッションの repository の実装を表す構造体です。
+// SigningKeys は署名と検証に使う鍵のセットの JSON で、指定しない場合は SecretKey の HS256 の鍵のみを使います。
 type SessionRepositoryImpl struct {
-	SecretKey     string
-	TokenLifeDays int
+	SecretKey              string
+	SigningKeys            string
+	AccessTokenLifeMinutes int
 }
 
 // NewSessionRepository は SessionRepository を生成します。
-func NewSessionRepository(secretKey string, tokenLifeDays int) SessionRepository {
-	return &SessionRepositoryImpl{SecretKey: secretKey, TokenLifeDays: tokenLifeDays}
+func NewSessionRepository(secretKey, signingKeys string, accessTokenLifeMinutes int) SessionRepository {
+	return &SessionRepositoryImpl{SecretKey: secretKey, SigningKeys: signingKeys, AccessTokenLifeMinutes: accessTokenLifeMinutes}
+}
+
+// GenerateToken はログインセッションのアクセストークンを生成します。
+func (r *SessionRepositoryImpl) GenerateToken(userID, sessionID string, version int) (string, error) {
+	return r.signToken(tokenClaims{Purpose: TokenPurposeSession, SessionID: sessionID, Version: version}, userID)
+}
+
+// IssueToken は用途を指定してトークンを発行します。
+// email はメールアドレス変更のトークンで変更後のメールアドレスを渡す場合のみ指定します。
+func (r *SessionRepositoryImpl) IssueToken(purpose TokenPurpose, subject, email string) (string, error) {
+	return r.signToken(tokenClaims{Purpose: purpose, Email: email}, subject)
+}
+
+// IssueMagicLinkToken はサインインのリンクのトークンを発行します。
+// nonceHash はリンクをリクエストしたブラウザに渡した nonce のハッシュです。
+func (r *SessionRepositoryImpl) IssueMagicLinkToken(subject, nonceHash string) (string, error) {
+	return r.signToken(tokenClaims{Purpose: TokenPurposeMagicLink, NonceHash: nonceHash}, subject)
 }
 
-// GenerateToken はセッショントークンを生成します。
-func (r *SessionRepositoryImpl) GenerateToken(value string) (string, error) {
-	claims := jwt.MapClaims{
-		TokenKeyValue: value,
-		TokenKeyExp:   time.Now().Add(time.Hour * 24 * time.Duration(r.TokenLifeDays)).Unix(),
+// signToken は発行者や有効期限などの claims を設定してトークンに署名します。
+// 鍵のセットで指定した鍵で署名する場合は、ヘッダーに鍵の kid を含めます。
+func (r *SessionRepositoryImpl) signToken(claims tokenClaims, subject string) (string, error) {
+	now := time.Now()
+	set, err := loadKeySet(r.SecretKey, r.SigningKeys)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := set.signing(now)
+	if err != nil {
+		return "", err
+	}
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        id.NewID(),
+		Issuer:    tokenIssuer,
+		Subject:   subject,
+		Audience:  jwt.ClaimStrings{claims.Purpose.Audience()},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(r.lifetime(claims.Purpose))),
 	}
-	jt := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	token, err := jt.SignedString([]byte(r.SecretKey))
+
+	jt := jwt.NewWithClaims(key.method, claims)
+	if key.kid != "" {
+		jt.Header["kid"] = key.kid
+	}
+	token, err := jt.SignedString(key.signKey)
 	if err != nil {
 		return "", err
 	}
@@ -43,44 +169,78 @@ func (r *SessionRepositoryImpl) GenerateToken(value string) (string, error) {
 	return token, nil
 }
 
-// IsValidToken はセッショントークンが有効かどうかを判定します。
-func (r *SessionRepositoryImpl) IsValidToken(token string) (bool, string) {
-	jt, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		return []byte(r.SecretKey), nil
-	})
+// ParseToken はトークンを検証して内容を返します。
+// ヘッダーの kid の鍵で検証し、kid を持たないトークンは SecretKey で検証します。
+// 署名、有効期限、発行者、audience と用途のいずれかが正しくない場合は ErrTokenInvalid を返します。
+func (r *SessionRepositoryImpl) ParseToken(token string, purpose TokenPurpose) (*Token, error) {
+	set, err := loadKeySet(r.SecretKey, r.SigningKeys)
 	if err != nil {
-		return false, ""
+		return nil, ErrTokenInvalid
 	}
 
-	claims, ok := jt.Claims.(jwt.MapClaims)
-	if !ok {
-		return false, ""
+	var claims tokenClaims
+	_, err = jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := set.verification(kid, time.Now())
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		// 別の鍵のアルゴリズムで署名されたトークンを受け付けないようにする
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected algorithm %q", token.Method.Alg())
+		}
+
+		return key.verifyKey, nil
+	},
+		jwt.WithValidMethods(set.algorithms()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithAudience(purpose.Audience()),
+	)
+	if err != nil {
+		return nil, ErrTokenInvalid
 	}
 
-	baseValue, ok := claims[TokenKeyValue]
-	if !ok {
-		return false, ""
+	if claims.Purpose != purpose || claims.Subject == "" || claims.ID == "" {
+		return nil, ErrTokenInvalid
 	}
 
-	value, ok := baseValue.(string)
-	if !ok {
-		return false, ""
-	}
+	return &Token{
+		ID:        claims.ID,
+		Purpose:   claims.Purpose,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		SessionID: claims.SessionID,
+		Version:   claims.Version,
+		NonceHash: claims.NonceHash,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
 
-	baseExp, ok := claims[TokenKeyExp]
-	if !ok {
-		return false, ""
+// JWKS はアクセストークンなどの検証に使える公開鍵の一覧を返します。
+func (r *SessionRepositoryImpl) JWKS() ([]JSONWebKey, error) {
+	set, err := loadKeySet(r.SecretKey, r.SigningKeys)
+	if err != nil {
+		return nil, err
 	}
 
-	exp, ok := baseExp.(float64)
-	if !ok {
-		return false, ""
-	}
+	return set.jwks(time.Now()), nil
+}
 
-	// 有効期限切れ
-	if time.Now().Unix() > int64(exp) {
-		return false, ""
+// lifetime は用途ごとのトークンの有効期間を返します。
+func (r *SessionRepositoryImpl) lifetime(purpose TokenPurpose) time.Duration {
+	switch purpose {
+	case TokenPurposePasswordSet:
+		return passwordSetTokenLifetime
+	case TokenPurposeEmailChange:
+		return emailChangeTokenLifetime
+	case TokenPurposeAccountUnlock:
+		return accountUnlockTokenLifetime
+	case TokenPurposeTwoFactor:
+		return twoFactorTokenLifetime
+	case TokenPurposeMagicLink:
+		return magicLinkTokenLifetime
+	default:
+		return time.Minute * time.Duration(r.AccessTokenLifeMinutes)
 	}
-
-	return jt.Valid, value
 }
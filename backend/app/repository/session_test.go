@@ -6,60 +6,77 @@ import (
 
 	jwt "github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateToken(t *testing.T) {
 	secretKey := "test-secret-key"
 	tokenLifeTime := 1
-	r := NewSessionRepository(secretKey, tokenLifeTime)
+	r := NewSessionRepository(secretKey, "", tokenLifeTime)
 
 	assert := assert.New(t)
 
-	token, err := r.GenerateToken("test-user-id")
+	token, err := r.GenerateToken("test-user-id", "test-session-id", 1)
 	assert.NoError(err)
 	assert.NotEmpty(token)
 }
 
-func TestIsValidToken(t *testing.T) {
-	genToken := func(secretKey string, exp int64, userID string) string {
-		claims := jwt.MapClaims{
-			TokenKeyValue: userID,
-			TokenKeyExp:   exp,
+func TestParseToken(t *testing.T) {
+	genToken := func(secretKey string, purpose TokenPurpose, exp time.Time) string {
+		claims := tokenClaims{
+			Purpose: purpose,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "test-token-id",
+				Issuer:    tokenIssuer,
+				Subject:   "test-user-id",
+				Audience:  jwt.ClaimStrings{purpose.Audience()},
+				ExpiresAt: jwt.NewNumericDate(exp),
+			},
 		}
 		jt := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		token, _ := jt.SignedString([]byte(secretKey))
 
 		return token
 	}
-	exp := time.Now().Add(time.Second * 10).Unix()
+	exp := time.Now().Add(time.Second * 10)
 
 	tests := []struct {
-		name       string
-		secretKey  string
-		token      string
-		wantValid  bool
-		wantUserID string
+		name        string
+		token       string
+		purpose     TokenPurpose
+		wantValid   bool
+		wantSubject string
 	}{
 		{
-			name:       "有効なトークンの場合、有効と判定され正しいユーザーIDが返される",
-			secretKey:  "test-secret-key",
-			token:      genToken("test-secret-key", exp, "test-user-id"),
-			wantValid:  true,
-			wantUserID: "test-user-id",
+			name:        "有効なトークンの場合、有効と判定され正しいユーザーIDが返される",
+			token:       genToken("test-secret-key", TokenPurposeSession, exp),
+			purpose:     TokenPurposeSession,
+			wantValid:   true,
+			wantSubject: "test-user-id",
 		},
 		{
-			name:       "無効なトークンの場合、無効と判定される",
-			secretKey:  "test-secret-key",
-			token:      genToken("test-dummy-secret-key", exp, "test-user-id"),
-			wantValid:  false,
-			wantUserID: "",
+			name:      "無効なトークンの場合、無効と判定される",
+			token:     genToken("test-dummy-secret-key", TokenPurposeSession, exp),
+			purpose:   TokenPurposeSession,
+			wantValid: false,
 		},
 		{
-			name:       "有効期限切れのトークンの場合、無効と判定される",
-			secretKey:  "test-secret-key",
-			token:      genToken("test-secret-key", time.Now().Add(time.Second*-10).Unix(), "test-user-id"),
-			wantValid:  false,
-			wantUserID: "",
+			name:      "有効期限切れのトークンの場合、無効と判定される",
+			token:     genToken("test-secret-key", TokenPurposeSession, time.Now().Add(time.Second*-10)),
+			purpose:   TokenPurposeSession,
+			wantValid: false,
+		},
+		{
+			name:      "パスワード設定のトークンをセッションとして使う場合、無効と判定される",
+			token:     genToken("test-secret-key", TokenPurposePasswordSet, exp),
+			purpose:   TokenPurposeSession,
+			wantValid: false,
+		},
+		{
+			name:      "セッションのトークンをメールアドレス変更に使う場合、無効と判定される",
+			token:     genToken("test-secret-key", TokenPurposeSession, exp),
+			purpose:   TokenPurposeEmailChange,
+			wantValid: false,
 		},
 	}
 
@@ -67,10 +84,51 @@ func TestIsValidToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
 
-			r := NewSessionRepository(tt.secretKey, 1)
-			valid, userID := r.IsValidToken(tt.token)
-			assert.Equal(tt.wantValid, valid)
-			assert.Equal(tt.wantUserID, userID)
+			r := NewSessionRepository("test-secret-key", "", 1)
+			token, err := r.ParseToken(tt.token, tt.purpose)
+			if !tt.wantValid {
+				assert.ErrorIs(err, ErrTokenInvalid)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.wantSubject, token.Subject)
 		})
 	}
 }
+
+func TestIssueToken(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	r := NewSessionRepository("test-secret-key", "", 1)
+	issued, err := r.IssueToken(TokenPurposeEmailChange, "test-user-id", "test-new-email@example.com")
+	require.NoError(err)
+
+	token, err := r.ParseToken(issued, TokenPurposeEmailChange)
+	require.NoError(err)
+	assert.NotEmpty(token.ID)
+	assert.Equal(TokenPurposeEmailChange, token.Purpose)
+	assert.Equal("test-user-id", token.Subject)
+	assert.Equal("test-new-email@example.com", token.Email)
+	assert.WithinDuration(time.Now().Add(emailChangeTokenLifetime), token.ExpiresAt, time.Minute)
+}
+
+func TestIssueMagicLinkToken(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	r := NewSessionRepository("test-secret-key", "", 1)
+	issued, err := r.IssueMagicLinkToken("test-user-id", "test-nonce-hash")
+	require.NoError(err)
+
+	token, err := r.ParseToken(issued, TokenPurposeMagicLink)
+	require.NoError(err)
+	assert.Equal(TokenPurposeMagicLink, token.Purpose)
+	assert.Equal("test-user-id", token.Subject)
+	assert.Equal("test-nonce-hash", token.NonceHash)
+	assert.WithinDuration(time.Now().Add(magicLinkTokenLifetime), token.ExpiresAt, time.Minute)
+
+	_, err = r.ParseToken(issued, TokenPurposeSession)
+	assert.ErrorIs(err, ErrTokenInvalid)
+}
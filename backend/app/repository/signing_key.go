@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// 署名のアルゴリズムです。
+const (
+	SigningAlgorithmHS256 = "HS256"
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+// minHMACKeyBytes は鍵のセットで指定する HS256 の鍵の最小のバイト数です。
+const minHMACKeyBytes = 32
+
+// JSONWebKey は JWKS で公開する公開鍵を表す構造体です。
+type JSONWebKey struct {
+	KeyType   string
+	Curve     string
+	Algorithm string
+	Use       string
+	KeyID     string
+	N         string
+	E         string
+	X         string
+}
+
+// signingKeyConfig は SESSION_SIGNING_KEYS で指定する鍵の1件を表す構造体です。
+// SignFrom を指定した鍵はその日時から署名に使い、指定しない鍵は検証のみに使います。
+// VerifyUntil を指定した鍵はその日時を過ぎると検証にも使いません。
+type signingKeyConfig struct {
+	KID         string     `json:"kid"`
+	Algorithm   string     `json:"alg"`
+	Key         string     `json:"key"`
+	SignFrom    *time.Time `json:"sign_from,omitempty"`
+	VerifyUntil *time.Time `json:"verify_until,omitempty"`
+}
+
+// signingKey は署名と検証に使う鍵を表す構造体です。
+// 公開鍵のみを指定した鍵は signKey が nil で、検証のみに使います。
+type signingKey struct {
+	kid         string
+	method      jwt.SigningMethod
+	signKey     interface{}
+	verifyKey   interface{}
+	canSign     bool
+	signFrom    time.Time
+	verifyUntil time.Time
+}
+
+// keySet は署名と検証に使う鍵のセットを表す構造体です。
+type keySet struct {
+	keys []signingKey
+}
+
+// keySetCache は解析した鍵のセットを設定ごとに保持します。
+// Lambda の実行環境が再利用される間は PEM の解析を繰り返さないようにします。
+var keySetCache sync.Map
+
+// keySetEntry は keySetCache に保持する解析の結果です。
+type keySetEntry struct {
+	set *keySet
+	err error
+}
+
+// loadKeySet は設定から鍵のセットを取得します。
+func loadKeySet(secretKey, signingKeys string) (*keySet, error) {
+	cacheKey := secretKey + "\x00" + signingKeys
+	if e, ok := keySetCache.Load(cacheKey); ok {
+		entry := e.(keySetEntry)
+		return entry.set, entry.err
+	}
+
+	set, err := parseKeySet(secretKey, signingKeys)
+	keySetCache.Store(cacheKey, keySetEntry{set: set, err: err})
+	return set, err
+}
+
+// parseKeySet は設定から鍵のセットを生成します。
+// secretKey は kid を持たない HS256 の鍵で、signingKeys のいずれかの鍵が署名を開始するまでは署名にも使います。
+func parseKeySet(secretKey, signingKeys string) (*keySet, error) {
+	set := &keySet{}
+	if secretKey != "" {
+		set.keys = append(set.keys, signingKey{
+			method:    jwt.SigningMethodHS256,
+			signKey:   []byte(secretKey),
+			verifyKey: []byte(secretKey),
+			canSign:   true,
+		})
+	}
+
+	if signingKeys == "" {
+		return set, nil
+	}
+
+	var configs []signingKeyConfig
+	if err := json.Unmarshal([]byte(signingKeys), &configs); err != nil {
+		return nil, fmt.Errorf("signing keys are not valid json: %w", err)
+	}
+
+	kids := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if c.KID == "" {
+			return nil, fmt.Errorf("signing key kid is required")
+		}
+		if kids[c.KID] {
+			return nil, fmt.Errorf("signing key kid %q is duplicated", c.KID)
+		}
+		kids[c.KID] = true
+
+		key, err := parseSigningKey(c)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: %w", c.KID, err)
+		}
+		set.keys = append(set.keys, *key)
+	}
+
+	return set, nil
+}
+
+// parseSigningKey は設定の1件から鍵を生成します。
+func parseSigningKey(c signingKeyConfig) (*signingKey, error) {
+	key := &signingKey{kid: c.KID}
+	if c.SignFrom != nil {
+		key.canSign = true
+		key.signFrom = *c.SignFrom
+	}
+	if c.VerifyUntil != nil {
+		key.verifyUntil = *c.VerifyUntil
+	}
+
+	switch c.Algorithm {
+	case SigningAlgorithmHS256:
+		if len(c.Key) < minHMACKeyBytes {
+			return nil, fmt.Errorf("HS256 key must be at least %d bytes", minHMACKeyBytes)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(c.Key)
+		key.verifyKey = []byte(c.Key)
+	case SigningAlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+		if err := key.parsePEM(c.Key); err != nil {
+			return nil, err
+		}
+		if _, ok := key.verifyKey.(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("RS256 key must be an RSA key")
+		}
+	case SigningAlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		if err := key.parsePEM(c.Key); err != nil {
+			return nil, err
+		}
+		if _, ok := key.verifyKey.(ed25519.PublicKey); !ok {
+			return nil, fmt.Errorf("EdDSA key must be an Ed25519 key")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", c.Algorithm)
+	}
+
+	if key.canSign && key.signKey == nil {
+		return nil, fmt.Errorf("public key cannot be used for signing")
+	}
+
+	return key, nil
+}
+
+// parsePEM は PEM の秘密鍵もしくは公開鍵を読み込みます。
+func (k *signingKey) parsePEM(s string) error {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return fmt.Errorf("key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		switch p := priv.(type) {
+		case *rsa.PrivateKey:
+			k.signKey, k.verifyKey = p, &p.PublicKey
+		case ed25519.PrivateKey:
+			k.signKey, k.verifyKey = p, p.Public()
+		default:
+			return fmt.Errorf("unsupported private key type %T", priv)
+		}
+	case "RSA PRIVATE KEY":
+		p, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		k.signKey, k.verifyKey = p, &p.PublicKey
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		k.verifyKey = pub
+	default:
+		return fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	return nil
+}
+
+// verifiable は now の時点で検証に使える鍵かどうかを返します。
+func (k *signingKey) verifiable(now time.Time) bool {
+	return k.verifyUntil.IsZero() || now.Before(k.verifyUntil)
+}
+
+// signing は now の時点で署名に使う鍵を返します。
+// 署名を開始している鍵のうち、署名の開始日時が最も新しい鍵を使います。
+func (s *keySet) signing(now time.Time) (*signingKey, error) {
+	var found *signingKey
+	for i := range s.keys {
+		k := &s.keys[i]
+		if !k.canSign || k.signFrom.After(now) || !k.verifiable(now) {
+			continue
+		}
+		if found == nil || k.signFrom.After(found.signFrom) {
+			found = k
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no signing key is available")
+	}
+
+	return found, nil
+}
+
+// verification は kid の鍵を返します。kid が空の場合は kid を持たない秘密鍵を返します。
+func (s *keySet) verification(kid string, now time.Time) (*signingKey, bool) {
+	for i := range s.keys {
+		k := &s.keys[i]
+		if k.kid == kid && k.verifiable(now) {
+			return k, true
+		}
+	}
+
+	return nil, false
+}
+
+// algorithms は鍵のセットで使うアルゴリズムの一覧を返します。
+func (s *keySet) algorithms() []string {
+	var algs []string
+	seen := map[string]bool{}
+	for _, k := range s.keys {
+		alg := k.method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+
+	return algs
+}
+
+// jwks は now の時点で検証に使える公開鍵の一覧を返します。HS256 の鍵は公開しません。
+func (s *keySet) jwks(now time.Time) []JSONWebKey {
+	keys := []JSONWebKey{}
+	for _, k := range s.keys {
+		if !k.verifiable(now) {
+			continue
+		}
+
+		switch pub := k.verifyKey.(type) {
+		case *rsa.PublicKey:
+			keys = append(keys, JSONWebKey{
+				KeyType:   "RSA",
+				Algorithm: k.method.Alg(),
+				Use:       "sig",
+				KeyID:     k.kid,
+				N:         base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			keys = append(keys, JSONWebKey{
+				KeyType:   "OKP",
+				Curve:     "Ed25519",
+				Algorithm: k.method.Alg(),
+				Use:       "sig",
+				KeyID:     k.kid,
+				X:         base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+
+	return keys
+}
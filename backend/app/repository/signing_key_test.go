@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeySet(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPrivPEM := testPrivateKeyPEM(t, edPriv)
+	edPubPEM := testPublicKeyPEM(t, edPub)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		keys    []signingKeyConfig
+		raw     string
+		wantErr bool
+	}{
+		{
+			name: "正しい設定の場合、鍵のセットが生成される",
+			keys: []signingKeyConfig{
+				{KID: "test-hs", Algorithm: SigningAlgorithmHS256, Key: strings.Repeat("k", minHMACKeyBytes)},
+				{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: edPrivPEM, SignFrom: &past},
+				{KID: "test-ed-pub", Algorithm: SigningAlgorithmEdDSA, Key: edPubPEM},
+			},
+		},
+		{name: "JSON でない場合、エラーになる", raw: "test-invalid", wantErr: true},
+		{
+			name:    "kid がない場合、エラーになる",
+			keys:    []signingKeyConfig{{Algorithm: SigningAlgorithmEdDSA, Key: edPrivPEM}},
+			wantErr: true,
+		},
+		{
+			name: "kid が重複する場合、エラーになる",
+			keys: []signingKeyConfig{
+				{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: edPrivPEM},
+				{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: edPrivPEM},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "対応していないアルゴリズムの場合、エラーになる",
+			keys:    []signingKeyConfig{{KID: "test-es", Algorithm: "ES256", Key: edPrivPEM}},
+			wantErr: true,
+		},
+		{
+			name:    "HS256 の鍵が短い場合、エラーになる",
+			keys:    []signingKeyConfig{{KID: "test-hs", Algorithm: SigningAlgorithmHS256, Key: "test-short-key"}},
+			wantErr: true,
+		},
+		{
+			name:    "アルゴリズムと鍵の種類が異なる場合、エラーになる",
+			keys:    []signingKeyConfig{{KID: "test-rs", Algorithm: SigningAlgorithmRS256, Key: edPrivPEM}},
+			wantErr: true,
+		},
+		{
+			name:    "公開鍵で署名する設定の場合、エラーになる",
+			keys:    []signingKeyConfig{{KID: "test-ed-pub", Algorithm: SigningAlgorithmEdDSA, Key: edPubPEM, SignFrom: &past}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := tt.raw
+			if raw == "" {
+				raw = testSigningKeys(t, tt.keys...)
+			}
+
+			set, err := parseKeySet("test-secret-key", raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, set.keys, len(tt.keys)+1)
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	// 鍵のセットを指定する前に発行したトークン
+	legacy, err := NewSessionRepository("test-secret-key", "", 1).GenerateToken("test-user-id", "test-session-id", 1)
+	require.NoError(err)
+
+	// 1段階目: 新しい鍵を検証のみに追加し、署名の開始日時まではこれまでの鍵で署名する
+	staged := NewSessionRepository("test-secret-key", testSigningKeys(t,
+		signingKeyConfig{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: testPrivateKeyPEM(t, edPriv), SignFrom: &future},
+	), 1)
+	token, err := staged.GenerateToken("test-user-id", "test-session-id", 1)
+	require.NoError(err)
+	assert.Equal("", testTokenHeader(t, token, "kid"))
+	_, err = staged.ParseToken(legacy, TokenPurposeSession)
+	assert.NoError(err)
+
+	// 2段階目: 新しい鍵で署名し、これまでの鍵は検証のみに使う
+	rotated := NewSessionRepository("test-secret-key", testSigningKeys(t,
+		signingKeyConfig{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: testPrivateKeyPEM(t, edPriv), SignFrom: &past},
+		signingKeyConfig{KID: "test-rs", Algorithm: SigningAlgorithmRS256, Key: testPrivateKeyPEM(t, rsaPriv)},
+	), 1)
+	token, err = rotated.GenerateToken("test-user-id", "test-session-id", 1)
+	require.NoError(err)
+	assert.Equal("test-ed", testTokenHeader(t, token, "kid"))
+	assert.Equal(SigningAlgorithmEdDSA, testTokenHeader(t, token, "alg"))
+
+	parsed, err := rotated.ParseToken(token, TokenPurposeSession)
+	require.NoError(err)
+	assert.Equal("test-user-id", parsed.Subject)
+	_, err = rotated.ParseToken(legacy, TokenPurposeSession)
+	assert.NoError(err)
+
+	// 3段階目: 検証の期限を過ぎた鍵のトークンは受け付けない
+	retired := NewSessionRepository("", testSigningKeys(t,
+		signingKeyConfig{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: testPrivateKeyPEM(t, edPriv), VerifyUntil: &past},
+		signingKeyConfig{KID: "test-rs", Algorithm: SigningAlgorithmRS256, Key: testPrivateKeyPEM(t, rsaPriv), SignFrom: &past},
+	), 1)
+	_, err = retired.ParseToken(token, TokenPurposeSession)
+	assert.ErrorIs(err, ErrTokenInvalid)
+	_, err = retired.ParseToken(legacy, TokenPurposeSession)
+	assert.ErrorIs(err, ErrTokenInvalid)
+
+	token, err = retired.GenerateToken("test-user-id", "test-session-id", 1)
+	require.NoError(err)
+	assert.Equal("test-rs", testTokenHeader(t, token, "kid"))
+	_, err = retired.ParseToken(token, TokenPurposeSession)
+	assert.NoError(err)
+}
+
+func TestKeySetRejectsAlgorithmMismatch(t *testing.T) {
+	require := require.New(t)
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	r := NewSessionRepository("test-secret-key", testSigningKeys(t,
+		signingKeyConfig{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: testPublicKeyPEM(t, edPub)},
+	), 1)
+
+	// 検証のみの鍵の kid を指定して HS256 で署名したトークン
+	claims := tokenClaims{
+		Purpose: TokenPurposeSession,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "test-token-id",
+			Issuer:    tokenIssuer,
+			Subject:   "test-user-id",
+			Audience:  jwt.ClaimStrings{TokenPurposeSession.Audience()},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	jt := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	jt.Header["kid"] = "test-ed"
+	token, err := jt.SignedString([]byte("test-secret-key"))
+	require.NoError(err)
+
+	_, err = r.ParseToken(token, TokenPurposeSession)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+}
+
+func TestJWKS(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	past := time.Now().Add(-time.Hour)
+
+	r := NewSessionRepository("test-secret-key", testSigningKeys(t,
+		signingKeyConfig{KID: "test-hs", Algorithm: SigningAlgorithmHS256, Key: strings.Repeat("k", minHMACKeyBytes)},
+		signingKeyConfig{KID: "test-ed", Algorithm: SigningAlgorithmEdDSA, Key: testPrivateKeyPEM(t, edPriv), SignFrom: &past},
+		signingKeyConfig{KID: "test-rs", Algorithm: SigningAlgorithmRS256, Key: testPrivateKeyPEM(t, rsaPriv)},
+		signingKeyConfig{KID: "test-retired", Algorithm: SigningAlgorithmRS256, Key: testPrivateKeyPEM(t, rsaPriv), VerifyUntil: &past},
+	), 1)
+
+	keys, err := r.JWKS()
+	require.NoError(err)
+	require.Len(keys, 2)
+
+	assert.Equal("test-ed", keys[0].KeyID)
+	assert.Equal("OKP", keys[0].KeyType)
+	assert.Equal("Ed25519", keys[0].Curve)
+	assert.Equal(SigningAlgorithmEdDSA, keys[0].Algorithm)
+	assert.Equal("sig", keys[0].Use)
+	assert.Equal(base64.RawURLEncoding.EncodeToString(edPub), keys[0].X)
+
+	assert.Equal("test-rs", keys[1].KeyID)
+	assert.Equal("RSA", keys[1].KeyType)
+	assert.Equal(SigningAlgorithmRS256, keys[1].Algorithm)
+	assert.NotEmpty(keys[1].N)
+	assert.Equal("AQAB", keys[1].E)
+}
+
+func testSigningKeys(t *testing.T, keys ...signingKeyConfig) string {
+	t.Helper()
+	b, err := json.Marshal(keys)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func testPrivateKeyPEM(t *testing.T, key interface{}) string {
+	t.Helper()
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}))
+}
+
+func testPublicKeyPEM(t *testing.T, key interface{}) string {
+	t.Helper()
+	b, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+}
+
+func testTokenHeader(t *testing.T, token, name string) string {
+	t.Helper()
+	jt, _, err := jwt.NewParser().ParseUnverified(token, &tokenClaims{})
+	require.NoError(t, err)
+	v, _ := jt.Header[name].(string)
+	return v
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const tagTableName = "AttendancePlan_Tag"
+
+// TagRepository はタグの repository を表すインターフェースです。
+type TagRepository interface {
+	Read(id string) (*model.Tag, error)
+	ReadByUserID(userID string) ([]model.Tag, error)
+	Create(tag *model.Tag) error
+	Update(tag *model.Tag) error
+	Delete(id string) error
+}
+
+// TagRepositoryImpl はタグの repository の実装を表す構造体です。
+type TagRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewTagRepository は TagRepository を生成します。
+func NewTagRepository(db dynamo.DB) TagRepository {
+	return &TagRepositoryImpl{DB: db, Table: db.Table(tagTableName)}
+}
+
+// Read は指定された ID のタグを取得します。
+func (r *TagRepositoryImpl) Read(id string) (*model.Tag, error) {
+	var tag *model.Tag
+	err := r.Table.Get("ID", id).One(&tag)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return tag, nil
+}
+
+// ReadByUserID は指定されたユーザー ID のタグを名前の昇順で取得します。
+func (r *TagRepositoryImpl) ReadByUserID(userID string) ([]model.Tag, error) {
+	tags := []model.Tag{}
+	err := r.Table.Get("UserID", userID).Index("UserID-index").Order(dynamo.Ascending).All(&tags)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Create はタグを保存します。
+func (r *TagRepositoryImpl) Create(tag *model.Tag) error {
+	return r.Table.Put(tag).Run()
+}
+
+// Update はタグを更新します。
+func (r *TagRepositoryImpl) Update(tag *model.Tag) error {
+	return r.Table.Put(tag).Run()
+}
+
+// Delete は指定された ID のタグを削除します。
+func (r *TagRepositoryImpl) Delete(id string) error {
+	return r.Table.Delete("ID", id).Run()
+}
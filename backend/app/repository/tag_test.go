@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTagSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(tagTableName)
+
+	var tags []model.Tag
+	err := table.Scan().All(&tags)
+	require.NoError(err)
+
+	for _, tag := range tags {
+		err := table.Delete("ID", tag.ID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestTag_ReadByUserID(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tags := []model.Tag{
+		{ID: "test-tag-2", UserID: "test-user-a", Name: "b-name", Color: "red", CreatedAt: date, UpdatedAt: date},
+		{ID: "test-tag-1", UserID: "test-user-a", Name: "a-name", Color: "blue", CreatedAt: date, UpdatedAt: date},
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		data   []model.Tag
+		want   []string
+	}{
+		{name: "0件取得", userID: "test-user-a", data: []model.Tag{}, want: []string{}},
+		{name: "名前の昇順で取得", userID: "test-user-a", data: tags, want: []string{"test-tag-1", "test-tag-2"}},
+		{name: "異なるユーザーID", userID: "test-user-b", data: tags, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			db, table, err := testTagSetup(t)
+			require.NoError(err)
+
+			for _, tag := range tt.data {
+				require.NoError(table.Put(tag).Run())
+			}
+
+			repo := NewTagRepository(*db)
+			got, err := repo.ReadByUserID(tt.userID)
+			require.NoError(err)
+
+			ids := []string{}
+			for _, tag := range got {
+				ids = append(ids, tag.ID)
+			}
+			assert.Equal(tt.want, ids)
+		})
+	}
+}
+
+func TestTag_Delete(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, table, err := testTagSetup(t)
+	require.NoError(err)
+
+	tag := model.Tag{ID: "test-tag", UserID: "test-user-a", Name: "test-name", Color: "red"}
+	require.NoError(table.Put(tag).Run())
+
+	repo := NewTagRepository(*db)
+	require.NoError(repo.Delete("test-tag"))
+
+	_, err = repo.Read("test-tag")
+	assert.True(IsNotFoundError(err))
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/guregu/dynamo"
+)
+
+const twoFactorTableName = "AttendancePlan_TwoFactor"
+
+var (
+	// ErrTwoFactorCodeUsed は二段階認証の認証コードやリカバリーコードがすでに使われている場合のエラーです。
+	ErrTwoFactorCodeUsed = errors.New("two factor code is already used")
+	// ErrTwoFactorAlreadyEnabled は二段階認証がすでに有効な場合のエラーです。
+	ErrTwoFactorAlreadyEnabled = errors.New("two factor is already enabled")
+)
+
+// TwoFactorRepository は二段階認証の設定の repository を表すインターフェースです。
+type TwoFactorRepository interface {
+	Read(userID string) (*model.TwoFactor, error)
+	Create(twoFactor *model.TwoFactor) error
+	Activate(twoFactor *model.TwoFactor) error
+	UseStep(userID string, step int64, now time.Time) error
+	UseRecoveryCode(twoFactor *model.TwoFactor, index int, now time.Time) error
+	Delete(userID string) error
+}
+
+// TwoFactorRepositoryImpl は二段階認証の設定の repository の実装を表す構造体です。
+type TwoFactorRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// NewTwoFactorRepository は TwoFactorRepository を生成します。
+func NewTwoFactorRepository(db dynamo.DB) TwoFactorRepository {
+	return &TwoFactorRepositoryImpl{DB: db, Table: db.Table(twoFactorTableName)}
+}
+
+// Read は指定されたユーザーの二段階認証の設定を取得します。
+func (r *TwoFactorRepositoryImpl) Read(userID string) (*model.TwoFactor, error) {
+	var tf *model.TwoFactor
+	err := r.Table.Get("UserID", userID).One(&tf)
+	if err != nil {
+		if errors.Is(err, dynamo.ErrNotFound) {
+			return nil, NewNotFoundError()
+		}
+
+		return nil, err
+	}
+	return tf, nil
+}
+
+// Create は確認前の二段階認証の設定を保存します。
+// 確認前の設定がある場合は置き換え、有効な設定がある場合は ErrTwoFactorAlreadyEnabled を返します。
+func (r *TwoFactorRepositoryImpl) Create(twoFactor *model.TwoFactor) error {
+	err := r.Table.Put(twoFactor).If("attribute_not_exists('UserID') OR 'Enabled' = ?", false).Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrTwoFactorAlreadyEnabled
+		}
+		return err
+	}
+	return nil
+}
+
+// Activate は確認した二段階認証の設定を有効にします。
+// 同時に確認された場合に1回のみ成功するよう、確認前の場合のみ更新し、それ以外は ErrTwoFactorCodeUsed を返します。
+func (r *TwoFactorRepositoryImpl) Activate(twoFactor *model.TwoFactor) error {
+	err := r.Table.Put(twoFactor).If("'Secret' = ? AND 'Enabled' = ?", twoFactor.Secret, false).Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrTwoFactorCodeUsed
+		}
+		return err
+	}
+	return nil
+}
+
+// UseStep は認証コードの間隔の番号を使用済みとして記録します。
+// 同じ間隔かそれより前の間隔の認証コードがすでに使われている場合は ErrTwoFactorCodeUsed を返します。
+func (r *TwoFactorRepositoryImpl) UseStep(userID string, step int64, now time.Time) error {
+	err := r.Table.Update("UserID", userID).
+		Set("LastUsedStep", step).
+		Set("UpdatedAt", now).
+		If("'Enabled' = ? AND 'LastUsedStep' < ?", true, step).
+		Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrTwoFactorCodeUsed
+		}
+		return err
+	}
+	return nil
+}
+
+// UseRecoveryCode は index の位置のリカバリーコードを使用済みとして削除します。
+// 同時に使われて削除済みの場合は ErrTwoFactorCodeUsed を返します。
+func (r *TwoFactorRepositoryImpl) UseRecoveryCode(twoFactor *model.TwoFactor, index int, now time.Time) error {
+	err := r.Table.Update("UserID", twoFactor.UserID).
+		RemoveExpr(fmt.Sprintf("'RecoveryCodes'[%d]", index)).
+		Set("UpdatedAt", now).
+		If(fmt.Sprintf("'Enabled' = ? AND 'RecoveryCodes'[%d] = ?", index), true, twoFactor.RecoveryCodes[index]).
+		Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrTwoFactorCodeUsed
+		}
+		return err
+	}
+
+	twoFactor.RecoveryCodes = append(twoFactor.RecoveryCodes[:index:index], twoFactor.RecoveryCodes[index+1:]...)
+	twoFactor.UpdatedAt = now
+	return nil
+}
+
+// Delete は指定されたユーザーの二段階認証の設定を削除します。
+func (r *TwoFactorRepositoryImpl) Delete(userID string) error {
+	return r.Table.Delete("UserID", userID).Run()
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+	"github.com/guregu/dynamo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTwoFactorSetup(t *testing.T) (*dynamo.DB, *dynamo.Table, error) {
+	t.Helper()
+
+	require := require.New(t)
+
+	db := infrastructure.NewDB()
+	require.NotNil(db)
+
+	table := db.Table(twoFactorTableName)
+
+	var twoFactors []model.TwoFactor
+	err := table.Scan().All(&twoFactors)
+	require.NoError(err)
+
+	for _, tf := range twoFactors {
+		err := table.Delete("UserID", tf.UserID).Run()
+		require.NoError(err)
+	}
+
+	return db, &table, nil
+}
+
+func TestTwoFactor_Activate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testTwoFactorSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewTwoFactorRepository(*db)
+
+	_, err = repo.Read("test-user-id")
+	assert.True(IsNotFoundError(err))
+
+	tf, err := model.NewTwoFactor("test-user-id", now)
+	require.NoError(err)
+	require.NoError(repo.Create(tf))
+
+	// 確認前の設定は発行し直せる
+	tf, err = model.NewTwoFactor("test-user-id", now)
+	require.NoError(err)
+	require.NoError(repo.Create(tf))
+
+	stale := *tf
+	_, err = tf.Activate(100, now)
+	require.NoError(err)
+	require.NoError(repo.Activate(tf))
+
+	// 有効にした後は設定を開始し直せず、同時に確認された場合も1回のみ有効にする
+	assert.ErrorIs(repo.Create(&stale), ErrTwoFactorAlreadyEnabled)
+	_, err = stale.Activate(100, now)
+	require.NoError(err)
+	assert.ErrorIs(repo.Activate(&stale), ErrTwoFactorCodeUsed)
+
+	got, err := repo.Read("test-user-id")
+	require.NoError(err)
+	assert.True(got.Enabled)
+	assert.Equal(tf.RecoveryCodes, got.RecoveryCodes)
+
+	require.NoError(repo.Delete("test-user-id"))
+	_, err = repo.Read("test-user-id")
+	assert.True(IsNotFoundError(err))
+}
+
+func TestTwoFactor_UseStep(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testTwoFactorSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewTwoFactorRepository(*db)
+
+	tf, err := model.NewTwoFactor("test-user-id", now)
+	require.NoError(err)
+	require.NoError(repo.Create(tf))
+	_, err = tf.Activate(100, now)
+	require.NoError(err)
+	require.NoError(repo.Activate(tf))
+
+	require.NoError(repo.UseStep("test-user-id", 101, now))
+	assert.ErrorIs(repo.UseStep("test-user-id", 101, now), ErrTwoFactorCodeUsed)
+	assert.ErrorIs(repo.UseStep("test-user-id", 100, now), ErrTwoFactorCodeUsed)
+
+	got, err := repo.Read("test-user-id")
+	require.NoError(err)
+	assert.Equal(int64(101), got.LastUsedStep)
+}
+
+func TestTwoFactor_UseRecoveryCode(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	db, _, err := testTwoFactorSetup(t)
+	require.NoError(err)
+
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewTwoFactorRepository(*db)
+
+	tf, err := model.NewTwoFactor("test-user-id", now)
+	require.NoError(err)
+	require.NoError(repo.Create(tf))
+	codes, err := tf.Activate(100, now)
+	require.NoError(err)
+	require.NoError(repo.Activate(tf))
+
+	first, err := repo.Read("test-user-id")
+	require.NoError(err)
+	second, err := repo.Read("test-user-id")
+	require.NoError(err)
+
+	require.NoError(repo.UseRecoveryCode(first, first.RecoveryCodeIndex(codes[0]), now))
+	assert.Len(first.RecoveryCodes, model.RecoveryCodeCount-1)
+
+	// 同時に同じリカバリーコードが使われた場合は1回のみ成功する
+	assert.ErrorIs(repo.UseRecoveryCode(second, second.RecoveryCodeIndex(codes[0]), now), ErrTwoFactorCodeUsed)
+
+	got, err := repo.Read("test-user-id")
+	require.NoError(err)
+	assert.Equal(first.RecoveryCodes, got.RecoveryCodes)
+	assert.Equal(-1, got.RecoveryCodeIndex(codes[0]))
+}
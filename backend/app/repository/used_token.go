@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const usedTokenTableName = "AttendancePlan_UsedToken"
+
+// ErrTokenAlreadyUsed は1回のみ使えるトークンがすでに使われている場合のエラーです。
+var ErrTokenAlreadyUsed = errors.New("token is already used")
+
+// UsedTokenRepository は使用済みのトークンの repository を表すインターフェースです。
+type UsedTokenRepository interface {
+	Consume(token *Token) error
+}
+
+// UsedTokenRepositoryImpl は使用済みのトークンの repository の実装を表す構造体です。
+type UsedTokenRepositoryImpl struct {
+	DB    dynamo.DB
+	Table dynamo.Table
+}
+
+// usedToken は使用済みのトークンの記録を表す構造体です。
+// ExpiresAt は DynamoDB の TTL で有効期限が切れた記録を削除するために UNIX 時間で保存します。
+type usedToken struct {
+	ID        string
+	Purpose   TokenPurpose
+	Subject   string
+	UsedAt    time.Time
+	ExpiresAt int64
+}
+
+// NewUsedTokenRepository は UsedTokenRepository を生成します。
+func NewUsedTokenRepository(db dynamo.DB) UsedTokenRepository {
+	return &UsedTokenRepositoryImpl{DB: db, Table: db.Table(usedTokenTableName)}
+}
+
+// Consume はトークンを使用済みとして記録します。
+// すでに使用済みの場合は ErrTokenAlreadyUsed を返します。
+func (r *UsedTokenRepositoryImpl) Consume(token *Token) error {
+	ut := usedToken{
+		ID:        token.ID,
+		Purpose:   token.Purpose,
+		Subject:   token.Subject,
+		UsedAt:    time.Now(),
+		ExpiresAt: token.ExpiresAt.Unix(),
+	}
+
+	err := r.Table.Put(ut).If("attribute_not_exists('ID')").Run()
+	if err != nil {
+		if dynamo.IsCondCheckFailed(err) {
+			return ErrTokenAlreadyUsed
+		}
+		return err
+	}
+	return nil
+}
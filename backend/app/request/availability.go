@@ -0,0 +1,186 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+)
+
+// GetAvailabilityRequest は受講可能日の設定取得のリクエストを表す構造体です。
+type GetAvailabilityRequest struct {
+	UserID string
+}
+
+// PutAvailabilityRequest は受講可能日の設定更新のリクエストを表す構造体です。
+type PutAvailabilityRequest struct {
+	UserID            string `json:"-"`
+	Weekdays          []int  `json:"weekdays"`
+	MaxLecturesPerDay int    `json:"max_lectures_per_day"`
+}
+
+// PostBlackoutRequest は受講できない期間登録のリクエストを表す構造体です。
+type PostBlackoutRequest struct {
+	Label    string `json:"label"`
+	StartsAt string `json:"starts_at"`
+	EndsAt   string `json:"ends_at"`
+}
+
+// PutBlackoutRequest は受講できない期間更新のリクエストを表す構造体です。
+type PutBlackoutRequest struct {
+	BlackoutID string `json:"id"`
+	Label      string `json:"label"`
+	StartsAt   string `json:"starts_at"`
+	EndsAt     string `json:"ends_at"`
+}
+
+// DeleteBlackoutRequest は受講できない期間削除のリクエストを表す構造体です。
+type DeleteBlackoutRequest struct {
+	BlackoutID string
+}
+
+// ToGetAvailabilityRequest は APIGatewayProxyRequest から GetAvailabilityRequest に変換します。
+func ToGetAvailabilityRequest(r events.APIGatewayProxyRequest) *GetAvailabilityRequest {
+	return &GetAvailabilityRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateGetAvailabilityRequest は GetAvailabilityRequest のバリデーションを行います。
+func ValidateGetAvailabilityRequest(req *GetAvailabilityRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDを指定してください")
+	}
+	return nil
+}
+
+// ToPutAvailabilityRequest は APIGatewayProxyRequest から PutAvailabilityRequest に変換します。
+func ToPutAvailabilityRequest(r events.APIGatewayProxyRequest) (*PutAvailabilityRequest, error) {
+	var req PutAvailabilityRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.UserID = r.PathParameters["user_id"]
+
+	return &req, nil
+}
+
+// ValidatePutAvailabilityRequest は PutAvailabilityRequest のバリデーションを行います。
+func ValidatePutAvailabilityRequest(req *PutAvailabilityRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDを指定してください")
+	}
+
+	// weekdays は 0（日曜日）～6（土曜日）の重複しない値
+	seen := make(map[int]bool)
+	for _, w := range req.Weekdays {
+		if w < int(time.Sunday) || w > int(time.Saturday) {
+			return fmt.Errorf("曜日は %d～%d の範囲で指定してください", time.Sunday, time.Saturday)
+		}
+		if seen[w] {
+			return fmt.Errorf("曜日が重複しています")
+		}
+		seen[w] = true
+	}
+
+	// max_lectures_per_day は 0（上限なし）以上
+	const upperMaxLecturesPerDay = 20
+	if req.MaxLecturesPerDay < 0 || req.MaxLecturesPerDay > upperMaxLecturesPerDay {
+		return fmt.Errorf("1日あたりの受講上限は0～%d件で指定してください", upperMaxLecturesPerDay)
+	}
+
+	return nil
+}
+
+// ValidateInputBlackoutRequest は受講できない期間の入力に対するバリデーションを行います。
+func ValidateInputBlackoutRequest(label, startsAt, endsAt string) error {
+	// label が空文字
+	if label == "" {
+		return fmt.Errorf("ラベルを入力してください")
+	}
+
+	// label が50文字より多い
+	const upperLabelLength = 50
+	if utf8.RuneCountInString(label) > upperLabelLength {
+		return fmt.Errorf("ラベルは%d文字以内で入力してください", upperLabelLength)
+	}
+
+	// starts_at が空文字
+	if startsAt == "" {
+		return fmt.Errorf("開始日を入力してください")
+	}
+
+	// ends_at が空文字
+	if endsAt == "" {
+		return fmt.Errorf("終了日を入力してください")
+	}
+
+	// starts_at のフォーマットが正しくない
+	sa, err := time.Parse(model.DateFormat, startsAt)
+	if err != nil {
+		return fmt.Errorf("開始日は yyyy-MM-dd の形式で入力してください")
+	}
+
+	// ends_at のフォーマットが正しくない
+	ea, err := time.Parse(model.DateFormat, endsAt)
+	if err != nil {
+		return fmt.Errorf("終了日は yyyy-MM-dd の形式で入力してください")
+	}
+
+	// starts_at が ends_at より後
+	if sa.After(ea) {
+		return fmt.Errorf("終了日は開始日以降の日付を入力してください")
+	}
+
+	return nil
+}
+
+// ToPostBlackoutRequest は APIGatewayProxyRequest から PostBlackoutRequest に変換します。
+func ToPostBlackoutRequest(r events.APIGatewayProxyRequest) (*PostBlackoutRequest, error) {
+	var req PostBlackoutRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ValidatePostBlackoutRequest は PostBlackoutRequest のバリデーションを行います。
+func ValidatePostBlackoutRequest(req *PostBlackoutRequest) error {
+	return ValidateInputBlackoutRequest(req.Label, req.StartsAt, req.EndsAt)
+}
+
+// ToPutBlackoutRequest は APIGatewayProxyRequest から PutBlackoutRequest に変換します。
+func ToPutBlackoutRequest(r events.APIGatewayProxyRequest) (*PutBlackoutRequest, error) {
+	var req PutBlackoutRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.BlackoutID = r.PathParameters["blackout_id"]
+
+	return &req, nil
+}
+
+// ValidatePutBlackoutRequest は PutBlackoutRequest のバリデーションを行います。
+func ValidatePutBlackoutRequest(req *PutBlackoutRequest) error {
+	if req.BlackoutID == "" {
+		return fmt.Errorf("受講できない期間のIDを指定してください")
+	}
+
+	return ValidateInputBlackoutRequest(req.Label, req.StartsAt, req.EndsAt)
+}
+
+// ToDeleteBlackoutRequest は APIGatewayProxyRequest から DeleteBlackoutRequest に変換します。
+func ToDeleteBlackoutRequest(r events.APIGatewayProxyRequest) *DeleteBlackoutRequest {
+	return &DeleteBlackoutRequest{BlackoutID: r.PathParameters["blackout_id"]}
+}
+
+// ValidateDeleteBlackoutRequest は DeleteBlackoutRequest のバリデーションを行います。
+func ValidateDeleteBlackoutRequest(req *DeleteBlackoutRequest) error {
+	if req.BlackoutID == "" {
+		return fmt.Errorf("受講できない期間のIDを指定してください")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToPutAvailabilityRequest(t *testing.T) {
+	r := events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"user_id": "test-user-id"},
+		Body:           `{"weekdays":[1,3,5],"max_lectures_per_day":2}`,
+	}
+	req, err := ToPutAvailabilityRequest(r)
+	require.NoError(t, err)
+
+	assert := assert.New(t)
+	assert.Equal("test-user-id", req.UserID)
+	assert.Equal([]int{1, 3, 5}, req.Weekdays)
+	assert.Equal(2, req.MaxLecturesPerDay)
+}
+
+func TestValidatePutAvailabilityRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *PutAvailabilityRequest
+		want error
+	}{
+		{
+			name: "異常系: user_id が未指定の場合はエラー",
+			req:  &PutAvailabilityRequest{Weekdays: []int{1}},
+			want: errors.New("ユーザーIDを指定してください"),
+		},
+		{
+			name: "異常系: 曜日が範囲外の場合はエラー",
+			req:  &PutAvailabilityRequest{UserID: "test-user-id", Weekdays: []int{7}},
+			want: errors.New("曜日は 0～6 の範囲で指定してください"),
+		},
+		{
+			name: "異常系: 曜日が重複している場合はエラー",
+			req:  &PutAvailabilityRequest{UserID: "test-user-id", Weekdays: []int{1, 1}},
+			want: errors.New("曜日が重複しています"),
+		},
+		{
+			name: "異常系: 受講上限が負の場合はエラー",
+			req:  &PutAvailabilityRequest{UserID: "test-user-id", MaxLecturesPerDay: -1},
+			want: errors.New("1日あたりの受講上限は0～20件で指定してください"),
+		},
+		{
+			name: "正常系: 曜日が空",
+			req:  &PutAvailabilityRequest{UserID: "test-user-id", Weekdays: []int{}},
+			want: nil,
+		},
+		{
+			name: "正常系",
+			req:  &PutAvailabilityRequest{UserID: "test-user-id", Weekdays: []int{0, 6}, MaxLecturesPerDay: 3},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidatePutAvailabilityRequest(tt.req))
+		})
+	}
+}
+
+func TestValidateInputBlackoutRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		label    string
+		startsAt string
+		endsAt   string
+		want     error
+	}{
+		{
+			name:     "異常系: label が空の場合はエラー",
+			startsAt: "2024-06-10",
+			endsAt:   "2024-06-12",
+			want:     errors.New("ラベルを入力してください"),
+		},
+		{
+			name:     "異常系: starts_at の形式が不正な場合はエラー",
+			label:    "出張",
+			startsAt: "2024-06-10 00:00:00",
+			endsAt:   "2024-06-12",
+			want:     errors.New("開始日は yyyy-MM-dd の形式で入力してください"),
+		},
+		{
+			name:     "異常系: ends_at が starts_at より前の場合はエラー",
+			label:    "出張",
+			startsAt: "2024-06-12",
+			endsAt:   "2024-06-10",
+			want:     errors.New("終了日は開始日以降の日付を入力してください"),
+		},
+		{
+			name:     "正常系: 1日のみ",
+			label:    "出張",
+			startsAt: "2024-06-10",
+			endsAt:   "2024-06-10",
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateInputBlackoutRequest(tt.label, tt.startsAt, tt.endsAt))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ResendEmailOutboxRequest は送信に失敗したメールの再送のリクエストを表す構造体です。
+type ResendEmailOutboxRequest struct {
+	EmailOutboxID string
+}
+
+// ToResendEmailOutboxRequest は APIGatewayProxyRequest から ResendEmailOutboxRequest に変換します。
+func ToResendEmailOutboxRequest(r events.APIGatewayProxyRequest) *ResendEmailOutboxRequest {
+	return &ResendEmailOutboxRequest{EmailOutboxID: r.PathParameters["email_outbox_id"]}
+}
+
+// ValidateResendEmailOutboxRequest は ResendEmailOutboxRequest のバリデーションを行います。
+func ValidateResendEmailOutboxRequest(req *ResendEmailOutboxRequest) error {
+	if req.EmailOutboxID == "" {
+		return fmt.Errorf("メールIDを指定してください")
+	}
+	return nil
+}
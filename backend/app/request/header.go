@@ -0,0 +1,33 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// AcceptLanguage は Accept-Language ヘッダーの値を返します。
+// HTTP/2 ではヘッダー名が小文字になるため、大文字と小文字を区別せずに探します。
+func AcceptLanguage(r events.APIGatewayProxyRequest) string {
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, "Accept-Language") {
+			return v
+		}
+	}
+	return ""
+}
+
+// UserAgent は User-Agent ヘッダーの値を返します。
+func UserAgent(r events.APIGatewayProxyRequest) string {
+	for k, v := range r.Headers {
+		if strings.EqualFold(k, "User-Agent") {
+			return v
+		}
+	}
+	return ""
+}
+
+// SourceIP はリクエストの送信元の IP アドレスを返します。
+func SourceIP(r events.APIGatewayProxyRequest) string {
+	return r.RequestContext.Identity.SourceIP
+}
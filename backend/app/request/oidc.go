@@ -0,0 +1,56 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// BeginOIDCSignInRequest は外部アカウントでのサインインの開始のリクエストパラメータの構造体です。
+type BeginOIDCSignInRequest struct {
+	ProviderID string
+}
+
+// FinishOIDCSignInRequest は外部アカウントでのサインインの完了のリクエストパラメータの構造体です。
+type FinishOIDCSignInRequest struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+}
+
+// ToBeginOIDCSignInRequest は外部アカウントでのサインインの開始のリクエストパラメータに変換します。
+func ToBeginOIDCSignInRequest(r events.APIGatewayProxyRequest) *BeginOIDCSignInRequest {
+	return &BeginOIDCSignInRequest{ProviderID: r.PathParameters["provider_id"]}
+}
+
+// ValidateBeginOIDCSignInRequest は外部アカウントでのサインインの開始のリクエストパラメータを検証します。
+func ValidateBeginOIDCSignInRequest(req *BeginOIDCSignInRequest) error {
+	if req.ProviderID == "" {
+		return fmt.Errorf("IDプロバイダーが指定されていません")
+	}
+
+	return nil
+}
+
+// ToFinishOIDCSignInRequest は外部アカウントでのサインインの完了のリクエストパラメータに変換します。
+func ToFinishOIDCSignInRequest(r events.APIGatewayProxyRequest) (*FinishOIDCSignInRequest, error) {
+	var req FinishOIDCSignInRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateFinishOIDCSignInRequest は外部アカウントでのサインインの完了のリクエストパラメータを検証します。
+func ValidateFinishOIDCSignInRequest(req *FinishOIDCSignInRequest) error {
+	if req.State == "" {
+		return fmt.Errorf("state が指定されていません")
+	}
+
+	if req.Code == "" {
+		return fmt.Errorf("認可コードが指定されていません")
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package request
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFinishOIDCSignInRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *FinishOIDCSignInRequest
+		want error
+	}{
+		{
+			name: "異常系: state が未指定の場合はエラー",
+			req:  &FinishOIDCSignInRequest{Code: "test-code"},
+			want: errors.New("state が指定されていません"),
+		},
+		{
+			name: "異常系: 認可コードが未指定の場合はエラー",
+			req:  &FinishOIDCSignInRequest{State: "test-state"},
+			want: errors.New("認可コードが指定されていません"),
+		},
+		{
+			name: "正常系",
+			req:  &FinishOIDCSignInRequest{State: "test-state", Code: "test-code"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateFinishOIDCSignInRequest(tt.req))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+)
+
+// PostCustomColorRequest はユーザー定義の色の登録のリクエストを表す構造体です。
+type PostCustomColorRequest struct {
+	Name  string `json:"name"`
+	Hex   string `json:"hex"`
+	Style string `json:"style"`
+}
+
+// DeleteCustomColorRequest はユーザー定義の色の削除のリクエストを表す構造体です。
+type DeleteCustomColorRequest struct {
+	ColorID string
+}
+
+// ToPostCustomColorRequest は APIGatewayProxyRequest から PostCustomColorRequest に変換します。
+func ToPostCustomColorRequest(r events.APIGatewayProxyRequest) (*PostCustomColorRequest, error) {
+	var req PostCustomColorRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ValidatePostCustomColorRequest は PostCustomColorRequest のバリデーションを行います。
+func ValidatePostCustomColorRequest(req *PostCustomColorRequest) error {
+	// name が空文字
+	if req.Name == "" {
+		return fmt.Errorf("色の名前を入力してください")
+	}
+
+	// name が20文字より多い
+	const upperNameLength = 20
+	if utf8.RuneCountInString(req.Name) > upperNameLength {
+		return fmt.Errorf("色の名前は%d文字以内で入力してください", upperNameLength)
+	}
+
+	// hex が空文字
+	if req.Hex == "" {
+		return fmt.Errorf("色コードを入力してください")
+	}
+
+	// hex が #rrggbb 形式でない
+	if !model.ValidHex(req.Hex) {
+		return fmt.Errorf("色コードは #rrggbb の形式で入力してください")
+	}
+
+	// style が不正
+	if model.ToColorStyle(req.Style) == "" {
+		return fmt.Errorf("表示方法は %s または %s を指定してください", model.ColorStyleFill, model.ColorStyleOutline)
+	}
+
+	return nil
+}
+
+// ToDeleteCustomColorRequest は APIGatewayProxyRequest から DeleteCustomColorRequest に変換します。
+func ToDeleteCustomColorRequest(r events.APIGatewayProxyRequest) *DeleteCustomColorRequest {
+	return &DeleteCustomColorRequest{ColorID: r.PathParameters["color_id"]}
+}
+
+// ValidateDeleteCustomColorRequest は DeleteCustomColorRequest のバリデーションを行います。
+func ValidateDeleteCustomColorRequest(req *DeleteCustomColorRequest) error {
+	if req.ColorID == "" {
+		return fmt.Errorf("色IDを指定してください")
+	}
+	return nil
+}
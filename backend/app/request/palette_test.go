@@ -0,0 +1,30 @@
+package request
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePostCustomColorRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PostCustomColorRequest
+		want error
+	}{
+		{name: "異常系: name が空の場合はエラー", req: PostCustomColorRequest{Hex: "#a78bfa", Style: "fill"}, want: errors.New("色の名前を入力してください")},
+		{name: "異常系: name が20文字より多い場合はエラー", req: PostCustomColorRequest{Name: strings.Repeat("あ", 21), Hex: "#a78bfa", Style: "fill"}, want: errors.New("色の名前は20文字以内で入力してください")},
+		{name: "異常系: hex が空の場合はエラー", req: PostCustomColorRequest{Name: "藤色", Style: "fill"}, want: errors.New("色コードを入力してください")},
+		{name: "異常系: hex の形式が不正な場合はエラー", req: PostCustomColorRequest{Name: "藤色", Hex: "a78bfa", Style: "fill"}, want: errors.New("色コードは #rrggbb の形式で入力してください")},
+		{name: "異常系: style が不正な場合はエラー", req: PostCustomColorRequest{Name: "藤色", Hex: "#a78bfa", Style: "dotted"}, want: errors.New("表示方法は fill または outline を指定してください")},
+		{name: "正常系", req: PostCustomColorRequest{Name: "藤色", Hex: "#A78BFA", Style: "outline"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidatePostCustomColorRequest(&tt.req))
+		})
+	}
+}
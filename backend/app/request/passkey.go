@@ -0,0 +1,150 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+)
+
+// PasskeyCredential はブラウザの PublicKeyCredential.toJSON の値を表す構造体です。
+// 登録では ClientDataJSON と AttestationObject を、サインインでは ClientDataJSON、AuthenticatorData、Signature、UserHandle を使います。
+type PasskeyCredential struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AttestationObject string `json:"attestationObject"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// BeginPasskeyRegistrationRequest はパスキーの登録の開始のリクエストパラメータの構造体です。
+type BeginPasskeyRegistrationRequest struct {
+	UserID string
+}
+
+// FinishPasskeyRegistrationRequest はパスキーの登録の完了のリクエストパラメータの構造体です。
+type FinishPasskeyRegistrationRequest struct {
+	UserID     string
+	Name       string            `json:"name"`
+	Credential PasskeyCredential `json:"credential"`
+}
+
+// GetPasskeyListRequest はパスキーの一覧の取得のリクエストパラメータの構造体です。
+type GetPasskeyListRequest struct {
+	UserID string
+}
+
+// DeletePasskeyRequest はパスキーの削除のリクエストパラメータの構造体です。
+type DeletePasskeyRequest struct {
+	UserID    string
+	PasskeyID string
+}
+
+// FinishPasskeySignInRequest はパスキーのサインインの完了のリクエストパラメータの構造体です。
+type FinishPasskeySignInRequest struct {
+	Credential PasskeyCredential `json:"credential"`
+}
+
+// ToBeginPasskeyRegistrationRequest はパスキーの登録の開始のリクエストパラメータに変換します。
+func ToBeginPasskeyRegistrationRequest(r events.APIGatewayProxyRequest) *BeginPasskeyRegistrationRequest {
+	return &BeginPasskeyRegistrationRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateBeginPasskeyRegistrationRequest はパスキーの登録の開始のリクエストパラメータを検証します。
+func ValidateBeginPasskeyRegistrationRequest(req *BeginPasskeyRegistrationRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToFinishPasskeyRegistrationRequest はパスキーの登録の完了のリクエストパラメータに変換します。
+func ToFinishPasskeyRegistrationRequest(r events.APIGatewayProxyRequest) (*FinishPasskeyRegistrationRequest, error) {
+	var req FinishPasskeyRegistrationRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.UserID = r.PathParameters["user_id"]
+
+	return &req, nil
+}
+
+// ValidateFinishPasskeyRegistrationRequest はパスキーの登録の完了のリクエストパラメータを検証します。
+func ValidateFinishPasskeyRegistrationRequest(req *FinishPasskeyRegistrationRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	if utf8.RuneCountInString(req.Name) > model.PasskeyNameMaxLength {
+		return fmt.Errorf("パスキーの名前は%d文字以内で入力してください", model.PasskeyNameMaxLength)
+	}
+
+	if req.Credential.Response.ClientDataJSON == "" || req.Credential.Response.AttestationObject == "" {
+		return fmt.Errorf("パスキーの応答が指定されていません")
+	}
+
+	return nil
+}
+
+// ToGetPasskeyListRequest はパスキーの一覧の取得のリクエストパラメータに変換します。
+func ToGetPasskeyListRequest(r events.APIGatewayProxyRequest) *GetPasskeyListRequest {
+	return &GetPasskeyListRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateGetPasskeyListRequest はパスキーの一覧の取得のリクエストパラメータを検証します。
+func ValidateGetPasskeyListRequest(req *GetPasskeyListRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToDeletePasskeyRequest はパスキーの削除のリクエストパラメータに変換します。
+func ToDeletePasskeyRequest(r events.APIGatewayProxyRequest) *DeletePasskeyRequest {
+	return &DeletePasskeyRequest{
+		UserID:    r.PathParameters["user_id"],
+		PasskeyID: r.PathParameters["passkey_id"],
+	}
+}
+
+// ValidateDeletePasskeyRequest はパスキーの削除のリクエストパラメータを検証します。
+func ValidateDeletePasskeyRequest(req *DeletePasskeyRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	if req.PasskeyID == "" {
+		return fmt.Errorf("パスキーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToFinishPasskeySignInRequest はパスキーのサインインの完了のリクエストパラメータに変換します。
+func ToFinishPasskeySignInRequest(r events.APIGatewayProxyRequest) (*FinishPasskeySignInRequest, error) {
+	var req FinishPasskeySignInRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateFinishPasskeySignInRequest はパスキーのサインインの完了のリクエストパラメータを検証します。
+func ValidateFinishPasskeySignInRequest(req *FinishPasskeySignInRequest) error {
+	res := req.Credential.Response
+	if req.Credential.ID == "" || res.ClientDataJSON == "" || res.AuthenticatorData == "" || res.Signature == "" {
+		return fmt.Errorf("パスキーの応答が指定されていません")
+	}
+
+	return nil
+}
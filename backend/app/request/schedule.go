@@ -3,16 +3,52 @@ package request
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
-	"unicode/utf8"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/model"
 )
 
 // GetScheduleListRequest はスケジュールリスト取得のリクエストを表す構造体です。
 type GetScheduleListRequest struct {
-	UserID string
+	UserID       string
+	Type         string
+	Color        string
+	TagID        string
+	NamePrefix   string
+	UpdatedSince string
+	From         string
+	To           string
+	Format       string
+	Fields       []string
+}
+
+const (
+	ScheduleListFormatGrouped = "grouped" // 日付ごとにまとめる
+	ScheduleListFormatFlat    = "flat"    // 日付ごとにまとめない
+)
+
+// scheduleListFields はスケジュールリスト取得で選択できる項目です。
+var scheduleListFields = []string{
+	"id",
+	"user_id",
+	"name",
+	"starts_at",
+	"ends_at",
+	"timed",
+	"color",
+	"type",
+	"order",
+	"lecture_number",
+	"note",
+	"links",
+	"memo",
+	"tag_ids",
+	"created_at",
+	"updated_at",
 }
 
 // GetScheduleRequest はスケジュール取得のリクエストを表す構造体です。
@@ -22,12 +58,18 @@ type GetScheduleRequest struct {
 
 // PostScheduleRequest はスケジュール登録のリクエストを表す構造体です。
 type PostScheduleRequest struct {
-	Name     string `json:"name"`
-	StartsAt string `json:"starts_at"`
-	EndsAt   string `json:"ends_at"`
-	Color    string `json:"color"`
-	Type     string `json:"type"`
-	Order    int    `json:"order"`
+	Name          string   `json:"name"`
+	StartsAt      string   `json:"starts_at"`
+	EndsAt        string   `json:"ends_at"`
+	Timed         bool     `json:"timed"`
+	Color         string   `json:"color"`
+	Type          string   `json:"type"`
+	Order         int      `json:"order"`
+	LectureNumber int      `json:"lecture_number"`
+	Note          string   `json:"note"`
+	Links         []string `json:"links"`
+	Memo          string   `json:"memo"`
+	TagIDs        []string `json:"tag_ids"`
 }
 
 type PostBulkScheduleRequest struct {
@@ -35,14 +77,21 @@ type PostBulkScheduleRequest struct {
 }
 
 // PutScheduleRequest はスケジュール更新のリクエストを表す構造体です。
+// Timed、LectureNumber、Note、Links、Memo、TagIDs は指定されなかった場合に既存の値を変更しません。
 type PutScheduleRequest struct {
-	ScheduleID string `json:"id"`
-	Name       string `json:"name"`
-	StartsAt   string `json:"starts_at"`
-	EndsAt     string `json:"ends_at"`
-	Color      string `json:"color"`
-	Type       string `json:"type"`
-	Order      int    `json:"order"`
+	ScheduleID    string   `json:"id"`
+	Name          string   `json:"name"`
+	StartsAt      string   `json:"starts_at"`
+	EndsAt        string   `json:"ends_at"`
+	Timed         *bool    `json:"timed"`
+	Color         string   `json:"color"`
+	Type          string   `json:"type"`
+	Order         int      `json:"order"`
+	LectureNumber *int     `json:"lecture_number"`
+	Note          *string  `json:"note"`
+	Links         []string `json:"links"`
+	Memo          *string  `json:"memo"`
+	TagIDs        []string `json:"tag_ids"`
 }
 
 type PutBulkScheduleRequest struct {
@@ -55,8 +104,29 @@ type DeleteScheduleRequest struct {
 }
 
 // ToGetScheduleListRequest は APIGatewayProxyRequest から GetScheduleListRequest に変換します。
+// fields はカンマ区切りで指定します。
 func ToGetScheduleListRequest(r events.APIGatewayProxyRequest) *GetScheduleListRequest {
-	return &GetScheduleListRequest{UserID: r.PathParameters["user_id"]}
+	q := r.QueryStringParameters
+
+	var fields []string
+	for _, f := range strings.Split(q["fields"], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+
+	return &GetScheduleListRequest{
+		UserID:       r.PathParameters["user_id"],
+		Type:         q["type"],
+		Color:        q["color"],
+		TagID:        q["tag"],
+		NamePrefix:   q["name_prefix"],
+		UpdatedSince: q["updated_since"],
+		From:         q["from"],
+		To:           q["to"],
+		Format:       q["format"],
+		Fields:       fields,
+	}
 }
 
 // ValidateGetScheduleListRequest は GetScheduleListRequest のバリデーションを行います。
@@ -64,9 +134,63 @@ func ValidateGetScheduleListRequest(req *GetScheduleListRequest) error {
 	if req.UserID == "" {
 		return fmt.Errorf("ユーザーIDを指定してください")
 	}
+
+	// type が不正
+	if req.Type != "" && model.ScheduleType(req.Type).String() == "" {
+		return fmt.Errorf("スケジュールの種類は %s または %s を指定してください", model.ScheduleTypeMaster, model.ScheduleTypeCustom)
+	}
+
+	// updated_since のフォーマットが正しくない
+	if req.UpdatedSince != "" {
+		if _, err := timezone.UTC().ParseDateTime(req.UpdatedSince); err != nil {
+			return fmt.Errorf("更新日時は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください")
+		}
+	}
+
+	// from のフォーマットが正しくない
+	var from, to time.Time
+	if req.From != "" {
+		d, err := time.Parse(model.DateFormat, req.From)
+		if err != nil {
+			return fmt.Errorf("開始日は yyyy-MM-dd の形式で入力してください")
+		}
+		from = d
+	}
+
+	// to のフォーマットが正しくない
+	if req.To != "" {
+		d, err := time.Parse(model.DateFormat, req.To)
+		if err != nil {
+			return fmt.Errorf("終了日は yyyy-MM-dd の形式で入力してください")
+		}
+		to = d
+	}
+
+	// from が to より後
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return fmt.Errorf("終了日は開始日以降の日付を入力してください")
+	}
+
+	// format が不正
+	if req.Format != "" && req.Format != ScheduleListFormatGrouped && req.Format != ScheduleListFormatFlat {
+		return fmt.Errorf("出力形式は %s または %s を指定してください", ScheduleListFormatGrouped, ScheduleListFormatFlat)
+	}
+
+	// fields に選択できない項目が含まれる
+	for _, f := range req.Fields {
+		if !slices.Contains(scheduleListFields, f) {
+			return fmt.Errorf("項目 %s は選択できません", f)
+		}
+	}
+
 	return nil
 }
 
+// IsFlat は日付ごとにまとめずに返すかどうかを返します。
+func (req *GetScheduleListRequest) IsFlat() bool {
+	return req.Format == ScheduleListFormatFlat
+}
+
 // ToGetScheduleRequest は APIGatewayProxyRequest から GetScheduleRequest に変換します。
 func ToGetScheduleRequest(r events.APIGatewayProxyRequest) *GetScheduleRequest {
 	return &GetScheduleRequest{ScheduleID: r.PathParameters["schedule_id"]}
@@ -89,67 +213,94 @@ func ToPostScheduleRequest(r events.APIGatewayProxyRequest) (*PostScheduleReques
 	return &req, nil
 }
 
-// ValidateInputScheduleRequest はスケジュールの入力に対するバリデーションを行います。
-func ValidateInputScheduleRequest(name, startsAt, endsAt, color, sType string) error {
-	// name が空文字
-	if name == "" {
-		return fmt.Errorf("スケジュール名を入力してください")
-	}
-
-	// name が50文字より多い
-	const upperNameLength = 50
-	if utf8.RuneCountInString(name) > upperNameLength {
-		return fmt.Errorf("スケジュール名は%d文字以内で入力してください", upperNameLength)
-	}
+// scheduleInput はスケジュールの登録、更新のリクエストのうち不変条件を検証する項目を表す構造体です。
+type scheduleInput struct {
+	Name          string
+	StartsAt      string
+	EndsAt        string
+	Timed         bool
+	Color         string
+	Type          string
+	LectureNumber int
+	Note          string
+	Memo          string
+	Links         []string
+	TagIDs        []string
+}
 
-	// starts_at が空文字
-	if startsAt == "" {
-		return fmt.Errorf("開始日を入力してください")
+// validate は日時の形式を確認したうえで、スケジュールの不変条件を model.Schedule.Validate で検証します。
+// 日時の形式が正しくない項目は、未入力の違反を形式の違反に置き換えます。
+func (in scheduleInput) validate() *model.ValidationError {
+	s := model.Schedule{
+		Name:          in.Name,
+		Timed:         in.Timed,
+		Color:         in.Color,
+		Type:          model.ScheduleType(in.Type),
+		LectureNumber: in.LectureNumber,
+		Note:          in.Note,
+		Memo:          in.Memo,
+		Links:         in.Links,
+		TagIDs:        in.TagIDs,
 	}
 
-	// ends_at が空文字
-	if endsAt == "" {
-		return fmt.Errorf("終了日を入力してください")
-	}
+	var formatErrors []model.FieldError
+	zone := timezone.UTC()
 
 	// starts_at のフォーマットが正しくない
-	sa, err := time.Parse(time.DateTime, startsAt)
-	if err != nil {
-		return fmt.Errorf("開始日は yyyy-MM-dd HH:mm:ss の形式で入力してください")
+	if in.StartsAt != "" {
+		sa, err := zone.ParseDateTime(in.StartsAt)
+		if err != nil {
+			formatErrors = append(formatErrors, model.FieldError{Field: "starts_at", Message: "開始日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"})
+		}
+		s.StartsAt = sa
 	}
 
 	// ends_at のフォーマットが正しくない
-	ea, err := time.Parse(time.DateTime, endsAt)
-	if err != nil {
-		return fmt.Errorf("終了日は yyyy-MM-dd HH:mm:ss の形式で入力してください")
-	}
-
-	// starts_at が ends_at より後
-	if sa.After(ea) {
-		return fmt.Errorf("終了日は開始日以降の日付を入力してください")
-	}
-
-	// color が空文字
-	if color == "" {
-		return fmt.Errorf("色を指定してください")
+	if in.EndsAt != "" {
+		ea, err := zone.ParseDateTime(in.EndsAt)
+		if err != nil {
+			formatErrors = append(formatErrors, model.FieldError{Field: "ends_at", Message: "終了日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"})
+		}
+		s.EndsAt = ea
 	}
 
-	// type が空文字
-	if sType == "" {
-		return fmt.Errorf("スケジュールの種類を指定してください")
+	ve := &model.ValidationError{}
+	ve.Merge("", s.Validate())
+	for _, fe := range formatErrors {
+		ve.Set(fe.Field, fe.Message)
 	}
 
-	// type が不正
-	if model.ScheduleType(sType).String() == "" {
-		return fmt.Errorf("スケジュールの種類は %s または %s を指定してください", model.ScheduleTypeMaster, model.ScheduleTypeCustom)
-	}
+	return ve
+}
 
-	return nil
+// ValidateInputScheduleRequest はスケジュールの入力に対するバリデーションを行います。
+// 違反はすべて model.ValidationError にまとめて返します。
+func ValidateInputScheduleRequest(name, startsAt, endsAt, color, sType string) error {
+	in := scheduleInput{Name: name, StartsAt: startsAt, EndsAt: endsAt, Color: color, Type: sType}
+	return in.validate().Err()
 }
 
 // ValidatePostScheduleRequest は PostScheduleRequest のバリデーションを行います。
+// 違反はすべて model.ValidationError にまとめて返します。
 func ValidatePostScheduleRequest(req *PostScheduleRequest) error {
-	return ValidateInputScheduleRequest(req.Name, req.StartsAt, req.EndsAt, req.Color, req.Type)
+	return req.scheduleInput().validate().Err()
+}
+
+// scheduleInput は PostScheduleRequest から検証する項目を取り出します。
+func (req PostScheduleRequest) scheduleInput() scheduleInput {
+	return scheduleInput{
+		Name:          req.Name,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		Timed:         req.Timed,
+		Color:         req.Color,
+		Type:          req.Type,
+		LectureNumber: req.LectureNumber,
+		Note:          req.Note,
+		Memo:          req.Memo,
+		Links:         req.Links,
+		TagIDs:        req.TagIDs,
+	}
 }
 
 // ToPostBulkScheduleRequest は APIGatewayProxyRequest から PostBulkScheduleRequest に変換します。
@@ -163,17 +314,21 @@ func ToPostBulkScheduleRequest(r events.APIGatewayProxyRequest) (*PostBulkSchedu
 }
 
 // ValidatePostBulkScheduleRequest は PostBulkScheduleRequest のバリデーションを行います。
+// 違反はすべて model.ValidationError にまとめ、項目のパスは schedules[0].name の形式とします。
 func ValidatePostBulkScheduleRequest(req *PostBulkScheduleRequest) error {
+	ve := &model.ValidationError{}
+
+	// schedules が空
 	if len(req.Schedules) == 0 {
-		return fmt.Errorf("スケジュールを指定してください")
+		ve.Add("schedules", "スケジュールを指定してください")
+		return ve
 	}
 
 	for i, schedule := range req.Schedules {
-		if err := ValidateInputScheduleRequest(schedule.Name, schedule.StartsAt, schedule.EndsAt, schedule.Color, schedule.Type); err != nil {
-			return fmt.Errorf("%s: %d番目", err.Error(), i+1)
-		}
+		ve.Merge(model.IndexedField("schedules", i), schedule.scheduleInput().validate().Err())
 	}
-	return nil
+
+	return ve.Err()
 }
 
 // ToPutScheduleRequest は APIGatewayProxyRequest から PutScheduleRequest に変換します。
@@ -189,13 +344,36 @@ func ToPutScheduleRequest(r events.APIGatewayProxyRequest) (*PutScheduleRequest,
 }
 
 // ValidatePutScheduleRequest は PutScheduleRequest のバリデーションを行います。
+// 違反はすべて model.ValidationError にまとめて返します。
 func ValidatePutScheduleRequest(req *PutScheduleRequest) error {
+	ve := &model.ValidationError{}
+
 	// ID が空文字
 	if req.ScheduleID == "" {
-		return fmt.Errorf("スケジュールIDを指定してください")
+		ve.Add("id", "スケジュールIDを指定してください")
 	}
 
-	return ValidateInputScheduleRequest(req.Name, req.StartsAt, req.EndsAt, req.Color, req.Type)
+	ve.Merge("", req.scheduleInput().validate().Err())
+
+	return ve.Err()
+}
+
+// scheduleInput は PutScheduleRequest から検証する項目を取り出します。
+// timed が未指定の場合は更新前の値を引き継ぐため、終日のスケジュールとして検証し、ユースケースで改めて検証します。
+func (req PutScheduleRequest) scheduleInput() scheduleInput {
+	return scheduleInput{
+		Name:          req.Name,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+		Timed:         req.Timed != nil && *req.Timed,
+		Color:         req.Color,
+		Type:          req.Type,
+		LectureNumber: intValue(req.LectureNumber),
+		Note:          stringValue(req.Note),
+		Memo:          stringValue(req.Memo),
+		Links:         req.Links,
+		TagIDs:        req.TagIDs,
+	}
 }
 
 // ToPutBulkScheduleRequest は APIGatewayProxyRequest から PutBulkScheduleRequest に変換します。
@@ -209,22 +387,28 @@ func ToPutBulkScheduleRequest(r events.APIGatewayProxyRequest) (*PutBulkSchedule
 }
 
 // ValidatePutBulkScheduleRequest は PutBulkScheduleRequest のバリデーションを行います。
+// 違反はすべて model.ValidationError にまとめ、項目のパスは schedules[0].name の形式とします。
 func ValidatePutBulkScheduleRequest(req *PutBulkScheduleRequest) error {
+	ve := &model.ValidationError{}
+
+	// schedules が空
 	if len(req.Schedules) == 0 {
-		return fmt.Errorf("スケジュールを指定してください")
+		ve.Add("schedules", "スケジュールを指定してください")
+		return ve
 	}
 
 	for i, schedule := range req.Schedules {
+		field := model.IndexedField("schedules", i)
+
 		// ID が空文字
 		if schedule.ScheduleID == "" {
-			return fmt.Errorf("スケジュールIDを指定してください: %d番目", i+1)
+			ve.Add(model.JoinFieldPath(field, "id"), "スケジュールIDを指定してください")
 		}
 
-		if err := ValidateInputScheduleRequest(schedule.Name, schedule.StartsAt, schedule.EndsAt, schedule.Color, schedule.Type); err != nil {
-			return fmt.Errorf("%s: %d番目", err.Error(), i+1)
-		}
+		ve.Merge(field, schedule.scheduleInput().validate().Err())
 	}
-	return nil
+
+	return ve.Err()
 }
 
 // ToDeleteScheduleRequest は APIGatewayProxyRequest から DeleteScheduleRequest に変換します。
@@ -239,3 +423,19 @@ func ValidateDeleteScheduleRequest(req *DeleteScheduleRequest) error {
 	}
 	return nil
 }
+
+// stringValue はポインタが nil の場合に空文字を返します。
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+// intValue はポインタが nil の場合に 0 を返します。
+func intValue(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
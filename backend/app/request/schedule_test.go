@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/datsukan/attendance-plan/backend/app/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestToGetScheduleListRequest(t *testing.T) {
@@ -18,6 +19,39 @@ func TestToGetScheduleListRequest(t *testing.T) {
 	}
 	req := ToGetScheduleListRequest(r)
 	assert.Equal(t, "test-user-id", req.UserID)
+	assert.Empty(t, req.Fields)
+	assert.False(t, req.IsFlat())
+}
+
+func TestToGetScheduleListRequest_Query(t *testing.T) {
+	r := events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{
+			"user_id": "test-user-id",
+		},
+		QueryStringParameters: map[string]string{
+			"type":          "custom",
+			"color":         "red",
+			"tag":           "test-tag-1",
+			"name_prefix":   "統計学",
+			"updated_since": "2024-06-01 00:00:00",
+			"from":          "2024-06-03",
+			"to":            "2024-06-09",
+			"format":        "flat",
+			"fields":        "name, starts_at,,color",
+		},
+	}
+	req := ToGetScheduleListRequest(r)
+
+	assert := assert.New(t)
+	assert.Equal("custom", req.Type)
+	assert.Equal("red", req.Color)
+	assert.Equal("test-tag-1", req.TagID)
+	assert.Equal("統計学", req.NamePrefix)
+	assert.Equal("2024-06-01 00:00:00", req.UpdatedSince)
+	assert.Equal("2024-06-03", req.From)
+	assert.Equal("2024-06-09", req.To)
+	assert.True(req.IsFlat())
+	assert.Equal([]string{"name", "starts_at", "color"}, req.Fields)
 }
 
 func TestValidateGetScheduleListRequest(t *testing.T) {
@@ -36,6 +70,56 @@ func TestValidateGetScheduleListRequest(t *testing.T) {
 			req:  &GetScheduleListRequest{UserID: "test-user-id"},
 			want: nil,
 		},
+		{
+			name: "異常系: type が不正な場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", Type: "other"},
+			want: errors.New("スケジュールの種類は master または custom を指定してください"),
+		},
+		{
+			name: "異常系: updated_since の形式が不正な場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", UpdatedSince: "2024-06-01"},
+			want: errors.New("更新日時は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
+		},
+		{
+			name: "異常系: from の形式が不正な場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", From: "2024/06/03"},
+			want: errors.New("開始日は yyyy-MM-dd の形式で入力してください"),
+		},
+		{
+			name: "異常系: to の形式が不正な場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", To: "2024/06/09"},
+			want: errors.New("終了日は yyyy-MM-dd の形式で入力してください"),
+		},
+		{
+			name: "異常系: from が to より後の場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", From: "2024-06-10", To: "2024-06-09"},
+			want: errors.New("終了日は開始日以降の日付を入力してください"),
+		},
+		{
+			name: "異常系: format が不正な場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", Format: "tree"},
+			want: errors.New("出力形式は grouped または flat を指定してください"),
+		},
+		{
+			name: "異常系: fields に選択できない項目が含まれる場合はエラー",
+			req:  &GetScheduleListRequest{UserID: "test-user-id", Fields: []string{"name", "password"}},
+			want: errors.New("項目 password は選択できません"),
+		},
+		{
+			name: "正常系: すべての条件を指定",
+			req: &GetScheduleListRequest{
+				UserID:       "test-user-id",
+				Type:         "custom",
+				Color:        "red",
+				NamePrefix:   "統計学",
+				UpdatedSince: "2024-06-01 00:00:00",
+				From:         "2024-06-03",
+				To:           "2024-06-09",
+				Format:       "flat",
+				Fields:       []string{"name", "starts_at"},
+			},
+			want: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -58,17 +142,28 @@ func TestToGetScheduleRequest(t *testing.T) {
 
 func TestToPostScheduleRequest(t *testing.T) {
 	r := events.APIGatewayProxyRequest{
-		Body: `{"name":"test-name","starts_at":"2021-01-01 00:00:00","ends_at":"2021-01-01 00:00:00","color":"test-color","type":"master"}`,
+		Body: `{"name":"test-name","starts_at":"2021-01-01 00:00:00","ends_at":"2021-01-01 00:00:00","color":"white","type":"master"}`,
 	}
 	req, err := ToPostScheduleRequest(r)
 	assert.Nil(t, err)
 	assert.Equal(t, "test-name", req.Name)
 	assert.Equal(t, "2021-01-01 00:00:00", req.StartsAt)
 	assert.Equal(t, "2021-01-01 00:00:00", req.EndsAt)
-	assert.Equal(t, "test-color", req.Color)
+	assert.Equal(t, "white", req.Color)
 	assert.Equal(t, "master", req.Type)
 }
 
+func TestToPostScheduleRequest_Detail(t *testing.T) {
+	r := events.APIGatewayProxyRequest{
+		Body: `{"name":"統計学 第6回","note":"# 要点\n- 標本分散","links":["https://lms.example.com/lectures/6"],"memo":"小テストあり"}`,
+	}
+	req, err := ToPostScheduleRequest(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "# 要点\n- 標本分散", req.Note)
+	assert.Equal(t, []string{"https://lms.example.com/lectures/6"}, req.Links)
+	assert.Equal(t, "小テストあり", req.Memo)
+}
+
 func TestValidateInputScheduleRequest(t *testing.T) {
 	type Param struct {
 		Name     string
@@ -95,7 +190,7 @@ func TestValidateInputScheduleRequest(t *testing.T) {
 		},
 		{
 			name: "正常系: name が50文字の場合はエラーになし",
-			req:  Param{Name: strings.Repeat("a", 50), StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+			req:  Param{Name: strings.Repeat("a", 50), StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
 			want: nil,
 		},
 		{
@@ -111,12 +206,17 @@ func TestValidateInputScheduleRequest(t *testing.T) {
 		{
 			name: "異常系: starts_at のフォーマットが不正な場合はエラー",
 			req:  Param{Name: "test-name", StartsAt: "2021-01-01", EndsAt: "2021-01-01 00:00:00"},
-			want: errors.New("開始日は yyyy-MM-dd HH:mm:ss の形式で入力してください"),
+			want: errors.New("開始日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
 		},
 		{
 			name: "異常系: ends_at のフォーマットが不正な場合はエラー",
 			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01"},
-			want: errors.New("終了日は yyyy-MM-dd HH:mm:ss の形式で入力してください"),
+			want: errors.New("終了日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
+		},
+		{
+			name: "正常系: starts_at と ends_at がオフセット付きの場合はエラーなし",
+			req:  Param{Name: "test-name", StartsAt: "2021-01-01T00:00:00+09:00", EndsAt: "2021-01-01T01:00:00+09:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
+			want: nil,
 		},
 		{
 			name: "異常系: starts_at が ends_at より後の場合はエラー",
@@ -128,19 +228,29 @@ func TestValidateInputScheduleRequest(t *testing.T) {
 			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00"},
 			want: errors.New("色を指定してください"),
 		},
+		{
+			name: "異常系: color がパレットにない場合はエラー",
+			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "purple"},
+			want: errors.New("色はパレットの色またはユーザーが定義した色を指定してください"),
+		},
+		{
+			name: "正常系: color がユーザー定義の色の場合はエラーなし",
+			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "custom_test-color-id", Type: model.ScheduleTypeMaster.String()},
+			want: nil,
+		},
 		{
 			name: "異常系: type が未指定の場合はエラー",
-			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color"},
+			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white"},
 			want: errors.New("スケジュールの種類を指定してください"),
 		},
 		{
 			name: "異常系: type が不正な場合はエラー",
-			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: "invalid"},
+			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: "invalid"},
 			want: errors.New("スケジュールの種類は master または custom を指定してください"),
 		},
 		{
 			name: "正常系",
-			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+			req:  Param{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
 			want: nil,
 		},
 	}
@@ -148,7 +258,11 @@ func TestValidateInputScheduleRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := ValidateInputScheduleRequest(tt.req.Name, tt.req.StartsAt, tt.req.EndsAt, tt.req.Color, tt.req.Type)
-			assert.Equal(t, tt.want, err)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want.Error())
 		})
 	}
 }
@@ -171,9 +285,19 @@ func TestValidatePostScheduleRequest(t *testing.T) {
 		},
 		{
 			name: "正常系: name が50文字の場合はエラーになし",
-			req:  &PostScheduleRequest{Name: strings.Repeat("a", 50), StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+			req:  &PostScheduleRequest{Name: strings.Repeat("a", 50), StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
+			want: nil,
+		},
+		{
+			name: "正常系: timed が true で日をまたぐ場合はエラーなし",
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 23:00:00", EndsAt: "2021-01-02 01:00:00", Timed: true, Color: "white", Type: model.ScheduleTypeCustom.String()},
 			want: nil,
 		},
+		{
+			name: "異常系: timed が true で ends_at が starts_at と同じ場合はエラー",
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 20:00:00", EndsAt: "2021-01-01 20:00:00", Timed: true, Color: "white", Type: model.ScheduleTypeCustom.String()},
+			want: errors.New("時間指定のスケジュールは終了日時を開始日時より後にしてください"),
+		},
 		{
 			name: "異常系: starts_at が未指定の場合はエラー",
 			req:  &PostScheduleRequest{Name: "test-name"},
@@ -187,12 +311,12 @@ func TestValidatePostScheduleRequest(t *testing.T) {
 		{
 			name: "異常系: starts_at のフォーマットが不正な場合はエラー",
 			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01", EndsAt: "2021-01-01 00:00:00"},
-			want: errors.New("開始日は yyyy-MM-dd HH:mm:ss の形式で入力してください"),
+			want: errors.New("開始日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
 		},
 		{
 			name: "異常系: ends_at のフォーマットが不正な場合はエラー",
 			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01"},
-			want: errors.New("終了日は yyyy-MM-dd HH:mm:ss の形式で入力してください"),
+			want: errors.New("終了日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
 		},
 		{
 			name: "異常系: starts_at が ends_at より後の場合はエラー",
@@ -206,17 +330,32 @@ func TestValidatePostScheduleRequest(t *testing.T) {
 		},
 		{
 			name: "異常系: type が未指定の場合はエラー",
-			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color"},
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white"},
 			want: errors.New("スケジュールの種類を指定してください"),
 		},
 		{
 			name: "異常系: type が不正な場合はエラー",
-			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: "invalid"},
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: "invalid"},
 			want: errors.New("スケジュールの種類は master または custom を指定してください"),
 		},
+		{
+			name: "異常系: lecture_number が負の場合はエラー",
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeCustom.String(), LectureNumber: -1},
+			want: errors.New("講義回は1～999で指定してください"),
+		},
+		{
+			name: "正常系: lecture_number を指定",
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeCustom.String(), LectureNumber: 3},
+			want: nil,
+		},
+		{
+			name: "異常系: links が不正な場合はエラー",
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeCustom.String(), Links: []string{"lms"}},
+			want: errors.New("リンクは http または https の URL を入力してください"),
+		},
 		{
 			name: "正常系",
-			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+			req:  &PostScheduleRequest{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
 			want: nil,
 		},
 	}
@@ -224,17 +363,59 @@ func TestValidatePostScheduleRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := ValidatePostScheduleRequest(tt.req)
-			assert.Equal(t, tt.want, err)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want.Error())
 		})
 	}
 }
 
+func TestValidatePostScheduleRequest_FieldErrors(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	err := ValidatePostScheduleRequest(&PostScheduleRequest{StartsAt: "2021/01/01", EndsAt: "2021-01-01 00:00:00", Type: "invalid", Links: []string{"https://example.com", "ftp://example.com"}})
+
+	ve, ok := model.AsValidationError(err)
+	require.True(ok)
+	assert.Equal([]model.FieldError{
+		{Field: "name", Message: "スケジュール名を入力してください"},
+		{Field: "starts_at", Message: "開始日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"},
+		{Field: "color", Message: "色を指定してください"},
+		{Field: "type", Message: "スケジュールの種類は master または custom を指定してください"},
+		{Field: "links[1]", Message: "リンクは http または https の URL を入力してください"},
+	}, ve.Errors)
+}
+
+func TestValidatePostBulkScheduleRequest(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	req := &PostBulkScheduleRequest{
+		Schedules: []PostScheduleRequest{
+			{Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
+			{StartsAt: "2021-01-02 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
+		},
+	}
+
+	ve, ok := model.AsValidationError(ValidatePostBulkScheduleRequest(req))
+	require.True(ok)
+	assert.Equal([]model.FieldError{
+		{Field: "schedules[1].name", Message: "スケジュール名を入力してください"},
+		{Field: "schedules[1].ends_at", Message: "終了日は開始日以降の日付を入力してください"},
+	}, ve.Errors)
+
+	assert.EqualError(ValidatePostBulkScheduleRequest(&PostBulkScheduleRequest{}), "スケジュールを指定してください")
+}
+
 func TestToPutScheduleRequest(t *testing.T) {
 	r := events.APIGatewayProxyRequest{
 		PathParameters: map[string]string{
 			"schedule_id": "test-schedule-id",
 		},
-		Body: `{"name":"test-name","starts_at":"2021-01-01 00:00:00","ends_at":"2021-01-01 00:00:00","color":"test-color","type":"master"}`,
+		Body: `{"name":"test-name","starts_at":"2021-01-01 00:00:00","ends_at":"2021-01-01 00:00:00","color":"white","type":"master"}`,
 	}
 	req, err := ToPutScheduleRequest(r)
 	assert.Nil(t, err)
@@ -242,11 +423,34 @@ func TestToPutScheduleRequest(t *testing.T) {
 	assert.Equal(t, "test-name", req.Name)
 	assert.Equal(t, "2021-01-01 00:00:00", req.StartsAt)
 	assert.Equal(t, "2021-01-01 00:00:00", req.EndsAt)
-	assert.Equal(t, "test-color", req.Color)
+	assert.Equal(t, "white", req.Color)
 	assert.Equal(t, "master", req.Type)
+	assert.Nil(t, req.Note)
+	assert.Nil(t, req.Links)
+	assert.Nil(t, req.Memo)
+}
+
+func TestToPutScheduleRequest_Detail(t *testing.T) {
+	r := events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{
+			"schedule_id": "test-schedule-id",
+		},
+		Body: `{"name":"test-name","note":"","links":[],"memo":"test-memo"}`,
+	}
+	req, err := ToPutScheduleRequest(r)
+	assert.Nil(t, err)
+	if assert.NotNil(t, req.Note) {
+		assert.Empty(t, *req.Note)
+	}
+	assert.Equal(t, []string{}, req.Links)
+	if assert.NotNil(t, req.Memo) {
+		assert.Equal(t, "test-memo", *req.Memo)
+	}
 }
 
 func TestValidatePutScheduleRequest(t *testing.T) {
+	timed := true
+
 	tests := []struct {
 		name string
 		req  *PutScheduleRequest
@@ -269,7 +473,17 @@ func TestValidatePutScheduleRequest(t *testing.T) {
 		},
 		{
 			name: "正常系: name が50文字の場合はエラーになし",
-			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: strings.Repeat("a", 50), StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: strings.Repeat("a", 50), StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
+			want: nil,
+		},
+		{
+			name: "異常系: timed が true で ends_at が starts_at と同じ場合はエラー",
+			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 20:00:00", EndsAt: "2021-01-01 20:00:00", Timed: &timed, Color: "white", Type: model.ScheduleTypeCustom.String()},
+			want: errors.New("時間指定のスケジュールは終了日時を開始日時より後にしてください"),
+		},
+		{
+			name: "正常系: timed が未指定の場合は時刻の前後を確認しない",
+			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 20:00:00", EndsAt: "2021-01-01 20:00:00", Color: "white", Type: model.ScheduleTypeCustom.String()},
 			want: nil,
 		},
 		{
@@ -285,12 +499,12 @@ func TestValidatePutScheduleRequest(t *testing.T) {
 		{
 			name: "異常系: starts_at のフォーマットが不正な場合はエラー",
 			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01", EndsAt: "2021-01-01 00:00:00"},
-			want: errors.New("開始日は yyyy-MM-dd HH:mm:ss の形式で入力してください"),
+			want: errors.New("開始日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
 		},
 		{
 			name: "異常系: ends_at のフォーマットが不正な場合はエラー",
 			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01"},
-			want: errors.New("終了日は yyyy-MM-dd HH:mm:ss の形式で入力してください"),
+			want: errors.New("終了日は yyyy-MM-dd HH:mm:ss または RFC 3339 の形式で入力してください"),
 		},
 		{
 			name: "異常系: starts_at が ends_at より後の場合はエラー",
@@ -304,17 +518,17 @@ func TestValidatePutScheduleRequest(t *testing.T) {
 		},
 		{
 			name: "異常系: type が未指定の場合はエラー",
-			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color"},
+			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white"},
 			want: errors.New("スケジュールの種類を指定してください"),
 		},
 		{
 			name: "異常系: type が不正な場合はエラー",
-			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: "invalid"},
+			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: "invalid"},
 			want: errors.New("スケジュールの種類は master または custom を指定してください"),
 		},
 		{
 			name: "正常系",
-			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+			req:  &PutScheduleRequest{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
 			want: nil,
 		},
 	}
@@ -322,14 +536,18 @@ func TestValidatePutScheduleRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := ValidatePutScheduleRequest(tt.req)
-			assert.Equal(t, tt.want, err)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want.Error())
 		})
 	}
 }
 
 func TestToPutBulkScheduleRequest(t *testing.T) {
 	r := events.APIGatewayProxyRequest{
-		Body: `{"schedules":[{"id":"test-schedule-id","name":"test-name","starts_at":"2021-01-01 00:00:00","ends_at":"2021-01-01 00:00:00","color":"test-color","type":"master","order":0}]}`,
+		Body: `{"schedules":[{"id":"test-schedule-id","name":"test-name","starts_at":"2021-01-01 00:00:00","ends_at":"2021-01-01 00:00:00","color":"white","type":"master","order":0}]}`,
 	}
 	req, err := ToPutBulkScheduleRequest(r)
 	assert.Nil(t, err)
@@ -338,7 +556,7 @@ func TestToPutBulkScheduleRequest(t *testing.T) {
 	assert.Equal(t, "test-name", req.Schedules[0].Name)
 	assert.Equal(t, "2021-01-01 00:00:00", req.Schedules[0].StartsAt)
 	assert.Equal(t, "2021-01-01 00:00:00", req.Schedules[0].EndsAt)
-	assert.Equal(t, "test-color", req.Schedules[0].Color)
+	assert.Equal(t, "white", req.Schedules[0].Color)
 	assert.Equal(t, "master", req.Schedules[0].Type)
 	assert.Equal(t, 0, req.Schedules[0].Order)
 }
@@ -363,7 +581,7 @@ func TestValidatePutBulkScheduleRequest(t *testing.T) {
 			name: "正常系",
 			req: &PutBulkScheduleRequest{
 				Schedules: []PutScheduleRequest{
-					{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "test-color", Type: model.ScheduleTypeMaster.String()},
+					{ScheduleID: "test-schedule-id", Name: "test-name", StartsAt: "2021-01-01 00:00:00", EndsAt: "2021-01-01 00:00:00", Color: "white", Type: model.ScheduleTypeMaster.String()},
 				},
 			},
 			want: nil,
@@ -373,7 +591,11 @@ func TestValidatePutBulkScheduleRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := ValidatePutBulkScheduleRequest(tt.req)
-			assert.Equal(t, tt.want, err)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want.Error())
 		})
 	}
 }
@@ -409,7 +631,11 @@ func TestValidateDeleteScheduleRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := ValidateDeleteScheduleRequest(tt.req)
-			assert.Equal(t, tt.want, err)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want.Error())
 		})
 	}
 }
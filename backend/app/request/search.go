@@ -0,0 +1,102 @@
+package request
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+)
+
+// GetSearchRequest は検索のリクエストを表す構造体です。
+type GetSearchRequest struct {
+	Query     string
+	Type      string
+	Color     string
+	TagID     string
+	From      string
+	To        string
+	Completed string
+}
+
+// ToGetSearchRequest は APIGatewayProxyRequest から GetSearchRequest に変換します。
+func ToGetSearchRequest(r events.APIGatewayProxyRequest) *GetSearchRequest {
+	return &GetSearchRequest{
+		Query:     r.QueryStringParameters["q"],
+		Type:      r.QueryStringParameters["type"],
+		Color:     r.QueryStringParameters["color"],
+		TagID:     r.QueryStringParameters["tag"],
+		From:      r.QueryStringParameters["from"],
+		To:        r.QueryStringParameters["to"],
+		Completed: r.QueryStringParameters["completed"],
+	}
+}
+
+// ValidateGetSearchRequest は GetSearchRequest のバリデーションを行います。
+func ValidateGetSearchRequest(req *GetSearchRequest) error {
+	// q が空文字
+	if strings.TrimSpace(req.Query) == "" {
+		return fmt.Errorf("検索語を入力してください")
+	}
+
+	// q が100文字より多い
+	const upperQueryLength = 100
+	if utf8.RuneCountInString(req.Query) > upperQueryLength {
+		return fmt.Errorf("検索語は%d文字以内で入力してください", upperQueryLength)
+	}
+
+	// type が不正
+	if req.Type != "" && model.ScheduleType(req.Type).String() == "" {
+		return fmt.Errorf("スケジュールの種類は %s または %s を指定してください", model.ScheduleTypeMaster, model.ScheduleTypeCustom)
+	}
+
+	// from のフォーマットが正しくない
+	var from, to time.Time
+	if req.From != "" {
+		d, err := time.Parse(model.DateFormat, req.From)
+		if err != nil {
+			return fmt.Errorf("開始日は yyyy-MM-dd の形式で入力してください")
+		}
+		from = d
+	}
+
+	// to のフォーマットが正しくない
+	if req.To != "" {
+		d, err := time.Parse(model.DateFormat, req.To)
+		if err != nil {
+			return fmt.Errorf("終了日は yyyy-MM-dd の形式で入力してください")
+		}
+		to = d
+	}
+
+	// from が to より後
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return fmt.Errorf("終了日は開始日以降の日付を入力してください")
+	}
+
+	// completed が true、false 以外
+	if req.Completed != "" {
+		if _, err := strconv.ParseBool(req.Completed); err != nil {
+			return fmt.Errorf("完了状態は true または false を指定してください")
+		}
+	}
+
+	return nil
+}
+
+// CompletedFilter は完了状態の絞り込み条件を返します。
+// 指定されていない場合は nil を返します。
+func (req *GetSearchRequest) CompletedFilter() *bool {
+	if req.Completed == "" {
+		return nil
+	}
+
+	completed, err := strconv.ParseBool(req.Completed)
+	if err != nil {
+		return nil
+	}
+	return &completed
+}
@@ -0,0 +1,108 @@
+package request
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToGetSearchRequest(t *testing.T) {
+	r := events.APIGatewayProxyRequest{
+		QueryStringParameters: map[string]string{
+			"q":         "統計学 第6回",
+			"type":      "custom",
+			"color":     "red",
+			"from":      "2024-04-01",
+			"to":        "2024-09-30",
+			"completed": "false",
+		},
+	}
+	req := ToGetSearchRequest(r)
+
+	assert := assert.New(t)
+	assert.Equal("統計学 第6回", req.Query)
+	assert.Equal("custom", req.Type)
+	assert.Equal("red", req.Color)
+	assert.Equal("2024-04-01", req.From)
+	assert.Equal("2024-09-30", req.To)
+	if assert.NotNil(req.CompletedFilter()) {
+		assert.False(*req.CompletedFilter())
+	}
+}
+
+func TestValidateGetSearchRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *GetSearchRequest
+		want error
+	}{
+		{
+			name: "異常系: 検索語が未指定の場合はエラー",
+			req:  &GetSearchRequest{},
+			want: errors.New("検索語を入力してください"),
+		},
+		{
+			name: "異常系: 検索語が空白のみの場合はエラー",
+			req:  &GetSearchRequest{Query: "　 "},
+			want: errors.New("検索語を入力してください"),
+		},
+		{
+			name: "異常系: 検索語が100文字より多い場合はエラー",
+			req:  &GetSearchRequest{Query: strings.Repeat("あ", 101)},
+			want: errors.New("検索語は100文字以内で入力してください"),
+		},
+		{
+			name: "異常系: 種類が不正な場合はエラー",
+			req:  &GetSearchRequest{Query: "統計", Type: "other"},
+			want: errors.New("スケジュールの種類は master または custom を指定してください"),
+		},
+		{
+			name: "異常系: 開始日の形式が不正な場合はエラー",
+			req:  &GetSearchRequest{Query: "統計", From: "2024/04/01"},
+			want: errors.New("開始日は yyyy-MM-dd の形式で入力してください"),
+		},
+		{
+			name: "異常系: 終了日の形式が不正な場合はエラー",
+			req:  &GetSearchRequest{Query: "統計", To: "2024/09/30"},
+			want: errors.New("終了日は yyyy-MM-dd の形式で入力してください"),
+		},
+		{
+			name: "異常系: 開始日が終了日より後の場合はエラー",
+			req:  &GetSearchRequest{Query: "統計", From: "2024-10-01", To: "2024-09-30"},
+			want: errors.New("終了日は開始日以降の日付を入力してください"),
+		},
+		{
+			name: "異常系: 完了状態が不正な場合はエラー",
+			req:  &GetSearchRequest{Query: "統計", Completed: "yes"},
+			want: errors.New("完了状態は true または false を指定してください"),
+		},
+		{
+			name: "正常系: 検索語のみ",
+			req:  &GetSearchRequest{Query: "統計"},
+			want: nil,
+		},
+		{
+			name: "正常系: すべての条件を指定",
+			req:  &GetSearchRequest{Query: "統計", Type: "master", Color: "red", From: "2024-04-01", To: "2024-04-01", Completed: "true"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateGetSearchRequest(tt.req))
+		})
+	}
+}
+
+func TestGetSearchRequest_CompletedFilter(t *testing.T) {
+	assert.Nil(t, (&GetSearchRequest{}).CompletedFilter())
+
+	completed := (&GetSearchRequest{Completed: "true"}).CompletedFilter()
+	if assert.NotNil(t, completed) {
+		assert.True(t, *completed)
+	}
+}
@@ -0,0 +1,121 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// RefreshSessionRequest はアクセストークンの再発行のリクエストパラメータの構造体です。
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// SignOutRequest はサインアウトのリクエストパラメータの構造体です。
+type SignOutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ToRefreshSessionRequest はアクセストークンの再発行のリクエストパラメータに変換します。
+func ToRefreshSessionRequest(r events.APIGatewayProxyRequest) (*RefreshSessionRequest, error) {
+	var req RefreshSessionRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateRefreshSessionRequest はアクセストークンの再発行のリクエストパラメータを検証します。
+func ValidateRefreshSessionRequest(req *RefreshSessionRequest) error {
+	if req.RefreshToken == "" {
+		return fmt.Errorf("リフレッシュトークンが指定されていません")
+	}
+
+	return nil
+}
+
+// ToSignOutRequest はサインアウトのリクエストパラメータに変換します。
+func ToSignOutRequest(r events.APIGatewayProxyRequest) (*SignOutRequest, error) {
+	var req SignOutRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateSignOutRequest はサインアウトのリクエストパラメータを検証します。
+func ValidateSignOutRequest(req *SignOutRequest) error {
+	if req.RefreshToken == "" {
+		return fmt.Errorf("リフレッシュトークンが指定されていません")
+	}
+
+	return nil
+}
+
+// GetLoginSessionListRequest はログインセッションのリスト取得のリクエストパラメータの構造体です。
+type GetLoginSessionListRequest struct {
+	UserID string
+}
+
+// RevokeLoginSessionRequest はログインセッションの無効化のリクエストパラメータの構造体です。
+type RevokeLoginSessionRequest struct {
+	UserID    string
+	SessionID string
+}
+
+// RevokeAllLoginSessionsRequest はすべてのログインセッションの無効化のリクエストパラメータの構造体です。
+type RevokeAllLoginSessionsRequest struct {
+	UserID string
+}
+
+// ToGetLoginSessionListRequest はログインセッションのリスト取得のリクエストパラメータに変換します。
+func ToGetLoginSessionListRequest(r events.APIGatewayProxyRequest) *GetLoginSessionListRequest {
+	return &GetLoginSessionListRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateGetLoginSessionListRequest はログインセッションのリスト取得のリクエストパラメータを検証します。
+func ValidateGetLoginSessionListRequest(req *GetLoginSessionListRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToRevokeLoginSessionRequest はログインセッションの無効化のリクエストパラメータに変換します。
+func ToRevokeLoginSessionRequest(r events.APIGatewayProxyRequest) *RevokeLoginSessionRequest {
+	return &RevokeLoginSessionRequest{
+		UserID:    r.PathParameters["user_id"],
+		SessionID: r.PathParameters["session_id"],
+	}
+}
+
+// ValidateRevokeLoginSessionRequest はログインセッションの無効化のリクエストパラメータを検証します。
+func ValidateRevokeLoginSessionRequest(req *RevokeLoginSessionRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	if req.SessionID == "" {
+		return fmt.Errorf("セッションIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToRevokeAllLoginSessionsRequest はすべてのログインセッションの無効化のリクエストパラメータに変換します。
+func ToRevokeAllLoginSessionsRequest(r events.APIGatewayProxyRequest) *RevokeAllLoginSessionsRequest {
+	return &RevokeAllLoginSessionsRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateRevokeAllLoginSessionsRequest はすべてのログインセッションの無効化のリクエストパラメータを検証します。
+func ValidateRevokeAllLoginSessionsRequest(req *RevokeAllLoginSessionsRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
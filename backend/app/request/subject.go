@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/model"
 )
 
 // GetSubjectListRequest は科目リスト取得のリクエストを表す構造体です。
@@ -47,14 +48,10 @@ func ToPostSubjectRequest(r events.APIGatewayProxyRequest) (*PostSubjectRequest,
 }
 
 // ValidatePostSubjectRequest は PostSubjectRequest のバリデーションを行います。
+// 違反はすべて model.ValidationError にまとめて返します。
 func ValidatePostSubjectRequest(req *PostSubjectRequest) error {
-	if req.Name == "" {
-		return fmt.Errorf("科目名を入力してください")
-	}
-	if req.Color == "" {
-		return fmt.Errorf("色を指定してください")
-	}
-	return nil
+	subject := model.Subject{Name: req.Name, Color: req.Color}
+	return subject.Validate()
 }
 
 // ToDeleteSubjectRequest は APIGatewayProxyRequest から DeleteSubjectRequest に変換します。
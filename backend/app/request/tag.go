@@ -0,0 +1,197 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// GetTagListRequest はタグリスト取得のリクエストを表す構造体です。
+type GetTagListRequest struct {
+	UserID string
+}
+
+// PostTagRequest はタグ登録のリクエストを表す構造体です。
+type PostTagRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// PutTagRequest はタグ更新のリクエストを表す構造体です。
+type PutTagRequest struct {
+	TagID string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// DeleteTagRequest はタグ削除のリクエストを表す構造体です。
+type DeleteTagRequest struct {
+	TagID string
+}
+
+// PostTagMergeRequest はタグ統合のリクエストを表す構造体です。
+// TagID のタグを TargetID のタグに統合します。
+type PostTagMergeRequest struct {
+	TagID    string `json:"-"`
+	TargetID string `json:"target_id"`
+}
+
+// PutTagSchedulesRequest はタグを付けるスケジュールの一括変更のリクエストを表す構造体です。
+type PutTagSchedulesRequest struct {
+	TagID  string   `json:"-"`
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// ToGetTagListRequest は APIGatewayProxyRequest から GetTagListRequest に変換します。
+func ToGetTagListRequest(r events.APIGatewayProxyRequest) *GetTagListRequest {
+	return &GetTagListRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateGetTagListRequest は GetTagListRequest のバリデーションを行います。
+func ValidateGetTagListRequest(req *GetTagListRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDを指定してください")
+	}
+	return nil
+}
+
+// ValidateInputTagRequest はタグの入力に対するバリデーションを行います。
+func ValidateInputTagRequest(name, color string) error {
+	// name が空文字
+	if name == "" {
+		return fmt.Errorf("タグ名を入力してください")
+	}
+
+	// name が20文字より多い
+	const upperNameLength = 20
+	if utf8.RuneCountInString(name) > upperNameLength {
+		return fmt.Errorf("タグ名は%d文字以内で入力してください", upperNameLength)
+	}
+
+	// color が空文字
+	if color == "" {
+		return fmt.Errorf("色を指定してください")
+	}
+
+	return nil
+}
+
+// ToPostTagRequest は APIGatewayProxyRequest から PostTagRequest に変換します。
+func ToPostTagRequest(r events.APIGatewayProxyRequest) (*PostTagRequest, error) {
+	var req PostTagRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// ValidatePostTagRequest は PostTagRequest のバリデーションを行います。
+func ValidatePostTagRequest(req *PostTagRequest) error {
+	return ValidateInputTagRequest(req.Name, req.Color)
+}
+
+// ToPutTagRequest は APIGatewayProxyRequest から PutTagRequest に変換します。
+func ToPutTagRequest(r events.APIGatewayProxyRequest) (*PutTagRequest, error) {
+	var req PutTagRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.TagID = r.PathParameters["tag_id"]
+
+	return &req, nil
+}
+
+// ValidatePutTagRequest は PutTagRequest のバリデーションを行います。
+func ValidatePutTagRequest(req *PutTagRequest) error {
+	if req.TagID == "" {
+		return fmt.Errorf("タグIDを指定してください")
+	}
+	return ValidateInputTagRequest(req.Name, req.Color)
+}
+
+// ToDeleteTagRequest は APIGatewayProxyRequest から DeleteTagRequest に変換します。
+func ToDeleteTagRequest(r events.APIGatewayProxyRequest) *DeleteTagRequest {
+	return &DeleteTagRequest{TagID: r.PathParameters["tag_id"]}
+}
+
+// ValidateDeleteTagRequest は DeleteTagRequest のバリデーションを行います。
+func ValidateDeleteTagRequest(req *DeleteTagRequest) error {
+	if req.TagID == "" {
+		return fmt.Errorf("タグIDを指定してください")
+	}
+	return nil
+}
+
+// ToPostTagMergeRequest は APIGatewayProxyRequest から PostTagMergeRequest に変換します。
+func ToPostTagMergeRequest(r events.APIGatewayProxyRequest) (*PostTagMergeRequest, error) {
+	var req PostTagMergeRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.TagID = r.PathParameters["tag_id"]
+
+	return &req, nil
+}
+
+// ValidatePostTagMergeRequest は PostTagMergeRequest のバリデーションを行います。
+func ValidatePostTagMergeRequest(req *PostTagMergeRequest) error {
+	if req.TagID == "" {
+		return fmt.Errorf("タグIDを指定してください")
+	}
+
+	// target_id が空文字
+	if req.TargetID == "" {
+		return fmt.Errorf("統合先のタグIDを指定してください")
+	}
+
+	// target_id が統合元と同じ
+	if req.TargetID == req.TagID {
+		return fmt.Errorf("統合先には統合元と異なるタグを指定してください")
+	}
+
+	return nil
+}
+
+// ToPutTagSchedulesRequest は APIGatewayProxyRequest から PutTagSchedulesRequest に変換します。
+func ToPutTagSchedulesRequest(r events.APIGatewayProxyRequest) (*PutTagSchedulesRequest, error) {
+	var req PutTagSchedulesRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.TagID = r.PathParameters["tag_id"]
+
+	return &req, nil
+}
+
+// ValidatePutTagSchedulesRequest は PutTagSchedulesRequest のバリデーションを行います。
+func ValidatePutTagSchedulesRequest(req *PutTagSchedulesRequest) error {
+	if req.TagID == "" {
+		return fmt.Errorf("タグIDを指定してください")
+	}
+
+	// add と remove がどちらも空
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		return fmt.Errorf("タグを付けるまたは外すスケジュールを指定してください")
+	}
+
+	// add と remove が100件より多い
+	const upperSchedules = 100
+	if len(req.Add)+len(req.Remove) > upperSchedules {
+		return fmt.Errorf("スケジュールは%d件以内で指定してください", upperSchedules)
+	}
+
+	// add または remove に空文字が含まれる
+	for _, id := range append(append([]string{}, req.Add...), req.Remove...) {
+		if id == "" {
+			return fmt.Errorf("スケジュールIDを指定してください")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInputTagRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagName string
+		color   string
+		want    error
+	}{
+		{name: "異常系: name が空の場合はエラー", color: "red", want: errors.New("タグ名を入力してください")},
+		{name: "異常系: name が20文字より多い場合はエラー", tagName: strings.Repeat("あ", 21), color: "red", want: errors.New("タグ名は20文字以内で入力してください")},
+		{name: "異常系: color が空の場合はエラー", tagName: "試験", want: errors.New("色を指定してください")},
+		{name: "正常系: name が20文字", tagName: strings.Repeat("あ", 20), color: "red", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateInputTagRequest(tt.tagName, tt.color))
+		})
+	}
+}
+
+func TestToPostTagMergeRequest(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	r := events.APIGatewayProxyRequest{
+		PathParameters: map[string]string{"tag_id": "test-tag-1"},
+		Body:           `{"target_id": "test-tag-2"}`,
+	}
+
+	req, err := ToPostTagMergeRequest(r)
+	require.NoError(err)
+	assert.Equal(&PostTagMergeRequest{TagID: "test-tag-1", TargetID: "test-tag-2"}, req)
+}
+
+func TestValidatePostTagMergeRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *PostTagMergeRequest
+		want error
+	}{
+		{name: "正常系", req: &PostTagMergeRequest{TagID: "test-tag-1", TargetID: "test-tag-2"}, want: nil},
+		{name: "異常系: target_id が空の場合はエラー", req: &PostTagMergeRequest{TagID: "test-tag-1"}, want: errors.New("統合先のタグIDを指定してください")},
+		{name: "異常系: target_id が統合元と同じ場合はエラー", req: &PostTagMergeRequest{TagID: "test-tag-1", TargetID: "test-tag-1"}, want: errors.New("統合先には統合元と異なるタグを指定してください")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidatePostTagMergeRequest(tt.req))
+		})
+	}
+}
+
+func TestValidatePutTagSchedulesRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *PutTagSchedulesRequest
+		want error
+	}{
+		{name: "正常系: 付けるのみ", req: &PutTagSchedulesRequest{TagID: "test-tag-1", Add: []string{"test-id-1"}}, want: nil},
+		{name: "正常系: 外すのみ", req: &PutTagSchedulesRequest{TagID: "test-tag-1", Remove: []string{"test-id-1"}}, want: nil},
+		{name: "異常系: どちらも空の場合はエラー", req: &PutTagSchedulesRequest{TagID: "test-tag-1"}, want: errors.New("タグを付けるまたは外すスケジュールを指定してください")},
+		{name: "異常系: 空文字が含まれる場合はエラー", req: &PutTagSchedulesRequest{TagID: "test-tag-1", Add: []string{"test-id-1"}, Remove: []string{""}}, want: errors.New("スケジュールIDを指定してください")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidatePutTagSchedulesRequest(tt.req))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// GetTwoFactorRequest は二段階認証の設定の取得のリクエストパラメータの構造体です。
+type GetTwoFactorRequest struct {
+	UserID string
+}
+
+// EnrollTwoFactorRequest は二段階認証の設定の開始のリクエストパラメータの構造体です。
+type EnrollTwoFactorRequest struct {
+	UserID string
+}
+
+// ActivateTwoFactorRequest は二段階認証の有効化のリクエストパラメータの構造体です。
+type ActivateTwoFactorRequest struct {
+	UserID string
+	Code   string `json:"code"`
+}
+
+// ResetTwoFactorRequest は二段階認証のリセットのリクエストパラメータの構造体です。
+type ResetTwoFactorRequest struct {
+	UserID string
+}
+
+// VerifyTwoFactorRequest は二段階認証のサインインのリクエストパラメータの構造体です。
+type VerifyTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// ToGetTwoFactorRequest は二段階認証の設定の取得のリクエストパラメータに変換します。
+func ToGetTwoFactorRequest(r events.APIGatewayProxyRequest) *GetTwoFactorRequest {
+	return &GetTwoFactorRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateGetTwoFactorRequest は二段階認証の設定の取得のリクエストパラメータを検証します。
+func ValidateGetTwoFactorRequest(req *GetTwoFactorRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToEnrollTwoFactorRequest は二段階認証の設定の開始のリクエストパラメータに変換します。
+func ToEnrollTwoFactorRequest(r events.APIGatewayProxyRequest) *EnrollTwoFactorRequest {
+	return &EnrollTwoFactorRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateEnrollTwoFactorRequest は二段階認証の設定の開始のリクエストパラメータを検証します。
+func ValidateEnrollTwoFactorRequest(req *EnrollTwoFactorRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToActivateTwoFactorRequest は二段階認証の有効化のリクエストパラメータに変換します。
+func ToActivateTwoFactorRequest(r events.APIGatewayProxyRequest) (*ActivateTwoFactorRequest, error) {
+	var req ActivateTwoFactorRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.UserID = r.PathParameters["user_id"]
+
+	return &req, nil
+}
+
+// ValidateActivateTwoFactorRequest は二段階認証の有効化のリクエストパラメータを検証します。
+func ValidateActivateTwoFactorRequest(req *ActivateTwoFactorRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	if req.Code == "" {
+		return fmt.Errorf("認証コードを入力してください")
+	}
+
+	return nil
+}
+
+// ToResetTwoFactorRequest は二段階認証のリセットのリクエストパラメータに変換します。
+func ToResetTwoFactorRequest(r events.APIGatewayProxyRequest) *ResetTwoFactorRequest {
+	return &ResetTwoFactorRequest{UserID: r.PathParameters["user_id"]}
+}
+
+// ValidateResetTwoFactorRequest は二段階認証のリセットのリクエストパラメータを検証します。
+func ValidateResetTwoFactorRequest(req *ResetTwoFactorRequest) error {
+	if req.UserID == "" {
+		return fmt.Errorf("ユーザーIDが指定されていません")
+	}
+
+	return nil
+}
+
+// ToVerifyTwoFactorRequest は二段階認証のサインインのリクエストパラメータに変換します。
+func ToVerifyTwoFactorRequest(r events.APIGatewayProxyRequest) (*VerifyTwoFactorRequest, error) {
+	var req VerifyTwoFactorRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateVerifyTwoFactorRequest は二段階認証のサインインのリクエストパラメータを検証します。
+func ValidateVerifyTwoFactorRequest(req *VerifyTwoFactorRequest) error {
+	if req.TwoFactorToken == "" {
+		return fmt.Errorf("トークンが指定されていません")
+	}
+
+	if req.Code == "" && req.RecoveryCode == "" {
+		return fmt.Errorf("認証コードを入力してください")
+	}
+
+	return nil
+}
@@ -10,18 +10,21 @@ import (
 
 // SignInRequest はサインインのリクエストパラメータの構造体です。
 type SignInRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	AcceptLanguage string `json:"-"`
 }
 
 // SignUpRequest はサインアップのリクエストパラメータの構造体です。
 type SignUpRequest struct {
-	Email string `json:"email"`
+	Email          string `json:"email"`
+	AcceptLanguage string `json:"-"`
 }
 
 // PasswordResetRequest はパスワードリセットのリクエストパラメータの構造体です。
 type PasswordResetRequest struct {
-	Email string `json:"email"`
+	Email          string `json:"email"`
+	AcceptLanguage string `json:"-"`
 }
 
 // PasswordSetRequest はパスワード設定のリクエストパラメータの構造体です。
@@ -37,8 +40,11 @@ type GetUserRequest struct {
 
 // UpdateUser はユーザー情報更新のリクエストパラメータの構造体です。
 type PutUserRequest struct {
-	UserID string
-	Name   string `json:"name"`
+	UserID             string
+	Name               string `json:"name"`
+	Timezone           string `json:"timezone"`
+	Locale             string `json:"locale"`
+	SequenceStrictness string `json:"sequence_strictness"`
 }
 
 // DeleteUser はユーザー削除のリクエストパラメータの構造体です。
@@ -48,14 +54,31 @@ type DeleteUserRequest struct {
 
 // ResetEmail はメールアドレスリセットのリクエストパラメータの構造体です。
 type ResetEmailRequest struct {
-	UserID string
-	Email  string `json:"email"`
+	UserID         string
+	Email          string `json:"email"`
+	AcceptLanguage string `json:"-"`
 }
 
 // SetEmail はメールアドレス設定のリクエストパラメータの構造体です。
 type SetEmailRequest struct {
-	UserIDToken string `json:"id_token"`
-	EmailToken  string `json:"email_token"`
+	Token string `json:"token"`
+}
+
+// UnlockAccountRequest はロックの解除のリクエストパラメータの構造体です。
+type UnlockAccountRequest struct {
+	Token string `json:"token"`
+}
+
+// RequestMagicLinkRequest はサインインのリンクのリクエストのリクエストパラメータの構造体です。
+type RequestMagicLinkRequest struct {
+	Email          string `json:"email"`
+	AcceptLanguage string `json:"-"`
+}
+
+// SignInMagicLinkRequest はサインインのリンクによるサインインのリクエストパラメータの構造体です。
+type SignInMagicLinkRequest struct {
+	Token string `json:"token"`
+	Nonce string `json:"nonce"`
 }
 
 // ToSignInRequest はサインインのリクエストパラメータへ変換します。
@@ -65,6 +88,8 @@ func ToSignInRequest(r events.APIGatewayProxyRequest) (*SignInRequest, error) {
 		return nil, err
 	}
 
+	req.AcceptLanguage = AcceptLanguage(r)
+
 	return &req, nil
 }
 
@@ -88,6 +113,8 @@ func ToSignUpRequest(r events.APIGatewayProxyRequest) (*SignUpRequest, error) {
 		return nil, err
 	}
 
+	req.AcceptLanguage = AcceptLanguage(r)
+
 	return &req, nil
 }
 
@@ -107,6 +134,8 @@ func ToPasswordResetRequest(r events.APIGatewayProxyRequest) (*PasswordResetRequ
 		return nil, err
 	}
 
+	req.AcceptLanguage = AcceptLanguage(r)
+
 	return &req, nil
 }
 
@@ -181,7 +210,9 @@ func ValidatePutUserRequest(req *PutUserRequest) error {
 		return fmt.Errorf("ユーザーIDが指定されていません")
 	}
 
-	return nil
+	// name、timezone、locale、sequence_strictness は model.User の不変条件で検証する
+	user := model.User{Name: req.Name, Timezone: req.Timezone, Locale: req.Locale, SequenceStrictness: model.SequenceStrictness(req.SequenceStrictness)}
+	return user.ValidateProfile()
 }
 
 // ToDeleteUserRequest はユーザー削除のリクエストパラメータへ変換します。
@@ -208,6 +239,7 @@ func ToResetEmailRequest(r events.APIGatewayProxyRequest) (*ResetEmailRequest, e
 	}
 
 	req.UserID = r.PathParameters["user_id"]
+	req.AcceptLanguage = AcceptLanguage(r)
 
 	return &req, nil
 }
@@ -237,12 +269,71 @@ func ToSetEmailRequest(r events.APIGatewayProxyRequest) (*SetEmailRequest, error
 
 // ValidateSetEmailRequest はメールアドレス設定のリクエストパラメータを検証します。
 func ValidateSetEmailRequest(req *SetEmailRequest) error {
-	if req.UserIDToken == "" {
-		return fmt.Errorf("ユーザーIDトークンが指定されていません")
+	if req.Token == "" {
+		return fmt.Errorf("トークンが指定されていません")
+	}
+
+	return nil
+}
+
+// ToUnlockAccountRequest はロックの解除のリクエストパラメータへ変換します。
+func ToUnlockAccountRequest(r events.APIGatewayProxyRequest) (*UnlockAccountRequest, error) {
+	var req UnlockAccountRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateUnlockAccountRequest はロックの解除のリクエストパラメータを検証します。
+func ValidateUnlockAccountRequest(req *UnlockAccountRequest) error {
+	if req.Token == "" {
+		return fmt.Errorf("トークンが指定されていません")
+	}
+
+	return nil
+}
+
+// ToRequestMagicLinkRequest はサインインのリンクのリクエストのリクエストパラメータへ変換します。
+func ToRequestMagicLinkRequest(r events.APIGatewayProxyRequest) (*RequestMagicLinkRequest, error) {
+	var req RequestMagicLinkRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	req.AcceptLanguage = AcceptLanguage(r)
+
+	return &req, nil
+}
+
+// ValidateRequestMagicLinkRequest はサインインのリンクのリクエストのリクエストパラメータを検証します。
+func ValidateRequestMagicLinkRequest(req *RequestMagicLinkRequest) error {
+	if req.Email == "" {
+		return fmt.Errorf("メールアドレスを入力してください")
+	}
+
+	return nil
+}
+
+// ToSignInMagicLinkRequest はサインインのリンクによるサインインのリクエストパラメータへ変換します。
+func ToSignInMagicLinkRequest(r events.APIGatewayProxyRequest) (*SignInMagicLinkRequest, error) {
+	var req SignInMagicLinkRequest
+	if err := json.Unmarshal([]byte(r.Body), &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// ValidateSignInMagicLinkRequest はサインインのリンクによるサインインのリクエストパラメータを検証します。
+func ValidateSignInMagicLinkRequest(req *SignInMagicLinkRequest) error {
+	if req.Token == "" {
+		return fmt.Errorf("トークンが指定されていません")
 	}
 
-	if req.EmailToken == "" {
-		return fmt.Errorf("メールアドレストークンが指定されていません")
+	if req.Nonce == "" {
+		return fmt.Errorf("nonce が指定されていません")
 	}
 
 	return nil
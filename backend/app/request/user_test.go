@@ -200,3 +200,93 @@ func TestValidatePasswordSetRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePutUserRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *PutUserRequest
+		want error
+	}{
+		{
+			name: "異常系: user_id が未指定の場合はエラー",
+			req:  &PutUserRequest{Name: "test-name"},
+			want: errors.New("ユーザーIDが指定されていません"),
+		},
+		{
+			name: "異常系: timezone が IANA タイムゾーン名でない場合はエラー",
+			req:  &PutUserRequest{UserID: "test-user-id", Timezone: "JST"},
+			want: errors.New("タイムゾーンは IANA タイムゾーン名（例: Asia/Tokyo）で指定してください"),
+		},
+		{
+			name: "正常系: timezone を指定",
+			req:  &PutUserRequest{UserID: "test-user-id", Timezone: "America/New_York"},
+			want: nil,
+		},
+		{
+			name: "正常系: timezone を省略",
+			req:  &PutUserRequest{UserID: "test-user-id"},
+			want: nil,
+		},
+		{
+			name: "異常系: locale が対応していない言語の場合はエラー",
+			req:  &PutUserRequest{UserID: "test-user-id", Locale: "fr"},
+			want: errors.New("言語は ja または en を指定してください"),
+		},
+		{
+			name: "正常系: locale を指定",
+			req:  &PutUserRequest{UserID: "test-user-id", Locale: "en"},
+			want: nil,
+		},
+		{
+			name: "異常系: 講義回の順番の確認が不正な場合はエラー",
+			req:  &PutUserRequest{UserID: "test-user-id", SequenceStrictness: "strict"},
+			want: errors.New("講義回の順番の確認は off、warn、reject のいずれかを指定してください"),
+		},
+		{
+			name: "正常系: 講義回の順番の確認を指定",
+			req:  &PutUserRequest{UserID: "test-user-id", SequenceStrictness: "reject"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePutUserRequest(tt.req)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.want.Error())
+		})
+	}
+}
+
+func TestValidateSignInMagicLinkRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *SignInMagicLinkRequest
+		want error
+	}{
+		{
+			name: "異常系: token が未指定の場合はエラー",
+			req:  &SignInMagicLinkRequest{Nonce: "test-nonce"},
+			want: errors.New("トークンが指定されていません"),
+		},
+		{
+			name: "異常系: nonce が未指定の場合はエラー",
+			req:  &SignInMagicLinkRequest{Token: "test-token"},
+			want: errors.New("nonce が指定されていません"),
+		},
+		{
+			name: "正常系",
+			req:  &SignInMagicLinkRequest{Token: "test-token", Nonce: "test-nonce"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateSignInMagicLinkRequest(tt.req))
+		})
+	}
+}
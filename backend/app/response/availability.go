@@ -0,0 +1,101 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// BaseBlackoutResponse は受講できない期間のレスポンスデータの基本を表す構造体です。
+type BaseBlackoutResponse struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Label     string `json:"label"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// BaseAvailabilityResponse は受講可能日の設定のレスポンスデータの基本を表す構造体です。
+type BaseAvailabilityResponse struct {
+	UserID            string                 `json:"user_id"`
+	Weekdays          []int                  `json:"weekdays"`
+	MaxLecturesPerDay int                    `json:"max_lectures_per_day"`
+	Blackouts         []BaseBlackoutResponse `json:"blackouts"`
+}
+
+// GetAvailabilityResponse は受講可能日の設定取得のレスポンスを表す構造体です。
+type GetAvailabilityResponse BaseAvailabilityResponse
+
+// PutAvailabilityResponse は受講可能日の設定更新のレスポンスを表す構造体です。
+type PutAvailabilityResponse BaseAvailabilityResponse
+
+// PostBlackoutResponse は受講できない期間登録のレスポンスを表す構造体です。
+type PostBlackoutResponse BaseBlackoutResponse
+
+// PutBlackoutResponse は受講できない期間更新のレスポンスを表す構造体です。
+type PutBlackoutResponse BaseBlackoutResponse
+
+// ToGetAvailabilityResponse は受講可能日の設定取得のレスポンスに変換します。
+func ToGetAvailabilityResponse(output *port.GetAvailabilityOutputData) GetAvailabilityResponse {
+	if output == nil {
+		return GetAvailabilityResponse{Weekdays: []int{}, Blackouts: []BaseBlackoutResponse{}}
+	}
+
+	return GetAvailabilityResponse(toBaseAvailabilityResponse(output.Availability))
+}
+
+// ToPutAvailabilityResponse は受講可能日の設定更新のレスポンスに変換します。
+func ToPutAvailabilityResponse(output *port.UpdateAvailabilityOutputData) PutAvailabilityResponse {
+	if output == nil {
+		return PutAvailabilityResponse{Weekdays: []int{}, Blackouts: []BaseBlackoutResponse{}}
+	}
+
+	return PutAvailabilityResponse(toBaseAvailabilityResponse(output.Availability))
+}
+
+// ToPostBlackoutResponse は受講できない期間登録のレスポンスに変換します。
+func ToPostBlackoutResponse(output *port.CreateBlackoutOutputData) PostBlackoutResponse {
+	if output == nil {
+		return PostBlackoutResponse{}
+	}
+
+	return PostBlackoutResponse(toBaseBlackoutResponse(output.Blackout))
+}
+
+// ToPutBlackoutResponse は受講できない期間更新のレスポンスに変換します。
+func ToPutBlackoutResponse(output *port.UpdateBlackoutOutputData) PutBlackoutResponse {
+	if output == nil {
+		return PutBlackoutResponse{}
+	}
+
+	return PutBlackoutResponse(toBaseBlackoutResponse(output.Blackout))
+}
+
+func toBaseAvailabilityResponse(a port.BaseAvailabilityData) BaseAvailabilityResponse {
+	weekdays := a.Weekdays
+	if weekdays == nil {
+		weekdays = []int{}
+	}
+
+	blackouts := []BaseBlackoutResponse{}
+	for _, b := range a.Blackouts {
+		blackouts = append(blackouts, toBaseBlackoutResponse(b))
+	}
+
+	return BaseAvailabilityResponse{
+		UserID:            a.UserID,
+		Weekdays:          weekdays,
+		MaxLecturesPerDay: a.MaxLecturesPerDay,
+		Blackouts:         blackouts,
+	}
+}
+
+func toBaseBlackoutResponse(b port.BaseBlackoutData) BaseBlackoutResponse {
+	return BaseBlackoutResponse{
+		ID:        b.ID,
+		UserID:    b.UserID,
+		Label:     b.Label,
+		StartsAt:  b.StartsAt,
+		EndsAt:    b.EndsAt,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
@@ -5,3 +5,15 @@ var CORSHeaders = map[string]string{
 	"Access-Control-Allow-Methods": "GET,POST,PUT,DELETE,OPTIONS",
 	"Access-Control-Allow-Headers": "Accept,Content-Type,Authorization",
 }
+
+// WithCORSHeaders は CORS のヘッダーに headers を加えたヘッダーを返します。
+func WithCORSHeaders(headers map[string]string) map[string]string {
+	res := make(map[string]string, len(CORSHeaders)+len(headers))
+	for k, v := range CORSHeaders {
+		res[k] = v
+	}
+	for k, v := range headers {
+		res[k] = v
+	}
+	return res
+}
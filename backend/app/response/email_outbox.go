@@ -0,0 +1,98 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// EmailDeliveryAttemptResponse はメールの送信の試行のレスポンスデータを表す構造体です。
+type EmailDeliveryAttemptResponse struct {
+	AttemptedAt string `json:"attempted_at"`
+	MessageID   string `json:"message_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// EmailOutboxResponse は送信するメールのレスポンスデータを表す構造体です。
+type EmailOutboxResponse struct {
+	ID            string                         `json:"id"`
+	To            string                         `json:"to"`
+	Subject       string                         `json:"subject"`
+	Status        string                         `json:"status"`
+	Failures      int                            `json:"failures"`
+	LastError     string                         `json:"last_error"`
+	Attempts      []EmailDeliveryAttemptResponse `json:"attempts"`
+	NextAttemptAt string                         `json:"next_attempt_at"`
+	MessageID     string                         `json:"message_id"`
+	CreatedAt     string                         `json:"created_at"`
+	UpdatedAt     string                         `json:"updated_at"`
+}
+
+// DispatchEmailOutboxResponse は送信待ちのメールの送信のレスポンスを表す構造体です。
+type DispatchEmailOutboxResponse struct {
+	Sent     int `json:"sent"`
+	Retrying int `json:"retrying"`
+	Failed   int `json:"failed"`
+}
+
+// GetFailedEmailOutboxListResponse は送信に失敗したメールのリスト取得のレスポンスを表す構造体です。
+type GetFailedEmailOutboxListResponse struct {
+	Total      int                   `json:"total"`
+	Deliveries []EmailOutboxResponse `json:"deliveries"`
+}
+
+// ResendEmailOutboxResponse は送信に失敗したメールの再送のレスポンスを表す構造体です。
+type ResendEmailOutboxResponse struct {
+	Delivery EmailOutboxResponse `json:"delivery"`
+}
+
+// ToDispatchEmailOutboxResponse は送信待ちのメールの送信のレスポンスに変換します。
+func ToDispatchEmailOutboxResponse(output *port.DispatchEmailOutboxOutputData) DispatchEmailOutboxResponse {
+	if output == nil {
+		return DispatchEmailOutboxResponse{}
+	}
+	return DispatchEmailOutboxResponse{Sent: output.Sent, Retrying: output.Retrying, Failed: output.Failed}
+}
+
+// ToGetFailedEmailOutboxListResponse は送信に失敗したメールのリスト取得のレスポンスに変換します。
+func ToGetFailedEmailOutboxListResponse(output *port.GetFailedEmailOutboxListOutputData) GetFailedEmailOutboxListResponse {
+	if output == nil {
+		return GetFailedEmailOutboxListResponse{Total: 0, Deliveries: []EmailOutboxResponse{}}
+	}
+
+	deliveries := make([]EmailOutboxResponse, 0, len(output.Deliveries))
+	for _, d := range output.Deliveries {
+		deliveries = append(deliveries, toEmailOutboxResponse(d))
+	}
+
+	return GetFailedEmailOutboxListResponse{Total: output.Total, Deliveries: deliveries}
+}
+
+// ToResendEmailOutboxResponse は送信に失敗したメールの再送のレスポンスに変換します。
+func ToResendEmailOutboxResponse(output *port.ResendEmailOutboxOutputData) ResendEmailOutboxResponse {
+	if output == nil {
+		return ResendEmailOutboxResponse{}
+	}
+	return ResendEmailOutboxResponse{Delivery: toEmailOutboxResponse(output.Delivery)}
+}
+
+func toEmailOutboxResponse(d port.EmailOutboxData) EmailOutboxResponse {
+	attempts := make([]EmailDeliveryAttemptResponse, 0, len(d.Attempts))
+	for _, a := range d.Attempts {
+		attempts = append(attempts, EmailDeliveryAttemptResponse{
+			AttemptedAt: a.AttemptedAt,
+			MessageID:   a.MessageID,
+			Error:       a.Error,
+		})
+	}
+
+	return EmailOutboxResponse{
+		ID:            d.ID,
+		To:            d.To,
+		Subject:       d.Subject,
+		Status:        d.Status,
+		Failures:      d.Failures,
+		LastError:     d.LastError,
+		Attempts:      attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		MessageID:     d.MessageID,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
@@ -1,20 +1,174 @@
 package response
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
 )
 
+// RequestIDHeader はリクエスト ID を返すレスポンスヘッダーの名前です。
+const RequestIDHeader = "X-Request-Id"
+
+// ErrorResponse はエラーレスポンスを表す構造体です。
+// Code はクライアントが判定に使うエラーコードで、Errors には項目ごとの違反を格納します。
+// RetryAfter は回数の制限でエラーになった場合に、再試行できるまでの秒数です。
+type ErrorResponse struct {
+	Code       string               `json:"code"`
+	Message    string               `json:"message"`
+	RequestID  string               `json:"request_id,omitempty"`
+	RetryAfter int                  `json:"retry_after,omitempty"`
+	Errors     []FieldErrorResponse `json:"errors,omitempty"`
+}
+
+// FieldErrorResponse は項目ごとの違反のレスポンスを表す構造体です。
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 // NewError はエラーレスポンスを生成します。
-func NewError(statusCode int, message string) (events.APIGatewayProxyResponse, error) {
+func NewError(statusCode int, code port.ErrorCode, message string) (events.APIGatewayProxyResponse, error) {
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
-		Body:       ToErrorBody(message),
+		Body:       ToErrorBody(code, message),
+		Headers:    CORSHeaders,
+	}, nil
+}
+
+// NewBadRequestError はリクエストのバリデーションエラーのレスポンスを生成します。
+// err が model.ValidationError の場合は違反を項目ごとに返します。
+func NewBadRequestError(err error) (events.APIGatewayProxyResponse, error) {
+	ve, ok := model.AsValidationError(err)
+	if !ok {
+		return NewError(http.StatusBadRequest, port.ErrorCodeValidationFailed, err.Error())
+	}
+
+	errors := make([]FieldErrorResponse, 0, len(ve.Errors))
+	for _, fe := range ve.Errors {
+		errors = append(errors, FieldErrorResponse{Field: fe.Field, Message: fe.Message})
+	}
+
+	res := ErrorResponse{
+		Code:    port.ErrorCodeValidationFailed.String(),
+		Message: ve.Error(),
+		Errors:  errors,
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusBadRequest,
+		Body:       toErrorResponseBody(res),
 		Headers:    CORSHeaders,
 	}, nil
 }
 
-func ToErrorBody(message string) string {
-	return fmt.Sprintf(`{"message": "%s"}`, message)
+// ToErrorBody はエラーコードとメッセージからエラーレスポンスのボディを生成します。
+func ToErrorBody(code port.ErrorCode, message string) string {
+	return toErrorResponseBody(ErrorResponse{Code: code.String(), Message: message})
+}
+
+// ToResultErrorBody は usecase の結果からエラーレスポンスのボディを生成します。
+func ToResultErrorBody(result port.Result) string {
+	res := ErrorResponse{
+		Code:       result.ErrorCode.String(),
+		Message:    result.ErrorMessage,
+		RetryAfter: retryAfterSeconds(result.RetryAfter),
+	}
+	for _, fe := range result.Errors {
+		res.Errors = append(res.Errors, FieldErrorResponse{Field: fe.Field, Message: fe.Message})
+	}
+	return toErrorResponseBody(res)
+}
+
+// WithRequestID はレスポンスのヘッダーにリクエスト ID を付け、エラーレスポンスの場合はボディにも含めます。
+// ボディがエラーレスポンスの形式でない場合はボディを変更しません。
+func WithRequestID(res events.APIGatewayProxyResponse, requestID string) events.APIGatewayProxyResponse {
+	if requestID == "" {
+		return res
+	}
+
+	res = withHeader(res, RequestIDHeader, requestID)
+
+	er, ok := parseErrorResponse(res)
+	if !ok {
+		return res
+	}
+	er.RequestID = requestID
+	res.Body = toErrorResponseBody(er)
+
+	return res
+}
+
+// WithRetryAfter はエラーレスポンスのボディに再試行できるまでの秒数がある場合、Retry-After ヘッダーを付けます。
+func WithRetryAfter(res events.APIGatewayProxyResponse) events.APIGatewayProxyResponse {
+	er, ok := parseErrorResponse(res)
+	if !ok || er.RetryAfter <= 0 {
+		return res
+	}
+
+	return withHeader(res, "Retry-After", strconv.Itoa(er.RetryAfter))
+}
+
+// retryAfterSeconds は再試行できるまでの時間を切り上げた秒数に変換します。
+func retryAfterSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// LocalizeError はエラーレスポンスのメッセージを指定された言語に翻訳します。
+// エラーレスポンスでない場合はレスポンスを変更しません。
+func LocalizeError(res events.APIGatewayProxyResponse, locale i18n.Locale) events.APIGatewayProxyResponse {
+	er, ok := parseErrorResponse(res)
+	if !ok {
+		return res
+	}
+
+	er.Message = i18n.Translate(locale, er.Message)
+	for i := range er.Errors {
+		er.Errors[i].Message = i18n.Translate(locale, er.Errors[i].Message)
+	}
+	res.Body = toErrorResponseBody(er)
+
+	return withHeader(res, "Content-Language", locale.String())
+}
+
+// parseErrorResponse はエラーレスポンスのボディを解析します。
+func parseErrorResponse(res events.APIGatewayProxyResponse) (ErrorResponse, bool) {
+	if res.StatusCode < http.StatusBadRequest {
+		return ErrorResponse{}, false
+	}
+
+	var er ErrorResponse
+	if err := json.Unmarshal([]byte(res.Body), &er); err != nil || er.Code == "" {
+		return ErrorResponse{}, false
+	}
+	return er, true
+}
+
+// withHeader はレスポンスのヘッダーを追加します。
+// CORSHeaders を共有しているため、書き換えずに複製します。
+func withHeader(res events.APIGatewayProxyResponse, key, value string) events.APIGatewayProxyResponse {
+	headers := make(map[string]string, len(res.Headers)+1)
+	for k, v := range res.Headers {
+		headers[k] = v
+	}
+	headers[key] = value
+	res.Headers = headers
+
+	return res
+}
+
+func toErrorResponseBody(res ErrorResponse) string {
+	b, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Sprintf(`{"code": "%s", "message": "%s"}`, port.ErrorCodeInternal, res.Message)
+	}
+	return string(b)
 }
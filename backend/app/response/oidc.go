@@ -0,0 +1,43 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// OIDCProviderResponse は ID プロバイダーのレスポンスを表す構造体です。
+type OIDCProviderResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetOIDCProviderListResponse は ID プロバイダーの一覧の取得のレスポンスを表す構造体です。
+type GetOIDCProviderListResponse struct {
+	Providers []OIDCProviderResponse `json:"providers"`
+}
+
+// BeginOIDCSignInResponse は外部アカウントでのサインインの開始のレスポンスを表す構造体です。
+type BeginOIDCSignInResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// ToGetOIDCProviderListResponse は ID プロバイダーの一覧の取得のレスポンスに変換します。
+func ToGetOIDCProviderListResponse(output *port.GetOIDCProviderListOutputData) GetOIDCProviderListResponse {
+	if output == nil {
+		return GetOIDCProviderListResponse{Providers: []OIDCProviderResponse{}}
+	}
+
+	providers := make([]OIDCProviderResponse, 0, len(output.Providers))
+	for _, p := range output.Providers {
+		providers = append(providers, OIDCProviderResponse{ID: p.ID, Name: p.Name})
+	}
+
+	return GetOIDCProviderListResponse{Providers: providers}
+}
+
+// ToBeginOIDCSignInResponse は外部アカウントでのサインインの開始のレスポンスに変換します。
+func ToBeginOIDCSignInResponse(output *port.BeginOIDCSignInOutputData) BeginOIDCSignInResponse {
+	if output == nil {
+		return BeginOIDCSignInResponse{}
+	}
+
+	return BeginOIDCSignInResponse{AuthorizationURL: output.AuthorizationURL, State: output.State}
+}
@@ -0,0 +1,54 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// BasePaletteColorResponse はパレットの色のレスポンスデータの基本を表す構造体です。
+type BasePaletteColorResponse struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Hex    string `json:"hex"`
+	Style  string `json:"style"`
+	Custom bool   `json:"custom"`
+}
+
+// BaseCustomColorResponse はユーザー定義の色のレスポンスデータの基本を表す構造体です。
+type BaseCustomColorResponse struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Hex       string `json:"hex"`
+	Style     string `json:"style"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// GetPaletteResponse はパレット取得のレスポンスを表す構造体です。
+type GetPaletteResponse struct {
+	Colors []BasePaletteColorResponse `json:"colors"`
+}
+
+// PostCustomColorResponse はユーザー定義の色の登録のレスポンスを表す構造体です。
+type PostCustomColorResponse BaseCustomColorResponse
+
+// ToGetPaletteResponse はパレット取得のレスポンスに変換します。
+func ToGetPaletteResponse(output *port.GetPaletteOutputData) GetPaletteResponse {
+	res := GetPaletteResponse{Colors: []BasePaletteColorResponse{}}
+	if output == nil {
+		return res
+	}
+
+	for _, c := range output.Colors {
+		res.Colors = append(res.Colors, BasePaletteColorResponse(c))
+	}
+	return res
+}
+
+// ToPostCustomColorResponse はユーザー定義の色の登録のレスポンスに変換します。
+func ToPostCustomColorResponse(output *port.CreateCustomColorOutputData) PostCustomColorResponse {
+	if output == nil {
+		return PostCustomColorResponse{}
+	}
+
+	return PostCustomColorResponse(output.CustomColor)
+}
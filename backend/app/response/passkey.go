@@ -0,0 +1,204 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// PasskeyResponse はパスキーのレスポンスを表す構造体です。
+type PasskeyResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	BackedUp   bool   `json:"backed_up"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+}
+
+// PublicKeyCredentialDescriptor は WebAuthn の資格情報の指定を表す構造体です。
+type PublicKeyCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// PublicKeyCredentialParameters は WebAuthn の署名のアルゴリズムの指定を表す構造体です。
+type PublicKeyCredentialParameters struct {
+	Type      string `json:"type"`
+	Algorithm int    `json:"alg"`
+}
+
+// PublicKeyCredentialRPEntity は WebAuthn の RP を表す構造体です。
+type PublicKeyCredentialRPEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PublicKeyCredentialUserEntity は WebAuthn のユーザーを表す構造体です。
+type PublicKeyCredentialUserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// AuthenticatorSelectionCriteria は WebAuthn の認証器の条件を表す構造体です。
+type AuthenticatorSelectionCriteria struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PublicKeyCredentialCreationOptions はブラウザの navigator.credentials.create に渡すオプションです。
+// PublicKeyCredential.parseCreationOptionsFromJSON でそのまま読み取れるよう、WebAuthn の仕様の名前を使います。
+type PublicKeyCredentialCreationOptions struct {
+	Challenge              string                          `json:"challenge"`
+	RP                     PublicKeyCredentialRPEntity     `json:"rp"`
+	User                   PublicKeyCredentialUserEntity   `json:"user"`
+	PubKeyCredParams       []PublicKeyCredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int64                           `json:"timeout"`
+	ExcludeCredentials     []PublicKeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelectionCriteria  `json:"authenticatorSelection"`
+	Attestation            string                          `json:"attestation"`
+}
+
+// PublicKeyCredentialRequestOptions はブラウザの navigator.credentials.get に渡すオプションです。
+// PublicKeyCredential.parseRequestOptionsFromJSON でそのまま読み取れるよう、WebAuthn の仕様の名前を使います。
+type PublicKeyCredentialRequestOptions struct {
+	Challenge        string                          `json:"challenge"`
+	RPID             string                          `json:"rpId"`
+	Timeout          int64                           `json:"timeout"`
+	AllowCredentials []PublicKeyCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                          `json:"userVerification"`
+}
+
+// BeginPasskeyRegistrationResponse はパスキーの登録の開始のレスポンスを表す構造体です。
+type BeginPasskeyRegistrationResponse struct {
+	PublicKey PublicKeyCredentialCreationOptions `json:"public_key"`
+}
+
+// FinishPasskeyRegistrationResponse はパスキーの登録の完了のレスポンスを表す構造体です。
+type FinishPasskeyRegistrationResponse PasskeyResponse
+
+// GetPasskeyListResponse はパスキーの一覧の取得のレスポンスを表す構造体です。
+type GetPasskeyListResponse struct {
+	Passkeys []PasskeyResponse `json:"passkeys"`
+}
+
+// BeginPasskeySignInResponse はパスキーのサインインの開始のレスポンスを表す構造体です。
+type BeginPasskeySignInResponse struct {
+	PublicKey PublicKeyCredentialRequestOptions `json:"public_key"`
+}
+
+// FinishPasskeySignInResponse はパスキーのサインインの完了のレスポンスを表す構造体です。
+type FinishPasskeySignInResponse SignInResponse
+
+// WebAuthn のオプションの値です。パスキーとして使えるよう、認証器に保存される資格情報とユーザーの検証を必須にします。
+const (
+	publicKeyCredentialType  = "public-key"
+	residentKeyRequired      = "required"
+	userVerificationRequired = "required"
+	attestationNone          = "none"
+)
+
+// ToBeginPasskeyRegistrationResponse はパスキーの登録の開始のレスポンスに変換します。
+func ToBeginPasskeyRegistrationResponse(output *port.BeginPasskeyRegistrationOutputData) BeginPasskeyRegistrationResponse {
+	if output == nil {
+		return BeginPasskeyRegistrationResponse{}
+	}
+
+	params := make([]PublicKeyCredentialParameters, 0, len(output.Algorithms))
+	for _, alg := range output.Algorithms {
+		params = append(params, PublicKeyCredentialParameters{Type: publicKeyCredentialType, Algorithm: alg})
+	}
+
+	excludes := make([]PublicKeyCredentialDescriptor, 0, len(output.ExcludeCredentialIDs))
+	for _, id := range output.ExcludeCredentialIDs {
+		excludes = append(excludes, PublicKeyCredentialDescriptor{Type: publicKeyCredentialType, ID: id})
+	}
+
+	return BeginPasskeyRegistrationResponse{
+		PublicKey: PublicKeyCredentialCreationOptions{
+			Challenge: output.Challenge,
+			RP:        PublicKeyCredentialRPEntity{ID: output.RPID, Name: output.RPName},
+			User: PublicKeyCredentialUserEntity{
+				ID:          output.UserHandle,
+				Name:        output.UserName,
+				DisplayName: output.UserDisplayName,
+			},
+			PubKeyCredParams:   params,
+			Timeout:            output.TimeoutMilliseconds,
+			ExcludeCredentials: excludes,
+			AuthenticatorSelection: AuthenticatorSelectionCriteria{
+				ResidentKey:      residentKeyRequired,
+				UserVerification: userVerificationRequired,
+			},
+			Attestation: attestationNone,
+		},
+	}
+}
+
+// ToPasskeyResponse はパスキーのレスポンスに変換します。
+func ToPasskeyResponse(passkey port.PasskeyData) PasskeyResponse {
+	return PasskeyResponse{
+		ID:         passkey.ID,
+		Name:       passkey.Name,
+		BackedUp:   passkey.BackedUp,
+		CreatedAt:  passkey.CreatedAt,
+		LastUsedAt: passkey.LastUsedAt,
+	}
+}
+
+// ToFinishPasskeyRegistrationResponse はパスキーの登録の完了のレスポンスに変換します。
+func ToFinishPasskeyRegistrationResponse(output *port.FinishPasskeyRegistrationOutputData) FinishPasskeyRegistrationResponse {
+	if output == nil {
+		return FinishPasskeyRegistrationResponse{}
+	}
+
+	return FinishPasskeyRegistrationResponse(ToPasskeyResponse(output.PasskeyData))
+}
+
+// ToGetPasskeyListResponse はパスキーの一覧の取得のレスポンスに変換します。
+func ToGetPasskeyListResponse(output *port.GetPasskeyListOutputData) GetPasskeyListResponse {
+	if output == nil {
+		return GetPasskeyListResponse{Passkeys: []PasskeyResponse{}}
+	}
+
+	passkeys := make([]PasskeyResponse, 0, len(output.Passkeys))
+	for _, p := range output.Passkeys {
+		passkeys = append(passkeys, ToPasskeyResponse(p))
+	}
+
+	return GetPasskeyListResponse{Passkeys: passkeys}
+}
+
+// ToBeginPasskeySignInResponse はパスキーのサインインの開始のレスポンスに変換します。
+func ToBeginPasskeySignInResponse(output *port.BeginPasskeySignInOutputData) BeginPasskeySignInResponse {
+	if output == nil {
+		return BeginPasskeySignInResponse{}
+	}
+
+	return BeginPasskeySignInResponse{
+		PublicKey: PublicKeyCredentialRequestOptions{
+			Challenge:        output.Challenge,
+			RPID:             output.RPID,
+			Timeout:          output.TimeoutMilliseconds,
+			AllowCredentials: []PublicKeyCredentialDescriptor{},
+			UserVerification: userVerificationRequired,
+		},
+	}
+}
+
+// ToFinishPasskeySignInResponse はパスキーのサインインの完了のレスポンスに変換します。
+func ToFinishPasskeySignInResponse(output *port.FinishPasskeySignInOutputData) FinishPasskeySignInResponse {
+	if output == nil {
+		return FinishPasskeySignInResponse{}
+	}
+
+	return FinishPasskeySignInResponse{
+		ID:                 output.ID,
+		Email:              output.Email,
+		Name:               output.Name,
+		Timezone:           output.Timezone,
+		Locale:             output.Locale,
+		SequenceStrictness: output.SequenceStrictness,
+		CreatedAt:          output.CreatedAt,
+		UpdatedAt:          output.UpdatedAt,
+		SessionToken:       output.SessionToken,
+		RefreshToken:       output.RefreshToken,
+		ExpiresIn:          output.ExpiresIn,
+	}
+}
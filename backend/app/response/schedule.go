@@ -1,27 +1,78 @@
 package response
 
 import (
+	"encoding/json"
+
 	"github.com/datsukan/attendance-plan/backend/app/port"
 )
 
+// ICalendarHeaders はスケジュールのエクスポートのレスポンスのヘッダーです。
+var ICalendarHeaders = WithCORSHeaders(map[string]string{
+	"Content-Type":        "text/calendar; charset=utf-8",
+	"Content-Disposition": `attachment; filename="schedules.ics"`,
+})
+
 // ScheduleResponse はスケジュールのレスポンスを表す構造体です。
 type ScheduleResponse struct {
-	ID        string `json:"id"`
-	UserID    string `json:"user_id"`
-	Name      string `json:"name"`
-	StartsAt  string `json:"starts_at"`
-	EndsAt    string `json:"ends_at"`
-	Color     string `json:"color"`
-	Type      string `json:"type"`
-	Order     int    `json:"order"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID            string   `json:"id"`
+	UserID        string   `json:"user_id"`
+	Name          string   `json:"name"`
+	StartsAt      string   `json:"starts_at"`
+	EndsAt        string   `json:"ends_at"`
+	Timed         bool     `json:"timed"`
+	Color         string   `json:"color"`
+	Type          string   `json:"type"`
+	Order         int      `json:"order"`
+	LectureNumber int      `json:"lecture_number"`
+	Note          string   `json:"note"`
+	Links         []string `json:"links"`
+	Memo          string   `json:"memo"`
+	TagIDs        []string `json:"tag_ids"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
+
+// WarningResponse はスケジュールの警告のレスポンスを表す構造体です。
+type WarningResponse struct {
+	Code        string   `json:"code"`
+	Message     string   `json:"message"`
+	Date        string   `json:"date"`
+	ScheduleIDs []string `json:"schedule_ids"`
+}
+
+// ScheduleListItemResponse は項目を選択できるスケジュールのレスポンスを表す構造体です。
+// Fields が指定されている場合は id と Fields に含まれる項目のみを出力します。
+type ScheduleListItemResponse struct {
+	ScheduleResponse
+	Fields []string `json:"-"`
+}
+
+// MarshalJSON は Fields に含まれる項目のみを JSON に変換します。
+func (r ScheduleListItemResponse) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(r.ScheduleResponse)
+	if err != nil || len(r.Fields) == 0 {
+		return b, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]json.RawMessage{"id": all["id"]}
+	for _, f := range r.Fields {
+		if v, ok := all[f]; ok {
+			selected[f] = v
+		}
+	}
+
+	return json.Marshal(selected)
 }
 
 type ScheduleResponseDateItem struct {
-	Date      string             `json:"date"`
-	Type      string             `json:"type"`
-	Schedules []ScheduleResponse `json:"schedules"`
+	Date      string                     `json:"date"`
+	Type      string                     `json:"type"`
+	Schedules []ScheduleListItemResponse `json:"schedules"`
 }
 
 // GetScheduleListResponse はスケジュールリスト取得のレスポンスを表す構造体です。
@@ -30,67 +81,72 @@ type GetScheduleListResponse struct {
 	CustomSchedules []ScheduleResponseDateItem `json:"custom_schedules"`
 }
 
+// GetFlatScheduleListResponse は日付ごとにまとめないスケジュールリスト取得のレスポンスを表す構造体です。
+type GetFlatScheduleListResponse struct {
+	Schedules []ScheduleListItemResponse `json:"schedules"`
+}
+
 // GetScheduleResponse はスケジュール取得のレスポンスを表す構造体です。
 type GetScheduleResponse ScheduleResponse
 
 // PostScheduleResponse はスケジュール登録のレスポンスを表す構造体です。
-type PostScheduleResponse ScheduleResponse
+type PostScheduleResponse struct {
+	ScheduleResponse
+	Warnings []WarningResponse `json:"warnings"`
+}
 
 // PostBulkScheduleResponse はスケジュール一括登録のレスポンスを表す構造体です。
 type PostBulkScheduleResponse struct {
 	Schedules []ScheduleResponse `json:"schedules"`
+	Warnings  []WarningResponse  `json:"warnings"`
 }
 
 // PutScheduleResponse はスケジュール更新のレスポンスを表す構造体です。
-type PutScheduleResponse ScheduleResponse
+type PutScheduleResponse struct {
+	ScheduleResponse
+	Warnings []WarningResponse `json:"warnings"`
+}
 
 type PutBulkScheduleResponse struct {
 	Schedules []ScheduleResponse `json:"schedules"`
+	Warnings  []WarningResponse  `json:"warnings"`
+}
+
+// GetScheduleLintResponse はスケジュールの警告一覧取得のレスポンスを表す構造体です。
+type GetScheduleLintResponse struct {
+	Warnings []WarningResponse `json:"warnings"`
 }
 
 // ToGetScheduleListResponse はスケジュールリスト取得のレスポンスに変換します。
 func ToGetScheduleListResponse(output *port.GetScheduleListOutputData) GetScheduleListResponse {
-	if output == nil || (len(output.MasterSchedules) == 0 && len(output.CustomSchedules) == 0) {
+	if output == nil {
 		return GetScheduleListResponse{
 			MasterSchedules: []ScheduleResponseDateItem{},
 			CustomSchedules: []ScheduleResponseDateItem{},
 		}
 	}
 
-	var ms []ScheduleResponseDateItem
-	for _, s := range output.MasterSchedules {
-		di := ScheduleResponseDateItem{
-			Date:      s.Date,
-			Type:      s.Type,
-			Schedules: []ScheduleResponse{},
-		}
-
-		for _, ss := range s.Schedules {
-			di.Schedules = append(di.Schedules, ScheduleResponse(ss))
-		}
+	ms := toScheduleResponseDateItems(output.MasterSchedules, output.Fields)
+	cs := toScheduleResponseDateItems(output.CustomSchedules, output.Fields)
 
-		ms = append(ms, di)
+	return GetScheduleListResponse{
+		MasterSchedules: ms,
+		CustomSchedules: cs,
 	}
+}
 
-	var cs []ScheduleResponseDateItem
-	for _, s := range output.CustomSchedules {
-		di := ScheduleResponseDateItem{
-			Date:      s.Date,
-			Type:      s.Type,
-			Schedules: []ScheduleResponse{},
-		}
-
-		for _, ss := range s.Schedules {
-			di.Schedules = append(di.Schedules, ScheduleResponse(ss))
-		}
-
-		cs = append(cs, di)
+// ToGetFlatScheduleListResponse は日付ごとにまとめないスケジュールリスト取得のレスポンスに変換します。
+func ToGetFlatScheduleListResponse(output *port.GetScheduleListOutputData) GetFlatScheduleListResponse {
+	res := GetFlatScheduleListResponse{Schedules: []ScheduleListItemResponse{}}
+	if output == nil {
+		return res
 	}
 
-	return GetScheduleListResponse{
-		MasterSchedules: ms,
-		CustomSchedules: cs,
+	for _, s := range output.Schedules {
+		res.Schedules = append(res.Schedules, ScheduleListItemResponse{ScheduleResponse: ScheduleResponse(s), Fields: output.Fields})
 	}
+
+	return res
 }
 
 // ToGetScheduleResponse はスケジュール取得のレスポンスに変換します。
@@ -105,20 +161,29 @@ func ToGetScheduleResponse(output *port.GetScheduleOutputData) GetScheduleRespon
 // ToPostScheduleResponse はスケジュール登録のレスポンスに変換します。
 func ToPostScheduleResponse(output *port.CreateScheduleOutputData) PostScheduleResponse {
 	if output == nil {
-		return PostScheduleResponse{}
+		return PostScheduleResponse{Warnings: []WarningResponse{}}
 	}
 
 	return PostScheduleResponse{
-		ID:        output.Schedule.ID,
-		UserID:    output.Schedule.UserID,
-		Name:      output.Schedule.Name,
-		StartsAt:  output.Schedule.StartsAt,
-		EndsAt:    output.Schedule.EndsAt,
-		Color:     output.Schedule.Color,
-		Type:      output.Schedule.Type,
-		Order:     output.Schedule.Order,
-		CreatedAt: output.Schedule.CreatedAt,
-		UpdatedAt: output.Schedule.UpdatedAt,
+		ScheduleResponse: ScheduleResponse{
+			ID:            output.Schedule.ID,
+			UserID:        output.Schedule.UserID,
+			Name:          output.Schedule.Name,
+			StartsAt:      output.Schedule.StartsAt,
+			EndsAt:        output.Schedule.EndsAt,
+			Timed:         output.Schedule.Timed,
+			Color:         output.Schedule.Color,
+			Type:          output.Schedule.Type,
+			Order:         output.Schedule.Order,
+			LectureNumber: output.Schedule.LectureNumber,
+			Note:          output.Schedule.Note,
+			Links:         output.Schedule.Links,
+			Memo:          output.Schedule.Memo,
+			TagIDs:        output.Schedule.TagIDs,
+			CreatedAt:     output.Schedule.CreatedAt,
+			UpdatedAt:     output.Schedule.UpdatedAt,
+		},
+		Warnings: toWarningResponses(output.Warnings),
 	}
 }
 
@@ -127,6 +192,7 @@ func ToPostBulkScheduleResponse(output *port.CreateBulkScheduleOutputData) PostB
 	if output == nil || len(output.Schedules) == 0 {
 		return PostBulkScheduleResponse{
 			Schedules: []ScheduleResponse{},
+			Warnings:  []WarningResponse{},
 		}
 	}
 
@@ -137,16 +203,20 @@ func ToPostBulkScheduleResponse(output *port.CreateBulkScheduleOutputData) PostB
 
 	return PostBulkScheduleResponse{
 		Schedules: ss,
+		Warnings:  toWarningResponses(output.Warnings),
 	}
 }
 
 // ToPutScheduleResponse はスケジュール更新のレスポンスに変換します。
 func ToPutScheduleResponse(output *port.UpdateScheduleOutputData) PutScheduleResponse {
 	if output == nil {
-		return PutScheduleResponse{}
+		return PutScheduleResponse{Warnings: []WarningResponse{}}
 	}
 
-	return PutScheduleResponse(output.Schedule)
+	return PutScheduleResponse{
+		ScheduleResponse: ScheduleResponse(output.Schedule),
+		Warnings:         toWarningResponses(output.Warnings),
+	}
 }
 
 // ToPutBulkScheduleResponse はスケジュール一括更新のレスポンスに変換します。
@@ -154,6 +224,7 @@ func ToPutBulkScheduleResponse(output *port.UpdateBulkScheduleOutputData) PutBul
 	if output == nil || len(output.Schedules) == 0 {
 		return PutBulkScheduleResponse{
 			Schedules: []ScheduleResponse{},
+			Warnings:  []WarningResponse{},
 		}
 	}
 
@@ -164,5 +235,43 @@ func ToPutBulkScheduleResponse(output *port.UpdateBulkScheduleOutputData) PutBul
 
 	return PutBulkScheduleResponse{
 		Schedules: ss,
+		Warnings:  toWarningResponses(output.Warnings),
+	}
+}
+
+// ToGetScheduleLintResponse はスケジュールの警告一覧取得のレスポンスに変換します。
+func ToGetScheduleLintResponse(output *port.LintScheduleOutputData) GetScheduleLintResponse {
+	if output == nil {
+		return GetScheduleLintResponse{Warnings: []WarningResponse{}}
+	}
+
+	return GetScheduleLintResponse{Warnings: toWarningResponses(output.Warnings)}
+}
+
+func toWarningResponses(warnings []port.BaseWarningData) []WarningResponse {
+	res := make([]WarningResponse, 0, len(warnings))
+	for _, w := range warnings {
+		res = append(res, WarningResponse(w))
+	}
+	return res
+}
+
+// toScheduleResponseDateItems は日付ごとのスケジュールの出力データをレスポンスに変換します。
+// fields を指定した場合は各スケジュールの項目を選択します。
+func toScheduleResponseDateItems(items []port.BaseDateItemData, fields []string) []ScheduleResponseDateItem {
+	res := []ScheduleResponseDateItem{}
+	for _, s := range items {
+		di := ScheduleResponseDateItem{
+			Date:      s.Date,
+			Type:      s.Type,
+			Schedules: []ScheduleListItemResponse{},
+		}
+
+		for _, ss := range s.Schedules {
+			di.Schedules = append(di.Schedules, ScheduleListItemResponse{ScheduleResponse: ScheduleResponse(ss), Fields: fields})
+		}
+
+		res = append(res, di)
 	}
+	return res
 }
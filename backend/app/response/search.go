@@ -0,0 +1,29 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// GetSearchResponse は検索のレスポンスを表す構造体です。
+type GetSearchResponse struct {
+	Schedules []ScheduleResponseDateItem `json:"schedules"`
+	Subjects  []BaseSubjectResponse      `json:"subjects"`
+}
+
+// ToGetSearchResponse は検索のレスポンスに変換します。
+func ToGetSearchResponse(output *port.SearchOutputData) GetSearchResponse {
+	res := GetSearchResponse{
+		Schedules: []ScheduleResponseDateItem{},
+		Subjects:  []BaseSubjectResponse{},
+	}
+
+	if output == nil {
+		return res
+	}
+
+	res.Schedules = toScheduleResponseDateItems(output.Schedules, nil)
+
+	for _, s := range output.Subjects {
+		res.Subjects = append(res.Subjects, BaseSubjectResponse(s))
+	}
+
+	return res
+}
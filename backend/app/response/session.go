@@ -0,0 +1,103 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// RefreshSessionResponse はアクセストークンの再発行のレスポンスを表す構造体です。
+type RefreshSessionResponse struct {
+	SessionToken string `json:"session_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// ToRefreshSessionResponse はアクセストークンの再発行のレスポンスに変換します。
+func ToRefreshSessionResponse(output *port.RefreshSessionOutputData) RefreshSessionResponse {
+	if output == nil {
+		return RefreshSessionResponse{}
+	}
+
+	return RefreshSessionResponse{
+		SessionToken: output.SessionToken,
+		RefreshToken: output.RefreshToken,
+		ExpiresIn:    output.ExpiresIn,
+	}
+}
+
+// LoginSessionResponse はログインセッションのレスポンスデータを表す構造体です。
+type LoginSessionResponse struct {
+	ID         string `json:"id"`
+	Device     string `json:"device"`
+	IPAddress  string `json:"ip_address"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+}
+
+// GetLoginSessionListResponse はログインセッションのリスト取得のレスポンスを表す構造体です。
+type GetLoginSessionListResponse struct {
+	Sessions []LoginSessionResponse `json:"sessions"`
+}
+
+// ToGetLoginSessionListResponse はログインセッションのリスト取得のレスポンスに変換します。
+func ToGetLoginSessionListResponse(output *port.GetLoginSessionListOutputData) GetLoginSessionListResponse {
+	if output == nil {
+		return GetLoginSessionListResponse{Sessions: []LoginSessionResponse{}}
+	}
+
+	sessions := make([]LoginSessionResponse, 0, len(output.Sessions))
+	for _, s := range output.Sessions {
+		sessions = append(sessions, LoginSessionResponse{
+			ID:         s.ID,
+			Device:     s.Device,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+		})
+	}
+
+	return GetLoginSessionListResponse{Sessions: sessions}
+}
+
+// JWKSHeaders は JWKS のレスポンスのヘッダーです。検証する側がキャッシュできるようにします。
+var JWKSHeaders = WithCORSHeaders(map[string]string{
+	"Content-Type":  "application/json",
+	"Cache-Control": "public, max-age=300",
+})
+
+// JSONWebKeyResponse は JWKS の公開鍵のレスポンスデータを表す構造体です。
+type JSONWebKeyResponse struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv,omitempty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// GetJWKSResponse は JWKS の取得のレスポンスを表す構造体です。
+type GetJWKSResponse struct {
+	Keys []JSONWebKeyResponse `json:"keys"`
+}
+
+// ToGetJWKSResponse は JWKS の取得のレスポンスに変換します。
+func ToGetJWKSResponse(output *port.GetJWKSOutputData) GetJWKSResponse {
+	if output == nil {
+		return GetJWKSResponse{Keys: []JSONWebKeyResponse{}}
+	}
+
+	keys := make([]JSONWebKeyResponse, 0, len(output.Keys))
+	for _, k := range output.Keys {
+		keys = append(keys, JSONWebKeyResponse{
+			KeyType:   k.KeyType,
+			Curve:     k.Curve,
+			Algorithm: k.Algorithm,
+			Use:       k.Use,
+			KeyID:     k.KeyID,
+			N:         k.N,
+			E:         k.E,
+			X:         k.X,
+		})
+	}
+
+	return GetJWKSResponse{Keys: keys}
+}
@@ -0,0 +1,101 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// BaseTagResponse はタグのレスポンスデータの基本を表す構造体です。
+type BaseTagResponse struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// GetTagListResponse はタグリスト取得のレスポンスを表す構造体です。
+type GetTagListResponse struct {
+	Tags []BaseTagResponse `json:"tags"`
+}
+
+// PostTagResponse はタグ登録のレスポンスを表す構造体です。
+type PostTagResponse BaseTagResponse
+
+// PutTagResponse はタグ更新のレスポンスを表す構造体です。
+type PutTagResponse BaseTagResponse
+
+// PostTagMergeResponse はタグ統合のレスポンスを表す構造体です。
+type PostTagMergeResponse struct {
+	Tag         BaseTagResponse `json:"tag"`
+	ScheduleIDs []string        `json:"schedule_ids"`
+}
+
+// PutTagSchedulesResponse はタグを付けるスケジュールの一括変更のレスポンスを表す構造体です。
+type PutTagSchedulesResponse struct {
+	TagID       string   `json:"tag_id"`
+	ScheduleIDs []string `json:"schedule_ids"`
+}
+
+// ToGetTagListResponse はタグリスト取得のレスポンスに変換します。
+func ToGetTagListResponse(output *port.GetTagListOutputData) GetTagListResponse {
+	res := GetTagListResponse{Tags: []BaseTagResponse{}}
+	if output == nil {
+		return res
+	}
+
+	for _, t := range output.Tags {
+		res.Tags = append(res.Tags, BaseTagResponse(t))
+	}
+	return res
+}
+
+// ToPostTagResponse はタグ登録のレスポンスに変換します。
+func ToPostTagResponse(output *port.CreateTagOutputData) PostTagResponse {
+	if output == nil {
+		return PostTagResponse{}
+	}
+
+	return PostTagResponse(output.Tag)
+}
+
+// ToPutTagResponse はタグ更新のレスポンスに変換します。
+func ToPutTagResponse(output *port.UpdateTagOutputData) PutTagResponse {
+	if output == nil {
+		return PutTagResponse{}
+	}
+
+	return PutTagResponse(output.Tag)
+}
+
+// ToPostTagMergeResponse はタグ統合のレスポンスに変換します。
+func ToPostTagMergeResponse(output *port.MergeTagOutputData) PostTagMergeResponse {
+	if output == nil {
+		return PostTagMergeResponse{ScheduleIDs: []string{}}
+	}
+
+	ids := output.ScheduleIDs
+	if ids == nil {
+		ids = []string{}
+	}
+
+	return PostTagMergeResponse{
+		Tag:         BaseTagResponse(output.Tag),
+		ScheduleIDs: ids,
+	}
+}
+
+// ToPutTagSchedulesResponse はタグを付けるスケジュールの一括変更のレスポンスに変換します。
+func ToPutTagSchedulesResponse(output *port.AssignTagOutputData) PutTagSchedulesResponse {
+	if output == nil {
+		return PutTagSchedulesResponse{ScheduleIDs: []string{}}
+	}
+
+	ids := output.ScheduleIDs
+	if ids == nil {
+		ids = []string{}
+	}
+
+	return PutTagSchedulesResponse{
+		TagID:       output.TagID,
+		ScheduleIDs: ids,
+	}
+}
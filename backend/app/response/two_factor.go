@@ -0,0 +1,96 @@
+package response
+
+import "github.com/datsukan/attendance-plan/backend/app/port"
+
+// TwoFactorChallengeResponse は二段階認証が有効なユーザーのサインインのレスポンスを表す構造体です。
+// TwoFactorToken は認証コードとともに二段階認証のサインインに送るトークンです。
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	TwoFactorToken    string `json:"two_factor_token"`
+}
+
+// GetTwoFactorResponse は二段階認証の設定の取得のレスポンスを表す構造体です。
+type GetTwoFactorResponse struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// EnrollTwoFactorResponse は二段階認証の設定の開始のレスポンスを表す構造体です。
+type EnrollTwoFactorResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// ActivateTwoFactorResponse は二段階認証の有効化のレスポンスを表す構造体です。
+type ActivateTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// VerifyTwoFactorResponse は二段階認証のサインインのレスポンスを表す構造体です。
+type VerifyTwoFactorResponse SignInResponse
+
+// ToTwoFactorChallengeResponse は二段階認証が有効なユーザーのサインインのレスポンスに変換します。
+func ToTwoFactorChallengeResponse(output *port.SignInOutputData) TwoFactorChallengeResponse {
+	if output == nil {
+		return TwoFactorChallengeResponse{}
+	}
+
+	return TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		TwoFactorToken:    output.TwoFactorToken,
+	}
+}
+
+// ToGetTwoFactorResponse は二段階認証の設定の取得のレスポンスに変換します。
+func ToGetTwoFactorResponse(output *port.GetTwoFactorOutputData) GetTwoFactorResponse {
+	if output == nil {
+		return GetTwoFactorResponse{}
+	}
+
+	return GetTwoFactorResponse{
+		Enabled:                output.Enabled,
+		RecoveryCodesRemaining: output.RecoveryCodesRemaining,
+	}
+}
+
+// ToEnrollTwoFactorResponse は二段階認証の設定の開始のレスポンスに変換します。
+func ToEnrollTwoFactorResponse(output *port.EnrollTwoFactorOutputData) EnrollTwoFactorResponse {
+	if output == nil {
+		return EnrollTwoFactorResponse{}
+	}
+
+	return EnrollTwoFactorResponse{
+		Secret: output.Secret,
+		URI:    output.URI,
+	}
+}
+
+// ToActivateTwoFactorResponse は二段階認証の有効化のレスポンスに変換します。
+func ToActivateTwoFactorResponse(output *port.ActivateTwoFactorOutputData) ActivateTwoFactorResponse {
+	if output == nil {
+		return ActivateTwoFactorResponse{RecoveryCodes: []string{}}
+	}
+
+	return ActivateTwoFactorResponse{RecoveryCodes: output.RecoveryCodes}
+}
+
+// ToVerifyTwoFactorResponse は二段階認証のサインインのレスポンスに変換します。
+func ToVerifyTwoFactorResponse(output *port.VerifyTwoFactorOutputData) VerifyTwoFactorResponse {
+	if output == nil {
+		return VerifyTwoFactorResponse{}
+	}
+
+	return VerifyTwoFactorResponse{
+		ID:                 output.ID,
+		Email:              output.Email,
+		Name:               output.Name,
+		Timezone:           output.Timezone,
+		Locale:             output.Locale,
+		SequenceStrictness: output.SequenceStrictness,
+		CreatedAt:          output.CreatedAt,
+		UpdatedAt:          output.UpdatedAt,
+		SessionToken:       output.SessionToken,
+		RefreshToken:       output.RefreshToken,
+		ExpiresIn:          output.ExpiresIn,
+	}
+}
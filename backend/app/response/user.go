@@ -4,21 +4,34 @@ import "github.com/datsukan/attendance-plan/backend/app/port"
 
 // UserResponse はユーザーのレスポンスを表す構造体です。
 type UserResponse struct {
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Name      string `json:"name"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID                 string `json:"id"`
+	Email              string `json:"email"`
+	Name               string `json:"name"`
+	Timezone           string `json:"timezone"`
+	Locale             string `json:"locale"`
+	SequenceStrictness string `json:"sequence_strictness"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
 }
 
 // SignInResponse はサインインのレスポンスを表す構造体です。
 type SignInResponse struct {
-	ID           string `json:"id"`
-	Email        string `json:"email"`
-	Name         string `json:"name"`
-	CreatedAt    string `json:"created_at"`
-	UpdatedAt    string `json:"updated_at"`
-	SessionToken string `json:"session_token"`
+	ID                 string `json:"id"`
+	Email              string `json:"email"`
+	Name               string `json:"name"`
+	Timezone           string `json:"timezone"`
+	Locale             string `json:"locale"`
+	SequenceStrictness string `json:"sequence_strictness"`
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
+	SessionToken       string `json:"session_token"`
+	RefreshToken       string `json:"refresh_token"`
+	ExpiresIn          int    `json:"expires_in"`
+}
+
+// RequestMagicLinkResponse はサインインのリンクのリクエストのレスポンスを表す構造体です。
+type RequestMagicLinkResponse struct {
+	Nonce string `json:"nonce"`
 }
 
 // GetUserResponse はユーザー取得のレスポンスを表す構造体です。
@@ -34,17 +47,31 @@ func ToSignInResponse(output *port.SignInOutputData) SignInResponse {
 	}
 
 	res := SignInResponse{
-		ID:           output.ID,
-		Email:        output.Email,
-		Name:         output.Name,
-		CreatedAt:    output.CreatedAt,
-		UpdatedAt:    output.UpdatedAt,
-		SessionToken: output.SessionToken,
+		ID:                 output.ID,
+		Email:              output.Email,
+		Name:               output.Name,
+		Timezone:           output.Timezone,
+		Locale:             output.Locale,
+		SequenceStrictness: output.SequenceStrictness,
+		CreatedAt:          output.CreatedAt,
+		UpdatedAt:          output.UpdatedAt,
+		SessionToken:       output.SessionToken,
+		RefreshToken:       output.RefreshToken,
+		ExpiresIn:          output.ExpiresIn,
 	}
 
 	return SignInResponse(res)
 }
 
+// ToRequestMagicLinkResponse はサインインのリンクのリクエストのレスポンスに変換します。
+func ToRequestMagicLinkResponse(output *port.RequestMagicLinkOutputData) RequestMagicLinkResponse {
+	if output == nil {
+		return RequestMagicLinkResponse{}
+	}
+
+	return RequestMagicLinkResponse{Nonce: output.Nonce}
+}
+
 // ToGetUserResponse はユーザー取得のレスポンスに変換します。
 func ToGetUserResponse(output *port.GetUserOutputData) GetUserResponse {
 	if output == nil {
@@ -52,11 +79,14 @@ func ToGetUserResponse(output *port.GetUserOutputData) GetUserResponse {
 	}
 
 	res := GetUserResponse{
-		ID:        output.ID,
-		Email:     output.Email,
-		Name:      output.Name,
-		CreatedAt: output.CreatedAt,
-		UpdatedAt: output.UpdatedAt,
+		ID:                 output.ID,
+		Email:              output.Email,
+		Name:               output.Name,
+		Timezone:           output.Timezone,
+		Locale:             output.Locale,
+		SequenceStrictness: output.SequenceStrictness,
+		CreatedAt:          output.CreatedAt,
+		UpdatedAt:          output.UpdatedAt,
 	}
 
 	return GetUserResponse(res)
@@ -69,11 +99,14 @@ func ToPutUserResponse(output *port.UpdateUserOutputData) PutUserResponse {
 	}
 
 	res := PutUserResponse{
-		ID:        output.ID,
-		Email:     output.Email,
-		Name:      output.Name,
-		CreatedAt: output.CreatedAt,
-		UpdatedAt: output.UpdatedAt,
+		ID:                 output.ID,
+		Email:              output.Email,
+		Name:               output.Name,
+		Timezone:           output.Timezone,
+		Locale:             output.Locale,
+		SequenceStrictness: output.SequenceStrictness,
+		CreatedAt:          output.CreatedAt,
+		UpdatedAt:          output.UpdatedAt,
 	}
 
 	return PutUserResponse(res)
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// AvailabilityInteractor は受講可能日のユースケースの実装を表す構造体です。
+type AvailabilityInteractor struct {
+	Logger                 *slog.Logger
+	Zone                   timezone.Zone
+	AvailabilityRepository repository.AvailabilityRepository
+	BlackoutRepository     repository.BlackoutRepository
+	OutputPort             port.AvailabilityOutputPort
+}
+
+// NewAvailabilityInteractor は AvailabilityInteractor を生成します。
+func NewAvailabilityInteractor(logger *slog.Logger, zone timezone.Zone, availabilityRepository repository.AvailabilityRepository, blackoutRepository repository.BlackoutRepository, outputPort port.AvailabilityOutputPort) port.AvailabilityInputPort {
+	return &AvailabilityInteractor{
+		Logger:                 logger,
+		Zone:                   zone,
+		AvailabilityRepository: availabilityRepository,
+		BlackoutRepository:     blackoutRepository,
+		OutputPort:             outputPort,
+	}
+}
+
+// GetAvailability は受講可能日の設定を取得します。
+func (i *AvailabilityInteractor) GetAvailability(input port.GetAvailabilityInputData) {
+	availability, err := readAvailability(i.AvailabilityRepository, input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetAvailability(nil, r)
+		return
+	}
+
+	blackouts, err := i.BlackoutRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetAvailability(nil, r)
+		return
+	}
+
+	o := &port.GetAvailabilityOutputData{Availability: toBaseAvailabilityData(i.Zone, availability, blackouts)}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetAvailability(o, r)
+}
+
+// UpdateAvailability は受講可能日の設定を更新します。
+func (i *AvailabilityInteractor) UpdateAvailability(input port.UpdateAvailabilityInputData) {
+	availability, err := readAvailability(i.AvailabilityRepository, input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateAvailability(nil, r)
+		return
+	}
+
+	weekdays := make([]time.Weekday, 0, len(input.Weekdays))
+	for _, w := range input.Weekdays {
+		weekdays = append(weekdays, time.Weekday(w))
+	}
+
+	if availability.CreatedAt.IsZero() {
+		availability.CreatedAt = time.Now()
+	}
+	availability.Weekdays = weekdays
+	availability.MaxLecturesPerDay = input.MaxLecturesPerDay
+	availability.UpdatedAt = time.Now()
+
+	if err := i.AvailabilityRepository.Update(availability); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateAvailability(nil, r)
+		return
+	}
+
+	blackouts, err := i.BlackoutRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateAvailability(nil, r)
+		return
+	}
+
+	o := &port.UpdateAvailabilityOutputData{Availability: toBaseAvailabilityData(i.Zone, availability, blackouts)}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseUpdateAvailability(o, r)
+}
+
+// CreateBlackout は受講できない期間を作成します。
+func (i *AvailabilityInteractor) CreateBlackout(input port.CreateBlackoutInputData) {
+	startsAt, err := time.Parse(model.DateFormat, input.StartsAt)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "開始日"))
+		i.OutputPort.SetResponseCreateBlackout(nil, r)
+		return
+	}
+
+	endsAt, err := time.Parse(model.DateFormat, input.EndsAt)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "終了日"))
+		i.OutputPort.SetResponseCreateBlackout(nil, r)
+		return
+	}
+
+	b := &model.Blackout{
+		ID:        id.NewID(),
+		UserID:    input.UserID,
+		Label:     input.Label,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	i.Logger.With("blackout_id", b.ID)
+
+	if err := i.BlackoutRepository.Create(b); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseCreateBlackout(nil, r)
+		return
+	}
+
+	o := &port.CreateBlackoutOutputData{Blackout: toBaseBlackoutData(i.Zone, *b)}
+	r := port.NewSuccessResult(http.StatusCreated)
+	i.OutputPort.SetResponseCreateBlackout(o, r)
+}
+
+// UpdateBlackout は受講できない期間を更新します。
+func (i *AvailabilityInteractor) UpdateBlackout(input port.UpdateBlackoutInputData) {
+	i.Logger.With("blackout_id", input.ID)
+
+	startsAt, err := time.Parse(model.DateFormat, input.StartsAt)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "開始日"))
+		i.OutputPort.SetResponseUpdateBlackout(nil, r)
+		return
+	}
+
+	endsAt, err := time.Parse(model.DateFormat, input.EndsAt)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "終了日"))
+		i.OutputPort.SetResponseUpdateBlackout(nil, r)
+		return
+	}
+
+	b, err := i.BlackoutRepository.Read(input.ID)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeBlackoutNotFound, MsgBlackoutNotFound)
+			i.OutputPort.SetResponseUpdateBlackout(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateBlackout(nil, r)
+		return
+	}
+
+	b.Label = input.Label
+	b.StartsAt = startsAt
+	b.EndsAt = endsAt
+	b.UpdatedAt = time.Now()
+
+	if err := i.BlackoutRepository.Update(b); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateBlackout(nil, r)
+		return
+	}
+
+	o := &port.UpdateBlackoutOutputData{Blackout: toBaseBlackoutData(i.Zone, *b)}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseUpdateBlackout(o, r)
+}
+
+// DeleteBlackout は受講できない期間を削除します。
+func (i *AvailabilityInteractor) DeleteBlackout(input port.DeleteBlackoutInputData) {
+	i.Logger.With("blackout_id", input.BlackoutID)
+
+	if err := i.BlackoutRepository.Delete(input.BlackoutID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeleteBlackout(nil, r)
+		return
+	}
+
+	r := port.NewSuccessResult(http.StatusNoContent)
+	i.OutputPort.SetResponseDeleteBlackout(nil, r)
+}
+
+// readAvailability はユーザーの受講可能日の設定を取得します。
+// 設定が存在しない場合は全曜日受講可能で上限なしの設定を返します。
+func readAvailability(r repository.AvailabilityRepository, userID string) (*model.Availability, error) {
+	availability, err := r.Read(userID)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			return model.NewDefaultAvailability(userID), nil
+		}
+		return nil, err
+	}
+	return availability, nil
+}
+
+func toBaseAvailabilityData(zone timezone.Zone, a *model.Availability, blackouts []model.Blackout) port.BaseAvailabilityData {
+	weekdays := make([]int, 0, len(a.Weekdays))
+	for _, w := range a.Weekdays {
+		weekdays = append(weekdays, int(w))
+	}
+
+	bl := model.BlackoutList(blackouts)
+	bl.Sort()
+
+	outputBlackouts := make([]port.BaseBlackoutData, 0, len(bl))
+	for _, b := range bl {
+		outputBlackouts = append(outputBlackouts, toBaseBlackoutData(zone, b))
+	}
+
+	return port.BaseAvailabilityData{
+		UserID:            a.UserID,
+		Weekdays:          weekdays,
+		MaxLecturesPerDay: a.MaxLecturesPerDay,
+		Blackouts:         outputBlackouts,
+	}
+}
+
+// toBaseBlackoutData は受講できない期間を出力データに変換します。
+// 期間は暦の日付のためタイムゾーンによらずそのまま表し、作成日時と更新日時のみユーザーのタイムゾーンで表します。
+func toBaseBlackoutData(zone timezone.Zone, b model.Blackout) port.BaseBlackoutData {
+	return port.BaseBlackoutData{
+		ID:        b.ID,
+		UserID:    b.UserID,
+		Label:     b.Label,
+		StartsAt:  b.StartsAt.Format(model.DateFormat),
+		EndsAt:    b.EndsAt.Format(model.DateFormat),
+		CreatedAt: zone.FormatDateTime(b.CreatedAt),
+		UpdatedAt: zone.FormatDateTime(b.UpdatedAt),
+	}
+}
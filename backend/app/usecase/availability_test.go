@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAvailability(t *testing.T) {
+	t.Run("受講可能日の設定を取得する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubAvailabilityOutputPort{}
+		i := NewAvailabilityInteractor(l, timezone.UTC(), &stubAvailabilityRepository{}, &stubBlackoutRepository{}, p)
+
+		i.GetAvailability(port.GetAvailabilityInputData{UserID: "test-user-id"})
+
+		output, ok := p.Output.(*port.GetAvailabilityOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.False(p.Result.HasError)
+		assert.Equal([]int{1, 2, 3, 4, 5}, output.Availability.Weekdays)
+		assert.Equal(3, output.Availability.MaxLecturesPerDay)
+
+		// 受講できない期間は開始日の昇順で返される
+		require.Len(output.Availability.Blackouts, 2)
+		assert.Equal("test-blackout-1", output.Availability.Blackouts[0].ID)
+		assert.Equal("2021-01-02", output.Availability.Blackouts[0].StartsAt)
+		assert.Equal("test-blackout-2", output.Availability.Blackouts[1].ID)
+	})
+
+	t.Run("設定が存在しない場合は全曜日受講可能で上限なしを返す", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubAvailabilityOutputPort{}
+		i := NewAvailabilityInteractor(l, timezone.UTC(), &stubNotFoundAvailabilityRepository{}, &stubNotFoundBlackoutRepository{}, p)
+
+		i.GetAvailability(port.GetAvailabilityInputData{UserID: "test-user-id"})
+
+		output, ok := p.Output.(*port.GetAvailabilityOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Equal([]int{0, 1, 2, 3, 4, 5, 6}, output.Availability.Weekdays)
+		assert.Equal(0, output.Availability.MaxLecturesPerDay)
+		assert.Empty(output.Availability.Blackouts)
+	})
+}
+
+func TestUpdateAvailability(t *testing.T) {
+	t.Run("受講可能日の設定を更新する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubAvailabilityOutputPort{}
+		i := NewAvailabilityInteractor(l, timezone.UTC(), &stubNotFoundAvailabilityRepository{}, &stubNotFoundBlackoutRepository{}, p)
+
+		i.UpdateAvailability(port.UpdateAvailabilityInputData{
+			UserID:            "test-user-id",
+			Weekdays:          []int{0, 6},
+			MaxLecturesPerDay: 2,
+		})
+
+		output, ok := p.Output.(*port.UpdateAvailabilityOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Equal([]int{0, 6}, output.Availability.Weekdays)
+		assert.Equal(2, output.Availability.MaxLecturesPerDay)
+	})
+}
+
+func TestCreateBlackout(t *testing.T) {
+	t.Run("受講できない期間を作成する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubAvailabilityOutputPort{}
+		i := NewAvailabilityInteractor(l, timezone.UTC(), &stubAvailabilityRepository{}, &stubBlackoutRepository{}, p)
+
+		i.CreateBlackout(port.CreateBlackoutInputData{
+			UserID:   "test-user-id",
+			Label:    "出張",
+			StartsAt: "2024-06-10",
+			EndsAt:   "2024-06-12",
+		})
+
+		output, ok := p.Output.(*port.CreateBlackoutOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal(http.StatusCreated, p.Result.StatusCode)
+		assert.NotEmpty(output.Blackout.ID)
+		assert.Equal("出張", output.Blackout.Label)
+		assert.Equal("2024-06-10", output.Blackout.StartsAt)
+		assert.Equal("2024-06-12", output.Blackout.EndsAt)
+	})
+
+	t.Run("日付の形式が不正な場合はエラーを返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubAvailabilityOutputPort{}
+		i := NewAvailabilityInteractor(l, timezone.UTC(), &stubAvailabilityRepository{}, &stubBlackoutRepository{}, p)
+
+		i.CreateBlackout(port.CreateBlackoutInputData{
+			UserID:   "test-user-id",
+			Label:    "出張",
+			StartsAt: "2024/06/10",
+			EndsAt:   "2024-06-12",
+		})
+
+		assert.Equal(http.StatusBadRequest, p.Result.StatusCode)
+		assert.True(p.Result.HasError)
+	})
+}
+
+func TestUpdateBlackout(t *testing.T) {
+	t.Run("受講できない期間が存在しない場合はエラーを返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubAvailabilityOutputPort{}
+		i := NewAvailabilityInteractor(l, timezone.UTC(), &stubAvailabilityRepository{}, &stubNotFoundBlackoutRepository{}, p)
+
+		i.UpdateBlackout(port.UpdateBlackoutInputData{
+			ID:       "not-found-id",
+			Label:    "出張",
+			StartsAt: "2024-06-10",
+			EndsAt:   "2024-06-12",
+		})
+
+		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Equal(MsgBlackoutNotFound, p.Result.ErrorMessage)
+		assert.True(p.Result.HasError)
+	})
+}
+
+func TestDayAvailability(t *testing.T) {
+	q := NewDayAvailabilityQuery(&stubAvailabilityRepository{}, &stubBlackoutRepository{}, &stubScheduleRepository{})
+
+	tests := []struct {
+		name          string
+		date          time.Time
+		wantAvailable bool
+		wantReason    model.UnavailableReason
+		wantRemaining int
+	}{
+		{
+			name:          "受講する曜日で受講が2件あるため残り1件",
+			date:          time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantAvailable: true,
+			wantRemaining: 1,
+		},
+		{
+			name:          "受講できない期間",
+			date:          time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			wantAvailable: false,
+			wantReason:    model.UnavailableReasonBlackout,
+		},
+		{
+			name:          "受講しない曜日",
+			date:          time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			wantAvailable: false,
+			wantReason:    model.UnavailableReasonWeekday,
+		},
+		{
+			name:          "上限を超えている",
+			date:          time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+			wantAvailable: true,
+			wantRemaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			got, err := q.DayAvailability("test-user-id", tt.date)
+			require.NoError(err)
+
+			assert.Equal(tt.wantAvailable, got.Available)
+			assert.Equal(tt.wantReason, got.Reason)
+			assert.Equal(tt.wantRemaining, got.Remaining())
+		})
+	}
+}
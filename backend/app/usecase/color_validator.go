@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// ColorValidator はスケジュールや科目に設定する色を確認するインターフェースです。
+type ColorValidator interface {
+	Validate(userID string, colors ...string) (bool, error)
+}
+
+// ColorValidatorImpl は ColorValidator の実装を表す構造体です。
+type ColorValidatorImpl struct {
+	CustomColorRepository repository.CustomColorRepository
+}
+
+// NewColorValidator は ColorValidator を生成します。
+func NewColorValidator(customColorRepository repository.CustomColorRepository) ColorValidator {
+	return &ColorValidatorImpl{CustomColorRepository: customColorRepository}
+}
+
+// Validate は指定された色がすべて標準のパレットの色か、ユーザーが定義した色として存在するかどうかを返します。
+// ユーザー定義の色が含まれない場合はユーザー定義の色を取得しません。
+func (v *ColorValidatorImpl) Validate(userID string, colors ...string) (bool, error) {
+	var customIDs []string
+	for _, c := range colors {
+		color := model.Color(c)
+		if color.IsPalette() {
+			continue
+		}
+		if !color.IsCustom() {
+			return false, nil
+		}
+		customIDs = append(customIDs, color.CustomColorID())
+	}
+
+	if len(customIDs) == 0 {
+		return true, nil
+	}
+
+	customColors, err := v.CustomColorRepository.ReadByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	owned := make(map[string]bool, len(customColors))
+	for _, cc := range customColors {
+		owned[cc.ID] = true
+	}
+
+	for _, id := range customIDs {
+		if !owned[id] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// DayAvailabilityQuery は指定された日に受講できるかどうかと、あと何件受講を追加できるかを問い合わせるインターフェースです。
+// スケジュールのユースケースはこのインターフェースを通して受講可能日の設定を参照します。
+type DayAvailabilityQuery interface {
+	DayAvailability(userID string, date time.Time) (*model.DayAvailability, error)
+	DayAvailabilityMap(userID string, schedules model.ScheduleList) (model.DayAvailabilityMap, error)
+}
+
+// DayAvailabilityQueryImpl は DayAvailabilityQuery の実装を表す構造体です。
+type DayAvailabilityQueryImpl struct {
+	AvailabilityRepository repository.AvailabilityRepository
+	BlackoutRepository     repository.BlackoutRepository
+	ScheduleRepository     repository.ScheduleRepository
+}
+
+// NewDayAvailabilityQuery は DayAvailabilityQuery を生成します。
+func NewDayAvailabilityQuery(availabilityRepository repository.AvailabilityRepository, blackoutRepository repository.BlackoutRepository, scheduleRepository repository.ScheduleRepository) DayAvailabilityQuery {
+	return &DayAvailabilityQueryImpl{
+		AvailabilityRepository: availabilityRepository,
+		BlackoutRepository:     blackoutRepository,
+		ScheduleRepository:     scheduleRepository,
+	}
+}
+
+// DayAvailability は指定された日の受講可否を取得します。
+// 受講可能数はその日に開始する受講スケジュールの件数をもとに算出します。
+func (q *DayAvailabilityQueryImpl) DayAvailability(userID string, date time.Time) (*model.DayAvailability, error) {
+	availability, err := readAvailability(q.AvailabilityRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := q.BlackoutRepository.ReadByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	to := from.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	schedules, err := q.ScheduleRepository.ReadByUserIDStartsAtBetween(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := len(model.ScheduleList(schedules).FilterByType(model.ScheduleTypeCustom))
+	da := availability.DayAvailability(date, blackouts, scheduled)
+
+	return &da, nil
+}
+
+// DayAvailabilityMap は schedules に含まれる受講スケジュールの日ごとの受講可否を取得します。
+// 受講可能数は schedules のうちその日に開始する受講スケジュールの件数をもとに算出します。
+func (q *DayAvailabilityQueryImpl) DayAvailabilityMap(userID string, schedules model.ScheduleList) (model.DayAvailabilityMap, error) {
+	availability, err := readAvailability(q.AvailabilityRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := q.BlackoutRepository.ReadByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return availability.DayAvailabilityMap(schedules, blackouts), nil
+}
@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/mailtemplate"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// emailOutboxDispatchLimit は1回の送信で処理する送信待ちのメールの上限です。
+const emailOutboxDispatchLimit = 50
+
+// EmailOutboxInteractor は送信するメールのユースケースの実装を表す構造体です。
+type EmailOutboxInteractor struct {
+	Logger                *slog.Logger
+	UserRepository        repository.UserRepository
+	EmailOutboxRepository repository.EmailOutboxRepository
+	MailRepository        repository.EmailRepository
+	OutputPort            port.EmailOutboxOutputPort
+}
+
+// NewEmailOutboxInteractor は EmailOutboxInteractor を生成します。
+func NewEmailOutboxInteractor(logger *slog.Logger, userRepository repository.UserRepository, emailOutboxRepository repository.EmailOutboxRepository, mailRepository repository.EmailRepository, outputPort port.EmailOutboxOutputPort) port.EmailOutboxInputPort {
+	return &EmailOutboxInteractor{
+		Logger:                logger,
+		UserRepository:        userRepository,
+		EmailOutboxRepository: emailOutboxRepository,
+		MailRepository:        mailRepository,
+		OutputPort:            outputPort,
+	}
+}
+
+// DispatchEmailOutbox は送信を試行するべき送信待ちのメールを送信します。
+// 送信に失敗したメールは間隔を空けて再試行し、上限に達したものは送信に失敗したメールとして残します。
+func (i *EmailOutboxInteractor) DispatchEmailOutbox(ctx context.Context, inputData port.DispatchEmailOutboxInputData) {
+	now := time.Now()
+
+	outboxes, err := i.EmailOutboxRepository.ReadDue(now, emailOutboxDispatchLimit)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDispatchEmailOutbox(nil, r)
+		return
+	}
+
+	o := &port.DispatchEmailOutboxOutputData{}
+	for idx := range outboxes {
+		outbox := &outboxes[idx]
+
+		outbox.Claim(now)
+		if err := i.EmailOutboxRepository.Update(outbox); err != nil {
+			if errors.Is(err, repository.ErrEmailOutboxConflict) {
+				// 他の処理が送信を試行しているため、その処理に任せる
+				i.Logger.Info("mail already claimed", "email_outbox_id", outbox.ID)
+				continue
+			}
+
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseDispatchEmailOutbox(nil, r)
+			return
+		}
+
+		if err := deliverEmailOutbox(ctx, i.Logger, i.EmailOutboxRepository, i.MailRepository, outbox, now); err != nil {
+			if errors.Is(err, repository.ErrEmailOutboxConflict) {
+				i.Logger.Warn(err.Error(), "email_outbox_id", outbox.ID)
+				continue
+			}
+
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseDispatchEmailOutbox(nil, r)
+			return
+		}
+
+		switch outbox.Status {
+		case model.EmailOutboxStatusSent:
+			o.Sent++
+		case model.EmailOutboxStatusFailed:
+			o.Failed++
+		default:
+			o.Retrying++
+		}
+	}
+
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseDispatchEmailOutbox(o, r)
+}
+
+// GetFailedEmailOutboxList は送信に失敗したメールのリストを取得します。管理者のみ取得できます。
+func (i *EmailOutboxInteractor) GetFailedEmailOutboxList(inputData port.GetFailedEmailOutboxListInputData) {
+	requester, result := i.readAdmin(inputData.RequesterUserID)
+	if result != nil {
+		i.OutputPort.SetResponseGetFailedEmailOutboxList(nil, *result)
+		return
+	}
+
+	outboxes, err := i.EmailOutboxRepository.ReadByStatus(model.EmailOutboxStatusFailed)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetFailedEmailOutboxList(nil, r)
+		return
+	}
+
+	zone := timezone.LoadOrDefault(requester.Timezone)
+
+	deliveries := make([]port.EmailOutboxData, 0, len(outboxes))
+	for _, outbox := range outboxes {
+		deliveries = append(deliveries, toEmailOutboxData(&outbox, zone))
+	}
+
+	o := &port.GetFailedEmailOutboxListOutputData{
+		Total:      len(deliveries),
+		Deliveries: deliveries,
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetFailedEmailOutboxList(o, r)
+}
+
+// ResendEmailOutbox は送信に失敗したメールを送信待ちに戻して送信を試行します。管理者のみ再送できます。
+func (i *EmailOutboxInteractor) ResendEmailOutbox(ctx context.Context, inputData port.ResendEmailOutboxInputData) {
+	requester, result := i.readAdmin(inputData.RequesterUserID)
+	if result != nil {
+		i.OutputPort.SetResponseResendEmailOutbox(nil, *result)
+		return
+	}
+
+	outbox, err := i.EmailOutboxRepository.Read(inputData.EmailOutboxID)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("email outbox not found", "email_outbox_id", inputData.EmailOutboxID)
+			r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeEmailOutboxNotFound, MsgEmailOutboxNotFound)
+			i.OutputPort.SetResponseResendEmailOutbox(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseResendEmailOutbox(nil, r)
+		return
+	}
+
+	if outbox.Status != model.EmailOutboxStatusFailed {
+		i.Logger.Warn("email outbox is not failed", "email_outbox_id", outbox.ID, "status", outbox.Status)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeEmailOutboxNotFailed, MsgEmailOutboxNotFailed)
+		i.OutputPort.SetResponseResendEmailOutbox(nil, r)
+		return
+	}
+
+	now := time.Now()
+	outbox.Resend(now)
+	outbox.Claim(now)
+	if err := i.EmailOutboxRepository.Update(outbox); err != nil {
+		if errors.Is(err, repository.ErrEmailOutboxConflict) {
+			// 他の管理者が同時に再送した
+			i.Logger.Warn(err.Error(), "email_outbox_id", outbox.ID)
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeEmailOutboxNotFailed, MsgEmailOutboxNotFailed)
+			i.OutputPort.SetResponseResendEmailOutbox(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseResendEmailOutbox(nil, r)
+		return
+	}
+
+	if err := deliverEmailOutbox(ctx, i.Logger, i.EmailOutboxRepository, i.MailRepository, outbox, now); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseResendEmailOutbox(nil, r)
+		return
+	}
+
+	zone := timezone.LoadOrDefault(requester.Timezone)
+	o := &port.ResendEmailOutboxOutputData{Delivery: toEmailOutboxData(outbox, zone)}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseResendEmailOutbox(o, r)
+}
+
+// readAdmin はリクエストしたユーザーを取得し、管理者でない場合はエラーの結果を返します。
+func (i *EmailOutboxInteractor) readAdmin(userID string) (*model.User, *port.Result) {
+	requester, err := i.UserRepository.Read(userID, true)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+		return nil, &r
+	}
+
+	config := infrastructure.GetConfig()
+	if !isAdmin(requester.Email, config.AdminEmails) {
+		i.Logger.Warn("forbidden: not admin", "email", requester.Email)
+		r := port.NewErrorResult(http.StatusForbidden, port.ErrorCodeAuthForbidden, MsgUserNotFound)
+		return nil, &r
+	}
+
+	return requester, nil
+}
+
+// enqueueEmail はメールを送信待ちとして保存し、すぐに送信を試行します。
+// 保存できた場合は送信に失敗しても後で再試行するため、エラーを返すのは保存できなかった場合のみです。
+func enqueueEmail(ctx context.Context, logger *slog.Logger, outboxRepository repository.EmailOutboxRepository, mailRepository repository.EmailRepository, to string, msg mailtemplate.Message) error {
+	now := time.Now()
+	outbox := model.NewEmailOutbox(id.NewID(), to, msg.Subject, msg.Text, msg.HTML, now)
+	// 送信している間に定期的な送信の処理が同じメールを送信しないよう、確保した状態で保存する
+	outbox.Claim(now)
+	if err := outboxRepository.Create(outbox); err != nil {
+		return err
+	}
+
+	logger.Info("mail enqueued", "email_outbox_id", outbox.ID)
+
+	if err := deliverEmailOutbox(ctx, logger, outboxRepository, mailRepository, outbox, now); err != nil {
+		// 送信の結果を記録できなくても送信待ちとして残っているため、再試行で送信する
+		logger.Error(err.Error(), "email_outbox_id", outbox.ID)
+	}
+
+	return nil
+}
+
+// queueEmail はメールを送信待ちとして保存し、送信は定期的な送信の処理に任せます。
+// 応答までの時間から宛先のアカウントの有無を推測されないよう、リクエストの処理の中で送信しない場合に使います。
+// suppressed が true の場合は送信しないメールとして保存し、アカウントが存在しない場合も同じ処理を行えるようにします。
+func queueEmail(logger *slog.Logger, outboxRepository repository.EmailOutboxRepository, to string, msg mailtemplate.Message, suppressed bool) error {
+	now := time.Now()
+	outbox := model.NewEmailOutbox(id.NewID(), to, msg.Subject, msg.Text, msg.HTML, now)
+	if suppressed {
+		outbox.Suppress(now)
+	}
+
+	if err := outboxRepository.Create(outbox); err != nil {
+		return err
+	}
+
+	logger.Info("mail enqueued", "email_outbox_id", outbox.ID, "status", outbox.Status)
+	return nil
+}
+
+// deliverEmailOutbox は Claim で確保した送信待ちのメールを送信し、試行の結果を記録します。
+// 送信の失敗は試行の結果として記録し、エラーを返すのは記録できなかった場合のみです。
+func deliverEmailOutbox(ctx context.Context, logger *slog.Logger, outboxRepository repository.EmailOutboxRepository, mailRepository repository.EmailRepository, outbox *model.EmailOutbox, now time.Time) error {
+	msgID, err := mailRepository.SendMessage(ctx, outbox.To, repository.EmailMessage{Subject: outbox.Subject, Text: outbox.Text, HTML: outbox.HTML})
+	if err != nil {
+		outbox.MarkFailed(err, infrastructure.GetConfig().EmailOutboxMaxAttempts, now)
+		logger.Warn("mail delivery failed", "email_outbox_id", outbox.ID, "failures", outbox.Failures, "status", outbox.Status, "error", err.Error())
+	} else {
+		outbox.MarkSent(msgID, now)
+		logger.Info("mail sent", "email_outbox_id", outbox.ID, "message_id", msgID)
+	}
+
+	return outboxRepository.Update(outbox)
+}
+
+// toEmailOutboxData は model.EmailOutbox を送信するメールのデータに変換します。
+func toEmailOutboxData(outbox *model.EmailOutbox, zone timezone.Zone) port.EmailOutboxData {
+	attempts := make([]port.EmailDeliveryAttemptData, 0, len(outbox.Attempts))
+	for _, a := range outbox.Attempts {
+		attempts = append(attempts, port.EmailDeliveryAttemptData{
+			AttemptedAt: zone.FormatDateTime(a.AttemptedAt),
+			MessageID:   a.MessageID,
+			Error:       a.Error,
+		})
+	}
+
+	return port.EmailOutboxData{
+		ID:            outbox.ID,
+		To:            outbox.To,
+		Subject:       outbox.Subject,
+		Status:        string(outbox.Status),
+		Failures:      outbox.Failures,
+		LastError:     outbox.LastError(),
+		Attempts:      attempts,
+		NextAttemptAt: zone.FormatDateTime(outbox.NextAttemptAt),
+		MessageID:     outbox.MessageID,
+		CreatedAt:     zone.FormatDateTime(outbox.CreatedAt),
+		UpdatedAt:     zone.FormatDateTime(outbox.UpdatedAt),
+	}
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchEmailOutbox(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		sendErr      error
+		failures     int
+		want         port.DispatchEmailOutboxOutputData
+		wantStatus   model.EmailOutboxStatus
+		wantFailures int
+	}{
+		{name: "送信に成功した場合は送信済みにする", want: port.DispatchEmailOutboxOutputData{Sent: 1}, wantStatus: model.EmailOutboxStatusSent},
+		{name: "送信に失敗した場合は再試行する", sendErr: errors.New("send error"), want: port.DispatchEmailOutboxOutputData{Retrying: 1}, wantStatus: model.EmailOutboxStatusPending, wantFailures: 1},
+		{name: "上限まで失敗した場合は送信を諦める", sendErr: errors.New("send error"), failures: 4, want: port.DispatchEmailOutboxOutputData{Failed: 1}, wantStatus: model.EmailOutboxStatusFailed, wantFailures: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			due := model.NewEmailOutbox("test-due", "to@example.com", "subject", "text", "", now.Add(-time.Minute))
+			due.Failures = tt.failures
+			later := model.NewEmailOutbox("test-later", "to@example.com", "subject", "text", "", now.Add(time.Minute))
+
+			or := &stubEmailOutboxRepository{}
+			require.NoError(or.Create(due))
+			require.NoError(or.Create(later))
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			p := &stubEmailOutboxOutputPort{}
+			i := NewEmailOutboxInteractor(l, &stubUserRepository{}, or, &stubEmailRepository{Err: tt.sendErr}, p)
+			i.DispatchEmailOutbox(context.Background(), port.DispatchEmailOutboxInputData{})
+
+			assert.Equal(http.StatusOK, p.Result.StatusCode)
+			output, ok := p.Output.(*port.DispatchEmailOutboxOutputData)
+			require.True(ok)
+			assert.Equal(tt.want, *output)
+
+			got := or.Outboxes["test-due"]
+			assert.Equal(tt.wantStatus, got.Status)
+			assert.Equal(tt.wantFailures, got.Failures)
+			assert.Len(got.Attempts, 1)
+
+			// 試行する日時になっていないメールは送信しない
+			assert.Empty(or.Outboxes["test-later"].Attempts)
+		})
+	}
+}
+
+func TestSignUp_EmailOutbox(t *testing.T) {
+	tests := []struct {
+		name       string
+		sendErr    error
+		wantStatus model.EmailOutboxStatus
+	}{
+		{name: "送信に成功した場合は送信済みとして記録する", wantStatus: model.EmailOutboxStatusSent},
+		{name: "送信に失敗しても送信待ちとして残して成功を返す", sendErr: errors.New("send error"), wantStatus: model.EmailOutboxStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			or := &stubEmailOutboxRepository{}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, &stubEmailRepository{Err: tt.sendErr}, or, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
+
+			i.SignUp(context.Background(), port.SignUpInputData{Email: "test-email@example.com"})
+
+			assert.Equal(http.StatusOK, p.Result.StatusCode)
+			require.Len(or.Outboxes, 1)
+			for _, o := range or.Outboxes {
+				assert.Equal("test-email@example.com", o.To)
+				assert.Equal(tt.wantStatus, o.Status)
+				assert.NotEmpty(o.Subject)
+			}
+		})
+	}
+}
+
+func TestDispatchEmailOutbox_Concurrent(t *testing.T) {
+	t.Run("送信待ちとして保存したメールの送信中に定期的な送信が実行されても1回のみ送信する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		or := &stubEmailOutboxRepository{}
+		mr := &stubEmailRepository{}
+
+		dp := &stubEmailOutboxOutputPort{}
+		dispatcher := NewEmailOutboxInteractor(l, &stubUserRepository{}, or, mr, dp)
+		mr.OnSend = func() {
+			dispatcher.DispatchEmailOutbox(context.Background(), port.DispatchEmailOutboxInputData{})
+		}
+
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, mr, or, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
+		i.SignUp(context.Background(), port.SignUpInputData{Email: "test-email@example.com"})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Equal(1, mr.Sent)
+
+		output, ok := dp.Output.(*port.DispatchEmailOutboxOutputData)
+		require.True(ok)
+		assert.Equal(port.DispatchEmailOutboxOutputData{}, *output)
+
+		require.Len(or.Outboxes, 1)
+		for _, o := range or.Outboxes {
+			assert.Equal(model.EmailOutboxStatusSent, o.Status)
+			assert.Len(o.Attempts, 1)
+		}
+	})
+
+	t.Run("送信の処理が重なっても先に確保した処理のみ送信する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		now := time.Now()
+		or := &stubEmailOutboxRepository{}
+		require.NoError(or.Create(model.NewEmailOutbox("test-due", "to@example.com", "subject", "text", "", now.Add(-time.Minute))))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		mr := &stubEmailRepository{}
+
+		second := &stubEmailOutboxOutputPort{}
+		mr.OnSend = func() {
+			NewEmailOutboxInteractor(l, &stubUserRepository{}, or, mr, second).DispatchEmailOutbox(context.Background(), port.DispatchEmailOutboxInputData{})
+		}
+
+		first := &stubEmailOutboxOutputPort{}
+		NewEmailOutboxInteractor(l, &stubUserRepository{}, or, mr, first).DispatchEmailOutbox(context.Background(), port.DispatchEmailOutboxInputData{})
+
+		assert.Equal(1, mr.Sent)
+		assert.Equal(&port.DispatchEmailOutboxOutputData{Sent: 1}, first.Output)
+		assert.Equal(&port.DispatchEmailOutboxOutputData{}, second.Output)
+		assert.Equal(model.EmailOutboxStatusSent, or.Outboxes["test-due"].Status)
+		assert.Len(or.Outboxes["test-due"].Attempts, 1)
+	})
+}
+
+func TestGetFailedEmailOutboxList(t *testing.T) {
+	t.Run("管理者でない場合はエラーを返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubEmailOutboxOutputPort{}
+		i := NewEmailOutboxInteractor(l, &stubUserRepository{}, &stubEmailOutboxRepository{}, &stubEmailRepository{}, p)
+		i.GetFailedEmailOutboxList(port.GetFailedEmailOutboxListInputData{RequesterUserID: "test-id"})
+
+		assert.Equal(http.StatusForbidden, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthForbidden, p.Result.ErrorCode)
+	})
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodes(t *testing.T) {
+	assert := assert.New(t)
+
+	pattern := regexp.MustCompile(`^[a-z]+\.[a-z_]+$`)
+	seen := map[port.ErrorCode]bool{}
+	for _, code := range port.ErrorCodes() {
+		assert.Regexp(pattern, code.String())
+		assert.False(seen[code], "重複したエラーコード: %s", code)
+		assert.True(code.Valid())
+		seen[code] = true
+	}
+
+	assert.False(port.ErrorCode("unknown.code").Valid())
+	assert.False(port.ErrorCode("").Valid())
+}
+
+// TestErrorResult_Code は usecase のすべてのエラーの経路が一覧にあるエラーコードを返すことを確認します。
+func TestErrorResult_Code(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	catalog := errorCodeCatalog(t)
+
+	files, err := filepath.Glob("*.go")
+	require.NoError(err)
+
+	fset := token.NewFileSet()
+	calls := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		require.NoError(err)
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || !isSelector(call.Fun, "port", "NewErrorResult") {
+				return true
+			}
+
+			calls++
+			pos := fset.Position(call.Pos())
+			if !assert.Len(call.Args, 3, pos.String()) {
+				return true
+			}
+
+			sel, ok := call.Args[1].(*ast.SelectorExpr)
+			if !assert.True(ok, "エラーコードを定数で指定してください: %s", pos) {
+				return true
+			}
+			assert.True(catalog[sel.Sel.Name], "一覧にないエラーコード %s: %s", sel.Sel.Name, pos)
+			return true
+		})
+	}
+
+	assert.NotZero(calls)
+}
+
+// errorCodeCatalog は port のエラーコードの一覧に含まれる定数の名前を返します。
+func errorCodeCatalog(t *testing.T) map[string]bool {
+	t.Helper()
+
+	f, err := parser.ParseFile(token.NewFileSet(), filepath.Join("..", "port", "error_code.go"), nil, 0)
+	require.NoError(t, err)
+
+	catalog := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "errorCodes" {
+			return true
+		}
+
+		for _, v := range spec.Values {
+			lit, ok := v.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			for _, elt := range lit.Elts {
+				if ident, ok := elt.(*ast.Ident); ok {
+					catalog[ident.Name] = true
+				}
+			}
+		}
+		return false
+	})
+
+	require.NotEmpty(t, catalog)
+	return catalog
+}
+
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == pkg && sel.Sel.Name == name
+}
@@ -1,15 +1,41 @@
 package usecase
 
 const (
-	MsgInternalServerError    = "サーバーエラーが発生しました。再試行してください。再試行しても解決しない場合は、管理者にお問い合わせください。"
-	MsgEmailOrPasswordInvalid = "メールアドレスまたはパスワードが間違っています"
-	MsgEmailAlreadyExists     = "入力されたメールアドレスはすでに登録されています"
-	MsgEmailNotFound          = "入力されたメールアドレスは登録されていません"
-	MsgTokenInvalid           = "トークンが無効もしくは期限切れです"
-	MsgScheduleNotFound       = "指定されたスケジュールは存在しません"
-	MsgFormatInvalid          = "%sの形式が正しくありません"
-	MsgUnauthorized           = "ログインしてください"
-	MsgUserNotFound           = "ユーザーが見つかりません"
-	MsgRequestFormatInvalid   = "リクエストの形式が正しくありません"
-	MsgEmailIsSame            = "新しいメールアドレスが現在と同じです"
+	MsgInternalServerError      = "サーバーエラーが発生しました。再試行してください。再試行しても解決しない場合は、管理者にお問い合わせください。"
+	MsgEmailOrPasswordInvalid   = "メールアドレスまたはパスワードが間違っています"
+	MsgEmailAlreadyExists       = "入力されたメールアドレスはすでに登録されています"
+	MsgEmailNotFound            = "入力されたメールアドレスは登録されていません"
+	MsgTokenInvalid             = "トークンが無効もしくは期限切れです"
+	MsgScheduleNotFound         = "指定されたスケジュールは存在しません"
+	MsgFormatInvalid            = "%sの形式が正しくありません"
+	MsgUnauthorized             = "ログインしてください"
+	MsgUserNotFound             = "ユーザーが見つかりません"
+	MsgRequestFormatInvalid     = "リクエストの形式が正しくありません"
+	MsgEmailIsSame              = "新しいメールアドレスが現在と同じです"
+	MsgBlackoutNotFound         = "指定された受講できない期間は存在しません"
+	MsgLectureSequenceBroken    = "講義回の順番が逆転するため変更できません（%s）"
+	MsgTagNotFound              = "指定されたタグは存在しません"
+	MsgTagNameDuplicated        = "同じ名前のタグがすでに存在します"
+	MsgColorNotFound            = "指定された色は存在しません"
+	MsgCustomColorLimitExceeded = "ユーザーが定義できる色は%d件までです"
+	MsgCustomColorInUse         = "スケジュールまたは科目で使われている色は削除できません"
+	MsgEmailOutboxNotFound      = "指定されたメールは存在しません"
+	MsgEmailOutboxNotFailed     = "送信に失敗したメールのみ再送できます"
+	MsgSessionNotFound          = "指定されたセッションは存在しません"
+	MsgTooManyAttempts          = "サインインの失敗が続いたため、しばらく時間をおいてから再試行してください"
+	MsgEmailCooldown            = "メールを送信したばかりです。しばらく時間をおいてから再試行してください"
+	MsgMFAAlreadyEnabled        = "二段階認証はすでに有効です"
+	MsgMFANotEnrolled           = "二段階認証の設定を開始してください"
+	MsgMFACodeInvalid           = "認証コードが正しくありません"
+	MsgPasskeyChallengeInvalid  = "パスキーの確認の有効期限が切れました。もう一度やり直してください"
+	MsgPasskeyInvalid           = "パスキーを確認できませんでした"
+	MsgPasskeyAlreadyRegistered = "このパスキーはすでに登録されています"
+	MsgPasskeyNotFound          = "指定されたパスキーは存在しません"
+	MsgPasskeyLimitExceeded     = "登録できるパスキーは%d件までです"
+	MsgMagicLinkBrowserMismatch = "サインインのリンクはリクエストしたブラウザで開いてください"
+	MsgOIDCProviderNotFound     = "指定された ID プロバイダーは存在しません"
+	MsgOIDCStateInvalid         = "サインインの有効期限が切れました。もう一度やり直してください"
+	MsgOIDCLoginFailed          = "外部アカウントでのサインインに失敗しました"
+	MsgOIDCEmailNotVerified     = "外部アカウントのメールアドレスが確認されていません"
+	MsgOIDCDomainNotAllowed     = "このメールアドレスのドメインではサインインできません"
 )
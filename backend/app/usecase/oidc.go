@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// OIDCInteractor は外部アカウントでのサインインのユースケースの実装を表す構造体です。
+type OIDCInteractor struct {
+	Logger                 *slog.Logger
+	Providers              []*oidc.Provider
+	UserRepository         repository.UserRepository
+	SessionRepository      repository.SessionRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	LoginSessionRepository repository.LoginSessionRepository
+	TwoFactorRepository    repository.TwoFactorRepository
+	OIDCIdentityRepository repository.OIDCIdentityRepository
+	OIDCStateRepository    repository.OIDCStateRepository
+	OutputPort             port.OIDCOutputPort
+}
+
+// NewOIDCInteractor は OIDCInteractor を生成します。
+func NewOIDCInteractor(logger *slog.Logger, providers []*oidc.Provider, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, twoFactorRepository repository.TwoFactorRepository, oidcIdentityRepository repository.OIDCIdentityRepository, oidcStateRepository repository.OIDCStateRepository, outputPort port.OIDCOutputPort) port.OIDCInputPort {
+	return &OIDCInteractor{
+		Logger:                 logger,
+		Providers:              providers,
+		UserRepository:         userRepository,
+		SessionRepository:      sessionRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		LoginSessionRepository: loginSessionRepository,
+		TwoFactorRepository:    twoFactorRepository,
+		OIDCIdentityRepository: oidcIdentityRepository,
+		OIDCStateRepository:    oidcStateRepository,
+		OutputPort:             outputPort,
+	}
+}
+
+// GetOIDCProviderList はサインインに使える ID プロバイダーの一覧を取得します。
+func (i *OIDCInteractor) GetOIDCProviderList(input port.GetOIDCProviderListInputData) {
+	providers := make([]port.OIDCProviderData, 0, len(i.Providers))
+	for _, p := range i.Providers {
+		providers = append(providers, port.OIDCProviderData{ID: p.Config.ID, Name: p.Config.Name})
+	}
+
+	o := &port.GetOIDCProviderListOutputData{Providers: providers}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetOIDCProviderList(o, r)
+}
+
+// BeginOIDCSignIn は認可のリクエストを保存して、外部アカウントでのサインインを開始します。
+// nonce と PKCE のコード検証値はサーバーのみが持ち、ブラウザには認可のリクエストの URL と state のみを返します。
+func (i *OIDCInteractor) BeginOIDCSignIn(ctx context.Context, input port.BeginOIDCSignInInputData) {
+	provider := i.findProvider(input.ProviderID)
+	if provider == nil {
+		i.Logger.Warn("oidc provider not found", "provider_id", input.ProviderID)
+		r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeOIDCProviderNotFound, MsgOIDCProviderNotFound)
+		i.OutputPort.SetResponseBeginOIDCSignIn(nil, r)
+		return
+	}
+
+	state, err := model.NewOIDCState(provider.Config.ID, time.Now())
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginOIDCSignIn(nil, r)
+		return
+	}
+
+	authorizationURL, err := provider.AuthorizationURL(ctx, state.State, state.Nonce, oidc.CodeChallenge(state.CodeVerifier))
+	if err != nil {
+		i.Logger.Error(err.Error(), "provider_id", provider.Config.ID)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginOIDCSignIn(nil, r)
+		return
+	}
+
+	if err := i.OIDCStateRepository.Create(state); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginOIDCSignIn(nil, r)
+		return
+	}
+
+	o := &port.BeginOIDCSignInOutputData{AuthorizationURL: authorizationURL, State: state.State}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseBeginOIDCSignIn(o, r)
+}
+
+// FinishOIDCSignIn は認可コードで ID トークンを取得して検証し、外部アカウントに紐付くユーザーでサインインします。
+// 初めてサインインする外部アカウントは、確認済みのメールアドレスが同じユーザーに紐付け、ユーザーがいない場合は作成します。
+// 二段階認証が有効なユーザーの場合はセッションを発行せず、認証コードを送るためのトークンを返します。
+func (i *OIDCInteractor) FinishOIDCSignIn(ctx context.Context, input port.FinishOIDCSignInInputData) {
+	now := time.Now()
+	state, err := i.OIDCStateRepository.Consume(input.State)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+	if err != nil || !state.IsValid(now) {
+		i.Logger.Warn("oidc state is invalid")
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeOIDCStateInvalid, MsgOIDCStateInvalid)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+
+	provider := i.findProvider(state.ProviderID)
+	if provider == nil {
+		i.Logger.Warn("oidc provider not found", "provider_id", state.ProviderID)
+		r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeOIDCProviderNotFound, MsgOIDCProviderNotFound)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+
+	i.Logger.With("provider_id", provider.Config.ID)
+
+	claims, err := i.verify(ctx, provider, state, input.Code, now)
+	if err != nil {
+		if errors.Is(err, oidc.ErrVerification) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeOIDCLoginFailed, MsgOIDCLoginFailed)
+			i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+
+	// ドメインを制限するプロバイダーでは、確認されていないメールアドレスのドメインを信用しない
+	if len(provider.Config.AllowedDomains) > 0 && !claims.EmailVerified {
+		i.Logger.Warn("oidc email is not verified")
+		r := port.NewErrorResult(http.StatusForbidden, port.ErrorCodeOIDCEmailNotVerified, MsgOIDCEmailNotVerified)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+	if !provider.Config.AllowsEmail(claims.Email) {
+		i.Logger.Warn("oidc email domain is not allowed")
+		r := port.NewErrorResult(http.StatusForbidden, port.ErrorCodeOIDCDomainNotAllowed, MsgOIDCDomainNotAllowed)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+
+	user, err := i.linkedUser(claims, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+
+	if user == nil {
+		if !claims.EmailVerified || claims.Email == "" {
+			i.Logger.Warn("oidc email is not verified")
+			r := port.NewErrorResult(http.StatusForbidden, port.ErrorCodeOIDCEmailNotVerified, MsgOIDCEmailNotVerified)
+			i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+			return
+		}
+
+		user, err = i.linkUser(provider, claims, now)
+		if err != nil {
+			if errors.Is(err, repository.ErrOIDCIdentityAlreadyLinked) {
+				i.Logger.Warn(err.Error())
+				r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeOIDCLoginFailed, MsgOIDCLoginFailed)
+				i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+				return
+			}
+
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+			return
+		}
+	}
+
+	o, err := completeSignIn(i.Logger, i.SessionRepository, i.RefreshTokenRepository, i.LoginSessionRepository, i.TwoFactorRepository, user, input.UserAgent, input.IPAddress, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishOIDCSignIn(nil, r)
+		return
+	}
+
+	i.Logger.Info("signed in with oidc", "user_id", user.ID)
+
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseFinishOIDCSignIn(o, r)
+}
+
+// findProvider は ID のプロバイダーを返します。存在しない場合は nil を返します。
+func (i *OIDCInteractor) findProvider(providerID string) *oidc.Provider {
+	for _, p := range i.Providers {
+		if p.Config.ID == providerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// verify は認可コードで ID トークンを取得し、認可のリクエストの nonce で検証します。
+func (i *OIDCInteractor) verify(ctx context.Context, provider *oidc.Provider, state *model.OIDCState, code string, now time.Time) (*oidc.Claims, error) {
+	rawIDToken, err := provider.Exchange(ctx, code, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.VerifyIDToken(ctx, rawIDToken, state.Nonce, now)
+}
+
+// linkedUser は外部アカウントを紐付けたユーザーを返し、外部アカウントの最後に使った日時を更新します。
+// 紐付けていない場合や、紐付けたユーザーが削除されている場合は nil を返します。
+func (i *OIDCInteractor) linkedUser(claims *oidc.Claims, now time.Time) (*model.User, error) {
+	identity, err := i.OIDCIdentityRepository.Read(model.OIDCIdentityID(claims.Issuer, claims.Subject))
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	user, err := i.UserRepository.Read(identity.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			// 削除したユーザーの紐付けは残さず、新たに紐付け直す
+			i.Logger.Warn("oidc identity user not found", "user_id", identity.UserID)
+			return nil, i.OIDCIdentityRepository.Delete(identity.ID)
+		}
+		return nil, err
+	}
+
+	identity.Email = claims.Email
+	identity.LastUsedAt = now
+	if err := i.OIDCIdentityRepository.Update(identity); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// linkUser は確認済みのメールアドレスが同じユーザーに外部アカウントを紐付けます。
+// ユーザーがいない場合は外部アカウントの名前で作成します。パスワードを設定していない無効なユーザーは、
+// ID プロバイダーがメールアドレスを確認しているため有効にします。
+// 同時にサインインした場合に1つのユーザーのみに紐付けるよう、ユーザーより先に外部アカウントを保存します。
+func (i *OIDCInteractor) linkUser(provider *oidc.Provider, claims *oidc.Claims, now time.Time) (*model.User, error) {
+	user, err := i.UserRepository.ReadByEmail(claims.Email, false)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		return nil, err
+	}
+
+	created := false
+	if user == nil {
+		user = &model.User{
+			ID:        id.NewID(),
+			Email:     claims.Email,
+			Name:      truncateRunes(claims.Name, model.UserNameMaxLength),
+			CreatedAt: now,
+		}
+		created = true
+	}
+
+	identity := &model.OIDCIdentity{
+		ID:         model.OIDCIdentityID(claims.Issuer, claims.Subject),
+		Issuer:     claims.Issuer,
+		Subject:    claims.Subject,
+		ProviderID: provider.Config.ID,
+		UserID:     user.ID,
+		Email:      claims.Email,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	if err := i.OIDCIdentityRepository.Create(identity); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case created:
+		user.Enabled = true
+		user.UpdatedAt = now
+		if err := i.UserRepository.Create(user); err != nil {
+			return nil, err
+		}
+		i.Logger.Info("user created with oidc", "user_id", user.ID)
+	case !user.Enabled:
+		user.Enabled = true
+		user.UpdatedAt = now
+		if err := i.UserRepository.Update(user); err != nil {
+			return nil, err
+		}
+		i.Logger.Info("user enabled with oidc", "user_id", user.ID)
+	default:
+		i.Logger.Info("oidc identity linked", "user_id", user.ID)
+	}
+
+	return user, nil
+}
+
+// truncateRunes は s を最大 n 文字に切り詰めます。
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
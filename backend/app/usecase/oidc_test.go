@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc"
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc/oidctest"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOIDCUser = oidctest.User{Subject: "test-subject", Email: "student@example.ac.jp", EmailVerified: true, Name: "テスト 学生"}
+
+// testOIDC は外部アカウントでのサインインのテストに使うモックのプロバイダーとスタブの repository を表す構造体です。
+type testOIDC struct {
+	Server     *oidctest.Server
+	Users      *stubMapUserRepository
+	Identities *stubOIDCIdentityRepository
+	States     *stubOIDCStateRepository
+	TwoFactors *stubTwoFactorRepository
+	Output     *stubOIDCOutputPort
+	Interactor port.OIDCInputPort
+}
+
+// newTestOIDC はモックのプロバイダーを起動し、そのプロバイダーを使うユースケースを生成します。
+func newTestOIDC(t *testing.T, allowedDomains []string) *testOIDC {
+	t.Helper()
+
+	server, err := oidctest.NewServer("test-client", "test-secret", testOIDCUser)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	config := oidc.ProviderConfig{ID: "test", Name: "テスト大学", Issuer: server.URL, ClientID: "test-client", ClientSecret: "test-secret", AllowedDomains: allowedDomains}
+	provider := oidc.NewProvider(config, "https://example.com/signin/oidc/callback", server.Client())
+
+	e := &testOIDC{
+		Server:     server,
+		Users:      &stubMapUserRepository{},
+		Identities: &stubOIDCIdentityRepository{},
+		States:     &stubOIDCStateRepository{},
+		TwoFactors: &stubTwoFactorRepository{},
+		Output:     &stubOIDCOutputPort{},
+	}
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	e.Interactor = NewOIDCInteractor(l, []*oidc.Provider{provider}, e.Users, &stubSessionRepository{}, &stubRefreshTokenRepository{}, &stubLoginSessionRepository{}, e.TwoFactors, e.Identities, e.States, e.Output)
+	return e
+}
+
+// authorize はサインインを開始し、モックのプロバイダーで認可してサインインを完了する入力データを返します。
+func (e *testOIDC) authorize(t *testing.T) port.FinishOIDCSignInInputData {
+	t.Helper()
+
+	e.Interactor.BeginOIDCSignIn(context.Background(), port.BeginOIDCSignInInputData{ProviderID: "test"})
+	require.Equal(t, http.StatusOK, e.Output.Result.StatusCode)
+	begin, ok := e.Output.Output.(*port.BeginOIDCSignInOutputData)
+	require.True(t, ok)
+
+	code, state, err := e.Server.Authorize(begin.AuthorizationURL)
+	require.NoError(t, err)
+	require.Equal(t, begin.State, state)
+
+	return port.FinishOIDCSignInInputData{State: state, Code: code, UserAgent: "test-user-agent", IPAddress: "192.0.2.1"}
+}
+
+func TestGetOIDCProviderList(t *testing.T) {
+	e := newTestOIDC(t, nil)
+
+	e.Interactor.GetOIDCProviderList(port.GetOIDCProviderListInputData{})
+	assert.Equal(t, http.StatusOK, e.Output.Result.StatusCode)
+	assert.Equal(t, &port.GetOIDCProviderListOutputData{Providers: []port.OIDCProviderData{{ID: "test", Name: "テスト大学"}}}, e.Output.Output)
+}
+
+func TestBeginOIDCSignIn(t *testing.T) {
+	t.Run("存在しないプロバイダー", func(t *testing.T) {
+		e := newTestOIDC(t, nil)
+
+		e.Interactor.BeginOIDCSignIn(context.Background(), port.BeginOIDCSignInInputData{ProviderID: "unknown"})
+		assert.Equal(t, http.StatusNotFound, e.Output.Result.StatusCode)
+		assert.Equal(t, port.ErrorCodeOIDCProviderNotFound, e.Output.Result.ErrorCode)
+		assert.Empty(t, e.States.States)
+	})
+}
+
+func TestFinishOIDCSignIn(t *testing.T) {
+	t.Run("初めてのサインインでユーザーを作成する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+
+		output, ok := e.Output.Output.(*port.SignInOutputData)
+		require.True(ok)
+		assert.Equal("test-token", output.SessionToken)
+		assert.Equal("student@example.ac.jp", output.Email)
+		assert.Equal("テスト 学生", output.Name)
+
+		user := e.Users.Users[output.ID]
+		assert.True(user.Enabled)
+		assert.Empty(user.Password)
+
+		identity := e.Identities.Identities[model.OIDCIdentityID(e.Server.URL, "test-subject")]
+		assert.Equal(output.ID, identity.UserID)
+		assert.Equal("test", identity.ProviderID)
+		assert.Empty(e.States.States)
+	})
+
+	t.Run("2回目のサインインは紐付けたユーザーでサインインする", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+		first := e.Output.Output.(*port.SignInOutputData)
+
+		// メールアドレスが変わっても同じ外部アカウントとして扱う
+		e.Server.SetUser(oidctest.User{Subject: "test-subject", Email: "renamed@example.ac.jp", EmailVerified: true})
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+		second := e.Output.Output.(*port.SignInOutputData)
+
+		assert.Equal(first.ID, second.ID)
+		assert.Len(e.Users.Users, 1)
+		assert.Equal("renamed@example.ac.jp", e.Identities.Identities[model.OIDCIdentityID(e.Server.URL, "test-subject")].Email)
+	})
+
+	t.Run("確認済みのメールアドレスが同じユーザーに紐付ける", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		e.Users.Users = map[string]model.User{
+			"test-id": {ID: "test-id", Email: "student@example.ac.jp", Name: "既存のユーザー", Password: "test-password-hash", Enabled: true},
+		}
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+
+		output := e.Output.Output.(*port.SignInOutputData)
+		assert.Equal("test-id", output.ID)
+		assert.Equal("既存のユーザー", output.Name)
+		assert.Len(e.Users.Users, 1)
+		assert.Equal("test-id", e.Identities.Identities[model.OIDCIdentityID(e.Server.URL, "test-subject")].UserID)
+	})
+
+	t.Run("パスワードを設定していないユーザーを有効にして紐付ける", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		e.Users.Users = map[string]model.User{
+			"test-id": {ID: "test-id", Email: "student@example.ac.jp"},
+		}
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+		assert.True(e.Users.Users["test-id"].Enabled)
+	})
+
+	t.Run("削除したユーザーの紐付けは作り直す", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		id := model.OIDCIdentityID(e.Server.URL, "test-subject")
+		e.Identities.Identities = map[string]model.OIDCIdentity{id: {ID: id, UserID: "deleted-user-id"}}
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+
+		output := e.Output.Output.(*port.SignInOutputData)
+		assert.NotEqual("deleted-user-id", output.ID)
+		assert.Equal(output.ID, e.Identities.Identities[id].UserID)
+	})
+
+	t.Run("二段階認証が有効なユーザーはセッションを発行しない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		e.Users.Users = map[string]model.User{
+			"test-id": {ID: "test-id", Email: "student@example.ac.jp", Enabled: true},
+		}
+		tf, _ := newEnabledTwoFactor(t, "test-id")
+		e.TwoFactors.TwoFactors = map[string]model.TwoFactor{"test-id": tf}
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		require.Equal(http.StatusOK, e.Output.Result.StatusCode)
+
+		output := e.Output.Output.(*port.SignInOutputData)
+		assert.Equal("test-token", output.TwoFactorToken)
+		assert.Empty(output.SessionToken)
+	})
+
+	t.Run("確認されていないメールアドレスでは紐付けない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		e.Users.Users = map[string]model.User{
+			"test-id": {ID: "test-id", Email: "student@example.ac.jp", Enabled: true},
+		}
+		e.Server.SetUser(oidctest.User{Subject: "test-subject", Email: "student@example.ac.jp", EmailVerified: false})
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		assert.Equal(http.StatusForbidden, e.Output.Result.StatusCode)
+		assert.Equal(port.ErrorCodeOIDCEmailNotVerified, e.Output.Result.ErrorCode)
+		assert.Empty(e.Identities.Identities)
+	})
+
+	t.Run("許可していないドメイン", func(t *testing.T) {
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, []string{"example.ac.jp"})
+		e.Server.SetUser(oidctest.User{Subject: "test-subject", Email: "someone@example.com", EmailVerified: true})
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), e.authorize(t))
+		assert.Equal(http.StatusForbidden, e.Output.Result.StatusCode)
+		assert.Equal(port.ErrorCodeOIDCDomainNotAllowed, e.Output.Result.ErrorCode)
+		assert.Empty(e.Users.Users)
+	})
+
+	t.Run("state は1回のみ使える", func(t *testing.T) {
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		input := e.authorize(t)
+		e.Interactor.FinishOIDCSignIn(context.Background(), input)
+		assert.Equal(http.StatusOK, e.Output.Result.StatusCode)
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), input)
+		assert.Equal(http.StatusUnauthorized, e.Output.Result.StatusCode)
+		assert.Equal(port.ErrorCodeOIDCStateInvalid, e.Output.Result.ErrorCode)
+	})
+
+	t.Run("期限切れの state", func(t *testing.T) {
+		assert := assert.New(t)
+
+		e := newTestOIDC(t, nil)
+		input := e.authorize(t)
+		s := e.States.States[input.State]
+		s.ExpiresAt = time.Now().Add(-time.Minute)
+		e.States.States[input.State] = s
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), input)
+		assert.Equal(http.StatusUnauthorized, e.Output.Result.StatusCode)
+		assert.Equal(port.ErrorCodeOIDCStateInvalid, e.Output.Result.ErrorCode)
+	})
+
+	t.Run("別のサインインの認可コード", func(t *testing.T) {
+		assert := assert.New(t)
+
+		// 別の認可のリクエストで発行された認可コードは PKCE の検証に失敗する
+		e := newTestOIDC(t, nil)
+		first := e.authorize(t)
+		second := e.authorize(t)
+		second.Code = first.Code
+
+		e.Interactor.FinishOIDCSignIn(context.Background(), second)
+		assert.Equal(http.StatusUnauthorized, e.Output.Result.StatusCode)
+		assert.Equal(port.ErrorCodeOIDCLoginFailed, e.Output.Result.ErrorCode)
+		assert.Empty(e.Users.Users)
+	})
+}
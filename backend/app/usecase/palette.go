@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// upperCustomColors はユーザーが定義できる色の上限です。
+const upperCustomColors = 20
+
+// PaletteInteractor はパレットのユースケースの実装を表す構造体です。
+type PaletteInteractor struct {
+	Logger                *slog.Logger
+	Zone                  timezone.Zone
+	CustomColorRepository repository.CustomColorRepository
+	ScheduleRepository    repository.ScheduleRepository
+	SubjectRepository     repository.SubjectRepository
+	OutputPort            port.PaletteOutputPort
+}
+
+// NewPaletteInteractor は PaletteInteractor を生成します。
+func NewPaletteInteractor(logger *slog.Logger, zone timezone.Zone, customColorRepository repository.CustomColorRepository, scheduleRepository repository.ScheduleRepository, subjectRepository repository.SubjectRepository, outputPort port.PaletteOutputPort) port.PaletteInputPort {
+	return &PaletteInteractor{
+		Logger:                logger,
+		Zone:                  zone,
+		CustomColorRepository: customColorRepository,
+		ScheduleRepository:    scheduleRepository,
+		SubjectRepository:     subjectRepository,
+		OutputPort:            outputPort,
+	}
+}
+
+// GetPalette は標準のパレットの色に続けて、ユーザーが定義した色を作成順に返します。
+func (i *PaletteInteractor) GetPalette(input port.GetPaletteInputData) {
+	customColors, err := i.CustomColorRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetPalette(nil, r)
+		return
+	}
+
+	palette := model.Palette()
+	o := &port.GetPaletteOutputData{Colors: make([]port.BasePaletteColorData, 0, len(palette)+len(customColors))}
+	for _, pc := range palette {
+		o.Colors = append(o.Colors, toBasePaletteColorData(pc, false))
+	}
+	for _, cc := range customColors {
+		o.Colors = append(o.Colors, toBasePaletteColorData(cc.PaletteColor(), true))
+	}
+
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetPalette(o, r)
+}
+
+// CreateCustomColor はユーザー定義の色を作成します。
+// 色コードは小文字にそろえて保存します。
+func (i *PaletteInteractor) CreateCustomColor(input port.CreateCustomColorInputData) {
+	customColors, err := i.CustomColorRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseCreateCustomColor(nil, r)
+		return
+	}
+
+	if len(customColors) >= upperCustomColors {
+		i.Logger.Warn("custom color limit exceeded", "count", len(customColors))
+		r := port.NewErrorResult(http.StatusConflict, port.ErrorCodeCustomColorLimitExceeded, fmt.Sprintf(MsgCustomColorLimitExceeded, upperCustomColors))
+		i.OutputPort.SetResponseCreateCustomColor(nil, r)
+		return
+	}
+
+	cc := &model.CustomColor{
+		ID:        id.NewID(),
+		UserID:    input.UserID,
+		Name:      input.Name,
+		Hex:       strings.ToLower(input.Hex),
+		Style:     model.ToColorStyle(input.Style),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	i.Logger.With("color_id", cc.ID)
+
+	if err := i.CustomColorRepository.Create(cc); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseCreateCustomColor(nil, r)
+		return
+	}
+
+	o := &port.CreateCustomColorOutputData{CustomColor: toBaseCustomColorData(i.Zone, *cc)}
+	r := port.NewSuccessResult(http.StatusCreated)
+	i.OutputPort.SetResponseCreateCustomColor(o, r)
+}
+
+// DeleteCustomColor はユーザー定義の色を削除します。
+// スケジュールや科目が参照している色は表示できなくなるため削除しません。
+func (i *PaletteInteractor) DeleteCustomColor(input port.DeleteCustomColorInputData) {
+	i.Logger.With("color_id", input.ColorID)
+
+	key := model.CustomColor{ID: input.ColorID}.Key().String()
+
+	inUse, err := i.isInUse(input.UserID, key)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeleteCustomColor(nil, r)
+		return
+	}
+
+	if inUse {
+		i.Logger.Warn("custom color in use")
+		r := port.NewErrorResult(http.StatusConflict, port.ErrorCodeCustomColorInUse, MsgCustomColorInUse)
+		i.OutputPort.SetResponseDeleteCustomColor(nil, r)
+		return
+	}
+
+	if err := i.CustomColorRepository.Delete(input.ColorID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeleteCustomColor(nil, r)
+		return
+	}
+
+	o := &port.DeleteCustomColorOutputData{}
+	r := port.NewSuccessResult(http.StatusNoContent)
+	i.OutputPort.SetResponseDeleteCustomColor(o, r)
+}
+
+// isInUse はユーザーのスケジュールまたは科目が指定された色を使っているかどうかを返します。
+func (i *PaletteInteractor) isInUse(userID, color string) (bool, error) {
+	schedules, err := i.ScheduleRepository.ReadByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range schedules {
+		if s.Color == color {
+			return true, nil
+		}
+	}
+
+	subjects, err := i.SubjectRepository.ReadByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range subjects {
+		if s.Color == color {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// toBasePaletteColorData はパレットの色を出力データに変換します。
+func toBasePaletteColorData(pc model.PaletteColor, custom bool) port.BasePaletteColorData {
+	return port.BasePaletteColorData{
+		Key:    pc.Key.String(),
+		Name:   pc.Name,
+		Hex:    pc.Hex,
+		Style:  pc.Style.String(),
+		Custom: custom,
+	}
+}
+
+// toBaseCustomColorData はユーザー定義の色を出力データに変換します。
+func toBaseCustomColorData(zone timezone.Zone, cc model.CustomColor) port.BaseCustomColorData {
+	return port.BaseCustomColorData{
+		ID:        cc.ID,
+		UserID:    cc.UserID,
+		Key:       cc.Key().String(),
+		Name:      cc.Name,
+		Hex:       cc.Hex,
+		Style:     cc.Style.String(),
+		CreatedAt: zone.FormatDateTime(cc.CreatedAt),
+		UpdatedAt: zone.FormatDateTime(cc.UpdatedAt),
+	}
+}
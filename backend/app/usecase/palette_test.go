@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPalette(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	p := &stubPaletteOutputPort{}
+	i := NewPaletteInteractor(l, timezone.UTC(), &stubCustomColorRepository{}, &stubScheduleRepository{}, &stubSubjectRepository{}, p)
+
+	i.GetPalette(port.GetPaletteInputData{UserID: "test-user-id"})
+
+	assert.Equal(http.StatusOK, p.Result.StatusCode)
+	o, ok := p.Output.(*port.GetPaletteOutputData)
+	require.True(ok)
+	require.Len(o.Colors, 14)
+
+	assert.Equal(port.BasePaletteColorData{Key: "white", Name: "白", Hex: "#ffffff", Style: "fill"}, o.Colors[0])
+	assert.Equal(port.BasePaletteColorData{Key: "outline_yellow", Name: "黄（枠線のみ）", Hex: "#eab308", Style: "outline"}, o.Colors[11])
+	assert.Equal(port.BasePaletteColorData{Key: "custom_test-color-1", Name: "藤色", Hex: "#a78bfa", Style: "fill", Custom: true}, o.Colors[12])
+	assert.Equal(port.BasePaletteColorData{Key: "custom_test-color-2", Name: "若草色", Hex: "#84cc16", Style: "outline", Custom: true}, o.Colors[13])
+}
+
+func TestCreateCustomColor(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		wantStatus int
+	}{
+		{name: "正常系", wantStatus: http.StatusCreated},
+		{name: "異常系: 上限に達している場合はエラー", count: 20, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			r := &stubCustomColorRepository{Count: tt.count}
+			p := &stubPaletteOutputPort{}
+			i := NewPaletteInteractor(l, timezone.UTC(), r, &stubScheduleRepository{}, &stubSubjectRepository{}, p)
+
+			i.CreateCustomColor(port.CreateCustomColorInputData{
+				UserID: "test-user-id",
+				Name:   "桜色",
+				Hex:    "#FBCFE8",
+				Style:  "outline",
+			})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			if tt.wantStatus != http.StatusCreated {
+				assert.Equal("ユーザーが定義できる色は20件までです", p.Result.ErrorMessage)
+				assert.Empty(r.Created)
+				return
+			}
+
+			require.Len(r.Created, 1)
+			o, ok := p.Output.(*port.CreateCustomColorOutputData)
+			require.True(ok)
+			assert.Equal("custom_"+r.Created[0].ID, o.CustomColor.Key)
+			assert.Equal("#fbcfe8", o.CustomColor.Hex)
+			assert.Equal("outline", o.CustomColor.Style)
+		})
+	}
+}
+
+func TestDeleteCustomColor(t *testing.T) {
+	tests := []struct {
+		name        string
+		colorID     string
+		wantStatus  int
+		wantDeleted []string
+	}{
+		{name: "正常系", colorID: "test-color-2", wantStatus: http.StatusNoContent, wantDeleted: []string{"test-color-2"}},
+		{name: "異常系: スケジュールで使われている場合はエラー", colorID: "test-color-1", wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			r := &stubCustomColorRepository{}
+			p := &stubPaletteOutputPort{}
+			i := NewPaletteInteractor(l, timezone.UTC(), r, &stubCustomColorScheduleRepository{}, &stubSubjectRepository{}, p)
+
+			i.DeleteCustomColor(port.DeleteCustomColorInputData{UserID: "test-user-id", ColorID: tt.colorID})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			assert.Equal(tt.wantDeleted, r.Deleted)
+		})
+	}
+}
+
+func TestColorValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors []string
+		want   bool
+	}{
+		{name: "パレットの色", colors: []string{"white", "outline_blue"}, want: true},
+		{name: "ユーザーが定義した色", colors: []string{"white", "custom_test-color-2"}, want: true},
+		{name: "存在しないユーザー定義の色", colors: []string{"custom_test-color-9"}, want: false},
+		{name: "パレットにない色", colors: []string{"purple"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			v := NewColorValidator(&stubCustomColorRepository{})
+			got, err := v.Validate("test-user-id", tt.colors...)
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/component/webauthn"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// PasskeyInteractor はパスキーのユースケースの実装を表す構造体です。
+type PasskeyInteractor struct {
+	Logger                      *slog.Logger
+	RelyingParty                webauthn.RelyingParty
+	UserRepository              repository.UserRepository
+	SessionRepository           repository.SessionRepository
+	RefreshTokenRepository      repository.RefreshTokenRepository
+	LoginSessionRepository      repository.LoginSessionRepository
+	PasskeyRepository           repository.PasskeyRepository
+	WebAuthnChallengeRepository repository.WebAuthnChallengeRepository
+	OutputPort                  port.PasskeyOutputPort
+}
+
+// NewPasskeyInteractor は PasskeyInteractor を生成します。
+func NewPasskeyInteractor(logger *slog.Logger, rp webauthn.RelyingParty, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, passkeyRepository repository.PasskeyRepository, webAuthnChallengeRepository repository.WebAuthnChallengeRepository, outputPort port.PasskeyOutputPort) port.PasskeyInputPort {
+	return &PasskeyInteractor{
+		Logger:                      logger,
+		RelyingParty:                rp,
+		UserRepository:              userRepository,
+		SessionRepository:           sessionRepository,
+		RefreshTokenRepository:      refreshTokenRepository,
+		LoginSessionRepository:      loginSessionRepository,
+		PasskeyRepository:           passkeyRepository,
+		WebAuthnChallengeRepository: webAuthnChallengeRepository,
+		OutputPort:                  outputPort,
+	}
+}
+
+// BeginPasskeyRegistration はチャレンジを発行して、パスキーの登録を開始します。
+func (i *PasskeyInteractor) BeginPasskeyRegistration(input port.BeginPasskeyRegistrationInputData) {
+	user, err := i.UserRepository.Read(input.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found", "user_id", input.UserID)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+			i.OutputPort.SetResponseBeginPasskeyRegistration(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginPasskeyRegistration(nil, r)
+		return
+	}
+
+	passkeys, err := i.PasskeyRepository.ReadByUserID(user.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginPasskeyRegistration(nil, r)
+		return
+	}
+
+	if len(passkeys) >= model.PasskeyMaxCount {
+		i.Logger.Warn("passkey limit exceeded", "user_id", user.ID)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyLimitExceeded, fmt.Sprintf(MsgPasskeyLimitExceeded, model.PasskeyMaxCount))
+		i.OutputPort.SetResponseBeginPasskeyRegistration(nil, r)
+		return
+	}
+
+	c, err := model.NewWebAuthnChallenge(model.WebAuthnChallengePurposeRegistration, user.ID, time.Now())
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginPasskeyRegistration(nil, r)
+		return
+	}
+
+	if err := i.WebAuthnChallengeRepository.Create(c); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginPasskeyRegistration(nil, r)
+		return
+	}
+
+	excludes := make([]string, 0, len(passkeys))
+	for _, p := range passkeys {
+		excludes = append(excludes, p.ID)
+	}
+
+	displayName := user.Name
+	if displayName == "" {
+		displayName = user.Email
+	}
+
+	o := &port.BeginPasskeyRegistrationOutputData{
+		Challenge:            c.Challenge,
+		RPID:                 i.RelyingParty.ID,
+		RPName:               i.RelyingParty.Name,
+		UserHandle:           webauthn.Encoding.EncodeToString([]byte(user.ID)),
+		UserName:             user.Email,
+		UserDisplayName:      displayName,
+		Algorithms:           webauthn.Algorithms,
+		ExcludeCredentialIDs: excludes,
+		TimeoutMilliseconds:  model.WebAuthnChallengeLifetime.Milliseconds(),
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseBeginPasskeyRegistration(o, r)
+}
+
+// FinishPasskeyRegistration は登録の応答を検証して、パスキーを保存します。
+// 構成証明の署名は検証せず、認証器の種類は問いません。
+func (i *PasskeyInteractor) FinishPasskeyRegistration(input port.FinishPasskeyRegistrationInputData) {
+	user, err := i.UserRepository.Read(input.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found", "user_id", input.UserID)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+			i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+
+	clientDataJSON, err := webauthn.Encoding.DecodeString(input.ClientDataJSON)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+	attestationObject, err := webauthn.Encoding.DecodeString(input.AttestationObject)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+
+	now := time.Now()
+	c, ok, err := i.consumeChallenge(clientDataJSON, model.WebAuthnChallengePurposeRegistration, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+	// 別のユーザーが発行したチャレンジでは登録できない
+	if !ok || c.UserID != user.ID {
+		i.Logger.Warn("passkey challenge is invalid", "user_id", user.ID)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyChallengeInvalid, MsgPasskeyChallengeInvalid)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+
+	credential, err := i.RelyingParty.VerifyRegistration(c.Challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		i.Logger.Warn(err.Error(), "user_id", user.ID)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+
+	// 登録を開始した後に別の端末で登録した場合にも上限を超えないよう、保存する前に数え直す
+	passkeys, err := i.PasskeyRepository.ReadByUserID(user.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+	if len(passkeys) >= model.PasskeyMaxCount {
+		i.Logger.Warn("passkey limit exceeded", "user_id", user.ID)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyLimitExceeded, fmt.Sprintf(MsgPasskeyLimitExceeded, model.PasskeyMaxCount))
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+
+	name := input.Name
+	if name == "" {
+		name = model.PasskeyDefaultName
+	}
+
+	passkey := &model.Passkey{
+		ID:             webauthn.Encoding.EncodeToString(credential.ID),
+		UserID:         user.ID,
+		Name:           name,
+		PublicKey:      credential.PublicKey,
+		Algorithm:      credential.Algorithm,
+		SignCount:      int64(credential.SignCount),
+		BackupEligible: credential.BackupEligible,
+		BackedUp:       credential.BackedUp,
+		CreatedAt:      now,
+	}
+	if err := i.PasskeyRepository.Create(passkey); err != nil {
+		if errors.Is(err, repository.ErrPasskeyAlreadyRegistered) {
+			i.Logger.Warn("passkey already registered", "user_id", user.ID, "passkey_id", passkey.ID)
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodePasskeyAlreadyRegistered, MsgPasskeyAlreadyRegistered)
+			i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeyRegistration(nil, r)
+		return
+	}
+
+	i.Logger.Info("passkey registered", "user_id", user.ID, "passkey_id", passkey.ID)
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+	o := &port.FinishPasskeyRegistrationOutputData{PasskeyData: toPasskeyData(zone, passkey)}
+	r := port.NewSuccessResult(http.StatusCreated)
+	i.OutputPort.SetResponseFinishPasskeyRegistration(o, r)
+}
+
+// GetPasskeyList は登録したパスキーを登録した順に取得します。
+func (i *PasskeyInteractor) GetPasskeyList(input port.GetPasskeyListInputData) {
+	user, err := i.UserRepository.Read(input.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found", "user_id", input.UserID)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+			i.OutputPort.SetResponseGetPasskeyList(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetPasskeyList(nil, r)
+		return
+	}
+
+	passkeys, err := i.PasskeyRepository.ReadByUserID(user.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetPasskeyList(nil, r)
+		return
+	}
+	sort.SliceStable(passkeys, func(a, b int) bool {
+		return passkeys[a].CreatedAt.Before(passkeys[b].CreatedAt)
+	})
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+	data := make([]port.PasskeyData, 0, len(passkeys))
+	for _, p := range passkeys {
+		data = append(data, toPasskeyData(zone, &p))
+	}
+
+	o := &port.GetPasskeyListOutputData{Passkeys: data}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetPasskeyList(o, r)
+}
+
+// DeletePasskey は指定されたパスキーを削除します。削除したパスキーではサインインできなくなります。
+func (i *PasskeyInteractor) DeletePasskey(input port.DeletePasskeyInputData) {
+	passkey, err := i.PasskeyRepository.Read(input.PasskeyID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeletePasskey(nil, r)
+		return
+	}
+
+	// 別のユーザーのパスキーは存在しないものとして扱う
+	if err != nil || passkey.UserID != input.UserID {
+		i.Logger.Warn("passkey not found", "passkey_id", input.PasskeyID)
+		r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodePasskeyNotFound, MsgPasskeyNotFound)
+		i.OutputPort.SetResponseDeletePasskey(nil, r)
+		return
+	}
+
+	if err := i.PasskeyRepository.Delete(passkey.ID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeletePasskey(nil, r)
+		return
+	}
+
+	i.Logger.Info("passkey deleted", "user_id", input.UserID, "passkey_id", passkey.ID)
+
+	o := &port.DeletePasskeyOutputData{}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseDeletePasskey(o, r)
+}
+
+// BeginPasskeySignIn はチャレンジを発行して、パスキーによるサインインを開始します。
+func (i *PasskeyInteractor) BeginPasskeySignIn(input port.BeginPasskeySignInInputData) {
+	c, err := model.NewWebAuthnChallenge(model.WebAuthnChallengePurposeAuthentication, "", time.Now())
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginPasskeySignIn(nil, r)
+		return
+	}
+
+	if err := i.WebAuthnChallengeRepository.Create(c); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseBeginPasskeySignIn(nil, r)
+		return
+	}
+
+	o := &port.BeginPasskeySignInOutputData{
+		Challenge:           c.Challenge,
+		RPID:                i.RelyingParty.ID,
+		TimeoutMilliseconds: model.WebAuthnChallengeLifetime.Milliseconds(),
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseBeginPasskeySignIn(o, r)
+}
+
+// FinishPasskeySignIn は認証の応答を登録済みのパスキーで検証し、パスワードのサインインと同じセッションを発行します。
+// パスキーはユーザーの検証を必須とするため、二段階認証が有効なユーザーでも認証コードは求めません。
+// 署名の回数が増えていない応答は複製された認証器によるものとして拒否します。
+func (i *PasskeyInteractor) FinishPasskeySignIn(input port.FinishPasskeySignInInputData) {
+	clientDataJSON, err1 := webauthn.Encoding.DecodeString(input.ClientDataJSON)
+	authenticatorData, err2 := webauthn.Encoding.DecodeString(input.AuthenticatorData)
+	signature, err3 := webauthn.Encoding.DecodeString(input.Signature)
+	userHandle, err4 := webauthn.Encoding.DecodeString(input.UserHandle)
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	now := time.Now()
+	c, ok, err := i.consumeChallenge(clientDataJSON, model.WebAuthnChallengePurposeAuthentication, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+	if !ok {
+		i.Logger.Warn("passkey challenge is invalid")
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyChallengeInvalid, MsgPasskeyChallengeInvalid)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	passkey, err := i.PasskeyRepository.Read(input.CredentialID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+	// 削除したパスキーや、ユーザーハンドルが登録したユーザーと異なる応答は受け付けない
+	if err != nil || (len(userHandle) > 0 && string(userHandle) != passkey.UserID) {
+		i.Logger.Warn("passkey not found", "passkey_id", input.CredentialID)
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	i.Logger.With("user_id", passkey.UserID, "passkey_id", passkey.ID)
+
+	a, err := i.RelyingParty.VerifyAssertion(c.Challenge, passkey.PublicKey, clientDataJSON, authenticatorData, signature)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	signCount := int64(a.SignCount)
+	if !passkey.IsValidSignCount(signCount) {
+		i.Logger.Warn("passkey sign count did not increase", "stored", passkey.SignCount, "received", signCount)
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	if err := i.PasskeyRepository.UpdateSignCount(passkey, signCount, now); err != nil {
+		if errors.Is(err, repository.ErrPasskeySignCountConflict) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+			i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	user, err := i.UserRepository.Read(passkey.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodePasskeyInvalid, MsgPasskeyInvalid)
+			i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	tokens, err := startLoginSession(i.SessionRepository, i.RefreshTokenRepository, i.LoginSessionRepository, user, input.UserAgent, input.IPAddress, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseFinishPasskeySignIn(nil, r)
+		return
+	}
+
+	i.Logger.Info("signed in with passkey")
+
+	o := &port.FinishPasskeySignInOutputData{
+		BaseUserData:     toBaseUserData(user),
+		SessionTokenData: *tokens,
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseFinishPasskeySignIn(o, r)
+}
+
+// consumeChallenge はクライアントデータのチャレンジを使用済みにして返します。
+// 発行していないチャレンジ、使用済みのチャレンジ、用途が異なるチャレンジ、期限切れのチャレンジの場合は false を返します。
+func (i *PasskeyInteractor) consumeChallenge(clientDataJSON []byte, purpose model.WebAuthnChallengePurpose, now time.Time) (*model.WebAuthnChallenge, bool, error) {
+	cd, err := webauthn.ParseClientData(clientDataJSON)
+	if err != nil || cd.Challenge == "" {
+		return nil, false, nil
+	}
+
+	c, err := i.WebAuthnChallengeRepository.Consume(cd.Challenge)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return c, c.IsValid(purpose, now), nil
+}
+
+// toPasskeyData はパスキーを出力データに変換します。
+func toPasskeyData(zone timezone.Zone, passkey *model.Passkey) port.PasskeyData {
+	lastUsedAt := ""
+	if !passkey.LastUsedAt.IsZero() {
+		lastUsedAt = zone.FormatDateTime(passkey.LastUsedAt)
+	}
+
+	return port.PasskeyData{
+		ID:         passkey.ID,
+		Name:       passkey.Name,
+		BackedUp:   passkey.BackedUp,
+		CreatedAt:  zone.FormatDateTime(passkey.CreatedAt),
+		LastUsedAt: lastUsedAt,
+	}
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/webauthn"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRelyingParty = webauthn.RelyingParty{ID: "example.com", Name: "受講計画", Origin: "https://example.com"}
+
+// newTestPasskeyInteractor はスタブの repository でパスキーのユースケースを生成します。
+func newTestPasskeyInteractor(pr *stubPasskeyRepository, cr *stubWebAuthnChallengeRepository, p *stubPasskeyOutputPort) port.PasskeyInputPort {
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return NewPasskeyInteractor(l, testRelyingParty, &stubUserRepository{}, &stubSessionRepository{}, &stubRefreshTokenRepository{}, &stubLoginSessionRepository{}, pr, cr, p)
+}
+
+// registerTestPasskey はソフトウェアの認証器でパスキーを作成し、登録の開始と完了を行います。
+func registerTestPasskey(t *testing.T, i port.PasskeyInputPort, p *stubPasskeyOutputPort) *webauthn.SoftwareAuthenticator {
+	t.Helper()
+
+	require := require.New(t)
+
+	i.BeginPasskeyRegistration(port.BeginPasskeyRegistrationInputData{UserID: "test-id"})
+	require.Equal(http.StatusOK, p.Result.StatusCode)
+	options, ok := p.Output.(*port.BeginPasskeyRegistrationOutputData)
+	require.True(ok)
+
+	a, err := webauthn.NewSoftwareAuthenticator(testRelyingParty)
+	require.NoError(err)
+	userHandle, err := webauthn.Encoding.DecodeString(options.UserHandle)
+	require.NoError(err)
+	clientDataJSON, attestationObject, err := a.Create(options.Challenge, userHandle)
+	require.NoError(err)
+
+	i.FinishPasskeyRegistration(port.FinishPasskeyRegistrationInputData{
+		UserID:            "test-id",
+		ClientDataJSON:    webauthn.Encoding.EncodeToString(clientDataJSON),
+		AttestationObject: webauthn.Encoding.EncodeToString(attestationObject),
+	})
+	require.Equal(http.StatusCreated, p.Result.StatusCode)
+
+	return a
+}
+
+// signInTestPasskey はサインインを開始し、ソフトウェアの認証器の応答でサインインを完了する入力データを返します。
+func signInTestPasskey(t *testing.T, i port.PasskeyInputPort, p *stubPasskeyOutputPort, a *webauthn.SoftwareAuthenticator) port.FinishPasskeySignInInputData {
+	t.Helper()
+
+	require := require.New(t)
+
+	i.BeginPasskeySignIn(port.BeginPasskeySignInInputData{})
+	require.Equal(http.StatusOK, p.Result.StatusCode)
+	options, ok := p.Output.(*port.BeginPasskeySignInOutputData)
+	require.True(ok)
+
+	clientDataJSON, authenticatorData, signature, err := a.Get(options.Challenge)
+	require.NoError(err)
+
+	return port.FinishPasskeySignInInputData{
+		CredentialID:      webauthn.Encoding.EncodeToString(a.CredentialID),
+		ClientDataJSON:    webauthn.Encoding.EncodeToString(clientDataJSON),
+		AuthenticatorData: webauthn.Encoding.EncodeToString(authenticatorData),
+		Signature:         webauthn.Encoding.EncodeToString(signature),
+		UserHandle:        webauthn.Encoding.EncodeToString(a.UserHandle),
+		UserAgent:         "test-user-agent",
+		IPAddress:         "192.0.2.1",
+	}
+}
+
+func TestPasskeyRegistration(t *testing.T) {
+	t.Run("パスキーを登録する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		pr := &stubPasskeyRepository{}
+		cr := &stubWebAuthnChallengeRepository{}
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(pr, cr, p)
+
+		a := registerTestPasskey(t, i, p)
+
+		output, ok := p.Output.(*port.FinishPasskeyRegistrationOutputData)
+		require.True(ok)
+		assert.Equal(webauthn.Encoding.EncodeToString(a.CredentialID), output.ID)
+		assert.Equal(model.PasskeyDefaultName, output.Name)
+		assert.Empty(output.LastUsedAt)
+
+		passkey := pr.Passkeys[output.ID]
+		assert.Equal("test-id", passkey.UserID)
+		assert.Equal(webauthn.AlgES256, passkey.Algorithm)
+		assert.Empty(cr.Challenges)
+	})
+
+	t.Run("複数のパスキーを登録し、登録済みのパスキーを除外する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		pr := &stubPasskeyRepository{}
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(pr, &stubWebAuthnChallengeRepository{}, p)
+
+		first := registerTestPasskey(t, i, p)
+		registerTestPasskey(t, i, p)
+
+		i.BeginPasskeyRegistration(port.BeginPasskeyRegistrationInputData{UserID: "test-id"})
+		options, ok := p.Output.(*port.BeginPasskeyRegistrationOutputData)
+		require.True(ok)
+		assert.Len(options.ExcludeCredentialIDs, 2)
+		assert.Contains(options.ExcludeCredentialIDs, webauthn.Encoding.EncodeToString(first.CredentialID))
+
+		i.GetPasskeyList(port.GetPasskeyListInputData{UserID: "test-id"})
+		list, ok := p.Output.(*port.GetPasskeyListOutputData)
+		require.True(ok)
+		assert.Len(list.Passkeys, 2)
+	})
+
+	t.Run("登録の上限を超える場合は開始できない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		pr := &stubPasskeyRepository{Passkeys: map[string]model.Passkey{}}
+		for n := range model.PasskeyMaxCount {
+			id := string(rune('a' + n))
+			pr.Passkeys[id] = model.Passkey{ID: id, UserID: "test-id"}
+		}
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(pr, &stubWebAuthnChallengeRepository{}, p)
+
+		i.BeginPasskeyRegistration(port.BeginPasskeyRegistrationInputData{UserID: "test-id"})
+		assert.Equal(http.StatusBadRequest, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyLimitExceeded, p.Result.ErrorCode)
+	})
+}
+
+func TestFinishPasskeyRegistration_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		purpose   model.WebAuthnChallengePurpose
+		expiresIn time.Duration
+		origin    string
+		wantCode  port.ErrorCode
+	}{
+		{name: "別のユーザーのチャレンジ", userID: "test-other-id", purpose: model.WebAuthnChallengePurposeRegistration, expiresIn: time.Minute, origin: testRelyingParty.Origin, wantCode: port.ErrorCodePasskeyChallengeInvalid},
+		{name: "サインインのチャレンジ", userID: "", purpose: model.WebAuthnChallengePurposeAuthentication, expiresIn: time.Minute, origin: testRelyingParty.Origin, wantCode: port.ErrorCodePasskeyChallengeInvalid},
+		{name: "期限切れのチャレンジ", userID: "test-id", purpose: model.WebAuthnChallengePurposeRegistration, expiresIn: -time.Minute, origin: testRelyingParty.Origin, wantCode: port.ErrorCodePasskeyChallengeInvalid},
+		{name: "オリジンが異なる", userID: "test-id", purpose: model.WebAuthnChallengePurposeRegistration, expiresIn: time.Minute, origin: "https://evil.example.com", wantCode: port.ErrorCodePasskeyInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			c := model.WebAuthnChallenge{Challenge: "test-challenge", Purpose: tt.purpose, UserID: tt.userID, ExpiresAt: time.Now().Add(tt.expiresIn)}
+			cr := &stubWebAuthnChallengeRepository{Challenges: map[string]model.WebAuthnChallenge{c.Challenge: c}}
+			pr := &stubPasskeyRepository{}
+			p := &stubPasskeyOutputPort{}
+			i := newTestPasskeyInteractor(pr, cr, p)
+
+			a, err := webauthn.NewSoftwareAuthenticator(testRelyingParty)
+			require.NoError(err)
+			a.Origin = tt.origin
+			clientDataJSON, attestationObject, err := a.Create(c.Challenge, []byte("test-id"))
+			require.NoError(err)
+
+			i.FinishPasskeyRegistration(port.FinishPasskeyRegistrationInputData{
+				UserID:            "test-id",
+				ClientDataJSON:    webauthn.Encoding.EncodeToString(clientDataJSON),
+				AttestationObject: webauthn.Encoding.EncodeToString(attestationObject),
+			})
+			assert.Equal(http.StatusBadRequest, p.Result.StatusCode)
+			assert.Equal(tt.wantCode, p.Result.ErrorCode)
+			assert.Empty(pr.Passkeys)
+		})
+	}
+}
+
+func TestFinishPasskeySignIn(t *testing.T) {
+	t.Run("パスキーでサインインし、署名の回数を記録する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		pr := &stubPasskeyRepository{}
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(pr, &stubWebAuthnChallengeRepository{}, p)
+		a := registerTestPasskey(t, i, p)
+
+		for range 2 {
+			i.FinishPasskeySignIn(signInTestPasskey(t, i, p, a))
+			require.Equal(http.StatusOK, p.Result.StatusCode)
+		}
+
+		output, ok := p.Output.(*port.FinishPasskeySignInOutputData)
+		require.True(ok)
+		assert.Equal("test-id", output.ID)
+		assert.Equal("test-token", output.SessionToken)
+		assert.NotEmpty(output.RefreshToken)
+
+		passkey := pr.Passkeys[webauthn.Encoding.EncodeToString(a.CredentialID)]
+		assert.Equal(int64(2), passkey.SignCount)
+		assert.False(passkey.LastUsedAt.IsZero())
+	})
+
+	t.Run("同じ応答は2回使えない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(&stubPasskeyRepository{}, &stubWebAuthnChallengeRepository{}, p)
+		a := registerTestPasskey(t, i, p)
+
+		input := signInTestPasskey(t, i, p, a)
+		i.FinishPasskeySignIn(input)
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		i.FinishPasskeySignIn(input)
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyChallengeInvalid, p.Result.ErrorCode)
+	})
+
+	t.Run("署名の回数が増えていない場合は複製された認証器として拒否する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		pr := &stubPasskeyRepository{}
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(pr, &stubWebAuthnChallengeRepository{}, p)
+		a := registerTestPasskey(t, i, p)
+
+		i.FinishPasskeySignIn(signInTestPasskey(t, i, p, a))
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		// 複製された認証器は元の認証器と同じ回数から数える
+		a.SignCount = 0
+		i.FinishPasskeySignIn(signInTestPasskey(t, i, p, a))
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyInvalid, p.Result.ErrorCode)
+		assert.Equal(int64(1), pr.Passkeys[webauthn.Encoding.EncodeToString(a.CredentialID)].SignCount)
+	})
+
+	t.Run("登録していないパスキーではサインインできない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(&stubPasskeyRepository{}, &stubWebAuthnChallengeRepository{}, p)
+		a, err := webauthn.NewSoftwareAuthenticator(testRelyingParty)
+		require.NoError(err)
+		a.UserHandle = []byte("test-id")
+
+		i.FinishPasskeySignIn(signInTestPasskey(t, i, p, a))
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyInvalid, p.Result.ErrorCode)
+	})
+
+	t.Run("ユーザーハンドルが異なる場合はサインインできない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(&stubPasskeyRepository{}, &stubWebAuthnChallengeRepository{}, p)
+		a := registerTestPasskey(t, i, p)
+		a.UserHandle = []byte("test-other-id")
+
+		i.FinishPasskeySignIn(signInTestPasskey(t, i, p, a))
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyInvalid, p.Result.ErrorCode)
+	})
+
+	t.Run("削除したパスキーではサインインできない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(&stubPasskeyRepository{}, &stubWebAuthnChallengeRepository{}, p)
+		a := registerTestPasskey(t, i, p)
+
+		i.DeletePasskey(port.DeletePasskeyInputData{UserID: "test-id", PasskeyID: webauthn.Encoding.EncodeToString(a.CredentialID)})
+		require.Equal(http.StatusOK, p.Result.StatusCode)
+
+		i.FinishPasskeySignIn(signInTestPasskey(t, i, p, a))
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyInvalid, p.Result.ErrorCode)
+	})
+}
+
+func TestDeletePasskey(t *testing.T) {
+	t.Run("別のユーザーのパスキーは削除できない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		pr := &stubPasskeyRepository{Passkeys: map[string]model.Passkey{
+			"test-passkey-id": {ID: "test-passkey-id", UserID: "test-other-id"},
+		}}
+		p := &stubPasskeyOutputPort{}
+		i := newTestPasskeyInteractor(pr, &stubWebAuthnChallengeRepository{}, p)
+
+		i.DeletePasskey(port.DeletePasskeyInputData{UserID: "test-id", PasskeyID: "test-passkey-id"})
+		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodePasskeyNotFound, p.Result.ErrorCode)
+		assert.Contains(pr.Passkeys, "test-passkey-id")
+	})
+}
@@ -5,76 +5,67 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/datsukan/attendance-plan/backend/app/component/ical"
 	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/model"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/datsukan/attendance-plan/backend/app/repository"
 )
 
 // ScheduleInteractor はスケジュールのユースケースの実装を表す構造体です。
+// 日時の解釈と出力、日付ごとのまとめは Zone のタイムゾーンで行います。
 type ScheduleInteractor struct {
 	Logger             *slog.Logger
+	Zone               timezone.Zone
 	ScheduleRepository repository.ScheduleRepository
+	Linter             ScheduleLinter
+	Tagger             ScheduleTagger
+	ColorValidator     ColorValidator
 	OutputPort         port.ScheduleOutputPort
 }
 
 // NewScheduleInteractor は ScheduleInteractor を生成します。
-func NewScheduleInteractor(logger *slog.Logger, scheduleRepository repository.ScheduleRepository, outputPort port.ScheduleOutputPort) port.ScheduleInputPort {
+func NewScheduleInteractor(logger *slog.Logger, zone timezone.Zone, scheduleRepository repository.ScheduleRepository, linter ScheduleLinter, tagger ScheduleTagger, colorValidator ColorValidator, outputPort port.ScheduleOutputPort) port.ScheduleInputPort {
 	return &ScheduleInteractor{
 		Logger:             logger,
+		Zone:               zone,
 		ScheduleRepository: scheduleRepository,
+		Linter:             linter,
+		Tagger:             tagger,
+		ColorValidator:     colorValidator,
 		OutputPort:         outputPort,
 	}
 }
 
 // GetScheduleList はスケジュールリストを取得します。
 func (i *ScheduleInteractor) GetScheduleList(input port.GetScheduleListInputData) {
-	schedules, err := i.ScheduleRepository.ReadByUserID(input.UserID)
+	schedules, err := i.readScheduleList(input.UserID, input.TagID)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseGetScheduleList(nil, r)
 		return
 	}
 
-	dil := model.ScheduleList(schedules).ToDateItemList()
-	dilMap := dil.ToTypeMap()
-	masterDateItems := dilMap[model.ScheduleTypeMaster]
-	customDateItems := dilMap[model.ScheduleTypeCustom]
-
-	appendSchedules := func(dis model.DateItemList) []port.BaseDateItemData {
-		res := make([]port.BaseDateItemData, 0, len(dis))
-		for _, di := range dis {
-			schedules := make([]port.BaseScheduleData, 0, len(di.Schedules))
-			for _, s := range di.Schedules {
-				schedules = append(schedules, port.BaseScheduleData{
-					ID:        s.ID,
-					UserID:    s.UserID,
-					Name:      s.Name,
-					StartsAt:  s.StartsAt.Format(time.DateTime),
-					EndsAt:    s.EndsAt.Format(time.DateTime),
-					Color:     s.Color,
-					Type:      s.Type.String(),
-					Order:     s.Order.Int(),
-					CreatedAt: s.CreatedAt.Format(time.DateTime),
-					UpdatedAt: s.UpdatedAt.Format(time.DateTime),
-				})
-			}
-			res = append(res, port.BaseDateItemData{
-				Date:      di.Date.Format(model.DateFormat),
-				Type:      di.Type.String(),
-				Schedules: schedules,
-			})
+	filtered := model.ScheduleList(schedules).In(i.Zone.Location()).Filter(toGetScheduleListFilter(i.Zone, input))
+
+	o := &port.GetScheduleListOutputData{Flat: input.Flat, Fields: input.Fields}
+	if input.Flat {
+		filtered.SortByStartsAt()
+		o.Schedules = make([]port.BaseScheduleData, 0, len(filtered))
+		for _, s := range filtered {
+			o.Schedules = append(o.Schedules, toBaseScheduleData(i.Zone, s))
 		}
-		return res
+	} else {
+		dilMap := filtered.ToDateItemList().ToTypeMap()
+		o.MasterSchedules = toBaseDateItemDataList(i.Zone, dilMap[model.ScheduleTypeMaster])
+		o.CustomSchedules = toBaseDateItemDataList(i.Zone, dilMap[model.ScheduleTypeCustom])
 	}
 
-	masterSchedules := appendSchedules(masterDateItems)
-	customSchedules := appendSchedules(customDateItems)
-
-	o := &port.GetScheduleListOutputData{MasterSchedules: masterSchedules, CustomSchedules: customSchedules}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseGetScheduleList(o, r)
 }
@@ -87,49 +78,36 @@ func (i *ScheduleInteractor) GetSchedule(input port.GetScheduleInputData) {
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn(err.Error())
-			r := port.NewErrorResult(http.StatusNotFound, MsgScheduleNotFound)
+			r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeScheduleNotFound, MsgScheduleNotFound)
 			i.OutputPort.SetResponseGetSchedule(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseGetSchedule(nil, r)
 		return
 	}
 
-	s := port.BaseScheduleData{
-		ID:        schedule.ID,
-		UserID:    schedule.UserID,
-		Name:      schedule.Name,
-		StartsAt:  schedule.StartsAt.Format(time.DateTime),
-		EndsAt:    schedule.EndsAt.Format(time.DateTime),
-		Color:     schedule.Color,
-		Type:      schedule.Type.String(),
-		Order:     schedule.Order.Int(),
-		CreatedAt: schedule.CreatedAt.Format(time.DateTime),
-		UpdatedAt: schedule.UpdatedAt.Format(time.DateTime),
-	}
-
-	o := &port.GetScheduleOutputData{Schedule: s}
+	o := &port.GetScheduleOutputData{Schedule: toBaseScheduleData(i.Zone, *schedule)}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseGetSchedule(o, r)
 }
 
 // CreateSchedule はスケジュールを作成します。
 func (i *ScheduleInteractor) CreateSchedule(input port.CreateScheduleInputData) {
-	startsAt, err := time.Parse(time.DateTime, input.Schedule.StartsAt)
+	startsAt, err := i.Zone.ParseDateTime(input.Schedule.StartsAt)
 	if err != nil {
 		i.Logger.Warn(err.Error())
-		r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, "開始日"))
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "開始日"))
 		i.OutputPort.SetResponseCreateSchedule(nil, r)
 		return
 	}
 
-	endsAt, err := time.Parse(time.DateTime, input.Schedule.EndsAt)
+	endsAt, err := i.Zone.ParseDateTime(input.Schedule.EndsAt)
 	if err != nil {
 		i.Logger.Warn(err.Error())
-		r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, "終了日"))
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "終了日"))
 		i.OutputPort.SetResponseCreateSchedule(nil, r)
 		return
 	}
@@ -141,7 +119,7 @@ func (i *ScheduleInteractor) CreateSchedule(input port.CreateScheduleInputData)
 		someStartsAtSchedules, err := i.ScheduleRepository.ReadByUserIDStartsAt(input.Schedule.UserID, startsAt)
 		if err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseCreateSchedule(nil, r)
 			return
 		}
@@ -151,62 +129,86 @@ func (i *ScheduleInteractor) CreateSchedule(input port.CreateScheduleInputData)
 	}
 
 	s := model.Schedule{
-		ID:        id.NewID(),
-		UserID:    input.Schedule.UserID,
-		Name:      input.Schedule.Name,
-		StartsAt:  startsAt,
-		EndsAt:    endsAt,
-		Color:     input.Schedule.Color,
-		Type:      sType,
-		Order:     order,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:            id.NewID(),
+		UserID:        input.Schedule.UserID,
+		Name:          input.Schedule.Name,
+		StartsAt:      startsAt,
+		EndsAt:        endsAt,
+		Timed:         input.Schedule.Timed,
+		Color:         input.Schedule.Color,
+		Type:          sType,
+		Order:         order,
+		LectureNumber: input.Schedule.LectureNumber,
+		Note:          input.Schedule.Note,
+		Links:         input.Schedule.Links,
+		Memo:          input.Schedule.Memo,
+		TagIDs:        model.UniqueTagIDs(input.Schedule.TagIDs),
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
 	}
 
 	i.Logger.With("schedule_id", s.ID)
 
+	if err := s.Validate(); err != nil {
+		i.Logger.Warn(err.Error())
+		r := validationErrorResult(err)
+		i.OutputPort.SetResponseCreateSchedule(nil, r)
+		return
+	}
+
+	if ok, err := i.ColorValidator.Validate(s.UserID, s.Color); err != nil || !ok {
+		r := i.colorErrorResult(err)
+		i.OutputPort.SetResponseCreateSchedule(nil, r)
+		return
+	}
+
+	if ok, err := i.Tagger.Validate(s.UserID, s.TagIDs); err != nil || !ok {
+		r := i.tagErrorResult(err)
+		i.OutputPort.SetResponseCreateSchedule(nil, r)
+		return
+	}
+
 	if err := i.ScheduleRepository.Create(&s); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseCreateSchedule(nil, r)
+		return
+	}
+
+	if err := i.Tagger.Sync(s, nil); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseCreateSchedule(nil, r)
 		return
 	}
 
 	o := &port.CreateScheduleOutputData{
-		Schedule: port.BaseScheduleData{
-			ID:        s.ID,
-			UserID:    s.UserID,
-			Name:      s.Name,
-			StartsAt:  s.StartsAt.Format(time.DateTime),
-			EndsAt:    s.EndsAt.Format(time.DateTime),
-			Color:     s.Color,
-			Type:      s.Type.String(),
-			Order:     s.Order.Int(),
-			CreatedAt: s.CreatedAt.Format(time.DateTime),
-			UpdatedAt: s.UpdatedAt.Format(time.DateTime),
-		},
+		Schedule: toBaseScheduleData(i.Zone, s),
+		Warnings: i.lint(s.UserID, s.ID),
 	}
 	r := port.NewSuccessResult(http.StatusCreated)
 	i.OutputPort.SetResponseCreateSchedule(o, r)
 }
 
 // CreateBulkSchedule はスケジュールを一括作成します。
+// すべてのスケジュールの不変条件を検証してから作成し、違反がある場合は要素の位置を含めてすべて返します。
 func (i *ScheduleInteractor) CreateBulkSchedule(input port.CreateBulkScheduleInputData) {
-	responseSchedules := make([]port.BaseScheduleData, 0, len(input.Schedules))
+	schedules := make([]model.Schedule, 0, len(input.Schedules))
+	ve := &model.ValidationError{}
 
-	for _, s := range input.Schedules {
-		startsAt, err := time.Parse(time.DateTime, s.StartsAt)
+	for idx, s := range input.Schedules {
+		startsAt, err := i.Zone.ParseDateTime(s.StartsAt)
 		if err != nil {
 			i.Logger.Warn(err.Error())
-			r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, s.Name+"の開始日"))
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, s.Name+"の開始日"))
 			i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
 			return
 		}
 
-		endsAt, err := time.Parse(time.DateTime, s.EndsAt)
+		endsAt, err := i.Zone.ParseDateTime(s.EndsAt)
 		if err != nil {
 			i.Logger.Warn(err.Error())
-			r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, s.Name+"の終了日"))
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, s.Name+"の終了日"))
 			i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
 			return
 		}
@@ -218,7 +220,7 @@ func (i *ScheduleInteractor) CreateBulkSchedule(input port.CreateBulkScheduleInp
 			someStartsAtSchedules, err := i.ScheduleRepository.ReadByUserIDStartsAt(s.UserID, startsAt)
 			if err != nil {
 				i.Logger.Error(err.Error())
-				r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+				r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 				i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
 				return
 			}
@@ -227,45 +229,76 @@ func (i *ScheduleInteractor) CreateBulkSchedule(input port.CreateBulkScheduleInp
 			order = filteredSchedules.NextOrder()
 		}
 
-		s := model.Schedule{
-			ID:        id.NewID(),
-			UserID:    s.UserID,
-			Name:      s.Name,
-			StartsAt:  startsAt,
-			EndsAt:    endsAt,
-			Color:     s.Color,
-			Type:      sType,
-			Order:     order,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+		schedule := model.Schedule{
+			ID:            id.NewID(),
+			UserID:        s.UserID,
+			Name:          s.Name,
+			StartsAt:      startsAt,
+			EndsAt:        endsAt,
+			Timed:         s.Timed,
+			Color:         s.Color,
+			Type:          sType,
+			Order:         order,
+			LectureNumber: s.LectureNumber,
+			Note:          s.Note,
+			Links:         s.Links,
+			Memo:          s.Memo,
+			TagIDs:        model.UniqueTagIDs(s.TagIDs),
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+
+		ve.Merge(model.IndexedField("schedules", idx), schedule.Validate())
+		schedules = append(schedules, schedule)
+	}
+
+	if err := ve.Err(); err != nil {
+		i.Logger.Warn(err.Error())
+		r := validationErrorResult(err)
+		i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
+		return
+	}
+
+	for _, s := range schedules {
+		if ok, err := i.ColorValidator.Validate(s.UserID, s.Color); err != nil || !ok {
+			r := i.colorErrorResult(err)
+			i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
+			return
 		}
 
+		if ok, err := i.Tagger.Validate(s.UserID, s.TagIDs); err != nil || !ok {
+			r := i.tagErrorResult(err)
+			i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
+			return
+		}
+	}
+
+	responseSchedules := make([]port.BaseScheduleData, 0, len(schedules))
+
+	for _, s := range schedules {
 		i.Logger.With("schedule_id", s.ID)
 
 		if err := i.ScheduleRepository.Create(&s); err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
 			return
 		}
 
-		o := port.BaseScheduleData{
-			ID:        s.ID,
-			UserID:    s.UserID,
-			Name:      s.Name,
-			StartsAt:  s.StartsAt.Format(time.DateTime),
-			EndsAt:    s.EndsAt.Format(time.DateTime),
-			Color:     s.Color,
-			Type:      s.Type.String(),
-			Order:     s.Order.Int(),
-			CreatedAt: s.CreatedAt.Format(time.DateTime),
-			UpdatedAt: s.UpdatedAt.Format(time.DateTime),
+		if err := i.Tagger.Sync(s, nil); err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseCreateBulkSchedule(nil, r)
+			return
 		}
 
-		responseSchedules = append(responseSchedules, o)
+		responseSchedules = append(responseSchedules, toBaseScheduleData(i.Zone, s))
 	}
 
-	o := &port.CreateBulkScheduleOutputData{Schedules: responseSchedules}
+	o := &port.CreateBulkScheduleOutputData{
+		Schedules: responseSchedules,
+		Warnings:  i.lintSchedules(responseSchedules),
+	}
 	r := port.NewSuccessResult(http.StatusCreated)
 	i.OutputPort.SetResponseCreateBulkSchedule(o, r)
 }
@@ -274,18 +307,18 @@ func (i *ScheduleInteractor) CreateBulkSchedule(input port.CreateBulkScheduleInp
 func (i *ScheduleInteractor) UpdateSchedule(input port.UpdateScheduleInputData) {
 	i.Logger.With("schedule_id", input.Schedule.ID)
 
-	startsAt, err := time.Parse(time.DateTime, input.Schedule.StartsAt)
+	startsAt, err := i.Zone.ParseDateTime(input.Schedule.StartsAt)
 	if err != nil {
 		i.Logger.Warn(err.Error())
-		r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, "開始日"))
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "開始日"))
 		i.OutputPort.SetResponseUpdateSchedule(nil, r)
 		return
 	}
 
-	endsAt, err := time.Parse(time.DateTime, input.Schedule.EndsAt)
+	endsAt, err := i.Zone.ParseDateTime(input.Schedule.EndsAt)
 	if err != nil {
 		i.Logger.Warn(err.Error())
-		r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, "終了日"))
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, "終了日"))
 		i.OutputPort.SetResponseUpdateSchedule(nil, r)
 		return
 	}
@@ -296,12 +329,12 @@ func (i *ScheduleInteractor) UpdateSchedule(input port.UpdateScheduleInputData)
 	if err != nil {
 		if repository.IsNotFoundError(err) {
 			i.Logger.Warn(err.Error())
-			r := port.NewErrorResult(http.StatusNotFound, MsgScheduleNotFound)
+			r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeScheduleNotFound, MsgScheduleNotFound)
 			i.OutputPort.SetResponseUpdateSchedule(nil, r)
 			return
 		}
 
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseUpdateSchedule(nil, r)
 		return
 	}
@@ -318,10 +351,52 @@ func (i *ScheduleInteractor) UpdateSchedule(input port.UpdateScheduleInputData)
 		CreatedAt: bs.CreatedAt,
 		UpdatedAt: time.Now(),
 	}
+	applyScheduleDetail(&s, *bs, input.Schedule)
+
+	if err := s.Validate(); err != nil {
+		i.Logger.Warn(err.Error())
+		r := validationErrorResult(err)
+		i.OutputPort.SetResponseUpdateSchedule(nil, r)
+		return
+	}
+
+	if ok, err := i.ColorValidator.Validate(s.UserID, s.Color); err != nil || !ok {
+		r := i.colorErrorResult(err)
+		i.OutputPort.SetResponseUpdateSchedule(nil, r)
+		return
+	}
+
+	if ok, err := i.Tagger.Validate(s.UserID, s.TagIDs); err != nil || !ok {
+		r := i.tagErrorResult(err)
+		i.OutputPort.SetResponseUpdateSchedule(nil, r)
+		return
+	}
+
+	violations, err := i.Linter.CheckSequence(s.UserID, model.ScheduleList{s})
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateSchedule(nil, r)
+		return
+	}
+
+	if len(violations) > 0 {
+		i.Logger.Warn("lecture sequence broken", "message", violations[0].Message)
+		r := port.NewErrorResult(http.StatusConflict, port.ErrorCodeScheduleSequenceBroken, fmt.Sprintf(MsgLectureSequenceBroken, violations[0].Message))
+		i.OutputPort.SetResponseUpdateSchedule(nil, r)
+		return
+	}
 
 	if err := i.ScheduleRepository.Update(&s); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateSchedule(nil, r)
+		return
+	}
+
+	if err := i.Tagger.Sync(s, bs.TagIDs); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseUpdateSchedule(nil, r)
 		return
 	}
@@ -329,24 +404,14 @@ func (i *ScheduleInteractor) UpdateSchedule(input port.UpdateScheduleInputData)
 	as, err := i.ScheduleRepository.Read(s.ID)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseUpdateSchedule(nil, r)
 		return
 	}
 
 	o := &port.UpdateScheduleOutputData{
-		Schedule: port.BaseScheduleData{
-			ID:        as.ID,
-			UserID:    as.UserID,
-			Name:      as.Name,
-			StartsAt:  as.StartsAt.Format(time.DateTime),
-			EndsAt:    as.EndsAt.Format(time.DateTime),
-			Color:     as.Color,
-			Type:      as.Type.String(),
-			Order:     as.Order.Int(),
-			CreatedAt: as.CreatedAt.Format(time.DateTime),
-			UpdatedAt: as.UpdatedAt.Format(time.DateTime),
-		},
+		Schedule: toBaseScheduleData(i.Zone, *as),
+		Warnings: i.lint(as.UserID, as.ID),
 	}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseUpdateSchedule(o, r)
@@ -354,21 +419,24 @@ func (i *ScheduleInteractor) UpdateSchedule(input port.UpdateScheduleInputData)
 
 // UpdateBulkSchedule はスケジュールを一括更新します。
 func (i *ScheduleInteractor) UpdateBulkSchedule(input port.UpdateBulkScheduleInputData) {
-	responseSchedules := make([]port.BaseScheduleData, 0, len(input.Schedules))
+	// 講義回の順番をまとめて確認するため、すべての変更を組み立ててから更新する
+	schedules := make(model.ScheduleList, 0, len(input.Schedules))
+	beforeTagIDs := make(map[string][]string, len(input.Schedules))
+	ve := &model.ValidationError{}
 
-	for _, s := range input.Schedules {
-		startsAt, err := time.Parse(time.DateTime, s.StartsAt)
+	for idx, s := range input.Schedules {
+		startsAt, err := i.Zone.ParseDateTime(s.StartsAt)
 		if err != nil {
 			i.Logger.Warn(err.Error())
-			r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, s.Name+"の開始日"))
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, s.Name+"の開始日"))
 			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
 			return
 		}
 
-		endsAt, err := time.Parse(time.DateTime, s.EndsAt)
+		endsAt, err := i.Zone.ParseDateTime(s.EndsAt)
 		if err != nil {
 			i.Logger.Warn(err.Error())
-			r := port.NewErrorResult(http.StatusBadRequest, fmt.Sprintf(MsgFormatInvalid, s.Name+"の終了日"))
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeRequestFormatInvalid, fmt.Sprintf(MsgFormatInvalid, s.Name+"の終了日"))
 			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
 			return
 		}
@@ -379,18 +447,18 @@ func (i *ScheduleInteractor) UpdateBulkSchedule(input port.UpdateBulkScheduleInp
 		if err != nil {
 			if repository.IsNotFoundError(err) {
 				i.Logger.Warn(err.Error())
-				r := port.NewErrorResult(http.StatusNotFound, MsgScheduleNotFound)
+				r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeScheduleNotFound, MsgScheduleNotFound)
 				i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
 				return
 			}
 
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
 			return
 		}
 
-		s := model.Schedule{
+		us := model.Schedule{
 			ID:        s.ID,
 			UserID:    bs.UserID,
 			Name:      s.Name,
@@ -402,10 +470,64 @@ func (i *ScheduleInteractor) UpdateBulkSchedule(input port.UpdateBulkScheduleInp
 			CreatedAt: bs.CreatedAt,
 			UpdatedAt: time.Now(),
 		}
+		applyScheduleDetail(&us, *bs, s)
+
+		ve.Merge(model.IndexedField("schedules", idx), us.Validate())
+		schedules = append(schedules, us)
+		beforeTagIDs[us.ID] = bs.TagIDs
+	}
+
+	if err := ve.Err(); err != nil {
+		i.Logger.Warn(err.Error())
+		r := validationErrorResult(err)
+		i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
+		return
+	}
+
+	for _, s := range schedules {
+		if ok, err := i.ColorValidator.Validate(s.UserID, s.Color); err != nil || !ok {
+			r := i.colorErrorResult(err)
+			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
+			return
+		}
+
+		if ok, err := i.Tagger.Validate(s.UserID, s.TagIDs); err != nil || !ok {
+			r := i.tagErrorResult(err)
+			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
+			return
+		}
+	}
+
+	if len(schedules) > 0 {
+		violations, err := i.Linter.CheckSequence(schedules[0].UserID, schedules)
+		if err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
+			return
+		}
+
+		if len(violations) > 0 {
+			i.Logger.Warn("lecture sequence broken", "message", violations[0].Message)
+			r := port.NewErrorResult(http.StatusConflict, port.ErrorCodeScheduleSequenceBroken, fmt.Sprintf(MsgLectureSequenceBroken, violations[0].Message))
+			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
+			return
+		}
+	}
+
+	responseSchedules := make([]port.BaseScheduleData, 0, len(schedules))
 
+	for _, s := range schedules {
 		if err := i.ScheduleRepository.Update(&s); err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
+			return
+		}
+
+		if err := i.Tagger.Sync(s, beforeTagIDs[s.ID]); err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
 			return
 		}
@@ -413,28 +535,20 @@ func (i *ScheduleInteractor) UpdateBulkSchedule(input port.UpdateBulkScheduleInp
 		as, err := i.ScheduleRepository.Read(s.ID)
 		if err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseUpdateBulkSchedule(nil, r)
 			return
 		}
 
-		o := port.BaseScheduleData{
-			ID:        as.ID,
-			UserID:    as.UserID,
-			Name:      as.Name,
-			StartsAt:  as.StartsAt.Format(time.DateTime),
-			EndsAt:    as.EndsAt.Format(time.DateTime),
-			Color:     as.Color,
-			Type:      as.Type.String(),
-			Order:     as.Order.Int(),
-			CreatedAt: as.CreatedAt.Format(time.DateTime),
-			UpdatedAt: as.UpdatedAt.Format(time.DateTime),
-		}
+		o := toBaseScheduleData(i.Zone, *as)
 
 		responseSchedules = append(responseSchedules, o)
 	}
 
-	o := &port.UpdateBulkScheduleOutputData{Schedules: responseSchedules}
+	o := &port.UpdateBulkScheduleOutputData{
+		Schedules: responseSchedules,
+		Warnings:  i.lintSchedules(responseSchedules),
+	}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseUpdateBulkSchedule(o, r)
 }
@@ -445,7 +559,14 @@ func (i *ScheduleInteractor) DeleteSchedule(input port.DeleteScheduleInputData)
 
 	if err := i.ScheduleRepository.Delete(input.ScheduleID); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeleteSchedule(nil, r)
+		return
+	}
+
+	if err := i.Tagger.Clear(input.ScheduleID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseDeleteSchedule(nil, r)
 		return
 	}
@@ -454,3 +575,247 @@ func (i *ScheduleInteractor) DeleteSchedule(input port.DeleteScheduleInputData)
 	r := port.NewSuccessResult(http.StatusNoContent)
 	i.OutputPort.SetResponseDeleteSchedule(o, r)
 }
+
+// LintSchedule はユーザーのスケジュール全体の警告を取得します。
+func (i *ScheduleInteractor) LintSchedule(input port.LintScheduleInputData) {
+	warnings, err := i.Linter.Lint(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseLintSchedule(nil, r)
+		return
+	}
+
+	o := &port.LintScheduleOutputData{Warnings: toBaseWarningDataList(warnings)}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseLintSchedule(o, r)
+}
+
+// ExportSchedule はユーザーのスケジュールを iCalendar 形式でエクスポートします。
+// 終日のスケジュールは日付のみ、時間指定のスケジュールは開始日時と終了日時を出力します。
+// 説明にはノート、メモ、リンクを出力します。
+func (i *ScheduleInteractor) ExportSchedule(input port.ExportScheduleInputData) {
+	schedules, err := i.ScheduleRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseExportSchedule(nil, r)
+		return
+	}
+
+	sl := model.ScheduleList(schedules).In(i.Zone.Location())
+	sl.SortByStartsAt()
+
+	events := make([]ical.Event, 0, len(sl))
+	for _, s := range sl {
+		events = append(events, ical.Event{
+			UID:         s.ID + "@attendance-plan",
+			Summary:     s.Name,
+			Description: scheduleDescription(s),
+			Start:       s.StartsAt,
+			End:         s.EndsAt,
+			AllDay:      !s.Timed,
+			Stamp:       s.UpdatedAt,
+		})
+	}
+
+	c := ical.Calendar{ProdID: "-//attendance-plan//schedule//JA", Name: "受講計画", Events: events}
+	o := &port.ExportScheduleOutputData{Calendar: c.String(i.Zone.Location())}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseExportSchedule(o, r)
+}
+
+// readScheduleList はユーザーのスケジュールを取得します。
+// tagID を指定した場合はタグごとの対応から対象のスケジュールのみを取得します。
+func (i *ScheduleInteractor) readScheduleList(userID, tagID string) ([]model.Schedule, error) {
+	if tagID == "" {
+		return i.ScheduleRepository.ReadByUserID(userID)
+	}
+
+	ids, err := i.Tagger.ScheduleIDs(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := i.ScheduleRepository.ReadByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]model.Schedule, 0, len(schedules))
+	for _, s := range schedules {
+		if s.UserID == userID {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+// tagErrorResult はタグの確認に失敗した場合の結果を返します。
+// err が nil の場合は存在しないタグが指定されたものとして扱います。
+func (i *ScheduleInteractor) tagErrorResult(err error) port.Result {
+	if err != nil {
+		i.Logger.Error(err.Error())
+		return port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+	}
+
+	i.Logger.Warn("tag not found")
+	return port.NewErrorResult(http.StatusNotFound, port.ErrorCodeTagNotFound, MsgTagNotFound)
+}
+
+// colorErrorResult は色の確認に失敗した場合の結果を返します。
+// err が nil の場合は存在しない色が指定されたものとして扱います。
+func (i *ScheduleInteractor) colorErrorResult(err error) port.Result {
+	if err != nil {
+		i.Logger.Error(err.Error())
+		return port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+	}
+
+	i.Logger.Warn("color not found")
+	return port.NewErrorResult(http.StatusNotFound, port.ErrorCodeColorNotFound, MsgColorNotFound)
+}
+
+// lint は書き込み後のスケジュール全体を評価し、指定されたスケジュールに関する警告を返します。
+// 警告は登録や更新の結果に影響しないため、評価に失敗した場合はログを出力して警告なしとします。
+func (i *ScheduleInteractor) lint(userID string, scheduleIDs ...string) []port.BaseWarningData {
+	warnings, err := i.Linter.Lint(userID)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		return []port.BaseWarningData{}
+	}
+
+	return toBaseWarningDataList(warnings.FilterByScheduleIDs(scheduleIDs...))
+}
+
+// lintSchedules は一括で書き込んだスケジュールに関する警告を返します。
+func (i *ScheduleInteractor) lintSchedules(schedules []port.BaseScheduleData) []port.BaseWarningData {
+	if len(schedules) == 0 {
+		return []port.BaseWarningData{}
+	}
+
+	ids := make([]string, 0, len(schedules))
+	for _, s := range schedules {
+		ids = append(ids, s.ID)
+	}
+
+	return i.lint(schedules[0].UserID, ids...)
+}
+
+// toGetScheduleListFilter はスケジュールリスト取得の入力データから絞り込み条件を生成します。
+// 日時の形式はリクエストのバリデーションで確認済みのため、変換できない場合は条件に含めません。
+// 日時と日付はユーザーのタイムゾーンで解釈します。
+func toGetScheduleListFilter(zone timezone.Zone, input port.GetScheduleListInputData) model.ScheduleFilter {
+	f := model.ScheduleFilter{
+		Type:       model.ToScheduleType(input.Type),
+		Color:      input.Color,
+		TagID:      input.TagID,
+		NamePrefix: input.NamePrefix,
+	}
+
+	if updatedSince, err := zone.ParseDateTime(input.UpdatedSince); err == nil {
+		f.UpdatedSince = updatedSince
+	}
+
+	if from, err := zone.ParseDate(input.From); err == nil {
+		f.From = from
+	}
+
+	if to, err := zone.ParseDate(input.To); err == nil {
+		f.To = to
+	}
+
+	return f
+}
+
+// toBaseScheduleData はスケジュールを出力データに変換します。
+// 日時はユーザーのタイムゾーンのオフセット付きで表します。
+func toBaseScheduleData(zone timezone.Zone, s model.Schedule) port.BaseScheduleData {
+	return port.BaseScheduleData{
+		ID:            s.ID,
+		UserID:        s.UserID,
+		Name:          s.Name,
+		StartsAt:      zone.FormatDateTime(s.StartsAt),
+		EndsAt:        zone.FormatDateTime(s.EndsAt),
+		Timed:         s.Timed,
+		Color:         s.Color,
+		Type:          s.Type.String(),
+		Order:         s.Order.Int(),
+		LectureNumber: s.LectureNumber,
+		Note:          s.Note,
+		Links:         toStringList(s.Links),
+		Memo:          s.Memo,
+		TagIDs:        toStringList(s.TagIDs),
+		CreatedAt:     zone.FormatDateTime(s.CreatedAt),
+		UpdatedAt:     zone.FormatDateTime(s.UpdatedAt),
+	}
+}
+
+// toBaseDateItemDataList は日付ごとのスケジュールリストを出力データに変換します。
+func toBaseDateItemDataList(zone timezone.Zone, dis model.DateItemList) []port.BaseDateItemData {
+	res := make([]port.BaseDateItemData, 0, len(dis))
+	for _, di := range dis {
+		schedules := make([]port.BaseScheduleData, 0, len(di.Schedules))
+		for _, s := range di.Schedules {
+			schedules = append(schedules, toBaseScheduleData(zone, s))
+		}
+		res = append(res, port.BaseDateItemData{
+			Date:      zone.FormatDate(di.Date),
+			Type:      di.Type.String(),
+			Schedules: schedules,
+		})
+	}
+	return res
+}
+
+// scheduleDescription はスケジュールのノート、メモ、リンクを空行で区切った iCalendar の説明に変換します。
+func scheduleDescription(s model.Schedule) string {
+	parts := []string{}
+	for _, p := range []string{s.Note, s.Memo, strings.Join(s.Links, "\n")} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// applyScheduleDetail は更新後のスケジュールに時間指定の有無、講義回、ノート、リンク、メモ、タグを設定します。
+// 入力で指定されなかった項目は更新前のスケジュールの値を引き継ぎます。
+func applyScheduleDetail(s *model.Schedule, before model.Schedule, input port.UpdateScheduleData) {
+	s.Timed = before.Timed
+	if input.Timed != nil {
+		s.Timed = *input.Timed
+	}
+
+	s.LectureNumber = before.LectureNumber
+	if input.LectureNumber != nil {
+		s.LectureNumber = *input.LectureNumber
+	}
+
+	s.Note = before.Note
+	if input.Note != nil {
+		s.Note = *input.Note
+	}
+
+	s.Links = before.Links
+	if input.Links != nil {
+		s.Links = input.Links
+	}
+
+	s.Memo = before.Memo
+	if input.Memo != nil {
+		s.Memo = *input.Memo
+	}
+
+	s.TagIDs = before.TagIDs
+	if input.TagIDs != nil {
+		s.TagIDs = model.UniqueTagIDs(input.TagIDs)
+	}
+}
+
+// toStringList はリストが未設定の場合に空のリストを返します。
+func toStringList(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// ScheduleLinter はユーザーのスケジュール全体をルールエンジンで評価するインターフェースです。
+type ScheduleLinter interface {
+	Lint(userID string) (model.WarningList, error)
+	CheckSequence(userID string, changed model.ScheduleList) (model.WarningList, error)
+}
+
+// ScheduleLinterImpl は ScheduleLinter の実装を表す構造体です。
+// スケジュールの日付は Zone のタイムゾーンで判定し、日ごとの受講可否は DayAvailabilityQuery に問い合わせます。
+// 警告のメッセージは Locale の言語で返します。
+type ScheduleLinterImpl struct {
+	RuleSet              model.RuleSet
+	Zone                 timezone.Zone
+	Locale               i18n.Locale
+	ScheduleRepository   repository.ScheduleRepository
+	UserRepository       repository.UserRepository
+	DayAvailabilityQuery DayAvailabilityQuery
+}
+
+// NewScheduleLinter は標準のルールで評価する ScheduleLinter を生成します。
+func NewScheduleLinter(zone timezone.Zone, locale i18n.Locale, scheduleRepository repository.ScheduleRepository, userRepository repository.UserRepository, dayAvailabilityQuery DayAvailabilityQuery) ScheduleLinter {
+	return &ScheduleLinterImpl{
+		RuleSet:              model.NewDefaultRuleSet(),
+		Zone:                 zone,
+		Locale:               locale,
+		ScheduleRepository:   scheduleRepository,
+		UserRepository:       userRepository,
+		DayAvailabilityQuery: dayAvailabilityQuery,
+	}
+}
+
+// Lint はユーザーのスケジュールを評価して警告を返します。
+func (l *ScheduleLinterImpl) Lint(userID string) (model.WarningList, error) {
+	schedules, err := l.ScheduleRepository.ReadByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := l.UserRepository.Read(userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sl := model.ScheduleList(schedules).In(l.Zone.Location())
+	days, err := l.DayAvailabilityQuery.DayAvailabilityMap(userID, sl)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := model.RuleContext{SequenceStrictness: user.Strictness(), Days: days, Locale: l.Locale}
+	return l.RuleSet.Evaluate(sl, ctx), nil
+}
+
+// CheckSequence は changed を反映した場合に新たに講義回の順番が逆転する箇所を返します。
+// ユーザーの SequenceStrictness が reject でない場合は変更を拒否しないため、何も返しません。
+func (l *ScheduleLinterImpl) CheckSequence(userID string, changed model.ScheduleList) (model.WarningList, error) {
+	user, err := l.UserRepository.Read(userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Strictness() != model.SequenceStrictnessReject {
+		return nil, nil
+	}
+
+	schedules, err := l.ScheduleRepository.ReadByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(changed))
+	for _, c := range changed {
+		ids = append(ids, c.ID)
+	}
+
+	rule := model.SequenceRule{}
+	ctx := model.RuleContext{SequenceStrictness: user.Strictness(), Locale: l.Locale}
+	loc := l.Zone.Location()
+	before := rule.Evaluate(model.ScheduleList(schedules).In(loc), ctx)
+	after := rule.Evaluate(model.ScheduleList(schedules).Replace(changed).In(loc), ctx)
+
+	return after.Difference(before).FilterByScheduleIDs(ids...), nil
+}
+
+// toBaseWarningDataList は警告のリストを出力データに変換します。
+func toBaseWarningDataList(wl model.WarningList) []port.BaseWarningData {
+	res := make([]port.BaseWarningData, 0, len(wl))
+	for _, w := range wl {
+		res = append(res, port.BaseWarningData{
+			Code:        w.Code.String(),
+			Message:     w.Message,
+			Date:        w.Date.Format(model.DateFormat),
+			ScheduleIDs: w.ScheduleIDs,
+		})
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// ScheduleTagger はスケジュールに付けるタグと、タグごとのスケジュールの対応を管理するインターフェースです。
+type ScheduleTagger interface {
+	Validate(userID string, tagIDs []string) (bool, error)
+	Sync(schedule model.Schedule, before []string) error
+	Clear(scheduleID string) error
+	ScheduleIDs(tagID string) ([]string, error)
+}
+
+// ScheduleTaggerImpl は ScheduleTagger の実装を表す構造体です。
+type ScheduleTaggerImpl struct {
+	TagRepository         repository.TagRepository
+	ScheduleTagRepository repository.ScheduleTagRepository
+}
+
+// NewScheduleTagger は ScheduleTagger を生成します。
+func NewScheduleTagger(tagRepository repository.TagRepository, scheduleTagRepository repository.ScheduleTagRepository) ScheduleTagger {
+	return &ScheduleTaggerImpl{
+		TagRepository:         tagRepository,
+		ScheduleTagRepository: scheduleTagRepository,
+	}
+}
+
+// Validate は指定されたタグがすべてユーザーのタグとして存在するかどうかを返します。
+func (t *ScheduleTaggerImpl) Validate(userID string, tagIDs []string) (bool, error) {
+	if len(tagIDs) == 0 {
+		return true, nil
+	}
+
+	tags, err := t.TagRepository.ReadByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	owned := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		owned[tag.ID] = true
+	}
+
+	for _, id := range tagIDs {
+		if !owned[id] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Sync はスケジュールのタグが before から変わった分だけタグごとの対応を追加、削除します。
+func (t *ScheduleTaggerImpl) Sync(schedule model.Schedule, before []string) error {
+	added, removed := model.DiffTagIDs(before, schedule.TagIDs)
+
+	for _, tagID := range added {
+		st := model.ScheduleTag{
+			TagID:      tagID,
+			ScheduleID: schedule.ID,
+			UserID:     schedule.UserID,
+			CreatedAt:  time.Now(),
+		}
+		if err := t.ScheduleTagRepository.Create(&st); err != nil {
+			return err
+		}
+	}
+
+	for _, tagID := range removed {
+		if err := t.ScheduleTagRepository.Delete(tagID, schedule.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Clear は削除されたスケジュールのタグごとの対応をすべて削除します。
+func (t *ScheduleTaggerImpl) Clear(scheduleID string) error {
+	scheduleTags, err := t.ScheduleTagRepository.ReadByScheduleID(scheduleID)
+	if err != nil {
+		return err
+	}
+
+	for _, st := range scheduleTags {
+		if err := t.ScheduleTagRepository.Delete(st.TagID, st.ScheduleID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScheduleIDs は指定されたタグが付いたスケジュールの ID を返します。
+func (t *ScheduleTaggerImpl) ScheduleIDs(tagID string) ([]string, error) {
+	scheduleTags, err := t.ScheduleTagRepository.ReadByTagID(tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(scheduleTags))
+	for _, st := range scheduleTags {
+		ids = append(ids, st.ScheduleID)
+	}
+	return ids, nil
+}
@@ -4,9 +4,12 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/model"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/stretchr/testify/assert"
@@ -21,7 +24,7 @@ func TestGetScheduleList(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.GetScheduleListInputData{UserID: "test-user-id"}
 		i.GetScheduleList(input)
@@ -78,13 +81,13 @@ func TestGetScheduleList(t *testing.T) {
 				assert.Equal(wantMasterSchedules[i*2+j].ID, ss.ID)
 				assert.Equal(wantMasterSchedules[i*2+j].UserID, ss.UserID)
 				assert.Equal(wantMasterSchedules[i*2+j].Name, ss.Name)
-				assert.Equal(wantMasterSchedules[i*2+j].StartsAt.Format(time.DateTime), ss.StartsAt)
-				assert.Equal(wantMasterSchedules[i*2+j].EndsAt.Format(time.DateTime), ss.EndsAt)
+				assert.Equal(wantMasterSchedules[i*2+j].StartsAt.Format(time.RFC3339), ss.StartsAt)
+				assert.Equal(wantMasterSchedules[i*2+j].EndsAt.Format(time.RFC3339), ss.EndsAt)
 				assert.Equal(wantMasterSchedules[i*2+j].Color, ss.Color)
 				assert.Equal(wantMasterSchedules[i*2+j].Type.String(), ss.Type)
 				assert.Equal(wantMasterSchedules[i*2+j].Order.Int(), ss.Order)
-				assert.Equal(wantMasterSchedules[i*2+j].CreatedAt.Format(time.DateTime), ss.CreatedAt)
-				assert.Equal(wantMasterSchedules[i*2+j].UpdatedAt.Format(time.DateTime), ss.UpdatedAt)
+				assert.Equal(wantMasterSchedules[i*2+j].CreatedAt.Format(time.RFC3339), ss.CreatedAt)
+				assert.Equal(wantMasterSchedules[i*2+j].UpdatedAt.Format(time.RFC3339), ss.UpdatedAt)
 			}
 		}
 
@@ -101,18 +104,122 @@ func TestGetScheduleList(t *testing.T) {
 				assert.Equal(wantCustomSchedules[i*2+j].ID, ss.ID)
 				assert.Equal(wantCustomSchedules[i*2+j].UserID, ss.UserID)
 				assert.Equal(wantCustomSchedules[i*2+j].Name, ss.Name)
-				assert.Equal(wantCustomSchedules[i*2+j].StartsAt.Format(time.DateTime), ss.StartsAt)
-				assert.Equal(wantCustomSchedules[i*2+j].EndsAt.Format(time.DateTime), ss.EndsAt)
+				assert.Equal(wantCustomSchedules[i*2+j].StartsAt.Format(time.RFC3339), ss.StartsAt)
+				assert.Equal(wantCustomSchedules[i*2+j].EndsAt.Format(time.RFC3339), ss.EndsAt)
 				assert.Equal(wantCustomSchedules[i*2+j].Color, ss.Color)
 				assert.Equal(wantCustomSchedules[i*2+j].Type.String(), ss.Type)
 				assert.Equal(wantCustomSchedules[i*2+j].Order.Int(), ss.Order)
-				assert.Equal(wantCustomSchedules[i*2+j].CreatedAt.Format(time.DateTime), ss.CreatedAt)
-				assert.Equal(wantCustomSchedules[i*2+j].UpdatedAt.Format(time.DateTime), ss.UpdatedAt)
+				assert.Equal(wantCustomSchedules[i*2+j].CreatedAt.Format(time.RFC3339), ss.CreatedAt)
+				assert.Equal(wantCustomSchedules[i*2+j].UpdatedAt.Format(time.RFC3339), ss.UpdatedAt)
 			}
 		}
 	})
 }
 
+func TestGetScheduleList_Options(t *testing.T) {
+	scheduleIDs := func(items []port.BaseDateItemData) []string {
+		ids := []string{}
+		for _, di := range items {
+			for _, s := range di.Schedules {
+				ids = append(ids, s.ID)
+			}
+		}
+		return ids
+	}
+
+	t.Run("種類と期間で絞り込む", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), &stubScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.GetScheduleList(port.GetScheduleListInputData{
+			UserID: "test-user-id",
+			Type:   model.ScheduleTypeCustom.String(),
+			From:   "2021-01-02",
+			To:     "2021-01-03",
+		})
+
+		output, ok := p.Output.(*port.GetScheduleListOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Empty(output.MasterSchedules)
+		assert.Equal([]string{"test-id-6", "test-id-8", "test-id-10", "test-id-12"}, scheduleIDs(output.CustomSchedules))
+	})
+
+	t.Run("名前の前方一致で絞り込む", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), &stubScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.GetScheduleList(port.GetScheduleListInputData{UserID: "test-user-id", NamePrefix: "test-name-1"})
+
+		output, ok := p.Output.(*port.GetScheduleListOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal([]string{"test-id-1", "test-id-11"}, scheduleIDs(output.MasterSchedules))
+		assert.Equal([]string{"test-id-10", "test-id-12", "test-id-13", "test-id-14", "test-id-15", "test-id-16", "test-id-17"}, scheduleIDs(output.CustomSchedules))
+	})
+
+	t.Run("更新日時で絞り込む", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), &stubScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.GetScheduleList(port.GetScheduleListInputData{UserID: "test-user-id", UpdatedSince: "2021-01-01 00:00:01"})
+
+		output, ok := p.Output.(*port.GetScheduleListOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Empty(output.MasterSchedules)
+		assert.Empty(output.CustomSchedules)
+	})
+
+	t.Run("日付ごとにまとめずに予定の早い順で返す", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), &stubScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.GetScheduleList(port.GetScheduleListInputData{
+			UserID: "test-user-id",
+			Type:   model.ScheduleTypeCustom.String(),
+			From:   "2021-01-03",
+			Flat:   true,
+			Fields: []string{"name"},
+		})
+
+		output, ok := p.Output.(*port.GetScheduleListOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.True(output.Flat)
+		assert.Equal([]string{"name"}, output.Fields)
+		assert.Nil(output.MasterSchedules)
+		assert.Nil(output.CustomSchedules)
+
+		ids := []string{}
+		for _, s := range output.Schedules {
+			ids = append(ids, s.ID)
+		}
+		assert.Equal([]string{"test-id-10", "test-id-12", "test-id-13", "test-id-14", "test-id-15", "test-id-16", "test-id-17"}, ids)
+	})
+}
+
 func TestGetSchedule(t *testing.T) {
 	t.Run("スケジュールを取得する", func(t *testing.T) {
 		require := require.New(t)
@@ -121,7 +228,7 @@ func TestGetSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.GetScheduleInputData{ScheduleID: "test-id"}
 		i.GetSchedule(input)
@@ -151,13 +258,13 @@ func TestGetSchedule(t *testing.T) {
 		assert.Equal(wantSchedule.ID, os.ID)
 		assert.Equal(wantSchedule.UserID, os.UserID)
 		assert.Equal(wantSchedule.Name, os.Name)
-		assert.Equal(wantSchedule.StartsAt.Format(time.DateTime), os.StartsAt)
-		assert.Equal(wantSchedule.EndsAt.Format(time.DateTime), os.EndsAt)
+		assert.Equal(wantSchedule.StartsAt.Format(time.RFC3339), os.StartsAt)
+		assert.Equal(wantSchedule.EndsAt.Format(time.RFC3339), os.EndsAt)
 		assert.Equal(wantSchedule.Color, os.Color)
 		assert.Equal(wantSchedule.Type.String(), os.Type)
 		assert.Equal(wantSchedule.Order.Int(), os.Order)
-		assert.Equal(wantSchedule.CreatedAt.Format(time.DateTime), os.CreatedAt)
-		assert.Equal(wantSchedule.UpdatedAt.Format(time.DateTime), os.UpdatedAt)
+		assert.Equal(wantSchedule.CreatedAt.Format(time.RFC3339), os.CreatedAt)
+		assert.Equal(wantSchedule.UpdatedAt.Format(time.RFC3339), os.UpdatedAt)
 
 		assert.Equal(http.StatusOK, p.Result.StatusCode)
 		assert.Empty(p.Result.ErrorMessage)
@@ -170,12 +277,13 @@ func TestGetSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubNotFoundScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.GetScheduleInputData{ScheduleID: "not-found-id"}
 		i.GetSchedule(input)
 
 		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeScheduleNotFound, p.Result.ErrorCode)
 		assert.Equal(MsgScheduleNotFound, p.Result.ErrorMessage)
 		assert.True(p.Result.HasError)
 	})
@@ -188,7 +296,7 @@ func TestCreateSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.CreateScheduleInputData{
 			Schedule: port.CreateScheduleData{
@@ -206,6 +314,92 @@ func TestCreateSchedule(t *testing.T) {
 		assert.Empty(p.Result.ErrorMessage)
 		assert.False(p.Result.HasError)
 	})
+
+	t.Run("存在しない色の場合はエラー", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{Missing: "custom_test-color-9"}, p)
+
+		input := port.CreateScheduleInputData{
+			Schedule: port.CreateScheduleData{
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "custom_test-color-9",
+				Type:     model.ScheduleTypeMaster.String(),
+				Order:    1,
+			},
+		}
+		i.CreateSchedule(input)
+
+		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeColorNotFound, p.Result.ErrorCode)
+		assert.Equal(MsgColorNotFound, p.Result.ErrorMessage)
+	})
+
+	t.Run("ノート、リンク、メモを指定してスケジュールを作成する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		input := port.CreateScheduleInputData{
+			Schedule: port.CreateScheduleData{
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "white",
+				Type:     model.ScheduleTypeCustom.String(),
+				Order:    1,
+				Note:     "# test-note",
+				Links:    []string{"https://example.com/lectures/1"},
+				Memo:     "test-memo",
+			},
+		}
+		i.CreateSchedule(input)
+
+		output, ok := p.Output.(*port.CreateScheduleOutputData)
+		require.True(ok)
+		require.NotNil(output)
+
+		assert.Equal(http.StatusCreated, p.Result.StatusCode)
+		assert.Equal("# test-note", output.Schedule.Note)
+		assert.Equal([]string{"https://example.com/lectures/1"}, output.Schedule.Links)
+		assert.Equal("test-memo", output.Schedule.Memo)
+	})
+
+	t.Run("リンクが未指定の場合は空のリストを返す", func(t *testing.T) {
+		require := require.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		input := port.CreateScheduleInputData{
+			Schedule: port.CreateScheduleData{
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "white",
+				Type:     model.ScheduleTypeCustom.String(),
+				Order:    1,
+			},
+		}
+		i.CreateSchedule(input)
+
+		output, ok := p.Output.(*port.CreateScheduleOutputData)
+		require.True(ok)
+		require.NotNil(output)
+		require.NotNil(output.Schedule.Links)
+		require.Empty(output.Schedule.Links)
+	})
 }
 
 func TestCreateBulkSchedule(t *testing.T) {
@@ -215,7 +409,7 @@ func TestCreateBulkSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.CreateBulkScheduleInputData{
 			Schedules: []port.CreateScheduleData{
@@ -243,6 +437,45 @@ func TestCreateBulkSchedule(t *testing.T) {
 		assert.Empty(p.Result.ErrorMessage)
 		assert.False(p.Result.HasError)
 	})
+
+	t.Run("不変条件の違反を要素の位置付きですべて返し作成しない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		input := port.CreateBulkScheduleInputData{
+			Schedules: []port.CreateScheduleData{
+				{
+					Name:     "test-name-1",
+					StartsAt: "2021-01-01 00:00:00",
+					EndsAt:   "2021-01-01 00:00:00",
+					Color:    "white",
+					Type:     model.ScheduleTypeMaster.String(),
+					Order:    1,
+				},
+				{
+					Name:     "test-name-2",
+					StartsAt: "2021-01-02 00:00:00",
+					EndsAt:   "2021-01-01 00:00:00",
+					Color:    "purple",
+					Type:     model.ScheduleTypeCustom.String(),
+					Order:    1,
+				},
+			},
+		}
+		i.CreateBulkSchedule(input)
+
+		assert.Equal(http.StatusBadRequest, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeValidationFailed, p.Result.ErrorCode)
+		assert.Equal([]port.FieldError{
+			{Field: "schedules[1].ends_at", Message: "終了日は開始日以降の日付を入力してください"},
+			{Field: "schedules[1].color", Message: "色はパレットの色またはユーザーが定義した色を指定してください"},
+		}, p.Result.Errors)
+		assert.Equal("終了日は開始日以降の日付を入力してください", p.Result.ErrorMessage)
+	})
 }
 
 func TestUpdateSchedule(t *testing.T) {
@@ -252,7 +485,7 @@ func TestUpdateSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.UpdateScheduleInputData{
 			Schedule: port.UpdateScheduleData{
@@ -270,6 +503,44 @@ func TestUpdateSchedule(t *testing.T) {
 		assert.Equal(http.StatusOK, p.Result.StatusCode)
 		assert.Empty(p.Result.ErrorMessage)
 		assert.False(p.Result.HasError)
+
+		output, ok := p.Output.(*port.UpdateScheduleOutputData)
+		if assert.True(ok) {
+			want := []port.BaseWarningData{
+				{Code: model.WarningCodeSequence.String(), Message: "test-message-2", Date: "2021-01-04", ScheduleIDs: []string{"test-id"}},
+			}
+			assert.Equal(want, output.Warnings)
+		}
+	})
+
+	t.Run("警告の評価に失敗しても更新は成功する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubErrorScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		input := port.UpdateScheduleInputData{
+			Schedule: port.UpdateScheduleData{
+				ID:       "test-id",
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "white",
+				Type:     model.ScheduleTypeMaster.String(),
+				Order:    1,
+			},
+		}
+		i.UpdateSchedule(input)
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.False(p.Result.HasError)
+
+		output, ok := p.Output.(*port.UpdateScheduleOutputData)
+		if assert.True(ok) {
+			assert.Empty(output.Warnings)
+		}
 	})
 
 	t.Run("スケジュールが存在しない場合はエラーを返す", func(t *testing.T) {
@@ -278,7 +549,7 @@ func TestUpdateSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubNotFoundScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.UpdateScheduleInputData{
 			Schedule: port.UpdateScheduleData{
@@ -294,11 +565,324 @@ func TestUpdateSchedule(t *testing.T) {
 		i.UpdateSchedule(input)
 
 		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeScheduleNotFound, p.Result.ErrorCode)
 		assert.Equal(MsgScheduleNotFound, p.Result.ErrorMessage)
 		assert.True(p.Result.HasError)
 	})
 }
 
+func TestUpdateSchedule_Detail(t *testing.T) {
+	newInput := func() port.UpdateScheduleInputData {
+		return port.UpdateScheduleInputData{
+			Schedule: port.UpdateScheduleData{
+				ID:       "test-id",
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "white",
+				Type:     model.ScheduleTypeCustom.String(),
+				Order:    1,
+			},
+		}
+	}
+
+	t.Run("講義回、ノート、リンク、メモが未指定の場合は既存の値を引き継ぐ", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubDetailScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.UpdateSchedule(newInput())
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		require.Len(r.Updated, 1)
+		assert.Equal(3, r.Updated[0].LectureNumber)
+		assert.Equal("before-note", r.Updated[0].Note)
+		assert.Equal([]string{"https://example.com/before"}, r.Updated[0].Links)
+		assert.Equal("before-memo", r.Updated[0].Memo)
+	})
+
+	t.Run("指定された値で更新する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubDetailScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		lectureNumber := 0
+		empty := ""
+		memo := "after-memo"
+		input := newInput()
+		input.Schedule.LectureNumber = &lectureNumber
+		input.Schedule.Note = &empty
+		input.Schedule.Links = []string{}
+		input.Schedule.Memo = &memo
+		i.UpdateBulkSchedule(port.UpdateBulkScheduleInputData{Schedules: []port.UpdateScheduleData{input.Schedule}})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		require.Len(r.Updated, 1)
+		assert.Zero(r.Updated[0].LectureNumber)
+		assert.Empty(r.Updated[0].Note)
+		assert.Empty(r.Updated[0].Links)
+		assert.Equal("after-memo", r.Updated[0].Memo)
+	})
+}
+
+func TestUpdateSchedule_Timed(t *testing.T) {
+	timed := true
+	allDay := false
+
+	tests := []struct {
+		name       string
+		timed      *bool
+		endsAt     string
+		wantStatus int
+		wantTimed  bool
+	}{
+		{name: "未指定の場合は時間指定を引き継ぐ", endsAt: "2021-01-01 21:30:00", wantStatus: http.StatusOK, wantTimed: true},
+		{name: "時間指定を引き継いで終了日時が開始日時と同じ場合はエラー", endsAt: "2021-01-01 20:00:00", wantStatus: http.StatusBadRequest},
+		{name: "終日に変更する場合は同じ日時でも更新する", timed: &allDay, endsAt: "2021-01-01 20:00:00", wantStatus: http.StatusOK, wantTimed: false},
+		{name: "時間指定を指定して更新する", timed: &timed, endsAt: "2021-01-02 01:00:00", wantStatus: http.StatusOK, wantTimed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			r := &stubTimedScheduleRepository{}
+			p := &stubScheduleOutputPort{}
+			i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+			i.UpdateSchedule(port.UpdateScheduleInputData{
+				Schedule: port.UpdateScheduleData{
+					ID:       "test-id",
+					Name:     "test-name",
+					StartsAt: "2021-01-01 20:00:00",
+					EndsAt:   tt.endsAt,
+					Timed:    tt.timed,
+					Color:    "white",
+					Type:     model.ScheduleTypeCustom.String(),
+					Order:    1,
+				},
+			})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			if tt.wantStatus != http.StatusOK {
+				assert.Equal([]port.FieldError{{Field: "ends_at", Message: "時間指定のスケジュールは終了日時を開始日時より後にしてください"}}, p.Result.Errors)
+				assert.Empty(r.Updated)
+				return
+			}
+
+			require.Len(r.Updated, 1)
+			assert.Equal(tt.wantTimed, r.Updated[0].Timed)
+		})
+	}
+}
+
+func TestExportSchedule(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	zone, err := timezone.Load("Asia/Tokyo")
+	require.NoError(err)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	p := &stubScheduleOutputPort{}
+	i := NewScheduleInteractor(l, zone, &stubScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+	i.ExportSchedule(port.ExportScheduleInputData{UserID: "test-user-id"})
+
+	assert.Equal(http.StatusOK, p.Result.StatusCode)
+	o, ok := p.Output.(*port.ExportScheduleOutputData)
+	require.True(ok)
+
+	assert.Equal(17, strings.Count(o.Calendar, "BEGIN:VEVENT"))
+	assert.Contains(o.Calendar, "UID:test-id-1@attendance-plan\r\n")
+	assert.Contains(o.Calendar, "DTSTART;VALUE=DATE:20210101\r\nDTEND;VALUE=DATE:20210111\r\n")
+}
+
+func TestExportSchedule_Description(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	zone, err := timezone.Load("Asia/Tokyo")
+	require.NoError(err)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	p := &stubScheduleOutputPort{}
+	i := NewScheduleInteractor(l, zone, &stubExportScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+	i.ExportSchedule(port.ExportScheduleInputData{UserID: "test-user-id"})
+
+	assert.Equal(http.StatusOK, p.Result.StatusCode)
+	o, ok := p.Output.(*port.ExportScheduleOutputData)
+	require.True(ok)
+
+	assert.Contains(o.Calendar, "DESCRIPTION:# 第1回\\n課題あり\\n\\nmemo\\n\\nhttps://a.jp\\nhttps://b.jp\r\n")
+	assert.Equal(1, strings.Count(o.Calendar, "DESCRIPTION:"))
+}
+
+func TestUpdateSchedule_Tag(t *testing.T) {
+	newInput := func() port.UpdateScheduleInputData {
+		return port.UpdateScheduleInputData{
+			Schedule: port.UpdateScheduleData{
+				ID:       "test-id",
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "white",
+				Type:     model.ScheduleTypeCustom.String(),
+				Order:    1,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		tagIDs     []string
+		tagger     ScheduleTagger
+		wantStatus int
+		wantTagIDs []string
+	}{
+		{name: "タグが未指定の場合は既存のタグを引き継ぐ", tagIDs: nil, tagger: &stubScheduleTagger{}, wantStatus: http.StatusOK, wantTagIDs: []string{"test-tag-1"}},
+		{name: "指定されたタグに重複を除いて置き換える", tagIDs: []string{"test-tag-2", "test-tag-2", "test-tag-3"}, tagger: &stubScheduleTagger{}, wantStatus: http.StatusOK, wantTagIDs: []string{"test-tag-2", "test-tag-3"}},
+		{name: "空のリストの場合はタグをすべて外す", tagIDs: []string{}, tagger: &stubScheduleTagger{}, wantStatus: http.StatusOK, wantTagIDs: []string{}},
+		{name: "存在しないタグの場合は更新しない", tagIDs: []string{"test-tag-x"}, tagger: &stubNotFoundScheduleTagger{}, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			r := &stubDetailScheduleRepository{}
+			p := &stubScheduleOutputPort{}
+			i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, tt.tagger, &stubColorValidator{}, p)
+
+			input := newInput()
+			input.Schedule.TagIDs = tt.tagIDs
+			i.UpdateSchedule(input)
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			if tt.wantStatus != http.StatusOK {
+				assert.Empty(r.Updated)
+				return
+			}
+
+			require.Len(r.Updated, 1)
+			assert.Equal(tt.wantTagIDs, r.Updated[0].TagIDs)
+		})
+	}
+}
+
+func TestGetScheduleList_Tag(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	p := &stubScheduleOutputPort{}
+	i := NewScheduleInteractor(l, timezone.UTC(), &stubTagScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+	i.GetScheduleList(port.GetScheduleListInputData{UserID: "test-user-id", TagID: "test-tag-1", Flat: true})
+
+	assert.Equal(http.StatusOK, p.Result.StatusCode)
+	o, ok := p.Output.(*port.GetScheduleListOutputData)
+	require.True(ok)
+
+	var ids []string
+	for _, s := range o.Schedules {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal([]string{"test-id-1", "test-id-4"}, ids)
+}
+
+func TestGetScheduleList_Timezone(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	zone, err := timezone.Load("America/New_York")
+	require.NoError(err)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	p := &stubScheduleOutputPort{}
+	i := NewScheduleInteractor(l, zone, &stubScheduleRepository{}, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+	i.GetScheduleList(port.GetScheduleListInputData{UserID: "test-user-id", From: "2020-12-31", To: "2020-12-31"})
+
+	assert.Equal(http.StatusOK, p.Result.StatusCode)
+	o, ok := p.Output.(*port.GetScheduleListOutputData)
+	require.True(ok)
+
+	require.Len(o.MasterSchedules, 1)
+	assert.Equal("2020-12-31", o.MasterSchedules[0].Date)
+	assert.Equal("2020-12-31T19:00:00-05:00", o.MasterSchedules[0].Schedules[0].StartsAt)
+	require.Len(o.CustomSchedules, 1)
+	assert.Equal("2020-12-31", o.CustomSchedules[0].Date)
+}
+
+func TestUpdateSchedule_SequenceStrictness(t *testing.T) {
+	t.Run("講義回の順番が逆転する場合は更新を拒否する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubRejectScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		input := port.UpdateScheduleInputData{
+			Schedule: port.UpdateScheduleData{
+				ID:       "test-id",
+				Name:     "test-name",
+				StartsAt: "2021-01-01 00:00:00",
+				EndsAt:   "2021-01-01 00:00:00",
+				Color:    "white",
+				Type:     model.ScheduleTypeCustom.String(),
+				Order:    1,
+			},
+		}
+		i.UpdateSchedule(input)
+
+		assert.Equal(http.StatusConflict, p.Result.StatusCode)
+		assert.Equal("講義回の順番が逆転するため変更できません（test-message）", p.Result.ErrorMessage)
+		assert.True(p.Result.HasError)
+	})
+
+	t.Run("一括更新で講義回の順番が逆転する場合は更新を拒否する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubRejectScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		input := port.UpdateBulkScheduleInputData{
+			Schedules: []port.UpdateScheduleData{
+				{
+					ID:       "test-id-1",
+					Name:     "test-name-1",
+					StartsAt: "2021-01-01 00:00:00",
+					EndsAt:   "2021-01-01 00:00:00",
+					Color:    "white",
+					Type:     model.ScheduleTypeCustom.String(),
+					Order:    1,
+				},
+			},
+		}
+		i.UpdateBulkSchedule(input)
+
+		assert.Equal(http.StatusConflict, p.Result.StatusCode)
+		assert.True(p.Result.HasError)
+	})
+}
+
 func TestUpdateBulkSchedule(t *testing.T) {
 	t.Run("スケジュールを一括更新する", func(t *testing.T) {
 		assert := assert.New(t)
@@ -306,7 +890,7 @@ func TestUpdateBulkSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.UpdateBulkScheduleInputData{
 			Schedules: []port.UpdateScheduleData{
@@ -343,7 +927,7 @@ func TestUpdateBulkSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubNotFoundScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.UpdateBulkScheduleInputData{
 			Schedules: []port.UpdateScheduleData{
@@ -361,6 +945,7 @@ func TestUpdateBulkSchedule(t *testing.T) {
 		i.UpdateBulkSchedule(input)
 
 		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeScheduleNotFound, p.Result.ErrorCode)
 		assert.Equal(MsgScheduleNotFound, p.Result.ErrorMessage)
 		assert.True(p.Result.HasError)
 	})
@@ -373,7 +958,7 @@ func TestDeleteSchedule(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubScheduleRepository{}
 		p := &stubScheduleOutputPort{}
-		i := NewScheduleInteractor(l, r, p)
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
 
 		input := port.DeleteScheduleInputData{ScheduleID: "test-id"}
 		i.DeleteSchedule(input)
@@ -383,3 +968,110 @@ func TestDeleteSchedule(t *testing.T) {
 		assert.False(p.Result.HasError)
 	})
 }
+
+func TestLintSchedule(t *testing.T) {
+	t.Run("スケジュールの警告一覧を取得する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.LintSchedule(port.LintScheduleInputData{UserID: "test-user-id"})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.False(p.Result.HasError)
+
+		output, ok := p.Output.(*port.LintScheduleOutputData)
+		if assert.True(ok) {
+			assert.Len(output.Warnings, 2)
+		}
+	})
+
+	t.Run("警告の評価に失敗した場合はエラーを返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		r := &stubScheduleRepository{}
+		p := &stubScheduleOutputPort{}
+		i := NewScheduleInteractor(l, timezone.UTC(), r, &stubErrorScheduleLinter{}, &stubScheduleTagger{}, &stubColorValidator{}, p)
+
+		i.LintSchedule(port.LintScheduleInputData{UserID: "test-user-id"})
+
+		assert.Equal(http.StatusInternalServerError, p.Result.StatusCode)
+		assert.Equal(MsgInternalServerError, p.Result.ErrorMessage)
+		assert.True(p.Result.HasError)
+	})
+}
+
+func TestScheduleLinter(t *testing.T) {
+	t.Run("受講可能日の設定をもとにスケジュールを評価する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		linter := NewScheduleLinter(timezone.UTC(), i18n.Japanese, &stubScheduleRepository{}, &stubUserRepository{}, NewDayAvailabilityQuery(&stubAvailabilityRepository{}, &stubBlackoutRepository{}, &stubScheduleRepository{}))
+		warnings, err := linter.Lint("test-user-id")
+		require.NoError(t, err)
+
+		codes := map[model.WarningCode]int{}
+		for _, w := range warnings {
+			codes[w.Code]++
+		}
+
+		// 2021-01-02 は受講できない期間、2021-01-03 は日曜日、2021-01-04 は上限の3件を超える5件
+		assert.Equal(map[model.WarningCode]int{
+			model.WarningCodeUnavailableDay: 4,
+			model.WarningCodeOverload:       1,
+		}, codes)
+	})
+
+	t.Run("警告のメッセージを指定された言語で返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		linter := NewScheduleLinter(timezone.UTC(), i18n.English, &stubScheduleRepository{}, &stubUserRepository{}, NewDayAvailabilityQuery(&stubAvailabilityRepository{}, &stubBlackoutRepository{}, &stubScheduleRepository{}))
+		warnings, err := linter.Lint("test-user-id")
+		require.NoError(t, err)
+
+		messages := []string{}
+		for _, w := range warnings {
+			messages = append(messages, w.Message)
+		}
+
+		assert.Contains(messages, "2021-01-04 has 5 lectures scheduled (limit 3).")
+		assert.Contains(messages, "\"test-name-10\" is scheduled on a day you do not attend (Sun).")
+	})
+}
+
+func TestScheduleLinter_CheckSequence(t *testing.T) {
+	// test-name-2 を test-name-4 より後の日に移動する
+	moved := model.ScheduleList{
+		{ID: "test-id-2", UserID: "test-user-id", Name: "test-name-2", StartsAt: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC), Type: model.ScheduleTypeCustom, Order: 1},
+	}
+
+	t.Run("reject の場合は新たに逆転する箇所を返す", func(t *testing.T) {
+		linter := NewScheduleLinter(timezone.UTC(), i18n.Japanese, &stubScheduleRepository{}, &stubStrictUserRepository{}, NewDayAvailabilityQuery(&stubAvailabilityRepository{}, &stubBlackoutRepository{}, &stubScheduleRepository{}))
+		violations, err := linter.CheckSequence("test-user-id", moved)
+		require.NoError(t, err)
+
+		if assert.Len(t, violations, 1) {
+			assert.Equal(t, []string{"test-id-4", "test-id-2"}, violations[0].ScheduleIDs)
+		}
+	})
+
+	t.Run("warn の場合は何も返さない", func(t *testing.T) {
+		linter := NewScheduleLinter(timezone.UTC(), i18n.Japanese, &stubScheduleRepository{}, &stubUserRepository{}, NewDayAvailabilityQuery(&stubAvailabilityRepository{}, &stubBlackoutRepository{}, &stubScheduleRepository{}))
+		violations, err := linter.CheckSequence("test-user-id", moved)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("順番が変わらない変更は拒否しない", func(t *testing.T) {
+		linter := NewScheduleLinter(timezone.UTC(), i18n.Japanese, &stubScheduleRepository{}, &stubStrictUserRepository{}, NewDayAvailabilityQuery(&stubAvailabilityRepository{}, &stubBlackoutRepository{}, &stubScheduleRepository{}))
+		unchanged := model.ScheduleList{
+			{ID: "test-id-2", UserID: "test-user-id", Name: "test-name-2 (renamed)", StartsAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Type: model.ScheduleTypeCustom, Order: 1, LectureNumber: 2},
+		}
+		violations, err := linter.CheckSequence("test-user-id", unchanged)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+}
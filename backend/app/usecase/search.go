@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/search"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// SearchInteractor は検索のユースケースの実装を表す構造体です。
+// 日付の条件と日付ごとのまとめは Zone のタイムゾーンで判定します。
+type SearchInteractor struct {
+	Logger             *slog.Logger
+	Zone               timezone.Zone
+	ScheduleRepository repository.ScheduleRepository
+	SubjectRepository  repository.SubjectRepository
+	OutputPort         port.SearchOutputPort
+}
+
+// NewSearchInteractor は SearchInteractor を生成します。
+func NewSearchInteractor(logger *slog.Logger, zone timezone.Zone, scheduleRepository repository.ScheduleRepository, subjectRepository repository.SubjectRepository, outputPort port.SearchOutputPort) port.SearchInputPort {
+	return &SearchInteractor{
+		Logger:             logger,
+		Zone:               zone,
+		ScheduleRepository: scheduleRepository,
+		SubjectRepository:  subjectRepository,
+		OutputPort:         outputPort,
+	}
+}
+
+// Search はスケジュールと科目を検索します。
+// スケジュールは名前、ノート、メモを、科目は名前を検索の対象とします。
+// スケジュールは絞り込み条件に一致するものを日付ごとにまとめ、科目は色の条件のみで絞り込みます。
+// 科目にはタグが付かないため、タグで絞り込む場合は科目を返しません。
+func (i *SearchInteractor) Search(input port.SearchInputData) {
+	m := search.NewMatcher(input.Query)
+	f := toScheduleFilter(i.Zone, input)
+
+	schedules, err := i.ScheduleRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSearch(nil, r)
+		return
+	}
+
+	matched := model.ScheduleList{}
+	for _, s := range model.ScheduleList(schedules).In(i.Zone.Location()).Filter(f) {
+		if m.Match(s.Name, s.Note, s.Memo) {
+			matched = append(matched, s)
+		}
+	}
+
+	subjects, err := i.SubjectRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSearch(nil, r)
+		return
+	}
+
+	outputSubjects := []port.BaseSubjectData{}
+	for _, s := range subjects {
+		if input.TagID != "" {
+			break
+		}
+		if input.Color != "" && s.Color != input.Color {
+			continue
+		}
+		if !m.Match(s.Name) {
+			continue
+		}
+
+		outputSubjects = append(outputSubjects, port.BaseSubjectData{
+			ID:        s.ID,
+			UserID:    s.UserID,
+			Name:      s.Name,
+			Color:     s.Color,
+			CreatedAt: i.Zone.FormatDateTime(s.CreatedAt),
+			UpdatedAt: i.Zone.FormatDateTime(s.UpdatedAt),
+		})
+	}
+
+	o := &port.SearchOutputData{
+		Schedules: toBaseDateItemDataList(i.Zone, matched.ToDateItemList()),
+		Subjects:  outputSubjects,
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseSearch(o, r)
+}
+
+// toScheduleFilter は検索の入力データからスケジュールの絞り込み条件を生成します。
+// 日付の形式はリクエストのバリデーションで確認済みのため、変換できない場合は条件に含めません。
+// 日付と完了済みの判定に使う現在日時はユーザーのタイムゾーンで扱います。
+func toScheduleFilter(zone timezone.Zone, input port.SearchInputData) model.ScheduleFilter {
+	f := model.ScheduleFilter{
+		Type:      model.ToScheduleType(input.Type),
+		Color:     input.Color,
+		TagID:     input.TagID,
+		Completed: input.Completed,
+		Now:       zone.Now(),
+	}
+
+	if from, err := zone.ParseDate(input.From); err == nil {
+		f.From = from
+	}
+
+	if to, err := zone.ParseDate(input.To); err == nil {
+		f.To = to
+	}
+
+	return f
+}
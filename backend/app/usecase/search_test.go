@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	scheduleIDs := func(items []port.BaseDateItemData) []string {
+		var ids []string
+		for _, di := range items {
+			for _, s := range di.Schedules {
+				ids = append(ids, s.ID)
+			}
+		}
+		return ids
+	}
+	subjectIDs := func(subjects []port.BaseSubjectData) []string {
+		ids := []string{}
+		for _, s := range subjects {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+	completed := true
+	notCompleted := false
+
+	tests := []struct {
+		name         string
+		input        port.SearchInputData
+		wantSchedule []string
+		wantSubject  []string
+	}{
+		{
+			name:         "全角数字の名前に半角数字の検索語で一致する",
+			input:        port.SearchInputData{Query: "統計学 第6回"},
+			wantSchedule: []string{"test-id-2"},
+			wantSubject:  []string{},
+		},
+		{
+			name:         "ひらがなの検索語で半角カナの名前と科目に一致する",
+			input:        port.SearchInputData{Query: "でーた"},
+			wantSchedule: []string{"test-id-3"},
+			wantSubject:  []string{"test-subject-2"},
+		},
+		{
+			name:         "タグで絞り込む場合は科目を返さない",
+			input:        port.SearchInputData{Query: "でーた", TagID: "test-tag-1"},
+			wantSchedule: []string{"test-id-3"},
+			wantSubject:  []string{},
+		},
+		{
+			name:         "ノートに一致する",
+			input:        port.SearchInputData{Query: "最小二乗法"},
+			wantSchedule: []string{"test-id-3"},
+			wantSubject:  []string{},
+		},
+		{
+			name:         "メモに一致する",
+			input:        port.SearchInputData{Query: "れぽーと"},
+			wantSchedule: []string{"test-id-3"},
+			wantSubject:  []string{},
+		},
+		{
+			name:         "日付順に返す",
+			input:        port.SearchInputData{Query: "統計学"},
+			wantSchedule: []string{"test-id-1", "test-id-2", "test-id-4"},
+			wantSubject:  []string{"test-subject-1"},
+		},
+		{
+			name:         "種類で絞り込む",
+			input:        port.SearchInputData{Query: "統計学", Type: "master"},
+			wantSchedule: []string{"test-id-4"},
+			wantSubject:  []string{"test-subject-1"},
+		},
+		{
+			name:         "色で絞り込む",
+			input:        port.SearchInputData{Query: "第6回", Color: "blue"},
+			wantSchedule: []string{"test-id-3"},
+			wantSubject:  []string{},
+		},
+		{
+			name:         "期間で絞り込む",
+			input:        port.SearchInputData{Query: "統計学", From: "2021-01-02", To: "2021-01-31"},
+			wantSchedule: []string{"test-id-2"},
+			wantSubject:  []string{"test-subject-1"},
+		},
+		{
+			name:         "完了済みで絞り込む",
+			input:        port.SearchInputData{Query: "統計学", Completed: &completed},
+			wantSchedule: []string{"test-id-1", "test-id-2"},
+			wantSubject:  []string{"test-subject-1"},
+		},
+		{
+			name:         "未完了で絞り込む",
+			input:        port.SearchInputData{Query: "統計学", Completed: &notCompleted},
+			wantSchedule: []string{"test-id-4"},
+			wantSubject:  []string{"test-subject-1"},
+		},
+		{
+			name:        "一致しない",
+			input:       port.SearchInputData{Query: "英語"},
+			wantSubject: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			p := &stubSearchOutputPort{}
+			i := NewSearchInteractor(l, timezone.UTC(), &stubSearchScheduleRepository{}, &stubSubjectRepository{}, p)
+
+			tt.input.UserID = "test-user-id"
+			i.Search(tt.input)
+
+			require.NotNil(p.Output)
+			assert.Equal(http.StatusOK, p.Result.StatusCode)
+			assert.False(p.Result.HasError)
+			assert.Equal(tt.wantSchedule, scheduleIDs(p.Output.Schedules))
+			assert.Equal(tt.wantSubject, subjectIDs(p.Output.Subjects))
+		})
+	}
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// SessionInteractor はセッションのユースケースの実装を表す構造体です。
+type SessionInteractor struct {
+	Logger                 *slog.Logger
+	UserRepository         repository.UserRepository
+	SessionRepository      repository.SessionRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	LoginSessionRepository repository.LoginSessionRepository
+	OutputPort             port.SessionOutputPort
+}
+
+// NewSessionInteractor は SessionInteractor を生成します。
+func NewSessionInteractor(logger *slog.Logger, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, outputPort port.SessionOutputPort) port.SessionInputPort {
+	return &SessionInteractor{
+		Logger:                 logger,
+		UserRepository:         userRepository,
+		SessionRepository:      sessionRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		LoginSessionRepository: loginSessionRepository,
+		OutputPort:             outputPort,
+	}
+}
+
+// RefreshSession はリフレッシュトークンを新しいものに置き換えて、アクセストークンを再発行します。
+// 置き換え済みのリフレッシュトークンが使われた場合は漏洩したとみなし、そのログインセッションを無効にします。
+func (i *SessionInteractor) RefreshSession(input port.RefreshSessionInputData) {
+	now := time.Now()
+
+	rt, err := i.RefreshTokenRepository.Read(model.HashRefreshToken(input.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("refresh token not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseRefreshSession(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	i.Logger.With("user_id", rt.UserID, "session_id", rt.FamilyID)
+
+	if rt.IsReused() {
+		i.Logger.Warn("refresh token reuse detected", "user_id", rt.UserID, "session_id", rt.FamilyID)
+		i.revokeForRefresh(rt.FamilyID, now)
+		return
+	}
+
+	if !rt.IsUsable(now) {
+		i.Logger.Warn("refresh token is not usable", "status", rt.Status)
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	user, err := i.UserRepository.Read(rt.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseRefreshSession(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	session, err := i.LoginSessionRepository.Read(rt.FamilyID)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("login session not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseRefreshSession(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	// パスワードの設定などでセッションの世代が上がった場合や、無効にされたログインセッションは再発行しない
+	if !session.IsActive(user, now) {
+		i.Logger.Warn("login session is not active", "revoked", session.Revoked, "token_version", session.TokenVersion)
+		i.revokeForRefresh(session.ID, now)
+		return
+	}
+
+	if err := i.RefreshTokenRepository.Rotate(rt, now); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			// 同時に置き換えられた場合も再利用とみなす
+			i.Logger.Warn("refresh token reuse detected", "user_id", rt.UserID, "session_id", rt.FamilyID)
+			i.revokeForRefresh(rt.FamilyID, now)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	session.Touch(input.UserAgent, input.IPAddress, refreshTokenLifetime(), now)
+	if err := i.LoginSessionRepository.Update(session); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	tokens, err := issueSessionTokens(i.SessionRepository, i.RefreshTokenRepository, user, session.ID, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	o := &port.RefreshSessionOutputData{SessionTokenData: *tokens}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseRefreshSession(o, r)
+}
+
+// SignOut はリフレッシュトークンのログインセッションを無効にしてサインアウトします。
+// 無効なリフレッシュトークンが指定された場合も、すでにサインアウトしているとみなして成功を返します。
+func (i *SessionInteractor) SignOut(input port.SignOutInputData) {
+	rt, err := i.RefreshTokenRepository.Read(model.HashRefreshToken(input.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("refresh token not found")
+			r := port.NewSuccessResult(http.StatusOK)
+			i.OutputPort.SetResponseSignOut(&port.SignOutOutputData{}, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignOut(nil, r)
+		return
+	}
+
+	if err := revokeLoginSession(i.RefreshTokenRepository, i.LoginSessionRepository, rt.FamilyID, time.Now()); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignOut(nil, r)
+		return
+	}
+
+	i.Logger.Info("signed out", "user_id", rt.UserID, "session_id", rt.FamilyID)
+
+	o := &port.SignOutOutputData{}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseSignOut(o, r)
+}
+
+// GetLoginSessionList は有効なログインセッションのリストを、最後にアクセスした日時の降順で取得します。
+func (i *SessionInteractor) GetLoginSessionList(input port.GetLoginSessionListInputData) {
+	user, err := i.UserRepository.Read(input.UserID, true)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+		i.OutputPort.SetResponseGetLoginSessionList(nil, r)
+		return
+	}
+
+	sessions, err := i.LoginSessionRepository.ReadByUserID(user.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetLoginSessionList(nil, r)
+		return
+	}
+
+	now := time.Now()
+	active := make([]model.LoginSession, 0, len(sessions))
+	for _, s := range sessions {
+		if s.IsActive(user, now) {
+			active = append(active, s)
+		}
+	}
+	sort.SliceStable(active, func(a, b int) bool {
+		return active[a].LastSeenAt.After(active[b].LastSeenAt)
+	})
+
+	zone := timezone.LoadOrDefault(user.Timezone)
+	data := make([]port.LoginSessionData, 0, len(active))
+	for _, s := range active {
+		data = append(data, port.LoginSessionData{
+			ID:         s.ID,
+			Device:     s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  zone.FormatDateTime(s.CreatedAt),
+			LastSeenAt: zone.FormatDateTime(s.LastSeenAt),
+		})
+	}
+
+	o := &port.GetLoginSessionListOutputData{Sessions: data}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetLoginSessionList(o, r)
+}
+
+// RevokeLoginSession は指定されたログインセッションを無効にします。
+func (i *SessionInteractor) RevokeLoginSession(input port.RevokeLoginSessionInputData) {
+	session, err := i.LoginSessionRepository.Read(input.SessionID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRevokeLoginSession(nil, r)
+		return
+	}
+
+	// 別のユーザーのログインセッションは存在しないものとして扱う
+	if err != nil || session.UserID != input.UserID || session.Revoked {
+		i.Logger.Warn("login session not found", "session_id", input.SessionID)
+		r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeSessionNotFound, MsgSessionNotFound)
+		i.OutputPort.SetResponseRevokeLoginSession(nil, r)
+		return
+	}
+
+	if err := revokeLoginSession(i.RefreshTokenRepository, i.LoginSessionRepository, session.ID, time.Now()); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRevokeLoginSession(nil, r)
+		return
+	}
+
+	i.Logger.Info("login session revoked", "session_id", session.ID)
+
+	o := &port.RevokeLoginSessionOutputData{}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseRevokeLoginSession(o, r)
+}
+
+// RevokeAllLoginSessions はセッションの世代を上げて、リクエストした端末を含むすべてのログインセッションを無効にします。
+func (i *SessionInteractor) RevokeAllLoginSessions(input port.RevokeAllLoginSessionsInputData) {
+	user, err := i.UserRepository.Read(input.UserID, true)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+		i.OutputPort.SetResponseRevokeAllLoginSessions(nil, r)
+		return
+	}
+
+	now := time.Now()
+	user.TokenVersion++
+	user.UpdatedAt = now
+	if err := i.UserRepository.Update(user); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRevokeAllLoginSessions(nil, r)
+		return
+	}
+
+	// 世代を上げた時点でアクセストークンもリフレッシュトークンも使えなくなるため、ここからは記録を揃えるための処理
+	sessions, err := i.LoginSessionRepository.ReadByUserID(user.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRevokeAllLoginSessions(nil, r)
+		return
+	}
+
+	for _, s := range sessions {
+		if s.Revoked {
+			continue
+		}
+
+		if err := revokeLoginSession(i.RefreshTokenRepository, i.LoginSessionRepository, s.ID, now); err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseRevokeAllLoginSessions(nil, r)
+			return
+		}
+	}
+
+	i.Logger.Info("all login sessions revoked", "token_version", user.TokenVersion)
+
+	o := &port.RevokeAllLoginSessionsOutputData{}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseRevokeAllLoginSessions(o, r)
+}
+
+// GetJWKS はトークンの検証に使える公開鍵の一覧を取得します。
+func (i *SessionInteractor) GetJWKS(input port.GetJWKSInputData) {
+	keys, err := i.SessionRepository.JWKS()
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetJWKS(nil, r)
+		return
+	}
+
+	data := make([]port.JSONWebKeyData, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, port.JSONWebKeyData{
+			KeyType:   k.KeyType,
+			Curve:     k.Curve,
+			Algorithm: k.Algorithm,
+			Use:       k.Use,
+			KeyID:     k.KeyID,
+			N:         k.N,
+			E:         k.E,
+			X:         k.X,
+		})
+	}
+
+	o := &port.GetJWKSOutputData{Keys: data}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetJWKS(o, r)
+}
+
+// revokeForRefresh はアクセストークンを再発行できないログインセッションを無効にし、エラーの結果をセットします。
+func (i *SessionInteractor) revokeForRefresh(sessionID string, now time.Time) {
+	if err := revokeLoginSession(i.RefreshTokenRepository, i.LoginSessionRepository, sessionID, now); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRefreshSession(nil, r)
+		return
+	}
+
+	r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+	i.OutputPort.SetResponseRefreshSession(nil, r)
+}
+
+// startLoginSession はサインインした端末のログインセッションを作成し、トークンを発行します。
+func startLoginSession(sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, user *model.User, userAgent, ipAddress string, now time.Time) (*port.SessionTokenData, error) {
+	session := model.NewLoginSession(id.NewID(), user, userAgent, ipAddress, refreshTokenLifetime(), now)
+	if err := loginSessionRepository.Create(session); err != nil {
+		return nil, err
+	}
+
+	return issueSessionTokens(sessionRepository, refreshTokenRepository, user, session.ID, now)
+}
+
+// completeSignIn は本人を確認したユーザーのサインインを完了します。
+// 二段階認証が有効な場合はセッションを発行せず、認証コードを送るためのトークンを返します。
+func completeSignIn(logger *slog.Logger, sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, twoFactorRepository repository.TwoFactorRepository, user *model.User, userAgent, ipAddress string, now time.Time) (*port.SignInOutputData, error) {
+	tf, err := twoFactorRepository.Read(user.ID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		token, err := sessionRepository.IssueToken(repository.TokenPurposeTwoFactor, user.ID, "")
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info("two factor required", "user_id", user.ID)
+
+		return &port.SignInOutputData{TwoFactorToken: token}, nil
+	}
+
+	tokens, err := startLoginSession(sessionRepository, refreshTokenRepository, loginSessionRepository, user, userAgent, ipAddress, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.SignInOutputData{
+		BaseUserData:     toBaseUserData(user),
+		SessionTokenData: *tokens,
+	}, nil
+}
+
+// revokeLoginSession はログインセッションとそのリフレッシュトークンを無効にします。
+// ログインセッションが存在しない場合もリフレッシュトークンは無効にします。
+func revokeLoginSession(refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, sessionID string, now time.Time) error {
+	session, err := loginSessionRepository.Read(sessionID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		return err
+	}
+
+	if err == nil && !session.Revoked {
+		session.Revoked = true
+		if err := loginSessionRepository.Update(session); err != nil {
+			return err
+		}
+	}
+
+	return refreshTokenRepository.RevokeFamily(sessionID, now)
+}
+
+// issueSessionTokens はログインセッションのアクセストークンとリフレッシュトークンを発行します。
+// リフレッシュトークンの系列の ID にはログインセッションの ID を使います。
+func issueSessionTokens(sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, user *model.User, sessionID string, now time.Time) (*port.SessionTokenData, error) {
+	rt, refreshToken, err := model.NewRefreshToken(user.ID, sessionID, refreshTokenLifetime(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := refreshTokenRepository.Create(rt); err != nil {
+		return nil, err
+	}
+
+	sessionToken, err := sessionRepository.GenerateToken(user.ID, sessionID, user.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.SessionTokenData{
+		SessionToken: sessionToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    infrastructure.GetConfig().AccessTokenLifeMinutes * 60,
+	}, nil
+}
+
+// refreshTokenLifetime はリフレッシュトークンとログインセッションの有効期間を返します。
+func refreshTokenLifetime() time.Duration {
+	return time.Hour * 24 * time.Duration(infrastructure.GetConfig().TokenLifeDays)
+}
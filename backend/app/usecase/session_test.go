@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshSession(t *testing.T) {
+	t.Run("リフレッシュトークンを置き換えてアクセストークンを再発行する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		user, _ := (&stubUserRepository{}).Read("test-id", true)
+		lsr := &stubLoginSessionRepository{}
+		require.NoError(lsr.Create(model.NewLoginSession("test-family-id", user, "test-agent", "192.0.2.1", time.Hour, time.Now())))
+		rtr := &stubRefreshTokenRepository{}
+		rt, token, err := model.NewRefreshToken("test-id", "test-family-id", time.Hour, time.Now())
+		require.NoError(err)
+		require.NoError(rtr.Create(rt))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, rtr, lsr, p)
+		i.RefreshSession(port.RefreshSessionInputData{RefreshToken: token, UserAgent: "test-new-agent", IPAddress: "192.0.2.2"})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		output, ok := p.Output.(*port.RefreshSessionOutputData)
+		require.True(ok)
+		assert.Equal("test-token", output.SessionToken)
+		assert.NotEqual(token, output.RefreshToken)
+
+		assert.Equal(model.RefreshTokenStatusRotated, rtr.Tokens[rt.ID].Status)
+		next := rtr.Tokens[model.HashRefreshToken(output.RefreshToken)]
+		assert.Equal(model.RefreshTokenStatusActive, next.Status)
+		assert.Equal("test-family-id", next.FamilyID)
+
+		session := lsr.Sessions["test-family-id"]
+		assert.Equal("test-new-agent", session.UserAgent)
+		assert.Equal("192.0.2.2", session.IPAddress)
+	})
+
+	t.Run("置き換え済みのリフレッシュトークンが使われた場合は系列ごと無効にする", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		user, _ := (&stubUserRepository{}).Read("test-id", true)
+		lsr := &stubLoginSessionRepository{}
+		require.NoError(lsr.Create(model.NewLoginSession("test-family-id", user, "test-agent", "192.0.2.1", time.Hour, time.Now())))
+		rtr := &stubRefreshTokenRepository{}
+		rt, token, err := model.NewRefreshToken("test-id", "test-family-id", time.Hour, time.Now())
+		require.NoError(err)
+		require.NoError(rtr.Create(rt))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, rtr, lsr, p)
+		i.RefreshSession(port.RefreshSessionInputData{RefreshToken: token})
+		require.Equal(http.StatusOK, p.Result.StatusCode)
+
+		p = &stubSessionOutputPort{}
+		i = NewSessionInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, rtr, lsr, p)
+		i.RefreshSession(port.RefreshSessionInputData{RefreshToken: token})
+
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTokenInvalid, p.Result.ErrorCode)
+		for _, got := range rtr.Tokens {
+			assert.Equal(model.RefreshTokenStatusRevoked, got.Status)
+		}
+		assert.True(lsr.Sessions["test-family-id"].Revoked)
+	})
+
+	t.Run("セッションの世代が上がった場合は再発行しない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		user, _ := (&stubUserRepository{}).Read("test-id", true)
+		lsr := &stubLoginSessionRepository{}
+		require.NoError(lsr.Create(model.NewLoginSession("test-family-id", user, "test-agent", "192.0.2.1", time.Hour, time.Now())))
+		rtr := &stubRefreshTokenRepository{}
+		rt, token, err := model.NewRefreshToken("test-id", "test-family-id", time.Hour, time.Now())
+		require.NoError(err)
+		require.NoError(rtr.Create(rt))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		ur := &stubTokenVersionUserRepository{TokenVersion: user.TokenVersion + 1}
+		i := NewSessionInteractor(l, ur, &stubSessionRepository{}, rtr, lsr, p)
+		i.RefreshSession(port.RefreshSessionInputData{RefreshToken: token})
+
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTokenInvalid, p.Result.ErrorCode)
+		assert.Equal(model.RefreshTokenStatusRevoked, rtr.Tokens[rt.ID].Status)
+	})
+
+	t.Run("存在しないリフレッシュトークンの場合はエラーを返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, &stubRefreshTokenRepository{}, &stubLoginSessionRepository{}, p)
+		i.RefreshSession(port.RefreshSessionInputData{RefreshToken: "test-unknown-token"})
+
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTokenInvalid, p.Result.ErrorCode)
+	})
+}
+
+func TestSignOut(t *testing.T) {
+	t.Run("ログインセッションとリフレッシュトークンの系列を無効にする", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		user, _ := (&stubUserRepository{}).Read("test-id", true)
+		lsr := &stubLoginSessionRepository{}
+		require.NoError(lsr.Create(model.NewLoginSession("test-family-id", user, "test-agent", "192.0.2.1", time.Hour, time.Now())))
+		rtr := &stubRefreshTokenRepository{}
+		rt, token, err := model.NewRefreshToken("test-id", "test-family-id", time.Hour, time.Now())
+		require.NoError(err)
+		require.NoError(rtr.Create(rt))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, nil, nil, rtr, lsr, p)
+		i.SignOut(port.SignOutInputData{RefreshToken: token})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Equal(model.RefreshTokenStatusRevoked, rtr.Tokens[rt.ID].Status)
+		assert.True(lsr.Sessions["test-family-id"].Revoked)
+	})
+}
+
+func TestGetLoginSessionList(t *testing.T) {
+	t.Run("有効なログインセッションを最後にアクセスした日時の降順で取得する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		user, _ := (&stubUserRepository{}).Read("test-id", true)
+		now := time.Now()
+		lsr := &stubLoginSessionRepository{}
+		require.NoError(lsr.Create(model.NewLoginSession("test-old-id", user, "test-old-agent", "192.0.2.1", time.Hour, now.Add(-time.Minute))))
+		require.NoError(lsr.Create(model.NewLoginSession("test-new-id", user, "test-new-agent", "192.0.2.2", time.Hour, now)))
+		revoked := model.NewLoginSession("test-revoked-id", user, "test-agent", "192.0.2.3", time.Hour, now)
+		revoked.Revoked = true
+		require.NoError(lsr.Create(revoked))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, &stubUserRepository{}, nil, nil, lsr, p)
+		i.GetLoginSessionList(port.GetLoginSessionListInputData{UserID: "test-id"})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		output, ok := p.Output.(*port.GetLoginSessionListOutputData)
+		require.True(ok)
+		require.Len(output.Sessions, 2)
+		assert.Equal("test-new-id", output.Sessions[0].ID)
+		assert.Equal("test-new-agent", output.Sessions[0].Device)
+		assert.Equal("test-old-id", output.Sessions[1].ID)
+	})
+}
+
+func TestRevokeLoginSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		sessionID  string
+		wantStatus int
+	}{
+		{name: "自分のログインセッションを無効にする", userID: "test-id", sessionID: "test-session-id", wantStatus: http.StatusOK},
+		{name: "別のユーザーのログインセッションは存在しないものとして扱う", userID: "test-other-id", sessionID: "test-session-id", wantStatus: http.StatusNotFound},
+		{name: "存在しないログインセッションの場合はエラーを返す", userID: "test-id", sessionID: "test-unknown-id", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			user, _ := (&stubUserRepository{}).Read("test-id", true)
+			lsr := &stubLoginSessionRepository{}
+			require.NoError(lsr.Create(model.NewLoginSession("test-session-id", user, "test-agent", "192.0.2.1", time.Hour, time.Now())))
+			rtr := &stubRefreshTokenRepository{}
+			rt, _, err := model.NewRefreshToken("test-id", "test-session-id", time.Hour, time.Now())
+			require.NoError(err)
+			require.NoError(rtr.Create(rt))
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			p := &stubSessionOutputPort{}
+			i := NewSessionInteractor(l, nil, nil, rtr, lsr, p)
+			i.RevokeLoginSession(port.RevokeLoginSessionInputData{UserID: tt.userID, SessionID: tt.sessionID})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			revoked := tt.wantStatus == http.StatusOK
+			assert.Equal(revoked, lsr.Sessions["test-session-id"].Revoked)
+			assert.Equal(revoked, rtr.Tokens[rt.ID].Status == model.RefreshTokenStatusRevoked)
+			if !revoked {
+				assert.Equal(port.ErrorCodeSessionNotFound, p.Result.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestRevokeAllLoginSessions(t *testing.T) {
+	t.Run("セッションの世代を上げてすべてのログインセッションを無効にする", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		ur := &stubTokenVersionUserRepository{TokenVersion: 2}
+		user, _ := ur.Read("test-id", true)
+		lsr := &stubLoginSessionRepository{}
+		require.NoError(lsr.Create(model.NewLoginSession("test-session-id-1", user, "test-agent", "192.0.2.1", time.Hour, time.Now())))
+		require.NoError(lsr.Create(model.NewLoginSession("test-session-id-2", user, "test-agent", "192.0.2.2", time.Hour, time.Now())))
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, ur, nil, &stubRefreshTokenRepository{}, lsr, p)
+		i.RevokeAllLoginSessions(port.RevokeAllLoginSessionsInputData{UserID: "test-id"})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		require.NotNil(ur.Updated)
+		assert.Equal(3, ur.Updated.TokenVersion)
+		for _, s := range lsr.Sessions {
+			assert.True(s.Revoked)
+		}
+	})
+}
+
+func TestGetJWKS(t *testing.T) {
+	t.Run("トークンの検証に使える公開鍵を取得する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubSessionOutputPort{}
+		i := NewSessionInteractor(l, nil, &stubSessionRepository{}, nil, nil, p)
+		i.GetJWKS(port.GetJWKSInputData{})
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		output, ok := p.Output.(*port.GetJWKSOutputData)
+		require.True(ok)
+		require.Len(output.Keys, 1)
+		assert.Equal("test-kid", output.Keys[0].KeyID)
+		assert.Equal("EdDSA", output.Keys[0].Algorithm)
+	})
+}
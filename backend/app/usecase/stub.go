@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/datsukan/attendance-plan/backend/app/model"
@@ -69,6 +71,32 @@ func (r *stubScheduleRepository) ReadByUserIDStartsAt(userID string, startsAt ti
 	return schedules, nil
 }
 
+func (r *stubScheduleRepository) ReadByUserIDStartsAtBetween(userID string, from, to time.Time) ([]model.Schedule, error) {
+	schedules, _ := r.ReadByUserID(userID)
+
+	res := []model.Schedule{}
+	for _, s := range schedules {
+		if !s.StartsAt.Before(from) && !s.StartsAt.After(to) {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func (r *stubScheduleRepository) ReadByIDs(ids []string) ([]model.Schedule, error) {
+	schedules, _ := r.ReadByUserID("test-user-id")
+
+	res := []model.Schedule{}
+	for _, s := range schedules {
+		for _, id := range ids {
+			if s.ID == id {
+				res = append(res, s)
+			}
+		}
+	}
+	return res, nil
+}
+
 func (r *stubScheduleRepository) Create(schedule *model.Schedule) error {
 	return nil
 }
@@ -85,6 +113,52 @@ func (r *stubScheduleRepository) Exists(id string) (bool, error) {
 	return true, nil
 }
 
+type stubDetailScheduleRepository struct {
+	stubScheduleRepository
+	Updated []model.Schedule
+}
+
+func (r *stubDetailScheduleRepository) Read(id string) (*model.Schedule, error) {
+	schedule, _ := r.stubScheduleRepository.Read(id)
+	schedule.LectureNumber = 3
+	schedule.Note = "before-note"
+	schedule.Links = []string{"https://example.com/before"}
+	schedule.Memo = "before-memo"
+	schedule.TagIDs = []string{"test-tag-1"}
+	return schedule, nil
+}
+
+func (r *stubDetailScheduleRepository) Update(schedule *model.Schedule) error {
+	r.Updated = append(r.Updated, *schedule)
+	return nil
+}
+
+type stubExportScheduleRepository struct {
+	stubScheduleRepository
+}
+
+func (r *stubExportScheduleRepository) ReadByUserID(userID string) ([]model.Schedule, error) {
+	schedules, _ := r.stubScheduleRepository.ReadByUserID(userID)
+	for i := range schedules {
+		if schedules[i].ID == "test-id-1" {
+			schedules[i].Note = "# 第1回\n課題あり"
+			schedules[i].Memo = "memo"
+			schedules[i].Links = []string{"https://a.jp", "https://b.jp"}
+		}
+	}
+	return schedules, nil
+}
+
+type stubTimedScheduleRepository struct {
+	stubDetailScheduleRepository
+}
+
+func (r *stubTimedScheduleRepository) Read(id string) (*model.Schedule, error) {
+	schedule, _ := r.stubDetailScheduleRepository.Read(id)
+	schedule.Timed = true
+	return schedule, nil
+}
+
 type stubNotFoundScheduleRepository struct{}
 
 func (r *stubNotFoundScheduleRepository) Read(id string) (*model.Schedule, error) {
@@ -103,6 +177,14 @@ func (r *stubNotFoundScheduleRepository) ReadByUserIDStartsAt(userID string, sta
 	return nil, nil
 }
 
+func (r *stubNotFoundScheduleRepository) ReadByUserIDStartsAtBetween(userID string, from, to time.Time) ([]model.Schedule, error) {
+	return nil, nil
+}
+
+func (r *stubNotFoundScheduleRepository) ReadByIDs(ids []string) ([]model.Schedule, error) {
+	return []model.Schedule{}, nil
+}
+
 func (r *stubNotFoundScheduleRepository) Update(schedule *model.Schedule) error {
 	return repository.NewNotFoundError()
 }
@@ -159,6 +241,16 @@ func (p *stubScheduleOutputPort) SetResponseDeleteSchedule(output *port.DeleteSc
 	p.Result = result
 }
 
+func (p *stubScheduleOutputPort) SetResponseLintSchedule(output *port.LintScheduleOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubScheduleOutputPort) SetResponseExportSchedule(output *port.ExportScheduleOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
 func (p *stubScheduleOutputPort) SetResponseResetEmail(output *port.ResetEmailOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
@@ -169,6 +261,85 @@ func (p *stubScheduleOutputPort) SetResponseSetEmail(output *port.SetEmailOutput
 	p.Result = result
 }
 
+type stubScheduleLinter struct{}
+
+func (l *stubScheduleLinter) Lint(userID string) (model.WarningList, error) {
+	date := time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)
+	warnings := model.WarningList{
+		{Code: model.WarningCodeOverload, Message: "test-message-1", Date: date, ScheduleIDs: []string{"test-id-13", "test-id-14"}},
+		{Code: model.WarningCodeSequence, Message: "test-message-2", Date: date, ScheduleIDs: []string{"test-id"}},
+	}
+	return warnings, nil
+}
+
+func (l *stubScheduleLinter) CheckSequence(userID string, changed model.ScheduleList) (model.WarningList, error) {
+	return nil, nil
+}
+
+type stubRejectScheduleLinter struct {
+	stubScheduleLinter
+}
+
+func (l *stubRejectScheduleLinter) CheckSequence(userID string, changed model.ScheduleList) (model.WarningList, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	warnings := model.WarningList{
+		{Code: model.WarningCodeSequence, Message: "test-message", Date: date, ScheduleIDs: []string{changed[0].ID}},
+	}
+	return warnings, nil
+}
+
+type stubErrorScheduleLinter struct{}
+
+func (l *stubErrorScheduleLinter) Lint(userID string) (model.WarningList, error) {
+	return nil, errors.New("test-error")
+}
+
+func (l *stubErrorScheduleLinter) CheckSequence(userID string, changed model.ScheduleList) (model.WarningList, error) {
+	return nil, nil
+}
+
+type stubColorValidator struct {
+	Missing string
+}
+
+func (v *stubColorValidator) Validate(userID string, colors ...string) (bool, error) {
+	for _, c := range colors {
+		if c == v.Missing {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type stubScheduleTagger struct {
+	Synced [][]string
+}
+
+func (t *stubScheduleTagger) Validate(userID string, tagIDs []string) (bool, error) {
+	return true, nil
+}
+
+func (t *stubScheduleTagger) Sync(schedule model.Schedule, before []string) error {
+	t.Synced = append(t.Synced, schedule.TagIDs)
+	return nil
+}
+
+func (t *stubScheduleTagger) Clear(scheduleID string) error {
+	return nil
+}
+
+func (t *stubScheduleTagger) ScheduleIDs(tagID string) ([]string, error) {
+	return []string{"test-id-1", "test-id-4", "test-id-deleted"}, nil
+}
+
+type stubNotFoundScheduleTagger struct {
+	stubScheduleTagger
+}
+
+func (t *stubNotFoundScheduleTagger) Validate(userID string, tagIDs []string) (bool, error) {
+	return false, nil
+}
+
 type stubUserRepository struct{}
 
 func (r *stubUserRepository) ReadByEmail(email string, enabledOnly bool) (*model.User, error) {
@@ -234,6 +405,33 @@ func (r *stubUserRepository) ScanAll(enabledOnly bool) ([]model.User, error) {
 	}}, nil
 }
 
+type stubTokenVersionUserRepository struct {
+	stubUserRepository
+	TokenVersion int
+	Updated      *model.User
+}
+
+func (r *stubTokenVersionUserRepository) Read(id string, enabledOnly bool) (*model.User, error) {
+	user, _ := r.stubUserRepository.Read(id, enabledOnly)
+	user.TokenVersion = r.TokenVersion
+	return user, nil
+}
+
+func (r *stubTokenVersionUserRepository) Update(user *model.User) error {
+	r.Updated = user
+	return nil
+}
+
+type stubStrictUserRepository struct {
+	stubUserRepository
+}
+
+func (r *stubStrictUserRepository) Read(id string, enabledOnly bool) (*model.User, error) {
+	user, _ := r.stubUserRepository.Read(id, enabledOnly)
+	user.SequenceStrictness = model.SequenceStrictnessReject
+	return user, nil
+}
+
 type stubNotFoundUserRepository struct{}
 
 func (r *stubNotFoundUserRepository) ReadByEmail(email string, enabledOnly bool) (*model.User, error) {
@@ -270,70 +468,1085 @@ func (r *stubNotFoundUserRepository) ScanAll(enabledOnly bool) ([]model.User, er
 
 type stubSessionRepository struct{}
 
-func (r *stubSessionRepository) GenerateToken(userID string) (string, error) {
+func (r *stubSessionRepository) GenerateToken(userID, sessionID string, version int) (string, error) {
+	return "test-token", nil
+}
+
+func (r *stubSessionRepository) IssueToken(purpose repository.TokenPurpose, subject, email string) (string, error) {
+	return "test-token", nil
+}
+
+func (r *stubSessionRepository) IssueMagicLinkToken(subject, nonceHash string) (string, error) {
 	return "test-token", nil
 }
 
-func (r *stubSessionRepository) IsValidToken(token string) (bool, string) {
-	return true, "test-user-id"
+func (r *stubSessionRepository) ParseToken(token string, purpose repository.TokenPurpose) (*repository.Token, error) {
+	return &repository.Token{ID: token, Purpose: purpose, Subject: "test-user-id", Email: "test-new-email@example.com"}, nil
+}
+
+func (r *stubSessionRepository) JWKS() ([]repository.JSONWebKey, error) {
+	return []repository.JSONWebKey{{KeyType: "OKP", Curve: "Ed25519", Algorithm: "EdDSA", Use: "sig", KeyID: "test-kid", X: "test-x"}}, nil
+}
+
+// stubMagicLinkSessionRepository はサインインのリンクのトークンとして NonceHash を持つトークンを返すセッションの repository です。
+type stubMagicLinkSessionRepository struct {
+	stubSessionRepository
+	NonceHash string
+}
+
+func (r *stubMagicLinkSessionRepository) ParseToken(token string, purpose repository.TokenPurpose) (*repository.Token, error) {
+	return &repository.Token{ID: token, Purpose: purpose, Subject: "test-user-id", NonceHash: r.NonceHash}, nil
+}
+
+type stubMapUserRepository struct {
+	Users map[string]model.User
+}
+
+func (r *stubMapUserRepository) ReadByEmail(email string, enabledOnly bool) (*model.User, error) {
+	for _, u := range r.Users {
+		if u.Email == email && (u.Enabled || !enabledOnly) {
+			return &u, nil
+		}
+	}
+	return nil, repository.NewNotFoundError()
+}
+
+func (r *stubMapUserRepository) Read(id string, enabledOnly bool) (*model.User, error) {
+	u, ok := r.Users[id]
+	if !ok || (!u.Enabled && enabledOnly) {
+		return nil, repository.NewNotFoundError()
+	}
+	return &u, nil
+}
+
+func (r *stubMapUserRepository) Create(user *model.User) error {
+	if r.Users == nil {
+		r.Users = map[string]model.User{}
+	}
+	r.Users[user.ID] = *user
+	return nil
+}
+
+func (r *stubMapUserRepository) Update(user *model.User) error {
+	r.Users[user.ID] = *user
+	return nil
+}
+
+func (r *stubMapUserRepository) Delete(id string) error {
+	delete(r.Users, id)
+	return nil
+}
+
+func (r *stubMapUserRepository) Exists(id string, enabledOnly bool) (bool, error) {
+	_, err := r.Read(id, enabledOnly)
+	return err == nil, nil
+}
+
+func (r *stubMapUserRepository) ExistsByEmail(email string, enabledOnly bool) (bool, error) {
+	_, err := r.ReadByEmail(email, enabledOnly)
+	return err == nil, nil
+}
+
+func (r *stubMapUserRepository) ScanAll(enabledOnly bool) ([]model.User, error) {
+	users := []model.User{}
+	for _, u := range r.Users {
+		if u.Enabled || !enabledOnly {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+type stubUsedTokenRepository struct {
+	Used map[string]bool
+}
+
+func (r *stubUsedTokenRepository) Consume(token *repository.Token) error {
+	if r.Used == nil {
+		r.Used = map[string]bool{}
+	}
+	if r.Used[token.ID] {
+		return repository.ErrTokenAlreadyUsed
+	}
+	r.Used[token.ID] = true
+	return nil
 }
 
-type stubEmailRepository struct{}
+type stubEmailRepository struct {
+	Message repository.EmailMessage
+	Err     error
+	Sent    int
+	OnSend  func()
+}
 
 func (r *stubEmailRepository) Send(ctx context.Context, to, subject, body string) (string, error) {
+	return r.SendMessage(ctx, to, repository.EmailMessage{Subject: subject, Text: body})
+}
+
+func (r *stubEmailRepository) SendMessage(ctx context.Context, to string, msg repository.EmailMessage) (string, error) {
+	r.Message = msg
+	r.Sent++
+	if onSend := r.OnSend; onSend != nil {
+		r.OnSend = nil
+		onSend()
+	}
+	if r.Err != nil {
+		return "", r.Err
+	}
 	return "test-message-id", nil
 }
 
-type stubUserOutputPort struct {
+type stubEmailOutboxRepository struct {
+	Outboxes map[string]model.EmailOutbox
+}
+
+func (r *stubEmailOutboxRepository) Read(id string) (*model.EmailOutbox, error) {
+	o, ok := r.Outboxes[id]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	return &o, nil
+}
+
+func (r *stubEmailOutboxRepository) ReadDue(now time.Time, limit int) ([]model.EmailOutbox, error) {
+	outboxes := []model.EmailOutbox{}
+	for _, o := range r.Outboxes {
+		if o.IsDue(now) && len(outboxes) < limit {
+			outboxes = append(outboxes, o)
+		}
+	}
+	return outboxes, nil
+}
+
+func (r *stubEmailOutboxRepository) ReadByStatus(status model.EmailOutboxStatus) ([]model.EmailOutbox, error) {
+	outboxes := []model.EmailOutbox{}
+	for _, o := range r.Outboxes {
+		if o.Status == status {
+			outboxes = append(outboxes, o)
+		}
+	}
+	return outboxes, nil
+}
+
+func (r *stubEmailOutboxRepository) Create(outbox *model.EmailOutbox) error {
+	if r.Outboxes == nil {
+		r.Outboxes = map[string]model.EmailOutbox{}
+	}
+	r.Outboxes[outbox.ID] = *outbox
+	return nil
+}
+
+func (r *stubEmailOutboxRepository) Update(outbox *model.EmailOutbox) error {
+	if r.Outboxes[outbox.ID].Version != outbox.Version {
+		return repository.ErrEmailOutboxConflict
+	}
+	outbox.Version++
+	return r.Create(outbox)
+}
+
+type stubEmailOutboxOutputPort struct {
 	Output interface{}
 	Result port.Result
 }
 
-func (p *stubUserOutputPort) GetResponse() (int, string) {
+func (p *stubEmailOutboxOutputPort) GetResponse() (int, string) {
 	return p.Result.StatusCode, p.Result.ErrorMessage
 }
 
-func (p *stubUserOutputPort) SetResponseSignIn(output *port.SignInOutputData, result port.Result) {
+func (p *stubEmailOutboxOutputPort) SetResponseDispatchEmailOutbox(output *port.DispatchEmailOutboxOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponseSignUp(output *port.SignUpOutputData, result port.Result) {
+func (p *stubEmailOutboxOutputPort) SetResponseGetFailedEmailOutboxList(output *port.GetFailedEmailOutboxListOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponsePasswordSet(output *port.PasswordSetOutputData, result port.Result) {
+func (p *stubEmailOutboxOutputPort) SetResponseResendEmailOutbox(output *port.ResendEmailOutboxOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponsePasswordReset(output *port.PasswordResetOutputData, result port.Result) {
+type stubRefreshTokenRepository struct {
+	Tokens map[string]model.RefreshToken
+}
+
+func (r *stubRefreshTokenRepository) Read(id string) (*model.RefreshToken, error) {
+	t, ok := r.Tokens[id]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	return &t, nil
+}
+
+func (r *stubRefreshTokenRepository) Create(token *model.RefreshToken) error {
+	if r.Tokens == nil {
+		r.Tokens = map[string]model.RefreshToken{}
+	}
+	r.Tokens[token.ID] = *token
+	return nil
+}
+
+func (r *stubRefreshTokenRepository) Rotate(token *model.RefreshToken, now time.Time) error {
+	if r.Tokens[token.ID].Status != model.RefreshTokenStatusActive {
+		return repository.ErrTokenAlreadyUsed
+	}
+	token.Status = model.RefreshTokenStatusRotated
+	token.UpdatedAt = now
+	return r.Create(token)
+}
+
+func (r *stubRefreshTokenRepository) RevokeFamily(familyID string, now time.Time) error {
+	for id, t := range r.Tokens {
+		if t.FamilyID == familyID {
+			t.Status = model.RefreshTokenStatusRevoked
+			t.UpdatedAt = now
+			r.Tokens[id] = t
+		}
+	}
+	return nil
+}
+
+type stubLoginSessionRepository struct {
+	Sessions map[string]model.LoginSession
+}
+
+func (r *stubLoginSessionRepository) Read(id string) (*model.LoginSession, error) {
+	s, ok := r.Sessions[id]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	return &s, nil
+}
+
+func (r *stubLoginSessionRepository) ReadByUserID(userID string) ([]model.LoginSession, error) {
+	var sessions []model.LoginSession
+	for _, s := range r.Sessions {
+		if s.UserID == userID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *stubLoginSessionRepository) Create(session *model.LoginSession) error {
+	if r.Sessions == nil {
+		r.Sessions = map[string]model.LoginSession{}
+	}
+	r.Sessions[session.ID] = *session
+	return nil
+}
+
+func (r *stubLoginSessionRepository) Update(session *model.LoginSession) error {
+	return r.Create(session)
+}
+
+type stubRateLimitRepository struct {
+	Limits map[string]model.RateLimit
+}
+
+func (r *stubRateLimitRepository) Read(key string, now time.Time) (*model.RateLimit, error) {
+	rl, ok := r.Limits[key]
+	if !ok || rl.IsExpired(now) {
+		return nil, repository.NewNotFoundError()
+	}
+	return &rl, nil
+}
+
+func (r *stubRateLimitRepository) AddFailure(key string, window time.Duration, now time.Time) (*model.RateLimit, error) {
+	if r.Limits == nil {
+		r.Limits = map[string]model.RateLimit{}
+	}
+	rl, ok := r.Limits[key]
+	if !ok || rl.IsExpired(now) {
+		rl = model.RateLimit{Key: key}
+	}
+	rl.Failures++
+	rl.LastFailureAt = now
+	rl.TTL = now.Add(window).Unix()
+	rl.UpdatedAt = now
+	r.Limits[key] = rl
+	return &rl, nil
+}
+
+func (r *stubRateLimitRepository) Lock(key string, until time.Time, now time.Time) error {
+	rl := r.Limits[key]
+	rl.LockedUntil = until
+	rl.TTL = until.Unix()
+	rl.UpdatedAt = now
+	r.Limits[key] = rl
+	return nil
+}
+
+func (r *stubRateLimitRepository) Delete(key string) error {
+	delete(r.Limits, key)
+	return nil
+}
+
+func (r *stubRateLimitRepository) Acquire(key string, cooldown time.Duration, now time.Time) (bool, error) {
+	if r.Limits == nil {
+		r.Limits = map[string]model.RateLimit{}
+	}
+	if rl, ok := r.Limits[key]; ok && !rl.IsExpired(now) {
+		return false, nil
+	}
+	r.Limits[key] = model.RateLimit{Key: key, TTL: now.Add(cooldown).Unix(), UpdatedAt: now}
+	return true, nil
+}
+
+type stubSessionOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubSessionOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubSessionOutputPort) SetResponseRefreshSession(output *port.RefreshSessionOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponseGetUser(output *port.GetUserOutputData, result port.Result) {
+func (p *stubSessionOutputPort) SetResponseSignOut(output *port.SignOutOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponseUpdateUser(output *port.UpdateUserOutputData, result port.Result) {
+func (p *stubSessionOutputPort) SetResponseGetLoginSessionList(output *port.GetLoginSessionListOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponseDeleteUser(output *port.DeleteUserOutputData, result port.Result) {
+func (p *stubSessionOutputPort) SetResponseRevokeLoginSession(output *port.RevokeLoginSessionOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponseResetEmail(output *port.ResetEmailOutputData, result port.Result) {
+func (p *stubSessionOutputPort) SetResponseRevokeAllLoginSessions(output *port.RevokeAllLoginSessionsOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
 
-func (p *stubUserOutputPort) SetResponseSetEmail(output *port.SetEmailOutputData, result port.Result) {
+func (p *stubSessionOutputPort) SetResponseGetJWKS(output *port.GetJWKSOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubUserOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubUserOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubUserOutputPort) SetResponseSignIn(output *port.SignInOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseSignUp(output *port.SignUpOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponsePasswordSet(output *port.PasswordSetOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponsePasswordReset(output *port.PasswordResetOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseGetUser(output *port.GetUserOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseUpdateUser(output *port.UpdateUserOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseDeleteUser(output *port.DeleteUserOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseResetEmail(output *port.ResetEmailOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseSetEmail(output *port.SetEmailOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseUnlockAccount(output *port.UnlockAccountOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseRequestMagicLink(output *port.RequestMagicLinkOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubUserOutputPort) SetResponseSignInMagicLink(output *port.SignInOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubAvailabilityRepository struct{}
+
+func (r *stubAvailabilityRepository) Read(userID string) (*model.Availability, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &model.Availability{
+		UserID:            userID,
+		Weekdays:          []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		MaxLecturesPerDay: 3,
+		CreatedAt:         date,
+		UpdatedAt:         date,
+	}, nil
+}
+
+func (r *stubAvailabilityRepository) Update(availability *model.Availability) error {
+	return nil
+}
+
+func (r *stubAvailabilityRepository) Delete(userID string) error {
+	return nil
+}
+
+type stubNotFoundAvailabilityRepository struct{}
+
+func (r *stubNotFoundAvailabilityRepository) Read(userID string) (*model.Availability, error) {
+	return nil, repository.NewNotFoundError()
+}
+
+func (r *stubNotFoundAvailabilityRepository) Update(availability *model.Availability) error {
+	return nil
+}
+
+func (r *stubNotFoundAvailabilityRepository) Delete(userID string) error {
+	return nil
+}
+
+type stubBlackoutRepository struct{}
+
+func (r *stubBlackoutRepository) Read(id string) (*model.Blackout, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &model.Blackout{
+		ID:        id,
+		UserID:    "test-user-id",
+		Label:     "test-label",
+		StartsAt:  date,
+		EndsAt:    date,
+		CreatedAt: date,
+		UpdatedAt: date,
+	}, nil
+}
+
+func (r *stubBlackoutRepository) ReadByUserID(userID string) ([]model.Blackout, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []model.Blackout{
+		{ID: "test-blackout-2", UserID: userID, Label: "test-label-2", StartsAt: time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC), CreatedAt: date, UpdatedAt: date},
+		{ID: "test-blackout-1", UserID: userID, Label: "test-label-1", StartsAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), CreatedAt: date, UpdatedAt: date},
+	}, nil
+}
+
+func (r *stubBlackoutRepository) Create(blackout *model.Blackout) error {
+	return nil
+}
+
+func (r *stubBlackoutRepository) Update(blackout *model.Blackout) error {
+	return nil
+}
+
+func (r *stubBlackoutRepository) Delete(id string) error {
+	return nil
+}
+
+type stubNotFoundBlackoutRepository struct{}
+
+func (r *stubNotFoundBlackoutRepository) Read(id string) (*model.Blackout, error) {
+	return nil, repository.NewNotFoundError()
+}
+
+func (r *stubNotFoundBlackoutRepository) ReadByUserID(userID string) ([]model.Blackout, error) {
+	return []model.Blackout{}, nil
+}
+
+func (r *stubNotFoundBlackoutRepository) Create(blackout *model.Blackout) error {
+	return nil
+}
+
+func (r *stubNotFoundBlackoutRepository) Update(blackout *model.Blackout) error {
+	return repository.NewNotFoundError()
+}
+
+func (r *stubNotFoundBlackoutRepository) Delete(id string) error {
+	return nil
+}
+
+type stubAvailabilityOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubAvailabilityOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubAvailabilityOutputPort) SetResponseGetAvailability(output *port.GetAvailabilityOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubAvailabilityOutputPort) SetResponseUpdateAvailability(output *port.UpdateAvailabilityOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubAvailabilityOutputPort) SetResponseCreateBlackout(output *port.CreateBlackoutOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubAvailabilityOutputPort) SetResponseUpdateBlackout(output *port.UpdateBlackoutOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubAvailabilityOutputPort) SetResponseDeleteBlackout(output *port.DeleteBlackoutOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubSearchScheduleRepository struct {
+	stubScheduleRepository
+}
+
+func (r *stubSearchScheduleRepository) ReadByUserID(userID string) ([]model.Schedule, error) {
+	date1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	date2 := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	schedules := []model.Schedule{
+		{ID: "test-id-1", UserID: "test-user-id", Name: "統計学 第5回", StartsAt: date1, EndsAt: date1, Color: "red", Type: model.ScheduleTypeCustom, Order: 1},
+		{ID: "test-id-2", UserID: "test-user-id", Name: "統計学 第６回", StartsAt: date2, EndsAt: date2, Color: "red", Type: model.ScheduleTypeCustom, Order: 1},
+		{ID: "test-id-3", UserID: "test-user-id", Name: "ﾃﾞｰﾀｻｲｴﾝｽ 第6回", StartsAt: date2, EndsAt: date2, Color: "blue", Type: model.ScheduleTypeCustom, Order: 2, Note: "## 回帰分析\n- 最小二乗法", Memo: "レポート提出", TagIDs: []string{"test-tag-1"}},
+		{ID: "test-id-4", UserID: "test-user-id", Name: "統計学 単位認定試験", StartsAt: future, EndsAt: future, Color: "gray", Type: model.ScheduleTypeMaster, Order: 1},
+	}
+	return schedules, nil
+}
+
+type stubSubjectRepository struct{}
+
+func (r *stubSubjectRepository) ReadByUserID(userID string) ([]model.Subject, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	subjects := []model.Subject{
+		{ID: "test-subject-1", UserID: "test-user-id", Name: "統計学", Color: "red", CreatedAt: date, UpdatedAt: date},
+		{ID: "test-subject-2", UserID: "test-user-id", Name: "データサイエンス", Color: "blue", CreatedAt: date, UpdatedAt: date},
+	}
+	return subjects, nil
+}
+
+func (r *stubSubjectRepository) Create(subject *model.Subject) error {
+	return nil
+}
+
+func (r *stubSubjectRepository) Delete(id string) error {
+	return nil
+}
+
+type stubSearchOutputPort struct {
+	Output *port.SearchOutputData
+	Result port.Result
+}
+
+func (p *stubSearchOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubSearchOutputPort) SetResponseSearch(output *port.SearchOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubTagRepository struct {
+	Updated []model.Tag
+	Deleted []string
+}
+
+func (r *stubTagRepository) Read(id string) (*model.Tag, error) {
+	tags, _ := r.ReadByUserID("test-user-id")
+	for _, t := range tags {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+
+	if id == "test-tag-other" {
+		return &model.Tag{ID: id, UserID: "test-other-user-id", Name: "other", Color: "gray"}, nil
+	}
+
+	return nil, repository.NewNotFoundError()
+}
+
+func (r *stubTagRepository) ReadByUserID(userID string) ([]model.Tag, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	tags := []model.Tag{
+		{ID: "test-tag-2", UserID: "test-user-id", Name: "試験", Color: "red", CreatedAt: date, UpdatedAt: date},
+		{ID: "test-tag-1", UserID: "test-user-id", Name: "レポート", Color: "blue", CreatedAt: date, UpdatedAt: date},
+	}
+	return tags, nil
+}
+
+func (r *stubTagRepository) Create(tag *model.Tag) error {
+	return nil
+}
+
+func (r *stubTagRepository) Update(tag *model.Tag) error {
+	r.Updated = append(r.Updated, *tag)
+	return nil
+}
+
+func (r *stubTagRepository) Delete(id string) error {
+	r.Deleted = append(r.Deleted, id)
+	return nil
+}
+
+type stubTagScheduleRepository struct {
+	stubScheduleRepository
+	Updated []model.Schedule
+}
+
+func (r *stubTagScheduleRepository) Read(id string) (*model.Schedule, error) {
+	schedules, _ := r.ReadByIDs([]string{id})
+	if len(schedules) == 0 {
+		return nil, repository.NewNotFoundError()
+	}
+	return &schedules[0], nil
+}
+
+func (r *stubTagScheduleRepository) ReadByIDs(ids []string) ([]model.Schedule, error) {
+	schedules, _ := r.stubScheduleRepository.ReadByIDs(ids)
+	for i := range schedules {
+		if schedules[i].ID == "test-id-1" {
+			schedules[i].TagIDs = []string{"test-tag-1"}
+		}
+		if schedules[i].ID == "test-id-4" {
+			schedules[i].TagIDs = []string{"test-tag-1", "test-tag-2"}
+		}
+	}
+	return schedules, nil
+}
+
+func (r *stubTagScheduleRepository) Update(schedule *model.Schedule) error {
+	r.Updated = append(r.Updated, *schedule)
+	return nil
+}
+
+type stubTagOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubTagOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubTagOutputPort) SetResponseGetTagList(output *port.GetTagListOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTagOutputPort) SetResponseCreateTag(output *port.CreateTagOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTagOutputPort) SetResponseUpdateTag(output *port.UpdateTagOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTagOutputPort) SetResponseDeleteTag(output *port.DeleteTagOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTagOutputPort) SetResponseMergeTag(output *port.MergeTagOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTagOutputPort) SetResponseAssignTag(output *port.AssignTagOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubCustomColorRepository struct {
+	Count   int
+	Created []model.CustomColor
+	Deleted []string
+}
+
+func (r *stubCustomColorRepository) Read(id string) (*model.CustomColor, error) {
+	customColors, _ := r.ReadByUserID("test-user-id")
+	for _, cc := range customColors {
+		if cc.ID == id {
+			return &cc, nil
+		}
+	}
+	return nil, repository.NewNotFoundError()
+}
+
+func (r *stubCustomColorRepository) ReadByUserID(userID string) ([]model.CustomColor, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	customColors := []model.CustomColor{
+		{ID: "test-color-1", UserID: "test-user-id", Name: "藤色", Hex: "#a78bfa", Style: model.ColorStyleFill, CreatedAt: date, UpdatedAt: date},
+		{ID: "test-color-2", UserID: "test-user-id", Name: "若草色", Hex: "#84cc16", Style: model.ColorStyleOutline, CreatedAt: date, UpdatedAt: date},
+	}
+	for i := len(customColors); i < r.Count; i++ {
+		customColors = append(customColors, model.CustomColor{ID: fmt.Sprintf("test-color-%d", i+1), UserID: "test-user-id", CreatedAt: date, UpdatedAt: date})
+	}
+	return customColors, nil
+}
+
+func (r *stubCustomColorRepository) Create(customColor *model.CustomColor) error {
+	r.Created = append(r.Created, *customColor)
+	return nil
+}
+
+func (r *stubCustomColorRepository) Delete(id string) error {
+	r.Deleted = append(r.Deleted, id)
+	return nil
+}
+
+type stubCustomColorScheduleRepository struct {
+	stubScheduleRepository
+}
+
+func (r *stubCustomColorScheduleRepository) ReadByUserID(userID string) ([]model.Schedule, error) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedules := []model.Schedule{
+		{ID: "test-id-1", UserID: userID, Name: "統計学 第1回", StartsAt: date, EndsAt: date, Color: "custom_test-color-1", Type: model.ScheduleTypeCustom, Order: 1},
+	}
+	return schedules, nil
+}
+
+type stubPaletteOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubPaletteOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubPaletteOutputPort) SetResponseGetPalette(output *port.GetPaletteOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPaletteOutputPort) SetResponseCreateCustomColor(output *port.CreateCustomColorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPaletteOutputPort) SetResponseDeleteCustomColor(output *port.DeleteCustomColorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubTwoFactorRepository struct {
+	TwoFactors map[string]model.TwoFactor
+}
+
+func (r *stubTwoFactorRepository) Read(userID string) (*model.TwoFactor, error) {
+	tf, ok := r.TwoFactors[userID]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	tf.RecoveryCodes = append([]string{}, tf.RecoveryCodes...)
+	return &tf, nil
+}
+
+func (r *stubTwoFactorRepository) Create(twoFactor *model.TwoFactor) error {
+	if r.TwoFactors == nil {
+		r.TwoFactors = map[string]model.TwoFactor{}
+	}
+	if r.TwoFactors[twoFactor.UserID].Enabled {
+		return repository.ErrTwoFactorAlreadyEnabled
+	}
+	r.TwoFactors[twoFactor.UserID] = *twoFactor
+	return nil
+}
+
+func (r *stubTwoFactorRepository) Activate(twoFactor *model.TwoFactor) error {
+	tf, ok := r.TwoFactors[twoFactor.UserID]
+	if !ok || tf.Secret != twoFactor.Secret || tf.Enabled {
+		return repository.ErrTwoFactorCodeUsed
+	}
+	r.TwoFactors[twoFactor.UserID] = *twoFactor
+	return nil
+}
+
+func (r *stubTwoFactorRepository) UseStep(userID string, step int64, now time.Time) error {
+	tf, ok := r.TwoFactors[userID]
+	if !ok || !tf.Enabled || tf.LastUsedStep >= step {
+		return repository.ErrTwoFactorCodeUsed
+	}
+	tf.LastUsedStep = step
+	tf.UpdatedAt = now
+	r.TwoFactors[userID] = tf
+	return nil
+}
+
+func (r *stubTwoFactorRepository) UseRecoveryCode(twoFactor *model.TwoFactor, index int, now time.Time) error {
+	tf, ok := r.TwoFactors[twoFactor.UserID]
+	if !ok || !tf.Enabled || index >= len(tf.RecoveryCodes) || tf.RecoveryCodes[index] != twoFactor.RecoveryCodes[index] {
+		return repository.ErrTwoFactorCodeUsed
+	}
+	tf.RecoveryCodes = append(tf.RecoveryCodes[:index:index], tf.RecoveryCodes[index+1:]...)
+	tf.UpdatedAt = now
+	r.TwoFactors[twoFactor.UserID] = tf
+	twoFactor.RecoveryCodes = append([]string{}, tf.RecoveryCodes...)
+	return nil
+}
+
+func (r *stubTwoFactorRepository) Delete(userID string) error {
+	delete(r.TwoFactors, userID)
+	return nil
+}
+
+type stubTwoFactorOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubTwoFactorOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubTwoFactorOutputPort) SetResponseGetTwoFactor(output *port.GetTwoFactorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTwoFactorOutputPort) SetResponseEnrollTwoFactor(output *port.EnrollTwoFactorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTwoFactorOutputPort) SetResponseActivateTwoFactor(output *port.ActivateTwoFactorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTwoFactorOutputPort) SetResponseResetTwoFactor(output *port.ResetTwoFactorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubTwoFactorOutputPort) SetResponseVerifyTwoFactor(output *port.VerifyTwoFactorOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubPasskeyRepository struct {
+	Passkeys map[string]model.Passkey
+}
+
+func (r *stubPasskeyRepository) Read(id string) (*model.Passkey, error) {
+	p, ok := r.Passkeys[id]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	return &p, nil
+}
+
+func (r *stubPasskeyRepository) ReadByUserID(userID string) ([]model.Passkey, error) {
+	passkeys := []model.Passkey{}
+	for _, p := range r.Passkeys {
+		if p.UserID == userID {
+			passkeys = append(passkeys, p)
+		}
+	}
+	return passkeys, nil
+}
+
+func (r *stubPasskeyRepository) Create(passkey *model.Passkey) error {
+	if r.Passkeys == nil {
+		r.Passkeys = map[string]model.Passkey{}
+	}
+	if _, ok := r.Passkeys[passkey.ID]; ok {
+		return repository.ErrPasskeyAlreadyRegistered
+	}
+	r.Passkeys[passkey.ID] = *passkey
+	return nil
+}
+
+func (r *stubPasskeyRepository) UpdateSignCount(passkey *model.Passkey, signCount int64, now time.Time) error {
+	p, ok := r.Passkeys[passkey.ID]
+	if !ok || p.SignCount != passkey.SignCount {
+		return repository.ErrPasskeySignCountConflict
+	}
+	p.SignCount = signCount
+	p.LastUsedAt = now
+	r.Passkeys[passkey.ID] = p
+	passkey.SignCount = signCount
+	passkey.LastUsedAt = now
+	return nil
+}
+
+func (r *stubPasskeyRepository) Delete(id string) error {
+	delete(r.Passkeys, id)
+	return nil
+}
+
+type stubWebAuthnChallengeRepository struct {
+	Challenges map[string]model.WebAuthnChallenge
+}
+
+func (r *stubWebAuthnChallengeRepository) Create(challenge *model.WebAuthnChallenge) error {
+	if r.Challenges == nil {
+		r.Challenges = map[string]model.WebAuthnChallenge{}
+	}
+	r.Challenges[challenge.Challenge] = *challenge
+	return nil
+}
+
+func (r *stubWebAuthnChallengeRepository) Consume(challenge string) (*model.WebAuthnChallenge, error) {
+	c, ok := r.Challenges[challenge]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	delete(r.Challenges, challenge)
+	return &c, nil
+}
+
+type stubPasskeyOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubPasskeyOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubPasskeyOutputPort) SetResponseBeginPasskeyRegistration(output *port.BeginPasskeyRegistrationOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPasskeyOutputPort) SetResponseFinishPasskeyRegistration(output *port.FinishPasskeyRegistrationOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPasskeyOutputPort) SetResponseGetPasskeyList(output *port.GetPasskeyListOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPasskeyOutputPort) SetResponseDeletePasskey(output *port.DeletePasskeyOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPasskeyOutputPort) SetResponseBeginPasskeySignIn(output *port.BeginPasskeySignInOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubPasskeyOutputPort) SetResponseFinishPasskeySignIn(output *port.FinishPasskeySignInOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+type stubOIDCStateRepository struct {
+	States map[string]model.OIDCState
+}
+
+func (r *stubOIDCStateRepository) Create(state *model.OIDCState) error {
+	if r.States == nil {
+		r.States = map[string]model.OIDCState{}
+	}
+	r.States[state.State] = *state
+	return nil
+}
+
+func (r *stubOIDCStateRepository) Consume(state string) (*model.OIDCState, error) {
+	s, ok := r.States[state]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	delete(r.States, state)
+	return &s, nil
+}
+
+type stubOIDCIdentityRepository struct {
+	Identities map[string]model.OIDCIdentity
+}
+
+func (r *stubOIDCIdentityRepository) Read(id string) (*model.OIDCIdentity, error) {
+	identity, ok := r.Identities[id]
+	if !ok {
+		return nil, repository.NewNotFoundError()
+	}
+	return &identity, nil
+}
+
+func (r *stubOIDCIdentityRepository) Create(identity *model.OIDCIdentity) error {
+	if r.Identities == nil {
+		r.Identities = map[string]model.OIDCIdentity{}
+	}
+	if _, ok := r.Identities[identity.ID]; ok {
+		return repository.ErrOIDCIdentityAlreadyLinked
+	}
+	r.Identities[identity.ID] = *identity
+	return nil
+}
+
+func (r *stubOIDCIdentityRepository) Update(identity *model.OIDCIdentity) error {
+	r.Identities[identity.ID] = *identity
+	return nil
+}
+
+func (r *stubOIDCIdentityRepository) Delete(id string) error {
+	delete(r.Identities, id)
+	return nil
+}
+
+type stubOIDCOutputPort struct {
+	Output interface{}
+	Result port.Result
+}
+
+func (p *stubOIDCOutputPort) GetResponse() (int, string) {
+	return p.Result.StatusCode, p.Result.ErrorMessage
+}
+
+func (p *stubOIDCOutputPort) SetResponseGetOIDCProviderList(output *port.GetOIDCProviderListOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubOIDCOutputPort) SetResponseBeginOIDCSignIn(output *port.BeginOIDCSignInOutputData, result port.Result) {
+	p.Output = output
+	p.Result = result
+}
+
+func (p *stubOIDCOutputPort) SetResponseFinishOIDCSignIn(output *port.SignInOutputData, result port.Result) {
 	p.Output = output
 	p.Result = result
 }
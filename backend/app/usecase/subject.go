@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/model"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/datsukan/attendance-plan/backend/app/repository"
@@ -14,15 +15,19 @@ import (
 // SubjectInteractor は科目のユースケースの実装を表す構造体です。
 type SubjectInteractor struct {
 	Logger            *slog.Logger
+	Zone              timezone.Zone
 	SubjectRepository repository.SubjectRepository
+	ColorValidator    ColorValidator
 	OutputPort        port.SubjectOutputPort
 }
 
 // NewSubjectInteractor はSubjectInteractor を生成します。
-func NewSubjectInteractor(logger *slog.Logger, subjectRepository repository.SubjectRepository, outputPort port.SubjectOutputPort) port.SubjectInputPort {
+func NewSubjectInteractor(logger *slog.Logger, zone timezone.Zone, subjectRepository repository.SubjectRepository, colorValidator ColorValidator, outputPort port.SubjectOutputPort) port.SubjectInputPort {
 	return &SubjectInteractor{
 		Logger:            logger,
+		Zone:              zone,
 		SubjectRepository: subjectRepository,
+		ColorValidator:    colorValidator,
 		OutputPort:        outputPort,
 	}
 }
@@ -32,7 +37,7 @@ func (i *SubjectInteractor) GetSubjectList(inputData port.GetSubjectListInputDat
 	subjects, err := i.SubjectRepository.ReadByUserID(inputData.UserID)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseGetSubjectList(nil, r)
 		return
 	}
@@ -44,8 +49,8 @@ func (i *SubjectInteractor) GetSubjectList(inputData port.GetSubjectListInputDat
 			UserID:    subject.UserID,
 			Name:      subject.Name,
 			Color:     subject.Color,
-			CreatedAt: subject.CreatedAt.Format(time.DateTime),
-			UpdatedAt: subject.UpdatedAt.Format(time.DateTime),
+			CreatedAt: i.Zone.FormatDateTime(subject.CreatedAt),
+			UpdatedAt: i.Zone.FormatDateTime(subject.UpdatedAt),
 		})
 	}
 
@@ -67,9 +72,30 @@ func (i *SubjectInteractor) CreateSubject(inputData port.CreateSubjectInputData)
 
 	i.Logger.With("subject_id", s.ID)
 
+	if err := s.Validate(); err != nil {
+		i.Logger.Warn(err.Error())
+		r := validationErrorResult(err)
+		i.OutputPort.SetResponseCreateSubject(nil, r)
+		return
+	}
+
+	if ok, err := i.ColorValidator.Validate(s.UserID, s.Color); err != nil || !ok {
+		if err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseCreateSubject(nil, r)
+			return
+		}
+
+		i.Logger.Warn("color not found")
+		r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeColorNotFound, MsgColorNotFound)
+		i.OutputPort.SetResponseCreateSubject(nil, r)
+		return
+	}
+
 	if err := i.SubjectRepository.Create(s); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseCreateSubject(nil, r)
 		return
 	}
@@ -80,8 +106,8 @@ func (i *SubjectInteractor) CreateSubject(inputData port.CreateSubjectInputData)
 			UserID:    s.UserID,
 			Name:      s.Name,
 			Color:     s.Color,
-			CreatedAt: s.CreatedAt.Format(time.DateTime),
-			UpdatedAt: s.UpdatedAt.Format(time.DateTime),
+			CreatedAt: i.Zone.FormatDateTime(s.CreatedAt),
+			UpdatedAt: i.Zone.FormatDateTime(s.UpdatedAt),
 		},
 	}
 	r := port.NewSuccessResult(http.StatusCreated)
@@ -94,7 +120,7 @@ func (i *SubjectInteractor) DeleteSubject(inputData port.DeleteSubjectInputData)
 
 	if err := i.SubjectRepository.Delete(inputData.SubjectID); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseDeleteSubject(nil, r)
 		return
 	}
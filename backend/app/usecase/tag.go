@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+)
+
+// TagInteractor はタグのユースケースの実装を表す構造体です。
+type TagInteractor struct {
+	Logger             *slog.Logger
+	Zone               timezone.Zone
+	TagRepository      repository.TagRepository
+	ScheduleRepository repository.ScheduleRepository
+	Tagger             ScheduleTagger
+	OutputPort         port.TagOutputPort
+}
+
+// NewTagInteractor は TagInteractor を生成します。
+func NewTagInteractor(logger *slog.Logger, zone timezone.Zone, tagRepository repository.TagRepository, scheduleRepository repository.ScheduleRepository, tagger ScheduleTagger, outputPort port.TagOutputPort) port.TagInputPort {
+	return &TagInteractor{
+		Logger:             logger,
+		Zone:               zone,
+		TagRepository:      tagRepository,
+		ScheduleRepository: scheduleRepository,
+		Tagger:             tagger,
+		OutputPort:         outputPort,
+	}
+}
+
+// GetTagList はユーザーのタグリストを取得します。
+func (i *TagInteractor) GetTagList(input port.GetTagListInputData) {
+	tags, err := i.TagRepository.ReadByUserID(input.UserID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetTagList(nil, r)
+		return
+	}
+
+	tl := model.TagList(tags)
+	tl.Sort()
+
+	o := &port.GetTagListOutputData{Tags: make([]port.BaseTagData, 0, len(tl))}
+	for _, t := range tl {
+		o.Tags = append(o.Tags, toBaseTagData(i.Zone, t))
+	}
+
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetTagList(o, r)
+}
+
+// CreateTag はタグを作成します。
+func (i *TagInteractor) CreateTag(input port.CreateTagInputData) {
+	duplicated, err := i.isNameDuplicated(input.UserID, input.Name, "")
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseCreateTag(nil, r)
+		return
+	}
+
+	if duplicated {
+		i.Logger.Warn("tag name duplicated", "name", input.Name)
+		r := port.NewErrorResult(http.StatusConflict, port.ErrorCodeTagNameDuplicated, MsgTagNameDuplicated)
+		i.OutputPort.SetResponseCreateTag(nil, r)
+		return
+	}
+
+	t := &model.Tag{
+		ID:        id.NewID(),
+		UserID:    input.UserID,
+		Name:      input.Name,
+		Color:     input.Color,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	i.Logger.With("tag_id", t.ID)
+
+	if err := i.TagRepository.Create(t); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseCreateTag(nil, r)
+		return
+	}
+
+	o := &port.CreateTagOutputData{Tag: toBaseTagData(i.Zone, *t)}
+	r := port.NewSuccessResult(http.StatusCreated)
+	i.OutputPort.SetResponseCreateTag(o, r)
+}
+
+// UpdateTag はタグの名前と色を更新します。
+// スケジュールはタグ ID で紐づいているため、名前を変更してもスケジュールの更新は不要です。
+func (i *TagInteractor) UpdateTag(input port.UpdateTagInputData) {
+	i.Logger.With("tag_id", input.ID)
+
+	bt, result := i.readTag(input.ID)
+	if bt == nil {
+		i.OutputPort.SetResponseUpdateTag(nil, result)
+		return
+	}
+
+	duplicated, err := i.isNameDuplicated(bt.UserID, input.Name, bt.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateTag(nil, r)
+		return
+	}
+
+	if duplicated {
+		i.Logger.Warn("tag name duplicated", "name", input.Name)
+		r := port.NewErrorResult(http.StatusConflict, port.ErrorCodeTagNameDuplicated, MsgTagNameDuplicated)
+		i.OutputPort.SetResponseUpdateTag(nil, r)
+		return
+	}
+
+	t := &model.Tag{
+		ID:        bt.ID,
+		UserID:    bt.UserID,
+		Name:      input.Name,
+		Color:     input.Color,
+		CreatedAt: bt.CreatedAt,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := i.TagRepository.Update(t); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUpdateTag(nil, r)
+		return
+	}
+
+	o := &port.UpdateTagOutputData{Tag: toBaseTagData(i.Zone, *t)}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseUpdateTag(o, r)
+}
+
+// DeleteTag はタグを削除し、タグが付いていたスケジュールからも外します。
+func (i *TagInteractor) DeleteTag(input port.DeleteTagInputData) {
+	i.Logger.With("tag_id", input.TagID)
+
+	if _, err := i.retag(input.TagID, ""); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeleteTag(nil, r)
+		return
+	}
+
+	if err := i.TagRepository.Delete(input.TagID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseDeleteTag(nil, r)
+		return
+	}
+
+	o := &port.DeleteTagOutputData{}
+	r := port.NewSuccessResult(http.StatusNoContent)
+	i.OutputPort.SetResponseDeleteTag(o, r)
+}
+
+// MergeTag は統合元のタグが付いたスケジュールに統合先のタグを付け、統合元のタグを削除します。
+func (i *TagInteractor) MergeTag(input port.MergeTagInputData) {
+	i.Logger.With("tag_id", input.SourceID)
+
+	source, result := i.readTag(input.SourceID)
+	if source == nil {
+		i.OutputPort.SetResponseMergeTag(nil, result)
+		return
+	}
+
+	target, result := i.readTag(input.TargetID)
+	if target == nil {
+		i.OutputPort.SetResponseMergeTag(nil, result)
+		return
+	}
+
+	// 他のユーザーのタグには統合できない
+	if target.UserID != source.UserID {
+		i.Logger.Warn("merge target tag belongs to another user", "target_id", target.ID)
+		r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeTagNotFound, MsgTagNotFound)
+		i.OutputPort.SetResponseMergeTag(nil, r)
+		return
+	}
+
+	scheduleIDs, err := i.retag(source.ID, target.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseMergeTag(nil, r)
+		return
+	}
+
+	if err := i.TagRepository.Delete(source.ID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseMergeTag(nil, r)
+		return
+	}
+
+	o := &port.MergeTagOutputData{Tag: toBaseTagData(i.Zone, *target), ScheduleIDs: scheduleIDs}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseMergeTag(o, r)
+}
+
+// AssignTag は指定されたスケジュールにまとめてタグを付けたり外したりします。
+// 指定されたスケジュールがすべてタグの所有者のものであることを確認してから変更します。
+func (i *TagInteractor) AssignTag(input port.AssignTagInputData) {
+	i.Logger.With("tag_id", input.TagID)
+
+	tag, result := i.readTag(input.TagID)
+	if tag == nil {
+		i.OutputPort.SetResponseAssignTag(nil, result)
+		return
+	}
+
+	type change struct {
+		schedule model.Schedule
+		before   []string
+	}
+
+	var changes []change
+	for _, sid := range append(append([]string{}, input.Add...), input.Remove...) {
+		s, err := i.ScheduleRepository.Read(sid)
+		if err != nil {
+			if repository.IsNotFoundError(err) {
+				i.Logger.Warn(err.Error(), "schedule_id", sid)
+				r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeScheduleNotFound, MsgScheduleNotFound)
+				i.OutputPort.SetResponseAssignTag(nil, r)
+				return
+			}
+
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseAssignTag(nil, r)
+			return
+		}
+
+		// 他のユーザーのスケジュールは存在しないものとして扱う
+		if s.UserID != tag.UserID {
+			i.Logger.Warn("schedule belongs to another user", "schedule_id", sid)
+			r := port.NewErrorResult(http.StatusNotFound, port.ErrorCodeScheduleNotFound, MsgScheduleNotFound)
+			i.OutputPort.SetResponseAssignTag(nil, r)
+			return
+		}
+
+		before := slices.Clone(s.TagIDs)
+		var changed bool
+		if slices.Contains(input.Remove, sid) {
+			changed = s.RemoveTag(tag.ID)
+		} else {
+			changed = s.AddTag(tag.ID)
+		}
+
+		if changed {
+			changes = append(changes, change{schedule: *s, before: before})
+		}
+	}
+
+	for _, c := range changes {
+		c.schedule.UpdatedAt = time.Now()
+		if err := i.ScheduleRepository.Update(&c.schedule); err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseAssignTag(nil, r)
+			return
+		}
+
+		if err := i.Tagger.Sync(c.schedule, c.before); err != nil {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseAssignTag(nil, r)
+			return
+		}
+	}
+
+	scheduleIDs, err := i.Tagger.ScheduleIDs(tag.ID)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseAssignTag(nil, r)
+		return
+	}
+
+	o := &port.AssignTagOutputData{TagID: tag.ID, ScheduleIDs: scheduleIDs}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseAssignTag(o, r)
+}
+
+// readTag は指定された ID のタグを取得します。取得できない場合は nil とエラーの結果を返します。
+func (i *TagInteractor) readTag(tagID string) (*model.Tag, port.Result) {
+	t, err := i.TagRepository.Read(tagID)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			i.Logger.Warn(err.Error(), "tag_id", tagID)
+			return nil, port.NewErrorResult(http.StatusNotFound, port.ErrorCodeTagNotFound, MsgTagNotFound)
+		}
+
+		i.Logger.Error(err.Error())
+		return nil, port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+	}
+	return t, port.Result{}
+}
+
+// isNameDuplicated はユーザーのタグに同じ名前のタグがあるかどうかを返します。
+// excludeID のタグは比較の対象に含めません。
+func (i *TagInteractor) isNameDuplicated(userID, name, excludeID string) (bool, error) {
+	tags, err := i.TagRepository.ReadByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	t, ok := model.TagList(tags).FindByName(name)
+	return ok && t.ID != excludeID, nil
+}
+
+// retag は sourceID のタグが付いたスケジュールからタグを外し、targetID が空でなければ targetID のタグを付けます。
+// 変更したスケジュールの ID を返します。
+func (i *TagInteractor) retag(sourceID, targetID string) ([]string, error) {
+	scheduleIDs, err := i.Tagger.ScheduleIDs(sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := i.ScheduleRepository.ReadByIDs(scheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(schedules))
+	for _, s := range schedules {
+		before := slices.Clone(s.TagIDs)
+		s.RemoveTag(sourceID)
+		if targetID != "" {
+			s.AddTag(targetID)
+		}
+		s.UpdatedAt = time.Now()
+
+		if err := i.ScheduleRepository.Update(&s); err != nil {
+			return nil, err
+		}
+
+		if err := i.Tagger.Sync(s, before); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, s.ID)
+	}
+
+	// スケジュールが削除済みで残っている対応も削除する
+	for _, sid := range scheduleIDs {
+		if !slices.Contains(ids, sid) {
+			if err := i.Tagger.Sync(model.Schedule{ID: sid}, []string{sourceID}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// toBaseTagData はタグを出力データに変換します。
+func toBaseTagData(zone timezone.Zone, t model.Tag) port.BaseTagData {
+	return port.BaseTagData{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Name:      t.Name,
+		Color:     t.Color,
+		CreatedAt: zone.FormatDateTime(t.CreatedAt),
+		UpdatedAt: zone.FormatDateTime(t.UpdatedAt),
+	}
+}
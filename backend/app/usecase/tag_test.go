@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTagList(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	p := &stubTagOutputPort{}
+	i := NewTagInteractor(l, timezone.UTC(), &stubTagRepository{}, &stubTagScheduleRepository{}, &stubScheduleTagger{}, p)
+
+	i.GetTagList(port.GetTagListInputData{UserID: "test-user-id"})
+
+	output, ok := p.Output.(*port.GetTagListOutputData)
+	require.True(ok)
+
+	// タグは名前の昇順で返される
+	assert.Equal(http.StatusOK, p.Result.StatusCode)
+	require.Len(output.Tags, 2)
+	assert.Equal("レポート", output.Tags[0].Name)
+	assert.Equal("試験", output.Tags[1].Name)
+}
+
+func TestCreateTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		tagName    string
+		wantStatus int
+	}{
+		{name: "タグを作成する", tagName: "課題", wantStatus: http.StatusCreated},
+		{name: "同じ名前のタグがある場合は作成しない", tagName: "試験", wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			p := &stubTagOutputPort{}
+			i := NewTagInteractor(l, timezone.UTC(), &stubTagRepository{}, &stubTagScheduleRepository{}, &stubScheduleTagger{}, p)
+
+			i.CreateTag(port.CreateTagInputData{UserID: "test-user-id", Name: tt.tagName, Color: "green"})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+		})
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		tagID      string
+		tagName    string
+		wantStatus int
+	}{
+		{name: "タグの名前を変更する", tagID: "test-tag-1", tagName: "課題", wantStatus: http.StatusOK},
+		{name: "名前を変えずに色のみ変更する", tagID: "test-tag-1", tagName: "レポート", wantStatus: http.StatusOK},
+		{name: "他のタグと同じ名前には変更しない", tagID: "test-tag-1", tagName: "試験", wantStatus: http.StatusConflict},
+		{name: "存在しないタグ", tagID: "test-tag-x", tagName: "課題", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			r := &stubTagRepository{}
+			p := &stubTagOutputPort{}
+			i := NewTagInteractor(l, timezone.UTC(), r, &stubTagScheduleRepository{}, &stubScheduleTagger{}, p)
+
+			i.UpdateTag(port.UpdateTagInputData{ID: tt.tagID, Name: tt.tagName, Color: "green"})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			if tt.wantStatus == http.StatusOK {
+				assert.Len(r.Updated, 1)
+			} else {
+				assert.Empty(r.Updated)
+			}
+		})
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	tr := &stubTagRepository{}
+	sr := &stubTagScheduleRepository{}
+	tagger := &stubScheduleTagger{}
+	p := &stubTagOutputPort{}
+	i := NewTagInteractor(l, timezone.UTC(), tr, sr, tagger, p)
+
+	i.DeleteTag(port.DeleteTagInputData{TagID: "test-tag-1"})
+
+	assert.Equal(http.StatusNoContent, p.Result.StatusCode)
+	assert.Equal([]string{"test-tag-1"}, tr.Deleted)
+
+	// タグが付いていたスケジュールからタグを外す
+	require.Len(sr.Updated, 2)
+	tagIDs := map[string][]string{}
+	for _, s := range sr.Updated {
+		tagIDs[s.ID] = s.TagIDs
+	}
+	assert.Empty(tagIDs["test-id-1"])
+	assert.Equal([]string{"test-tag-2"}, tagIDs["test-id-4"])
+
+	// 削除済みのスケジュールを含め、すべての対応を同期する
+	assert.Len(tagger.Synced, 3)
+}
+
+func TestMergeTag(t *testing.T) {
+	t.Run("統合元のタグが付いたスケジュールに統合先のタグを付ける", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		tr := &stubTagRepository{}
+		sr := &stubTagScheduleRepository{}
+		p := &stubTagOutputPort{}
+		i := NewTagInteractor(l, timezone.UTC(), tr, sr, &stubScheduleTagger{}, p)
+
+		i.MergeTag(port.MergeTagInputData{SourceID: "test-tag-1", TargetID: "test-tag-2"})
+
+		output, ok := p.Output.(*port.MergeTagOutputData)
+		require.True(ok)
+
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Equal("test-tag-2", output.Tag.ID)
+		assert.ElementsMatch([]string{"test-id-1", "test-id-4"}, output.ScheduleIDs)
+		assert.Equal([]string{"test-tag-1"}, tr.Deleted)
+
+		// すでに統合先のタグが付いている場合は重複させない
+		require.Len(sr.Updated, 2)
+		assert.Equal([]string{"test-tag-2"}, sr.Updated[0].TagIDs)
+		assert.Equal([]string{"test-tag-2"}, sr.Updated[1].TagIDs)
+	})
+
+	t.Run("他のユーザーのタグには統合しない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		tr := &stubTagRepository{}
+		sr := &stubTagScheduleRepository{}
+		p := &stubTagOutputPort{}
+		i := NewTagInteractor(l, timezone.UTC(), tr, sr, &stubScheduleTagger{}, p)
+
+		i.MergeTag(port.MergeTagInputData{SourceID: "test-tag-1", TargetID: "test-tag-other"})
+
+		assert.Equal(http.StatusNotFound, p.Result.StatusCode)
+		assert.Empty(tr.Deleted)
+		assert.Empty(sr.Updated)
+	})
+}
+
+func TestAssignTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       port.AssignTagInputData
+		wantStatus  int
+		wantUpdated int
+	}{
+		{name: "タグを付けるスケジュールと外すスケジュールをまとめて変更する", input: port.AssignTagInputData{TagID: "test-tag-1", Add: []string{"test-id-2", "test-id-3"}, Remove: []string{"test-id-4"}}, wantStatus: http.StatusOK, wantUpdated: 3},
+		{name: "すでにタグが付いているスケジュールは変更しない", input: port.AssignTagInputData{TagID: "test-tag-1", Add: []string{"test-id-1"}}, wantStatus: http.StatusOK, wantUpdated: 0},
+		{name: "存在しないスケジュールが含まれる場合は何も変更しない", input: port.AssignTagInputData{TagID: "test-tag-1", Add: []string{"test-id-2", "test-id-x"}}, wantStatus: http.StatusNotFound, wantUpdated: 0},
+		{name: "存在しないタグ", input: port.AssignTagInputData{TagID: "test-tag-x", Add: []string{"test-id-2"}}, wantStatus: http.StatusNotFound, wantUpdated: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			sr := &stubTagScheduleRepository{}
+			p := &stubTagOutputPort{}
+			i := NewTagInteractor(l, timezone.UTC(), &stubTagRepository{}, sr, &stubScheduleTagger{}, p)
+
+			i.AssignTag(tt.input)
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			assert.Len(sr.Updated, tt.wantUpdated)
+		})
+	}
+}
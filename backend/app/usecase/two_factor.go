@@ -0,0 +1,374 @@
+package usecase
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/datsukan/attendance-plan/backend/infrastructure"
+)
+
+// TwoFactorInteractor は二段階認証のユースケースの実装を表す構造体です。
+type TwoFactorInteractor struct {
+	Logger                 *slog.Logger
+	UserRepository         repository.UserRepository
+	SessionRepository      repository.SessionRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	LoginSessionRepository repository.LoginSessionRepository
+	UsedTokenRepository    repository.UsedTokenRepository
+	RateLimitRepository    repository.RateLimitRepository
+	TwoFactorRepository    repository.TwoFactorRepository
+	OutputPort             port.TwoFactorOutputPort
+}
+
+// NewTwoFactorInteractor は TwoFactorInteractor を生成します。
+func NewTwoFactorInteractor(logger *slog.Logger, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, usedTokenRepository repository.UsedTokenRepository, rateLimitRepository repository.RateLimitRepository, twoFactorRepository repository.TwoFactorRepository, outputPort port.TwoFactorOutputPort) port.TwoFactorInputPort {
+	return &TwoFactorInteractor{
+		Logger:                 logger,
+		UserRepository:         userRepository,
+		SessionRepository:      sessionRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		LoginSessionRepository: loginSessionRepository,
+		UsedTokenRepository:    usedTokenRepository,
+		RateLimitRepository:    rateLimitRepository,
+		TwoFactorRepository:    twoFactorRepository,
+		OutputPort:             outputPort,
+	}
+}
+
+// GetTwoFactor は二段階認証が有効かどうかと未使用のリカバリーコードの数を取得します。
+func (i *TwoFactorInteractor) GetTwoFactor(input port.GetTwoFactorInputData) {
+	tf, err := i.TwoFactorRepository.Read(input.UserID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseGetTwoFactor(nil, r)
+		return
+	}
+
+	o := &port.GetTwoFactorOutputData{}
+	if tf != nil && tf.Enabled {
+		o.Enabled = true
+		o.RecoveryCodesRemaining = len(tf.RecoveryCodes)
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseGetTwoFactor(o, r)
+}
+
+// EnrollTwoFactor は新しい秘密鍵を発行して二段階認証の設定を開始します。
+// 認証コードで確認するまでは有効にならず、確認前に再び開始した場合は秘密鍵を発行し直します。
+func (i *TwoFactorInteractor) EnrollTwoFactor(input port.EnrollTwoFactorInputData) {
+	user, err := i.UserRepository.Read(input.UserID, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found", "user_id", input.UserID)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+			i.OutputPort.SetResponseEnrollTwoFactor(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseEnrollTwoFactor(nil, r)
+		return
+	}
+
+	tf, err := model.NewTwoFactor(user.ID, time.Now())
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseEnrollTwoFactor(nil, r)
+		return
+	}
+
+	if err := i.TwoFactorRepository.Create(tf); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorAlreadyEnabled) {
+			i.Logger.Warn("two factor already enabled", "user_id", user.ID)
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeMFAAlreadyEnabled, MsgMFAAlreadyEnabled)
+			i.OutputPort.SetResponseEnrollTwoFactor(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseEnrollTwoFactor(nil, r)
+		return
+	}
+
+	config := infrastructure.GetConfig()
+	o := &port.EnrollTwoFactorOutputData{
+		Secret: tf.Secret,
+		URI:    tf.URI(config.ServiceName, user.Email),
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseEnrollTwoFactor(o, r)
+}
+
+// ActivateTwoFactor は認証アプリの認証コードを確認して二段階認証を有効にし、リカバリーコードを発行します。
+func (i *TwoFactorInteractor) ActivateTwoFactor(input port.ActivateTwoFactorInputData) {
+	tf, err := i.TwoFactorRepository.Read(input.UserID)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("two factor not enrolled", "user_id", input.UserID)
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeMFANotEnrolled, MsgMFANotEnrolled)
+			i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+		return
+	}
+
+	if tf.Enabled {
+		i.Logger.Warn("two factor already enabled", "user_id", input.UserID)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeMFAAlreadyEnabled, MsgMFAAlreadyEnabled)
+		i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+		return
+	}
+
+	now := time.Now()
+	step, ok := tf.Verify(input.Code, now)
+	if !ok {
+		i.Logger.Warn("two factor code is invalid", "user_id", input.UserID)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeMFACodeInvalid, MsgMFACodeInvalid)
+		i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+		return
+	}
+
+	codes, err := tf.Activate(step, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+		return
+	}
+
+	if err := i.TwoFactorRepository.Activate(tf); err != nil {
+		if errors.Is(err, repository.ErrTwoFactorCodeUsed) {
+			i.Logger.Warn("two factor activated concurrently", "user_id", input.UserID)
+			r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeMFAAlreadyEnabled, MsgMFAAlreadyEnabled)
+			i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseActivateTwoFactor(nil, r)
+		return
+	}
+
+	i.Logger.Info("two factor activated", "user_id", input.UserID)
+
+	o := &port.ActivateTwoFactorOutputData{RecoveryCodes: codes}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseActivateTwoFactor(o, r)
+}
+
+// ResetTwoFactor は認証アプリとリカバリーコードを失ったユーザーの二段階認証を無効にします。管理者のみリセットできます。
+// 本人の確認は管理者が別の手段で行い、リセットしたユーザーは次回からパスワードのみでサインインできます。
+func (i *TwoFactorInteractor) ResetTwoFactor(input port.ResetTwoFactorInputData) {
+	requester, err := i.UserRepository.Read(input.RequesterUserID, true)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
+		i.OutputPort.SetResponseResetTwoFactor(nil, r)
+		return
+	}
+
+	config := infrastructure.GetConfig()
+	if !isAdmin(requester.Email, config.AdminEmails) {
+		i.Logger.Warn("forbidden: not admin", "email", requester.Email)
+		r := port.NewErrorResult(http.StatusForbidden, port.ErrorCodeAuthForbidden, MsgUserNotFound)
+		i.OutputPort.SetResponseResetTwoFactor(nil, r)
+		return
+	}
+
+	if err := i.TwoFactorRepository.Delete(input.UserID); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseResetTwoFactor(nil, r)
+		return
+	}
+
+	i.Logger.Info("two factor reset", "user_id", input.UserID, "requester_user_id", requester.ID)
+
+	o := &port.ResetTwoFactorOutputData{}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseResetTwoFactor(o, r)
+}
+
+// VerifyTwoFactor はサインインで返したトークンと認証コードまたはリカバリーコードを確認し、セッションを発行します。
+// 認証コードの失敗はユーザーごとに数え、失敗が続いた場合は次の試行まで待たせ、さらに続いた場合はロックします。
+func (i *TwoFactorInteractor) VerifyTwoFactor(input port.VerifyTwoFactorInputData) {
+	token, err := i.SessionRepository.ParseToken(input.TwoFactorToken, repository.TokenPurposeTwoFactor)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	i.Logger.With("user_id", token.Subject)
+
+	now := time.Now()
+	key := model.RateLimitKey(model.RateLimitKindTwoFactor, token.Subject)
+	retryAfter, err := i.retryAfter(key, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+	if retryAfter > 0 {
+		i.Logger.Warn("too many two factor attempts")
+		r := port.NewErrorResult(http.StatusTooManyRequests, port.ErrorCodeAuthTooManyAttempts, MsgTooManyAttempts).WithRetryAfter(retryAfter)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	user, err := i.UserRepository.Read(token.Subject, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	tf, err := i.TwoFactorRepository.Read(user.ID)
+	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+	if tf == nil || !tf.Enabled {
+		// トークンを発行した後に管理者が二段階認証をリセットした場合は、サインインからやり直してもらう
+		i.Logger.Warn("two factor is not enabled")
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	ok, err := i.useCode(tf, input, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+	if !ok {
+		i.Logger.Warn("two factor code is invalid")
+		if err := i.recordFailure(key, now); err != nil {
+			i.Logger.Error(err.Error())
+		}
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeMFACodeInvalid, MsgMFACodeInvalid)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	// 同じトークンで別のセッションを発行できないよう、サインインに成功したトークンは使用済みにする
+	if err := i.UsedTokenRepository.Consume(token); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	if err := i.RateLimitRepository.Delete(key); err != nil {
+		i.Logger.Error(err.Error())
+	}
+
+	tokens, err := startLoginSession(i.SessionRepository, i.RefreshTokenRepository, i.LoginSessionRepository, user, input.UserAgent, input.IPAddress, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseVerifyTwoFactor(nil, r)
+		return
+	}
+
+	o := &port.VerifyTwoFactorOutputData{
+		BaseUserData:     toBaseUserData(user),
+		SessionTokenData: *tokens,
+	}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseVerifyTwoFactor(o, r)
+}
+
+// useCode は認証コードまたはリカバリーコードを確認し、正しい場合は使用済みにします。
+// 使用済みのコードは正しくないコードとして扱います。
+func (i *TwoFactorInteractor) useCode(tf *model.TwoFactor, input port.VerifyTwoFactorInputData, now time.Time) (bool, error) {
+	var err error
+	if input.RecoveryCode != "" {
+		index := tf.RecoveryCodeIndex(input.RecoveryCode)
+		if index < 0 {
+			return false, nil
+		}
+		err = i.TwoFactorRepository.UseRecoveryCode(tf, index, now)
+		if err == nil {
+			i.Logger.Info("recovery code used", "remaining", len(tf.RecoveryCodes))
+		}
+	} else {
+		step, ok := tf.Verify(input.Code, now)
+		if !ok {
+			return false, nil
+		}
+		err = i.TwoFactorRepository.UseStep(tf.UserID, step, now)
+	}
+
+	if err != nil {
+		if errors.Is(err, repository.ErrTwoFactorCodeUsed) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// retryAfter は now の時点で認証コードを試行できるまでの時間を返します。すぐに試行できる場合は 0 を返します。
+func (i *TwoFactorInteractor) retryAfter(key string, now time.Time) (time.Duration, error) {
+	rl, err := i.RateLimitRepository.Read(key, now)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return rl.RetryAfter(model.TwoFactorPolicy, now), nil
+}
+
+// recordFailure は認証コードの失敗を数え、ロックする回数に達した場合はロックします。
+func (i *TwoFactorInteractor) recordFailure(key string, now time.Time) error {
+	policy := model.TwoFactorPolicy
+	rl, err := i.RateLimitRepository.AddFailure(key, policy.Window, now)
+	if err != nil {
+		return err
+	}
+	if !rl.ShouldLock(policy) {
+		return nil
+	}
+
+	i.Logger.Warn("two factor locked")
+	return i.RateLimitRepository.Lock(key, now.Add(policy.LockDuration), now)
+}
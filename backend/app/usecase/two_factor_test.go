@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEnabledTwoFactor は有効な二段階認証の設定とリカバリーコードを返します。現在の認証コードを使えるよう、LastUsedStep は前後の間隔より前にします。
+func newEnabledTwoFactor(t *testing.T, userID string) (model.TwoFactor, []string) {
+	t.Helper()
+
+	tf, err := model.NewTwoFactor(userID, time.Now())
+	require.NoError(t, err)
+	codes, err := tf.Activate(time.Now().Unix()/30-2, time.Now())
+	require.NoError(t, err)
+
+	return *tf, codes
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	t.Run("秘密鍵を発行して otpauth URI を返す", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		tfr := &stubTwoFactorRepository{}
+		p := &stubTwoFactorOutputPort{}
+		i := NewTwoFactorInteractor(l, &stubUserRepository{}, nil, nil, nil, nil, nil, tfr, p)
+
+		i.EnrollTwoFactor(port.EnrollTwoFactorInputData{UserID: "test-id"})
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		output, ok := p.Output.(*port.EnrollTwoFactorOutputData)
+		require.True(ok)
+		assert.True(strings.HasPrefix(output.URI, "otpauth://totp/"))
+		assert.Contains(output.URI, "secret="+output.Secret)
+
+		tf := tfr.TwoFactors["test-id"]
+		assert.Equal(output.Secret, tf.Secret)
+		assert.False(tf.Enabled)
+	})
+
+	t.Run("二段階認証が有効な場合は設定を開始できない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		enabled, _ := newEnabledTwoFactor(t, "test-id")
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": enabled}}
+		p := &stubTwoFactorOutputPort{}
+		i := NewTwoFactorInteractor(l, &stubUserRepository{}, nil, nil, nil, nil, nil, tfr, p)
+
+		i.EnrollTwoFactor(port.EnrollTwoFactorInputData{UserID: "test-id"})
+		assert.Equal(http.StatusBadRequest, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeMFAAlreadyEnabled, p.Result.ErrorCode)
+		assert.Equal(enabled.Secret, tfr.TwoFactors["test-id"].Secret)
+	})
+}
+
+func TestActivateTwoFactor(t *testing.T) {
+	t.Run("認証コードを確認して有効にし、リカバリーコードを返す", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		tf, err := model.NewTwoFactor("test-id", time.Now())
+		require.NoError(err)
+		code, err := tf.Code(time.Now())
+		require.NoError(err)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": *tf}}
+		p := &stubTwoFactorOutputPort{}
+		i := NewTwoFactorInteractor(l, nil, nil, nil, nil, nil, nil, tfr, p)
+
+		i.ActivateTwoFactor(port.ActivateTwoFactorInputData{UserID: "test-id", Code: code})
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		output, ok := p.Output.(*port.ActivateTwoFactorOutputData)
+		require.True(ok)
+		assert.Len(output.RecoveryCodes, model.RecoveryCodeCount)
+
+		saved := tfr.TwoFactors["test-id"]
+		assert.True(saved.Enabled)
+		assert.Len(saved.RecoveryCodes, model.RecoveryCodeCount)
+		assert.NotContains(saved.RecoveryCodes, output.RecoveryCodes[0])
+	})
+
+	tests := []struct {
+		name          string
+		twoFactors    map[string]model.TwoFactor
+		code          string
+		wantErrorCode port.ErrorCode
+	}{
+		{
+			name:          "設定を開始していない場合は有効にできない",
+			twoFactors:    map[string]model.TwoFactor{},
+			code:          "000000",
+			wantErrorCode: port.ErrorCodeMFANotEnrolled,
+		},
+		{
+			name:          "認証コードが正しくない場合は有効にできない",
+			twoFactors:    map[string]model.TwoFactor{"test-id": {UserID: "test-id", Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"}},
+			code:          "abcdef",
+			wantErrorCode: port.ErrorCodeMFACodeInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			tfr := &stubTwoFactorRepository{TwoFactors: tt.twoFactors}
+			p := &stubTwoFactorOutputPort{}
+			i := NewTwoFactorInteractor(l, nil, nil, nil, nil, nil, nil, tfr, p)
+
+			i.ActivateTwoFactor(port.ActivateTwoFactorInputData{UserID: "test-id", Code: tt.code})
+			assert.Equal(http.StatusBadRequest, p.Result.StatusCode)
+			assert.Equal(tt.wantErrorCode, p.Result.ErrorCode)
+			assert.False(tfr.TwoFactors["test-id"].Enabled)
+		})
+	}
+}
+
+func TestResetTwoFactor(t *testing.T) {
+	t.Run("管理者以外はリセットできない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		enabled, _ := newEnabledTwoFactor(t, "test-other-id")
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-other-id": enabled}}
+		p := &stubTwoFactorOutputPort{}
+		i := NewTwoFactorInteractor(l, &stubUserRepository{}, nil, nil, nil, nil, nil, tfr, p)
+
+		i.ResetTwoFactor(port.ResetTwoFactorInputData{RequesterUserID: "test-id", UserID: "test-other-id"})
+		assert.Equal(http.StatusForbidden, p.Result.StatusCode)
+		assert.Contains(tfr.TwoFactors, "test-other-id")
+	})
+}
+
+func TestVerifyTwoFactor(t *testing.T) {
+	newInteractor := func(tfr *stubTwoFactorRepository, rlr *stubRateLimitRepository, p *stubTwoFactorOutputPort) port.TwoFactorInputPort {
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		return NewTwoFactorInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, &stubRefreshTokenRepository{}, &stubLoginSessionRepository{}, &stubUsedTokenRepository{}, rlr, tfr, p)
+	}
+
+	t.Run("認証コードでサインインし、同じ認証コードは再利用できない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		enabled, _ := newEnabledTwoFactor(t, "test-id")
+		code, err := enabled.Code(time.Now())
+		require.NoError(err)
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": enabled}}
+		rlr := &stubRateLimitRepository{}
+
+		p := &stubTwoFactorOutputPort{}
+		newInteractor(tfr, rlr, p).VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token-1", Code: code})
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		output, ok := p.Output.(*port.VerifyTwoFactorOutputData)
+		require.True(ok)
+		assert.Equal("test-id", output.ID)
+		assert.Equal("test-token", output.SessionToken)
+		assert.NotEmpty(output.RefreshToken)
+
+		p = &stubTwoFactorOutputPort{}
+		newInteractor(tfr, rlr, p).VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token-2", Code: code})
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeMFACodeInvalid, p.Result.ErrorCode)
+	})
+
+	t.Run("リカバリーコードでサインインし、同じリカバリーコードは再利用できない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		enabled, codes := newEnabledTwoFactor(t, "test-id")
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": enabled}}
+		rlr := &stubRateLimitRepository{}
+
+		p := &stubTwoFactorOutputPort{}
+		newInteractor(tfr, rlr, p).VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token-1", RecoveryCode: strings.ToUpper(codes[2])})
+		require.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.Len(tfr.TwoFactors["test-id"].RecoveryCodes, model.RecoveryCodeCount-1)
+
+		p = &stubTwoFactorOutputPort{}
+		newInteractor(tfr, rlr, p).VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token-2", RecoveryCode: codes[2]})
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeMFACodeInvalid, p.Result.ErrorCode)
+	})
+
+	t.Run("サインインに成功したトークンは再利用できない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		enabled, codes := newEnabledTwoFactor(t, "test-id")
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": enabled}}
+		rlr := &stubRateLimitRepository{}
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubTwoFactorOutputPort{}
+		i := NewTwoFactorInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, &stubRefreshTokenRepository{}, &stubLoginSessionRepository{}, &stubUsedTokenRepository{}, rlr, tfr, p)
+
+		i.VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token", RecoveryCode: codes[0]})
+		require.Equal(http.StatusOK, p.Result.StatusCode)
+
+		i.VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token", RecoveryCode: codes[1]})
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTokenInvalid, p.Result.ErrorCode)
+	})
+
+	t.Run("認証コードの失敗が続いた場合はロックし、正しい認証コードも受け付けない", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		enabled, _ := newEnabledTwoFactor(t, "test-id")
+		code, err := enabled.Code(time.Now())
+		require.NoError(err)
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": enabled}}
+		key := model.RateLimitKey(model.RateLimitKindTwoFactor, "test-user-id")
+		rlr := &stubRateLimitRepository{Limits: map[string]model.RateLimit{
+			key: {Key: key, Failures: model.TwoFactorPolicy.LockAfter - 1, LastFailureAt: time.Now().Add(-time.Minute), TTL: time.Now().Add(time.Hour).Unix()},
+		}}
+
+		p := &stubTwoFactorOutputPort{}
+		newInteractor(tfr, rlr, p).VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token-1", Code: "000000"})
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		rl := rlr.Limits[key]
+		assert.True(rl.IsLocked(time.Now()))
+
+		p = &stubTwoFactorOutputPort{}
+		newInteractor(tfr, rlr, p).VerifyTwoFactor(port.VerifyTwoFactorInputData{TwoFactorToken: "test-token-2", Code: code})
+		assert.Equal(http.StatusTooManyRequests, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTooManyAttempts, p.Result.ErrorCode)
+		assert.Greater(p.Result.RetryAfter, 10*time.Minute)
+	})
+}
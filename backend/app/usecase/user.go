@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
 	"github.com/datsukan/attendance-plan/backend/app/component/id"
+	"github.com/datsukan/attendance-plan/backend/app/component/mailtemplate"
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/model"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/datsukan/attendance-plan/backend/app/repository"
@@ -18,68 +22,99 @@ import (
 
 // UserInteractor はユーザーのユースケースの実装を表す構造体です。
 type UserInteractor struct {
-	Logger            *slog.Logger
-	UserRepository    repository.UserRepository
-	SessionRepository repository.SessionRepository
-	MailRepository    repository.EmailRepository
-	OutputPort        port.UserOutputPort
+	Logger                 *slog.Logger
+	UserRepository         repository.UserRepository
+	SessionRepository      repository.SessionRepository
+	MailRepository         repository.EmailRepository
+	OutboxRepository       repository.EmailOutboxRepository
+	UsedTokenRepository    repository.UsedTokenRepository
+	RefreshTokenRepository repository.RefreshTokenRepository
+	LoginSessionRepository repository.LoginSessionRepository
+	RateLimitRepository    repository.RateLimitRepository
+	TwoFactorRepository    repository.TwoFactorRepository
+	OutputPort             port.UserOutputPort
 }
 
 // NewUserInteractor は UserInteractor を生成します。
-func NewUserInteractor(logger *slog.Logger, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, mailRepository repository.EmailRepository, outputPort port.UserOutputPort) port.UserInputPort {
+func NewUserInteractor(logger *slog.Logger, userRepository repository.UserRepository, sessionRepository repository.SessionRepository, mailRepository repository.EmailRepository, outboxRepository repository.EmailOutboxRepository, usedTokenRepository repository.UsedTokenRepository, refreshTokenRepository repository.RefreshTokenRepository, loginSessionRepository repository.LoginSessionRepository, rateLimitRepository repository.RateLimitRepository, twoFactorRepository repository.TwoFactorRepository, outputPort port.UserOutputPort) port.UserInputPort {
 	return &UserInteractor{
-		Logger:            logger,
-		UserRepository:    userRepository,
-		SessionRepository: sessionRepository,
-		MailRepository:    mailRepository,
-		OutputPort:        outputPort,
+		Logger:                 logger,
+		UserRepository:         userRepository,
+		SessionRepository:      sessionRepository,
+		MailRepository:         mailRepository,
+		OutboxRepository:       outboxRepository,
+		UsedTokenRepository:    usedTokenRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		LoginSessionRepository: loginSessionRepository,
+		RateLimitRepository:    rateLimitRepository,
+		TwoFactorRepository:    twoFactorRepository,
+		OutputPort:             outputPort,
 	}
 }
 
 // SignIn はサインイン処理を行います。
-func (i *UserInteractor) SignIn(input port.SignInInputData) {
+// メールアドレスと送信元の IP アドレスごとに失敗の回数を数え、失敗が続いた場合は次の試行まで待たせ、さらに続いた場合はロックします。
+func (i *UserInteractor) SignIn(ctx context.Context, input port.SignInInputData) {
 	i.Logger.With("email", input.Email)
+	now := time.Now()
+
+	retryAfter, err := i.signInRetryAfter(input, now)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignIn(nil, r)
+		return
+	}
+	if retryAfter > 0 {
+		i.Logger.Warn("too many sign in attempts")
+		r := port.NewErrorResult(http.StatusTooManyRequests, port.ErrorCodeAuthTooManyAttempts, MsgTooManyAttempts).WithRetryAfter(retryAfter)
+		i.OutputPort.SetResponseSignIn(nil, r)
+		return
+	}
 
 	user, err := i.UserRepository.ReadByEmail(input.Email, true)
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn("user not found")
-			r := port.NewErrorResult(http.StatusUnauthorized, MsgEmailOrPasswordInvalid)
+			// 存在しないメールアドレスも失敗として数え、存在するかどうかを試行の結果から判別できないようにする
+			if err := i.recordSignInFailure(ctx, nil, input, now); err != nil {
+				i.Logger.Error(err.Error())
+			}
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthCredentialsInvalid, MsgEmailOrPasswordInvalid)
 			i.OutputPort.SetResponseSignIn(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSignIn(nil, r)
 		return
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
 		i.Logger.Warn("password is invalid")
-		r := port.NewErrorResult(http.StatusUnauthorized, MsgEmailOrPasswordInvalid)
+		if err := i.recordSignInFailure(ctx, user, input, now); err != nil {
+			i.Logger.Error(err.Error())
+		}
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthCredentialsInvalid, MsgEmailOrPasswordInvalid)
 		i.OutputPort.SetResponseSignIn(nil, r)
 		return
 	}
 
-	sessionToken, err := i.SessionRepository.GenerateToken(user.ID)
+	// サインインに成功した場合はメールアドレスの失敗の回数をリセットする
+	// 送信元の IP アドレスは他のユーザーと共有している場合があるため、期限が切れるまで数え続ける
+	if err := i.RateLimitRepository.Delete(model.RateLimitKey(model.RateLimitKindSignInEmail, input.Email)); err != nil {
+		i.Logger.Error(err.Error())
+	}
+
+	o, err := completeSignIn(i.Logger, i.SessionRepository, i.RefreshTokenRepository, i.LoginSessionRepository, i.TwoFactorRepository, user, input.UserAgent, input.IPAddress, now)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSignIn(nil, r)
 		return
 	}
 
-	o := &port.SignInOutputData{
-		BaseUserData: port.BaseUserData{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt.Format(time.DateTime),
-			UpdatedAt: user.UpdatedAt.Format(time.DateTime),
-		},
-		SessionToken: sessionToken,
-	}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseSignIn(o, r)
 }
@@ -91,13 +126,13 @@ func (i *UserInteractor) SignUp(ctx context.Context, input port.SignUpInputData)
 	user, err := i.UserRepository.ReadByEmail(input.Email, false)
 	if err != nil && !errors.Is(err, repository.NewNotFoundError()) {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSignUp(nil, r)
 		return
 	}
 	if user != nil && user.Enabled {
 		i.Logger.Warn("email already exists")
-		r := port.NewErrorResult(http.StatusBadRequest, MsgEmailAlreadyExists)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeUserEmailAlreadyExists, MsgEmailAlreadyExists)
 		i.OutputPort.SetResponseSignUp(nil, r)
 		return
 	}
@@ -112,7 +147,7 @@ func (i *UserInteractor) SignUp(ctx context.Context, input port.SignUpInputData)
 		}
 		if err := i.UserRepository.Create(user); err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseSignUp(nil, r)
 			return
 		}
@@ -120,38 +155,47 @@ func (i *UserInteractor) SignUp(ctx context.Context, input port.SignUpInputData)
 		i.Logger.Info("user created")
 	}
 
-	token, err := i.SessionRepository.GenerateToken(user.ID)
+	ok, err := i.RateLimitRepository.Acquire(model.RateLimitKey(model.RateLimitKindSignUpMail, input.Email), model.MailCooldown, time.Now())
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSignUp(nil, r)
 		return
 	}
-
-	config := infrastructure.GetConfig()
-	title := fmt.Sprintf("パスワードを設定してください | %s", config.ServiceName)
-	baseBodyMessages := []string{
-		"%sでパスワード設定のリクエストがありました。",
-		"以下のリンクから設定ページを開いてパスワードを設定してください。",
-		"",
-		"%s/password/set?token=%s",
-	}
-	baseBody := ""
-	for _, m := range baseBodyMessages {
-		baseBody += m + "\n"
+	if !ok {
+		i.Logger.Warn("sign up mail is in cooldown")
+		r := port.NewErrorResult(http.StatusTooManyRequests, port.ErrorCodeEmailCooldown, MsgEmailCooldown).WithRetryAfter(model.MailCooldown)
+		i.OutputPort.SetResponseSignUp(nil, r)
+		return
 	}
 
-	body := fmt.Sprintf(baseBody, config.ServiceName, config.BaseUrl, token)
+	token, err := i.SessionRepository.IssueToken(repository.TokenPurposePasswordSet, user.ID, "")
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignUp(nil, r)
+		return
+	}
 
-	msgID, err := i.MailRepository.Send(ctx, user.Email, title, body)
+	locale := i18n.Resolve(user.Locale, input.AcceptLanguage)
+	config := infrastructure.GetConfig()
+	msg, err := mailtemplate.Render(locale, mailtemplate.PasswordSetData{
+		ServiceName: config.ServiceName,
+		URL:         fmt.Sprintf("%s/password/set?token=%s", config.BaseUrl, url.QueryEscape(token)),
+	})
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSignUp(nil, r)
 		return
 	}
 
-	i.Logger.Info("mail sent", "message_id", msgID)
+	if err := enqueueEmail(ctx, i.Logger, i.OutboxRepository, i.MailRepository, user.Email, msg); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignUp(nil, r)
+		return
+	}
 
 	o := &port.SignUpOutputData{}
 	r := port.NewSuccessResult(http.StatusOK)
@@ -166,49 +210,58 @@ func (i *UserInteractor) PasswordReset(ctx context.Context, input port.PasswordR
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn("user not found by email")
-			r := port.NewErrorResult(http.StatusUnauthorized, MsgEmailNotFound)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeUserEmailNotFound, MsgEmailNotFound)
 			i.OutputPort.SetResponsePasswordReset(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponsePasswordReset(nil, r)
 		return
 	}
 
-	token, err := i.SessionRepository.GenerateToken(user.ID)
+	ok, err := i.RateLimitRepository.Acquire(model.RateLimitKey(model.RateLimitKindPasswordResetMail, input.Email), model.MailCooldown, time.Now())
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
-		i.OutputPort.SetResponseSignUp(nil, r)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponsePasswordReset(nil, r)
 		return
 	}
-
-	config := infrastructure.GetConfig()
-	title := fmt.Sprintf("パスワードを設定してください | %s", config.ServiceName)
-	baseBodyMessages := []string{
-		"%sでパスワードリセットのリクエストがありました。",
-		"以下のリンクから設定ページを開いてパスワードを設定してください。",
-		"",
-		"%s/password/set?token=%s",
-	}
-	baseBody := ""
-	for _, m := range baseBodyMessages {
-		baseBody += m + "\n"
+	if !ok {
+		i.Logger.Warn("password reset mail is in cooldown")
+		r := port.NewErrorResult(http.StatusTooManyRequests, port.ErrorCodeEmailCooldown, MsgEmailCooldown).WithRetryAfter(model.MailCooldown)
+		i.OutputPort.SetResponsePasswordReset(nil, r)
+		return
 	}
 
-	body := fmt.Sprintf(baseBody, config.ServiceName, config.BaseUrl, token)
-
-	msgID, err := i.MailRepository.Send(ctx, user.Email, title, body)
+	token, err := i.SessionRepository.IssueToken(repository.TokenPurposePasswordSet, user.ID, "")
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSignUp(nil, r)
 		return
 	}
 
-	i.Logger.Info("mail sent", "message_id", msgID)
+	locale := i18n.Resolve(user.Locale, input.AcceptLanguage)
+	config := infrastructure.GetConfig()
+	msg, err := mailtemplate.Render(locale, mailtemplate.PasswordResetData{
+		ServiceName: config.ServiceName,
+		URL:         fmt.Sprintf("%s/password/set?token=%s", config.BaseUrl, url.QueryEscape(token)),
+	})
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponsePasswordReset(nil, r)
+		return
+	}
+
+	if err := enqueueEmail(ctx, i.Logger, i.OutboxRepository, i.MailRepository, user.Email, msg); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponsePasswordReset(nil, r)
+		return
+	}
 
 	o := &port.PasswordResetOutputData{}
 	r := port.NewSuccessResult(http.StatusOK)
@@ -217,20 +270,25 @@ func (i *UserInteractor) PasswordReset(ctx context.Context, input port.PasswordR
 
 // PasswordSet はパスワード設定処理を行います。
 func (i *UserInteractor) PasswordSet(input port.PasswordSetInputData) {
-	i.Logger.With("token", input.Token)
+	token, err := i.consumeToken(input.Token, repository.TokenPurposePasswordSet)
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenInvalid) || errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponsePasswordReset(nil, r)
+			return
+		}
 
-	valid, userID := i.SessionRepository.IsValidToken(input.Token)
-	if !valid {
-		i.Logger.Warn("token is invalid")
-		r := port.NewErrorResult(http.StatusUnauthorized, MsgTokenInvalid)
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponsePasswordReset(nil, r)
 		return
 	}
 
-	user, err := i.UserRepository.Read(userID, false)
+	user, err := i.UserRepository.Read(token.Subject, false)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponsePasswordReset(nil, r)
 		return
 	}
@@ -238,18 +296,20 @@ func (i *UserInteractor) PasswordSet(input port.PasswordSetInputData) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponsePasswordReset(nil, r)
 		return
 	}
 
 	user.Password = string(hashedPassword)
 	user.Enabled = true
+	// パスワードを設定し直した場合は、漏洩したかもしれないセッションをすべて無効にする
+	user.TokenVersion++
 	user.UpdatedAt = time.Now()
 
 	if err := i.UserRepository.Update(user); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponsePasswordReset(nil, r)
 		return
 	}
@@ -267,25 +327,19 @@ func (i *UserInteractor) GetUser(input port.GetUserInputData) {
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn("user not found")
-			r := port.NewErrorResult(http.StatusUnauthorized, MsgUnauthorized)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
 			i.OutputPort.SetResponseGetUser(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseGetUser(nil, r)
 		return
 	}
 
 	o := &port.GetUserOutputData{
-		BaseUserData: port.BaseUserData{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt.Format(time.DateTime),
-			UpdatedAt: user.UpdatedAt.Format(time.DateTime),
-		},
+		BaseUserData: toBaseUserData(user),
 	}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseGetUser(o, r)
@@ -299,35 +353,45 @@ func (i *UserInteractor) UpdateUser(input port.UpdateUserInputData) {
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn("user not found")
-			r := port.NewErrorResult(http.StatusUnauthorized, MsgUnauthorized)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
 			i.OutputPort.SetResponseUpdateUser(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseUpdateUser(nil, r)
 		return
 	}
 
 	user.Name = input.Name
+	if input.Timezone != "" {
+		user.Timezone = input.Timezone
+	}
+	if input.Locale != "" {
+		user.Locale = input.Locale
+	}
+	if input.SequenceStrictness != "" {
+		user.SequenceStrictness = model.SequenceStrictness(input.SequenceStrictness)
+	}
 	user.UpdatedAt = time.Now()
 
+	if err := user.ValidateProfile(); err != nil {
+		i.Logger.Warn(err.Error())
+		r := validationErrorResult(err)
+		i.OutputPort.SetResponseUpdateUser(nil, r)
+		return
+	}
+
 	if err := i.UserRepository.Update(user); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseUpdateUser(nil, r)
 		return
 	}
 
 	o := &port.UpdateUserOutputData{
-		BaseUserData: port.BaseUserData{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt.Format(time.DateTime),
-			UpdatedAt: user.UpdatedAt.Format(time.DateTime),
-		},
+		BaseUserData: toBaseUserData(user),
 	}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseUpdateUser(o, r)
@@ -341,20 +405,20 @@ func (i *UserInteractor) DeleteUser(input port.DeleteUserInputData) {
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn("user not found")
-			r := port.NewErrorResult(http.StatusUnauthorized, MsgUnauthorized)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
 			i.OutputPort.SetResponseDeleteUser(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseDeleteUser(nil, r)
 		return
 	}
 
 	if err := i.UserRepository.Delete(user.ID); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseDeleteUser(nil, r)
 		return
 	}
@@ -372,20 +436,20 @@ func (i *UserInteractor) ResetEmail(input port.ResetEmailInputData) {
 	if err != nil {
 		if errors.Is(err, repository.NewNotFoundError()) {
 			i.Logger.Warn("user not found")
-			r := port.NewErrorResult(http.StatusUnauthorized, MsgUnauthorized)
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
 			i.OutputPort.SetResponseResetEmail(nil, r)
 			return
 		}
 
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
 
 	if user.Email == input.Email {
 		i.Logger.Warn("email is same")
-		r := port.NewErrorResult(http.StatusBadRequest, MsgEmailIsSame)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeUserEmailIsSame, MsgEmailIsSame)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
@@ -393,97 +457,117 @@ func (i *UserInteractor) ResetEmail(input port.ResetEmailInputData) {
 	exists, err := i.UserRepository.ExistsByEmail(input.Email, true)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
 	if exists {
 		i.Logger.Warn("email already exists")
-		r := port.NewErrorResult(http.StatusBadRequest, MsgEmailAlreadyExists)
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeUserEmailAlreadyExists, MsgEmailAlreadyExists)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
 
-	idToken, err := i.SessionRepository.GenerateToken(user.ID)
+	// 変更後のメールアドレスはトークンに含め、リンクを開いたユーザーが本人であることを SetEmail で確かめる
+	token, err := i.SessionRepository.IssueToken(repository.TokenPurposeEmailChange, user.ID, input.Email)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
 
-	emailToken, err := i.SessionRepository.GenerateToken(input.Email)
+	locale := i18n.Resolve(user.Locale, input.AcceptLanguage)
+	config := infrastructure.GetConfig()
+	msg, err := mailtemplate.Render(locale, mailtemplate.EmailChangeData{
+		ServiceName: config.ServiceName,
+		URL:         fmt.Sprintf("%s/email/set?token=%s", config.BaseUrl, url.QueryEscape(token)),
+	})
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
 
-	config := infrastructure.GetConfig()
-	title := fmt.Sprintf("メールアドレスを変更してください | %s", config.ServiceName)
-	baseBodyMessages := []string{
-		"%sでメールアドレス変更のリクエストがありました。",
-		"以下のリンクから変更ページを開いてメールアドレスを変更してください。",
-		"",
-		"%s/email/set?id_token=%s&email_token=%s",
-	}
-	baseBody := ""
-	for _, m := range baseBodyMessages {
-		baseBody += m + "\n"
-	}
-
-	body := fmt.Sprintf(baseBody, config.ServiceName, config.BaseUrl, idToken, emailToken)
-
-	msgID, err := i.MailRepository.Send(context.Background(), input.Email, title, body)
-	if err != nil {
+	if err := enqueueEmail(context.Background(), i.Logger, i.OutboxRepository, i.MailRepository, input.Email, msg); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseResetEmail(nil, r)
 		return
 	}
 
-	i.Logger.Info("mail sent", "message_id", msgID)
-
 	o := &port.ResetEmailOutputData{}
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseResetEmail(o, r)
 }
 
 // SetEmail はメールアドレス設定処理を行います。
+// 別のユーザーが開いたリンクや変更できなくなったリンクを使用済みにしないよう、確認してからトークンを使用済みにします。
 func (i *UserInteractor) SetEmail(input port.SetEmailInputData) {
-	i.Logger.With("user_id_token", input.UserIDToken, "email_token", input.EmailToken)
+	i.Logger.With("user_id", input.UserID)
+
+	token, err := i.SessionRepository.ParseToken(input.Token, repository.TokenPurposeEmailChange)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+		i.OutputPort.SetResponseSetEmail(nil, r)
+		return
+	}
 
-	valid, userID := i.SessionRepository.IsValidToken(input.UserIDToken)
-	if !valid {
-		i.Logger.Warn("user_id_token is invalid")
-		r := port.NewErrorResult(http.StatusUnauthorized, MsgTokenInvalid)
+	// 別のユーザーに届いたリンクでは変更できないようにする
+	if token.Subject != input.UserID {
+		i.Logger.Warn("token subject does not match", "subject", token.Subject)
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
 		i.OutputPort.SetResponseSetEmail(nil, r)
 		return
 	}
 
-	valid, email := i.SessionRepository.IsValidToken(input.EmailToken)
-	if !valid {
-		i.Logger.Warn("email_token is invalid")
-		r := port.NewErrorResult(http.StatusUnauthorized, MsgTokenInvalid)
+	user, err := i.UserRepository.Read(token.Subject, true)
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSetEmail(nil, r)
 		return
 	}
 
-	user, err := i.UserRepository.Read(userID, true)
+	// リンクを送信してから開くまでの間に、別のアカウントが同じメールアドレスを使い始めた場合は変更しない
+	exists, err := i.UserRepository.ExistsByEmail(token.Email, true)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSetEmail(nil, r)
+		return
+	}
+	if exists {
+		i.Logger.Warn("email already exists")
+		r := port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeUserEmailAlreadyExists, MsgEmailAlreadyExists)
 		i.OutputPort.SetResponseSetEmail(nil, r)
 		return
 	}
 
-	user.Email = email
+	if err := i.UsedTokenRepository.Consume(token); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseSetEmail(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSetEmail(nil, r)
+		return
+	}
+
+	user.Email = token.Email
+	// メールアドレスを変更した場合は、すべてのセッションを無効にしてサインインし直してもらう
+	user.TokenVersion++
 	user.UpdatedAt = time.Now()
 
 	if err := i.UserRepository.Update(user); err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseSetEmail(nil, r)
 		return
 	}
@@ -492,3 +576,317 @@ func (i *UserInteractor) SetEmail(input port.SetEmailInputData) {
 	r := port.NewSuccessResult(http.StatusOK)
 	i.OutputPort.SetResponseSetEmail(o, r)
 }
+
+// UnlockAccount はサインインの失敗によるメールアドレスのロックを解除します。
+// 送信元の IP アドレスのロックは解除しません。
+func (i *UserInteractor) UnlockAccount(input port.UnlockAccountInputData) {
+	token, err := i.consumeToken(input.Token, repository.TokenPurposeAccountUnlock)
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenInvalid) || errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseUnlockAccount(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUnlockAccount(nil, r)
+		return
+	}
+
+	user, err := i.UserRepository.Read(token.Subject, false)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseUnlockAccount(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUnlockAccount(nil, r)
+		return
+	}
+
+	if err := i.RateLimitRepository.Delete(model.RateLimitKey(model.RateLimitKindSignInEmail, user.Email)); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseUnlockAccount(nil, r)
+		return
+	}
+
+	i.Logger.Info("account unlocked", "user_id", user.ID)
+
+	o := &port.UnlockAccountOutputData{}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseUnlockAccount(o, r)
+}
+
+// RequestMagicLink はパスワードを使わずにサインインするリンクをメールで送信します。
+// メールアドレスが登録されているかどうかを判別できないよう、登録されていない場合もメールを送信せずに同じ結果を返します。
+// 応答までの時間にも差が出ないよう、登録されていない場合もトークンの発行からメールの保存までを同じように行い、送信しないメールとして保存します。
+// 登録されている場合もメールは送信待ちとして保存するのみで、送信は定期的な送信の処理に任せます。
+// リンクはリクエストしたブラウザのみで使えるよう、ブラウザに nonce を返し、トークンには nonce のハッシュを含めます。
+func (i *UserInteractor) RequestMagicLink(ctx context.Context, input port.RequestMagicLinkInputData) {
+	i.Logger.With("email", input.Email)
+
+	// 送信の間隔の制限はユーザーを読み込む前に確認し、登録されていないメールアドレスにも同じ制限をかける
+	ok, err := i.RateLimitRepository.Acquire(model.RateLimitKey(model.RateLimitKindMagicLinkMail, input.Email), model.MailCooldown, time.Now())
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRequestMagicLink(nil, r)
+		return
+	}
+	if !ok {
+		i.Logger.Warn("magic link mail is in cooldown")
+		r := port.NewErrorResult(http.StatusTooManyRequests, port.ErrorCodeEmailCooldown, MsgEmailCooldown).WithRetryAfter(model.MailCooldown)
+		i.OutputPort.SetResponseRequestMagicLink(nil, r)
+		return
+	}
+
+	nonce, err := model.NewMagicLinkNonce()
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRequestMagicLink(nil, r)
+		return
+	}
+
+	found := true
+	user, err := i.UserRepository.ReadByEmail(input.Email, true)
+	if err != nil {
+		if !errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Error(err.Error())
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+			i.OutputPort.SetResponseRequestMagicLink(nil, r)
+			return
+		}
+
+		i.Logger.Warn("user not found by email")
+		found = false
+		user = &model.User{Email: input.Email}
+	}
+
+	token, err := i.SessionRepository.IssueMagicLinkToken(user.ID, model.HashMagicLinkNonce(nonce))
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRequestMagicLink(nil, r)
+		return
+	}
+
+	locale := i18n.Resolve(user.Locale, input.AcceptLanguage)
+	config := infrastructure.GetConfig()
+	msg, err := mailtemplate.Render(locale, mailtemplate.MagicLinkData{
+		ServiceName: config.ServiceName,
+		URL:         fmt.Sprintf("%s/signin/link?token=%s", config.BaseUrl, url.QueryEscape(token)),
+	})
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRequestMagicLink(nil, r)
+		return
+	}
+
+	if err := queueEmail(i.Logger, i.OutboxRepository, user.Email, msg, !found); err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseRequestMagicLink(nil, r)
+		return
+	}
+
+	o := &port.RequestMagicLinkOutputData{Nonce: nonce}
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseRequestMagicLink(o, r)
+}
+
+// SignInMagicLink はサインインのリンクのトークンでサインインします。
+// 別のブラウザで開いたリンクでリンクを使用済みにしないよう、nonce を確認してからトークンを使用済みにします。
+func (i *UserInteractor) SignInMagicLink(input port.SignInMagicLinkInputData) {
+	token, err := i.SessionRepository.ParseToken(input.Token, repository.TokenPurposeMagicLink)
+	if err != nil {
+		i.Logger.Warn(err.Error())
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+		i.OutputPort.SetResponseSignInMagicLink(nil, r)
+		return
+	}
+	i.Logger.With("user_id", token.Subject)
+
+	if !model.VerifyMagicLinkNonce(token.NonceHash, input.Nonce) {
+		i.Logger.Warn("magic link nonce mismatch")
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthBrowserMismatch, MsgMagicLinkBrowserMismatch)
+		i.OutputPort.SetResponseSignInMagicLink(nil, r)
+		return
+	}
+
+	if err := i.UsedTokenRepository.Consume(token); err != nil {
+		if errors.Is(err, repository.ErrTokenAlreadyUsed) {
+			i.Logger.Warn(err.Error())
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseSignInMagicLink(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignInMagicLink(nil, r)
+		return
+	}
+
+	user, err := i.UserRepository.Read(token.Subject, true)
+	if err != nil {
+		if errors.Is(err, repository.NewNotFoundError()) {
+			i.Logger.Warn("user not found")
+			r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthTokenInvalid, MsgTokenInvalid)
+			i.OutputPort.SetResponseSignInMagicLink(nil, r)
+			return
+		}
+
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignInMagicLink(nil, r)
+		return
+	}
+
+	o, err := completeSignIn(i.Logger, i.SessionRepository, i.RefreshTokenRepository, i.LoginSessionRepository, i.TwoFactorRepository, user, input.UserAgent, input.IPAddress, time.Now())
+	if err != nil {
+		i.Logger.Error(err.Error())
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
+		i.OutputPort.SetResponseSignInMagicLink(nil, r)
+		return
+	}
+
+	r := port.NewSuccessResult(http.StatusOK)
+	i.OutputPort.SetResponseSignInMagicLink(o, r)
+}
+
+// signInRateLimit はサインインの失敗を数えるカウンターを表す構造体です。
+type signInRateLimit struct {
+	kind   model.RateLimitKind
+	key    string
+	policy model.RateLimitPolicy
+}
+
+// signInRateLimits はサインインの失敗を数えるカウンターの一覧を返します。
+// 送信元の IP アドレスが分からない場合はメールアドレスのみで数えます。
+func signInRateLimits(input port.SignInInputData) []signInRateLimit {
+	limits := []signInRateLimit{
+		{
+			kind:   model.RateLimitKindSignInEmail,
+			key:    model.RateLimitKey(model.RateLimitKindSignInEmail, input.Email),
+			policy: model.SignInEmailPolicy,
+		},
+	}
+	if input.IPAddress != "" {
+		limits = append(limits, signInRateLimit{
+			kind:   model.RateLimitKindSignInIP,
+			key:    model.RateLimitKey(model.RateLimitKindSignInIP, input.IPAddress),
+			policy: model.SignInIPPolicy,
+		})
+	}
+
+	return limits
+}
+
+// signInRetryAfter は now の時点でサインインを試行できるまでの時間を返します。すぐに試行できる場合は 0 を返します。
+func (i *UserInteractor) signInRetryAfter(input port.SignInInputData, now time.Time) (time.Duration, error) {
+	var retryAfter time.Duration
+	for _, l := range signInRateLimits(input) {
+		rl, err := i.RateLimitRepository.Read(l.key, now)
+		if err != nil {
+			if errors.Is(err, repository.NewNotFoundError()) {
+				continue
+			}
+			return 0, err
+		}
+
+		if d := rl.RetryAfter(l.policy, now); d > retryAfter {
+			retryAfter = d
+		}
+	}
+
+	return retryAfter, nil
+}
+
+// recordSignInFailure はサインインの失敗を数え、ロックする回数に達したカウンターをロックします。
+// メールアドレスをロックした場合は、ユーザーが存在すればロックを解除するメールを送信します。
+func (i *UserInteractor) recordSignInFailure(ctx context.Context, user *model.User, input port.SignInInputData, now time.Time) error {
+	for _, l := range signInRateLimits(input) {
+		rl, err := i.RateLimitRepository.AddFailure(l.key, l.policy.Window, now)
+		if err != nil {
+			return err
+		}
+		if !rl.ShouldLock(l.policy) {
+			continue
+		}
+
+		if err := i.RateLimitRepository.Lock(l.key, now.Add(l.policy.LockDuration), now); err != nil {
+			return err
+		}
+		i.Logger.Warn("sign in locked", "kind", l.kind)
+
+		if l.kind == model.RateLimitKindSignInEmail && user != nil {
+			if err := i.sendAccountUnlockEmail(ctx, user, input.AcceptLanguage); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sendAccountUnlockEmail はロックを解除するメールを送信します。
+func (i *UserInteractor) sendAccountUnlockEmail(ctx context.Context, user *model.User, acceptLanguage string) error {
+	token, err := i.SessionRepository.IssueToken(repository.TokenPurposeAccountUnlock, user.ID, "")
+	if err != nil {
+		return err
+	}
+
+	locale := i18n.Resolve(user.Locale, acceptLanguage)
+	config := infrastructure.GetConfig()
+	msg, err := mailtemplate.Render(locale, mailtemplate.AccountUnlockData{
+		ServiceName: config.ServiceName,
+		URL:         fmt.Sprintf("%s/unlock?token=%s", config.BaseUrl, url.QueryEscape(token)),
+	})
+	if err != nil {
+		return err
+	}
+
+	return enqueueEmail(ctx, i.Logger, i.OutboxRepository, i.MailRepository, user.Email, msg)
+}
+
+// consumeToken はトークンを検証し、1回のみ使えるトークンの場合は使用済みとして記録します。
+// 無効なトークンや用途の異なるトークンは repository.ErrTokenInvalid、使用済みのトークンは repository.ErrTokenAlreadyUsed を返します。
+func (i *UserInteractor) consumeToken(token string, purpose repository.TokenPurpose) (*repository.Token, error) {
+	t, err := i.SessionRepository.ParseToken(token, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	if purpose.SingleUse() {
+		if err := i.UsedTokenRepository.Consume(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// toBaseUserData は model.User をユーザーの基本データに変換します。
+// 日時はユーザーのタイムゾーンで表します。
+func toBaseUserData(user *model.User) port.BaseUserData {
+	zone := timezone.LoadOrDefault(user.Timezone)
+	return port.BaseUserData{
+		ID:                 user.ID,
+		Email:              user.Email,
+		Name:               user.Name,
+		Timezone:           zone.Name(),
+		Locale:             user.Locale,
+		SequenceStrictness: user.Strictness().String(),
+		CreatedAt:          zone.FormatDateTime(user.CreatedAt),
+		UpdatedAt:          zone.FormatDateTime(user.UpdatedAt),
+	}
+}
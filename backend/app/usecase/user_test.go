@@ -5,10 +5,14 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
 	"github.com/datsukan/attendance-plan/backend/app/port"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,14 +25,15 @@ func TestSignIn(t *testing.T) {
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 		r := &stubUserRepository{}
 		sr := &stubSessionRepository{}
+		rtr := &stubRefreshTokenRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, r, sr, nil, p)
+		i := NewUserInteractor(l, r, sr, nil, nil, nil, rtr, &stubLoginSessionRepository{}, &stubRateLimitRepository{}, &stubTwoFactorRepository{}, p)
 
 		input := port.SignInInputData{
 			Email:    "test-email@example.com",
 			Password: "test-password",
 		}
-		i.SignIn(input)
+		i.SignIn(context.Background(), input)
 
 		output, ok := p.Output.(*port.SignInOutputData)
 		require.True(ok)
@@ -41,11 +46,14 @@ func TestSignIn(t *testing.T) {
 		assert.Equal("test-email@example.com", output.Email)
 		assert.Equal("test name", output.Name)
 
-		date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
-		assert.Equal(date.Format(time.DateTime), output.CreatedAt)
-		assert.Equal(date.Format(time.DateTime), output.UpdatedAt)
+		assert.Equal(timezone.DefaultName, output.Timezone)
+		assert.Equal("2021-01-01T09:00:00+09:00", output.CreatedAt)
+		assert.Equal("2021-01-01T09:00:00+09:00", output.UpdatedAt)
 
 		assert.Equal("test-token", output.SessionToken)
+		assert.NotEmpty(output.RefreshToken)
+		assert.Positive(output.ExpiresIn)
+		assert.Len(rtr.Tokens, 1)
 
 		assert.Equal(http.StatusOK, p.Result.StatusCode)
 		assert.Empty(p.Result.ErrorMessage)
@@ -60,13 +68,13 @@ func TestSignIn(t *testing.T) {
 		r := &stubUserRepository{}
 		sr := &stubSessionRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, r, sr, nil, p)
+		i := NewUserInteractor(l, r, sr, nil, nil, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
 
 		input := port.SignInInputData{
 			Email:    "test-not-found-email@example.com",
 			Password: "test-not-found-password",
 		}
-		i.SignIn(input)
+		i.SignIn(context.Background(), input)
 
 		output, ok := p.Output.(*port.SignInOutputData)
 		require.True(ok)
@@ -78,6 +86,120 @@ func TestSignIn(t *testing.T) {
 	})
 }
 
+func TestSignIn_RateLimit(t *testing.T) {
+	t.Run("失敗が続いた場合は次の試行まで待たせる", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		rlr := &stubRateLimitRepository{}
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, &stubEmailRepository{}, &stubEmailOutboxRepository{}, nil, nil, nil, rlr, nil, p)
+
+		input := port.SignInInputData{Email: "test-email@example.com", Password: "test-wrong-password", IPAddress: "192.0.2.1"}
+		for n := 0; n < model.SignInEmailPolicy.DelayAfter; n++ {
+			i.SignIn(context.Background(), input)
+			assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		}
+
+		// 正しいパスワードでも待つ時間が過ぎるまでは試行できない
+		input.Password = "test-password"
+		i.SignIn(context.Background(), input)
+		assert.Equal(http.StatusTooManyRequests, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTooManyAttempts, p.Result.ErrorCode)
+		assert.Positive(p.Result.RetryAfter)
+		assert.LessOrEqual(p.Result.RetryAfter, time.Second)
+	})
+
+	t.Run("ロックする回数に達した場合はロックしてロックを解除するメールを送信する", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		rlr := &stubRateLimitRepository{}
+		mr := &stubEmailRepository{}
+		or := &stubEmailOutboxRepository{}
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, mr, or, nil, nil, nil, rlr, nil, p)
+
+		key := model.RateLimitKey(model.RateLimitKindSignInEmail, "test-email@example.com")
+		rlr.Limits = map[string]model.RateLimit{
+			key: {Key: key, Failures: model.SignInEmailPolicy.LockAfter - 1, TTL: time.Now().Add(time.Hour).Unix()},
+		}
+
+		input := port.SignInInputData{Email: "Test-Email@example.com", Password: "test-wrong-password"}
+		i.SignIn(context.Background(), input)
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+
+		require.Contains(rlr.Limits, key)
+		assert.True(rlr.Limits[key].LockedUntil.After(time.Now()))
+		assert.Len(or.Outboxes, 1)
+		assert.Contains(mr.Message.Text, "/unlock?token=")
+
+		input.Password = "test-password"
+		i.SignIn(context.Background(), input)
+		assert.Equal(http.StatusTooManyRequests, p.Result.StatusCode)
+		assert.Greater(p.Result.RetryAfter, 10*time.Minute)
+	})
+
+	t.Run("送信元の IP アドレスの失敗が続いた場合は別のメールアドレスも試行できない", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		key := model.RateLimitKey(model.RateLimitKindSignInIP, "192.0.2.1")
+		rlr := &stubRateLimitRepository{Limits: map[string]model.RateLimit{
+			key: {Key: key, Failures: model.SignInIPPolicy.LockAfter, LockedUntil: time.Now().Add(time.Minute), TTL: time.Now().Add(time.Minute).Unix()},
+		}}
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, nil, nil, nil, nil, nil, rlr, nil, p)
+
+		i.SignIn(context.Background(), port.SignInInputData{Email: "test-other-email@example.com", Password: "test-password", IPAddress: "192.0.2.1"})
+		assert.Equal(http.StatusTooManyRequests, p.Result.StatusCode)
+	})
+
+	t.Run("サインインに成功した場合はメールアドレスの失敗の回数をリセットする", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		emailKey := model.RateLimitKey(model.RateLimitKindSignInEmail, "test-email@example.com")
+		ipKey := model.RateLimitKey(model.RateLimitKindSignInIP, "192.0.2.1")
+		ttl := time.Now().Add(time.Hour).Unix()
+		rlr := &stubRateLimitRepository{Limits: map[string]model.RateLimit{
+			emailKey: {Key: emailKey, Failures: 1, TTL: ttl},
+			ipKey:    {Key: ipKey, Failures: 1, TTL: ttl},
+		}}
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, nil, nil, nil, &stubRefreshTokenRepository{}, &stubLoginSessionRepository{}, rlr, &stubTwoFactorRepository{}, p)
+
+		i.SignIn(context.Background(), port.SignInInputData{Email: "test-email@example.com", Password: "test-password", IPAddress: "192.0.2.1"})
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+		assert.NotContains(rlr.Limits, emailKey)
+		assert.Contains(rlr.Limits, ipKey)
+	})
+
+	t.Run("二段階認証が有効な場合はセッションの代わりに二段階認証のトークンを返す", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		rtr := &stubRefreshTokenRepository{}
+		tfr := &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{
+			"test-id": {UserID: "test-id", Secret: "test-secret", Enabled: true},
+		}}
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, nil, nil, nil, rtr, &stubLoginSessionRepository{}, &stubRateLimitRepository{}, tfr, p)
+
+		i.SignIn(context.Background(), port.SignInInputData{Email: "test-email@example.com", Password: "test-password"})
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		output, ok := p.Output.(*port.SignInOutputData)
+		require.True(ok)
+		assert.Equal("test-token", output.TwoFactorToken)
+		assert.Empty(output.SessionToken)
+		assert.Empty(output.RefreshToken)
+		assert.Empty(rtr.Tokens)
+	})
+}
+
 func TestSignUp(t *testing.T) {
 	t.Run("サインアップする", func(t *testing.T) {
 		assert := assert.New(t)
@@ -87,7 +209,7 @@ func TestSignUp(t *testing.T) {
 		sr := &stubSessionRepository{}
 		mr := &stubEmailRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, mr, p)
+		i := NewUserInteractor(l, ur, sr, mr, &stubEmailOutboxRepository{}, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
 
 		ctx := context.Background()
 		input := port.SignUpInputData{
@@ -101,6 +223,41 @@ func TestSignUp(t *testing.T) {
 	})
 }
 
+func TestPasswordReset_Locale(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantSubject    string
+		wantText       string
+		wantLang       string
+	}{
+		{name: "Accept-Language がない場合は日本語", wantSubject: "パスワードを設定してください |", wantText: "パスワードリセットのリクエストがありました。", wantLang: `lang="ja"`},
+		{name: "Accept-Language が英語の場合は英語", acceptLanguage: "en-US,en;q=0.9,ja;q=0.8", wantSubject: "Reset your password |", wantText: "A password reset was requested on ", wantLang: `lang="en"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			mr := &stubEmailRepository{}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, mr, &stubEmailOutboxRepository{}, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
+
+			i.PasswordReset(context.Background(), port.PasswordResetInputData{
+				Email:          "test-email@example.com",
+				AcceptLanguage: tt.acceptLanguage,
+			})
+
+			assert.Equal(http.StatusOK, p.Result.StatusCode)
+			assert.True(strings.HasPrefix(mr.Message.Subject, tt.wantSubject), mr.Message.Subject)
+			assert.Contains(mr.Message.Text, tt.wantText)
+			assert.Contains(mr.Message.HTML, tt.wantLang)
+			assert.Contains(mr.Message.HTML, "/password/set?token=")
+		})
+	}
+}
+
 func TestPasswordReset(t *testing.T) {
 	t.Run("パスワードリセットする", func(t *testing.T) {
 		assert := assert.New(t)
@@ -110,7 +267,7 @@ func TestPasswordReset(t *testing.T) {
 		sr := &stubSessionRepository{}
 		mr := &stubEmailRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, mr, p)
+		i := NewUserInteractor(l, ur, sr, mr, &stubEmailOutboxRepository{}, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
 
 		ctx := context.Background()
 		input := port.PasswordResetInputData{
@@ -124,16 +281,56 @@ func TestPasswordReset(t *testing.T) {
 	})
 }
 
+func TestMailCooldown(t *testing.T) {
+	tests := []struct {
+		name string
+		send func(i port.UserInputPort, email string)
+	}{
+		{
+			name: "サインアップのメールを続けて送信した場合はエラーを返す",
+			send: func(i port.UserInputPort, email string) {
+				i.SignUp(context.Background(), port.SignUpInputData{Email: email})
+			},
+		},
+		{
+			name: "パスワードリセットのメールを続けて送信した場合はエラーを返す",
+			send: func(i port.UserInputPort, email string) {
+				i.PasswordReset(context.Background(), port.PasswordResetInputData{Email: email})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			or := &stubEmailOutboxRepository{}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, &stubEmailRepository{}, or, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
+
+			tt.send(i, "test-email@example.com")
+			assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+			// 大文字と小文字が異なるだけのメールアドレスも同じ宛先として扱う
+			tt.send(i, "TEST-EMAIL@example.com")
+			assert.Equal(http.StatusTooManyRequests, p.Result.StatusCode)
+			assert.Equal(port.ErrorCodeEmailCooldown, p.Result.ErrorCode)
+			assert.Equal(model.MailCooldown, p.Result.RetryAfter)
+			assert.Len(or.Outboxes, 1)
+		})
+	}
+}
+
 func TestPasswordSet(t *testing.T) {
 	t.Run("パスワードリセットする", func(t *testing.T) {
 		assert := assert.New(t)
 
 		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-		ur := &stubUserRepository{}
+		ur := &stubTokenVersionUserRepository{TokenVersion: 1}
 		sr := &stubSessionRepository{}
-		mr := &stubEmailRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, mr, p)
+		i := NewUserInteractor(l, ur, sr, nil, nil, &stubUsedTokenRepository{}, nil, nil, nil, nil, p)
 
 		input := port.PasswordSetInputData{
 			Token:    "test-token",
@@ -144,6 +341,33 @@ func TestPasswordSet(t *testing.T) {
 		assert.Equal(http.StatusOK, p.Result.StatusCode)
 		assert.Empty(p.Result.ErrorMessage)
 		assert.False(p.Result.HasError)
+
+		// 他の端末のセッションを無効にするため、セッションの世代が上がる
+		if assert.NotNil(ur.Updated) {
+			assert.Equal(2, ur.Updated.TokenVersion)
+		}
+	})
+
+	t.Run("使用済みのトークンの場合はエラーを返す", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		ur := &stubUserRepository{}
+		sr := &stubSessionRepository{}
+		utr := &stubUsedTokenRepository{}
+		input := port.PasswordSetInputData{
+			Token:    "test-token",
+			Password: "test-password",
+		}
+
+		p := &stubUserOutputPort{}
+		NewUserInteractor(l, ur, sr, nil, nil, utr, nil, nil, nil, nil, p).PasswordSet(input)
+		assert.Equal(http.StatusOK, p.Result.StatusCode)
+
+		p = &stubUserOutputPort{}
+		NewUserInteractor(l, ur, sr, nil, nil, utr, nil, nil, nil, nil, p).PasswordSet(input)
+		assert.Equal(http.StatusUnauthorized, p.Result.StatusCode)
+		assert.Equal(port.ErrorCodeAuthTokenInvalid, p.Result.ErrorCode)
 	})
 }
 
@@ -155,7 +379,7 @@ func TestGetUser(t *testing.T) {
 		ur := &stubUserRepository{}
 		sr := &stubSessionRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, nil, p)
+		i := NewUserInteractor(l, ur, sr, nil, nil, nil, nil, nil, nil, nil, p)
 
 		input := port.GetUserInputData{
 			UserID: "test-id",
@@ -173,9 +397,9 @@ func TestGetUser(t *testing.T) {
 		assert.Equal("test-email@example.com", output.Email)
 		assert.Equal("test name", output.Name)
 
-		date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
-		assert.Equal(date.Format(time.DateTime), output.CreatedAt)
-		assert.Equal(date.Format(time.DateTime), output.UpdatedAt)
+		assert.Equal(timezone.DefaultName, output.Timezone)
+		assert.Equal("2021-01-01T09:00:00+09:00", output.CreatedAt)
+		assert.Equal("2021-01-01T09:00:00+09:00", output.UpdatedAt)
 
 		assert.Equal(http.StatusOK, p.Result.StatusCode)
 		assert.Empty(p.Result.ErrorMessage)
@@ -191,11 +415,13 @@ func TestUpdateUser(t *testing.T) {
 		ur := &stubUserRepository{}
 		sr := &stubSessionRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, nil, p)
+		i := NewUserInteractor(l, ur, sr, nil, nil, nil, nil, nil, nil, nil, p)
 
+		now := time.Now().Truncate(time.Second)
 		input := port.UpdateUserInputData{
-			UserID: "test-id",
-			Name:   "test-name",
+			UserID:   "test-id",
+			Name:     "test-name",
+			Timezone: "America/New_York",
 		}
 		i.UpdateUser(input)
 
@@ -210,14 +436,36 @@ func TestUpdateUser(t *testing.T) {
 		assert.Equal("test-email@example.com", output.Email)
 		assert.Equal("test-name", output.Name)
 
-		date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
-		assert.Equal(date.Format(time.DateTime), output.CreatedAt)
-		assert.True(output.UpdatedAt >= time.Now().Format(time.DateTime))
+		assert.Equal("America/New_York", output.Timezone)
+		assert.Equal(model.SequenceStrictnessWarn.String(), output.SequenceStrictness)
+		assert.Equal("2020-12-31T19:00:00-05:00", output.CreatedAt)
+		updatedAt, err := time.Parse(time.RFC3339, output.UpdatedAt)
+		assert.NoError(err)
+		assert.False(updatedAt.Before(now))
 
 		assert.Equal(http.StatusOK, p.Result.StatusCode)
 		assert.Empty(p.Result.ErrorMessage)
 		assert.False(p.Result.HasError)
 	})
+
+	t.Run("講義回の順番の確認を変更する", func(t *testing.T) {
+		assert := assert.New(t)
+
+		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		p := &stubUserOutputPort{}
+		i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, nil, nil, nil, nil, nil, nil, nil, p)
+
+		i.UpdateUser(port.UpdateUserInputData{
+			UserID:             "test-id",
+			Name:               "test-name",
+			SequenceStrictness: model.SequenceStrictnessReject.String(),
+		})
+
+		output, ok := p.Output.(*port.UpdateUserOutputData)
+		if assert.True(ok) && assert.NotNil(output) {
+			assert.Equal(model.SequenceStrictnessReject.String(), output.SequenceStrictness)
+		}
+	})
 }
 
 func TestDeleteUser(t *testing.T) {
@@ -228,7 +476,7 @@ func TestDeleteUser(t *testing.T) {
 		ur := &stubUserRepository{}
 		sr := &stubSessionRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, nil, p)
+		i := NewUserInteractor(l, ur, sr, nil, nil, nil, nil, nil, nil, nil, p)
 
 		input := port.DeleteUserInputData{
 			UserID: "test-id",
@@ -250,7 +498,7 @@ func TestResetEmail(t *testing.T) {
 		sr := &stubSessionRepository{}
 		mr := &stubEmailRepository{}
 		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, mr, p)
+		i := NewUserInteractor(l, ur, sr, mr, &stubEmailOutboxRepository{}, nil, nil, nil, nil, nil, p)
 
 		input := port.ResetEmailInputData{
 			UserID: "test-id",
@@ -265,24 +513,192 @@ func TestResetEmail(t *testing.T) {
 }
 
 func TestSetEmail(t *testing.T) {
-	t.Run("メールアドレスを設定する", func(t *testing.T) {
-		assert := assert.New(t)
+	tests := []struct {
+		name       string
+		userID     string
+		used       bool
+		taken      bool
+		wantStatus int
+		wantCode   port.ErrorCode
+		wantUsed   bool
+	}{
+		{name: "メールアドレスを設定する", userID: "test-user-id", wantStatus: http.StatusOK, wantUsed: true},
+		{name: "使用済みのトークンの場合はエラーを返す", userID: "test-user-id", used: true, wantStatus: http.StatusUnauthorized, wantCode: port.ErrorCodeAuthTokenInvalid, wantUsed: true},
+		{name: "別のユーザーのトークンの場合はトークンを使用済みにせずエラーを返す", userID: "test-other-user-id", wantStatus: http.StatusUnauthorized, wantCode: port.ErrorCodeAuthTokenInvalid},
+		{name: "別のアカウントがメールアドレスを使い始めた場合はエラーを返す", userID: "test-user-id", taken: true, wantStatus: http.StatusBadRequest, wantCode: port.ErrorCodeUserEmailAlreadyExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			ur := &stubMapUserRepository{Users: map[string]model.User{
+				"test-user-id": {ID: "test-user-id", Email: "test-email@example.com", Enabled: true},
+			}}
+			if tt.taken {
+				ur.Users["test-other-user-id"] = model.User{ID: "test-other-user-id", Email: "test-new-email@example.com", Enabled: true}
+			}
+			sr := &stubSessionRepository{}
+			utr := &stubUsedTokenRepository{Used: map[string]bool{"test-token": tt.used}}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, ur, sr, nil, nil, utr, nil, nil, nil, nil, p)
+
+			input := port.SetEmailInputData{
+				UserID: tt.userID,
+				Token:  "test-token",
+			}
+			i.SetEmail(input)
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			assert.Equal(tt.wantCode, p.Result.ErrorCode)
+			assert.Equal(tt.wantUsed, utr.Used["test-token"])
+
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal("test-new-email@example.com", ur.Users["test-user-id"].Email)
+			} else {
+				assert.Equal("test-email@example.com", ur.Users["test-user-id"].Email)
+			}
+		})
+	}
+}
 
-		l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-		ur := &stubUserRepository{}
-		sr := &stubSessionRepository{}
-		mr := &stubEmailRepository{}
-		p := &stubUserOutputPort{}
-		i := NewUserInteractor(l, ur, sr, mr, p)
+func TestUnlockAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		used       bool
+		wantStatus int
+	}{
+		{name: "ロックを解除する", wantStatus: http.StatusOK},
+		{name: "使用済みのトークンの場合はエラーを返す", used: true, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			key := model.RateLimitKey(model.RateLimitKindSignInEmail, "test-email@example.com")
+			until := time.Now().Add(time.Minute)
+			rlr := &stubRateLimitRepository{Limits: map[string]model.RateLimit{
+				key: {Key: key, Failures: model.SignInEmailPolicy.LockAfter, LockedUntil: until, TTL: until.Unix()},
+			}}
+			utr := &stubUsedTokenRepository{Used: map[string]bool{"test-token": tt.used}}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, &stubUserRepository{}, &stubSessionRepository{}, nil, nil, utr, nil, nil, rlr, nil, p)
+
+			i.UnlockAccount(port.UnlockAccountInputData{Token: "test-token"})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			if tt.wantStatus == http.StatusOK {
+				assert.NotContains(rlr.Limits, key)
+			} else {
+				assert.Equal(port.ErrorCodeAuthTokenInvalid, p.Result.ErrorCode)
+				assert.Contains(rlr.Limits, key)
+			}
+		})
+	}
+}
 
-		input := port.SetEmailInputData{
-			UserIDToken: "test-id-token",
-			EmailToken:  "test-email-token",
-		}
-		i.SetEmail(input)
+func TestRequestMagicLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		ur         repository.UserRepository
+		wantStatus model.EmailOutboxStatus
+	}{
+		{name: "サインインのリンクを送信する", ur: &stubUserRepository{}, wantStatus: model.EmailOutboxStatusPending},
+		{name: "登録されていないメールアドレスの場合も同じ処理を行い、送信しないメールとして保存する", ur: &stubNotFoundUserRepository{}, wantStatus: model.EmailOutboxStatusSuppressed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			mr := &stubEmailRepository{}
+			or := &stubEmailOutboxRepository{}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, tt.ur, &stubSessionRepository{}, mr, or, nil, nil, nil, &stubRateLimitRepository{}, nil, p)
+
+			i.RequestMagicLink(context.Background(), port.RequestMagicLinkInputData{Email: "test-email@example.com"})
+
+			output, ok := p.Output.(*port.RequestMagicLinkOutputData)
+			require.True(ok)
+			require.NotNil(output)
+			assert.NotEmpty(output.Nonce)
+			assert.Equal(http.StatusOK, p.Result.StatusCode)
+			assert.False(p.Result.HasError)
+
+			// 応答までの時間に差が出ないよう、どちらの場合もリクエストの処理の中では送信しない
+			assert.Zero(mr.Sent)
+
+			require.Len(or.Outboxes, 1)
+			for _, o := range or.Outboxes {
+				assert.Equal("test-email@example.com", o.To)
+				assert.Equal(tt.wantStatus, o.Status)
+				assert.Contains(o.HTML, "/signin/link?token=")
+			}
+
+			// 登録されていないメールアドレスにも送信の間隔の制限をかける
+			i.RequestMagicLink(context.Background(), port.RequestMagicLinkInputData{Email: "test-email@example.com"})
+			assert.Equal(http.StatusTooManyRequests, p.Result.StatusCode)
+			assert.Equal(port.ErrorCodeEmailCooldown, p.Result.ErrorCode)
+		})
+	}
+}
 
-		assert.Equal(http.StatusOK, p.Result.StatusCode)
-		assert.Empty(p.Result.ErrorMessage)
-		assert.False(p.Result.HasError)
-	})
+func TestSignInMagicLink(t *testing.T) {
+	nonce := "test-nonce"
+	enabled, _ := newEnabledTwoFactor(t, "test-id")
+
+	tests := []struct {
+		name          string
+		nonce         string
+		used          bool
+		tfr           *stubTwoFactorRepository
+		wantStatus    int
+		wantErrorCode port.ErrorCode
+		wantUsed      bool
+		wantTwoFactor bool
+	}{
+		{name: "サインインする", nonce: nonce, tfr: &stubTwoFactorRepository{}, wantStatus: http.StatusOK, wantUsed: true},
+		{name: "二段階認証が有効な場合は二段階認証のトークンを返す", nonce: nonce, tfr: &stubTwoFactorRepository{TwoFactors: map[string]model.TwoFactor{"test-id": enabled}}, wantStatus: http.StatusOK, wantUsed: true, wantTwoFactor: true},
+		{name: "別のブラウザの nonce の場合はリンクを使用済みにせずにエラーを返す", nonce: "other-nonce", tfr: &stubTwoFactorRepository{}, wantStatus: http.StatusUnauthorized, wantErrorCode: port.ErrorCodeAuthBrowserMismatch},
+		{name: "使用済みのリンクの場合はエラーを返す", nonce: nonce, used: true, tfr: &stubTwoFactorRepository{}, wantStatus: http.StatusUnauthorized, wantErrorCode: port.ErrorCodeAuthTokenInvalid, wantUsed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+			sr := &stubMagicLinkSessionRepository{NonceHash: model.HashMagicLinkNonce(nonce)}
+			utr := &stubUsedTokenRepository{Used: map[string]bool{"test-token": tt.used}}
+			lsr := &stubLoginSessionRepository{}
+			p := &stubUserOutputPort{}
+			i := NewUserInteractor(l, &stubUserRepository{}, sr, nil, nil, utr, &stubRefreshTokenRepository{}, lsr, nil, tt.tfr, p)
+
+			i.SignInMagicLink(port.SignInMagicLinkInputData{Token: "test-token", Nonce: tt.nonce})
+
+			assert.Equal(tt.wantStatus, p.Result.StatusCode)
+			assert.Equal(tt.wantErrorCode, p.Result.ErrorCode)
+			assert.Equal(tt.wantUsed, utr.Used["test-token"])
+
+			output, _ := p.Output.(*port.SignInOutputData)
+			if tt.wantStatus != http.StatusOK {
+				assert.Nil(output)
+				return
+			}
+			if tt.wantTwoFactor {
+				assert.Equal("test-token", output.TwoFactorToken)
+				assert.Empty(output.SessionToken)
+				assert.Empty(lsr.Sessions)
+				return
+			}
+			assert.Equal("test-id", output.ID)
+			assert.Equal("test-token", output.SessionToken)
+			assert.Len(lsr.Sessions, 1)
+		})
+	}
 }
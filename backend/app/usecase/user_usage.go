@@ -3,8 +3,8 @@ package usecase
 import (
 	"log/slog"
 	"net/http"
-	"time"
 
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
 	"github.com/datsukan/attendance-plan/backend/app/port"
 	"github.com/datsukan/attendance-plan/backend/app/repository"
 	"github.com/datsukan/attendance-plan/backend/infrastructure"
@@ -31,11 +31,12 @@ func NewUserUsageInteractor(logger *slog.Logger, userRepository repository.UserR
 }
 
 // GetUserUsageList は全ユーザーの利用状況リストを取得します。
+// 日時はリクエストした管理者のタイムゾーンで表します。
 func (i *UserUsageInteractor) GetUserUsageList(inputData port.GetUserUsageListInputData) {
 	requester, err := i.UserRepository.Read(inputData.RequesterUserID, true)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusUnauthorized, MsgUnauthorized)
+		r := port.NewErrorResult(http.StatusUnauthorized, port.ErrorCodeAuthUnauthorized, MsgUnauthorized)
 		i.OutputPort.SetResponseGetUserUsageList(nil, r)
 		return
 	}
@@ -43,7 +44,7 @@ func (i *UserUsageInteractor) GetUserUsageList(inputData port.GetUserUsageListIn
 	config := infrastructure.GetConfig()
 	if !isAdmin(requester.Email, config.AdminEmails) {
 		i.Logger.Warn("forbidden: not admin", "email", requester.Email)
-		r := port.NewErrorResult(http.StatusForbidden, MsgUserNotFound)
+		r := port.NewErrorResult(http.StatusForbidden, port.ErrorCodeAuthForbidden, MsgUserNotFound)
 		i.OutputPort.SetResponseGetUserUsageList(nil, r)
 		return
 	}
@@ -51,19 +52,19 @@ func (i *UserUsageInteractor) GetUserUsageList(inputData port.GetUserUsageListIn
 	users, err := i.UserRepository.ScanAll(true)
 	if err != nil {
 		i.Logger.Error(err.Error())
-		r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+		r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 		i.OutputPort.SetResponseGetUserUsageList(nil, r)
 		return
 	}
 
-	jst := time.FixedZone("JST", 9*60*60)
+	zone := timezone.LoadOrDefault(requester.Timezone)
 
 	outputUsers := make([]port.UserUsageData, 0, len(users))
 	for _, u := range users {
 		subjects, err := i.SubjectRepository.ReadByUserID(u.ID)
 		if err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseGetUserUsageList(nil, r)
 			return
 		}
@@ -71,7 +72,7 @@ func (i *UserUsageInteractor) GetUserUsageList(inputData port.GetUserUsageListIn
 		schedules, err := i.ScheduleRepository.ReadByUserID(u.ID)
 		if err != nil {
 			i.Logger.Error(err.Error())
-			r := port.NewErrorResult(http.StatusInternalServerError, MsgInternalServerError)
+			r := port.NewErrorResult(http.StatusInternalServerError, port.ErrorCodeInternal, MsgInternalServerError)
 			i.OutputPort.SetResponseGetUserUsageList(nil, r)
 			return
 		}
@@ -94,8 +95,8 @@ func (i *UserUsageInteractor) GetUserUsageList(inputData port.GetUserUsageListIn
 				ID:        s.ID,
 				Name:      s.Name,
 				Color:     s.Color,
-				CreatedAt: s.CreatedAt.In(jst).Format(time.DateTime),
-				UpdatedAt: s.UpdatedAt.In(jst).Format(time.DateTime),
+				CreatedAt: zone.FormatDateTime(s.CreatedAt),
+				UpdatedAt: zone.FormatDateTime(s.UpdatedAt),
 			})
 		}
 
@@ -103,8 +104,8 @@ func (i *UserUsageInteractor) GetUserUsageList(inputData port.GetUserUsageListIn
 			ID:           u.ID,
 			Email:        u.Email,
 			Name:         u.Name,
-			RegisteredAt: u.CreatedAt.In(jst).Format(time.DateTime),
-			LastUsedAt:   lastUsedAt.In(jst).Format(time.DateTime),
+			RegisteredAt: zone.FormatDateTime(u.CreatedAt),
+			LastUsedAt:   zone.FormatDateTime(lastUsedAt),
 			Subjects:     outputSubjects,
 		})
 	}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"net/http"
+
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/port"
+)
+
+// validationErrorResult は不変条件の検証に失敗した場合の結果を返します。
+// err が model.ValidationError の場合は違反を項目ごとに格納します。
+func validationErrorResult(err error) port.Result {
+	ve, ok := model.AsValidationError(err)
+	if !ok {
+		return port.NewErrorResult(http.StatusBadRequest, port.ErrorCodeValidationFailed, err.Error())
+	}
+
+	errors := make([]port.FieldError, 0, len(ve.Errors))
+	for _, fe := range ve.Errors {
+		errors = append(errors, port.FieldError{Field: fe.Field, Message: fe.Message})
+	}
+	return port.NewValidationErrorResult(http.StatusBadRequest, errors)
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/datsukan/attendance-plan/backend/app/handler"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
+)
+
+func main() {
+	lambda.Start(middleware.RequestID(middleware.Localize(handler.NewLocaleResolver(), handler.GetJWKS)))
+}
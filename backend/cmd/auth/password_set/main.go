@@ -4,8 +4,9 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 
 	"github.com/datsukan/attendance-plan/backend/app/handler"
+	"github.com/datsukan/attendance-plan/backend/app/middleware"
 )
 
 func main() {
-	lambda.Start(handler.PasswordSet)
+	lambda.Start(middleware.RequestID(middleware.Localize(handler.NewLocaleResolver(), handler.PasswordSet)))
 }
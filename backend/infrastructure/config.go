@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -17,22 +18,82 @@ type Config struct {
 	SenderEmail   string
 	SenderName    string
 	AdminEmails   []string
+
+	// AccessTokenLifeMinutes はアクセストークンの有効分数です。TokenLifeDays はリフレッシュトークンの有効日数です。
+	AccessTokenLifeMinutes int
+
+	// SigningKeys はトークンの署名と検証に使う鍵のセットの JSON です。指定しない場合は SecretKey で署名します。
+	SigningKeys string
+
+	// MailTransport はメールの送信手段です。ses、smtp、capture のいずれかを指定します。
+	MailTransport  string
+	SMTPHost       string
+	SMTPPort       int
+	SMTPUsername   string
+	SMTPPassword   string
+	MailCaptureDir string
+
+	// EmailOutboxMaxAttempts は送信待ちのメールの送信を諦めるまでの試行回数です。
+	EmailOutboxMaxAttempts int
+
+	// WebAuthnRPID はパスキーの RP ID です。指定しない場合は BaseUrl のホスト名を使います。
+	// WebAuthnOrigin はパスキーの登録と認証を受け付けるオリジンで、BaseUrl のスキームとホストです。
+	WebAuthnRPID   string
+	WebAuthnOrigin string
+
+	// OIDCProviders は外部アカウントでのサインインに使う ID プロバイダーの設定の JSON の配列です。
+	// 指定しない場合は外部アカウントでサインインできません。
+	OIDCProviders string
 }
 
 func init() {
 	serviceName := os.Getenv("SERVICE_NAME")
 	baseUrl := os.Getenv("BASE_URL")
 	secretKey := os.Getenv("SESSION_SECRET_KEY")
+	signingKeys := os.Getenv("SESSION_SIGNING_KEYS")
 	tokenLifeDaysStr := os.Getenv("SESSION_TOKEN_LIFE_DAYS")
 	tokenLifeDays, err := strconv.Atoi(tokenLifeDaysStr)
 	if err != nil {
 		tokenLifeDays = 30
 	}
+	accessTokenLifeMinutes, err := strconv.Atoi(os.Getenv("ACCESS_TOKEN_LIFE_MINUTES"))
+	if err != nil || accessTokenLifeMinutes < 1 {
+		accessTokenLifeMinutes = 15
+	}
 
 	sesRegion := os.Getenv("SES_REGION")
 	senderEmail := os.Getenv("SENDER_EMAIL")
 	senderName := os.Getenv("SENDER_NAME")
 
+	mailTransport := os.Getenv("MAIL_TRANSPORT")
+	if mailTransport == "" {
+		mailTransport = MailTransportSES
+	}
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil {
+		smtpPort = 25
+	}
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	mailCaptureDir := os.Getenv("MAIL_CAPTURE_DIR")
+
+	emailOutboxMaxAttempts, err := strconv.Atoi(os.Getenv("EMAIL_OUTBOX_MAX_ATTEMPTS"))
+	if err != nil || emailOutboxMaxAttempts < 1 {
+		emailOutboxMaxAttempts = 5
+	}
+
+	var webAuthnOrigin, webAuthnRPID string
+	if u, err := url.Parse(baseUrl); err == nil && u.Host != "" {
+		webAuthnOrigin = u.Scheme + "://" + u.Host
+		webAuthnRPID = u.Hostname()
+	}
+	if id := os.Getenv("WEBAUTHN_RP_ID"); id != "" {
+		webAuthnRPID = id
+	}
+
+	oidcProviders := os.Getenv("OIDC_PROVIDERS")
+
 	var adminEmails []string
 	for _, e := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
 		if e = strings.TrimSpace(e); e != "" {
@@ -49,6 +110,24 @@ func init() {
 		SenderEmail:   senderEmail,
 		SenderName:    senderName,
 		AdminEmails:   adminEmails,
+
+		AccessTokenLifeMinutes: accessTokenLifeMinutes,
+
+		SigningKeys: signingKeys,
+
+		MailTransport:  mailTransport,
+		SMTPHost:       smtpHost,
+		SMTPPort:       smtpPort,
+		SMTPUsername:   smtpUsername,
+		SMTPPassword:   smtpPassword,
+		MailCaptureDir: mailCaptureDir,
+
+		EmailOutboxMaxAttempts: emailOutboxMaxAttempts,
+
+		WebAuthnRPID:   webAuthnRPID,
+		WebAuthnOrigin: webAuthnOrigin,
+
+		OIDCProviders: oidcProviders,
 	}
 }
 
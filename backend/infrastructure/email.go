@@ -2,15 +2,62 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"net/mail"
 
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/aws/aws-sdk-go/aws"
 )
 
+// MailTransport の種類です。Config.MailTransport で指定します。
+const (
+	MailTransportSES     = "ses"
+	MailTransportSMTP    = "smtp"
+	MailTransportCapture = "capture"
+)
+
+// OutgoingMail は送信するメールを表す構造体です。
+// HTML を指定した場合はテキストと HTML を multipart/alternative として送信します。
+type OutgoingMail struct {
+	From    mail.Address
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// MailTransport はメールを送信する手段を表すインターフェースです。
+type MailTransport interface {
+	Send(ctx context.Context, m OutgoingMail) (messageID string, err error)
+}
+
+// NewMailTransport は設定に応じた MailTransport を生成します。
+// 種類が指定されていない場合は SES を使います。
+func NewMailTransport(ctx context.Context, config Config) (MailTransport, error) {
+	switch config.MailTransport {
+	case "", MailTransportSES:
+		client, err := NewMailClient(ctx, config.SESRegion)
+		if err != nil {
+			return nil, err
+		}
+		return NewSESTransport(client), nil
+	case MailTransportSMTP:
+		return NewSMTPTransport(config.SMTPHost, config.SMTPPort, config.SMTPUsername, config.SMTPPassword), nil
+	case MailTransportCapture:
+		return NewCaptureTransport(config.MailCaptureDir), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", config.MailTransport)
+	}
+}
+
+// MailClient は SES v2 のメール送信のクライアントを表すインターフェースです。
 type MailClient interface {
 	SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
 }
 
+// NewMailClient は SES v2 のクライアントを生成します。
 func NewMailClient(ctx context.Context, region string) (MailClient, error) {
 	cfg, err := awsConfig.LoadDefaultConfig(ctx, awsConfig.WithRegion(region))
 	if err != nil {
@@ -18,3 +65,61 @@ func NewMailClient(ctx context.Context, region string) (MailClient, error) {
 	}
 	return sesv2.NewFromConfig(cfg), nil
 }
+
+// SESTransport は SES v2 でメールを送信する MailTransport です。
+type SESTransport struct {
+	Client MailClient
+}
+
+// NewSESTransport は SESTransport を生成します。
+func NewSESTransport(client MailClient) *SESTransport {
+	return &SESTransport{Client: client}
+}
+
+// Send はメールを送信します。
+func (t *SESTransport) Send(ctx context.Context, m OutgoingMail) (string, error) {
+	if t.Client == nil {
+		return "", fmt.Errorf("client is nil")
+	}
+
+	body := &types.Body{
+		Text: &types.Content{
+			Data:    aws.String(m.Text), // 本文
+			Charset: aws.String("UTF-8"),
+		},
+	}
+	if m.HTML != "" {
+		// テキストと HTML の両方を指定すると SES が multipart/alternative で送信する
+		body.Html = &types.Content{
+			Data:    aws.String(m.HTML),
+			Charset: aws.String("UTF-8"),
+		}
+	}
+
+	input := &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(m.From.String()), // 送信元
+		Destination: &types.Destination{
+			ToAddresses: m.To, // 送信先
+		},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Body: body,
+				Subject: &types.Content{
+					Data:    aws.String(m.Subject), // 件名
+					Charset: aws.String("UTF-8"),
+				},
+			},
+		},
+	}
+
+	res, err := t.Client.SendEmail(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	if res == nil || res.MessageId == nil {
+		return "", fmt.Errorf("failed to send email")
+	}
+
+	return *res.MessageId, nil
+}
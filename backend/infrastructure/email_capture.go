@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CapturedMail は CaptureTransport が保存したメールを表す構造体です。
+type CapturedMail struct {
+	MessageID string
+	OutgoingMail
+}
+
+// CaptureTransport はメールを送信せずに保存する MailTransport です。
+// テストでは Messages で送信内容を確認し、Dir を指定した場合は .eml ファイルとしても書き出します。
+type CaptureTransport struct {
+	Dir string
+
+	mu       sync.Mutex
+	messages []CapturedMail
+}
+
+// NewCaptureTransport は CaptureTransport を生成します。
+// dir が空の場合はメモリにのみ保存します。
+func NewCaptureTransport(dir string) *CaptureTransport {
+	return &CaptureTransport{Dir: dir}
+}
+
+// Send はメールを保存します。
+func (t *CaptureTransport) Send(ctx context.Context, m OutgoingMail) (string, error) {
+	messageID := newMessageID(m.From.Address)
+
+	if t.Dir != "" {
+		msg, err := BuildMIMEMessage(m, messageID, time.Now())
+		if err != nil {
+			return "", err
+		}
+
+		if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(t.Dir, messageID+".eml"), msg, 0o644); err != nil {
+			return "", err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, CapturedMail{MessageID: messageID, OutgoingMail: m})
+
+	return messageID, nil
+}
+
+// Messages は保存したメールを送信した順に返します。
+func (t *CaptureTransport) Messages() []CapturedMail {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	messages := make([]CapturedMail, len(t.messages))
+	copy(messages, t.messages)
+	return messages
+}
+
+// Reset は保存したメールを破棄します。
+func (t *CaptureTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.messages = nil
+}
@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// SMTPTransport は SMTP サーバーでメールを送信する MailTransport です。
+// ローカル開発では Mailpit のような SMTP サーバーに送信して内容を確認します。
+type SMTPTransport struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// NewSMTPTransport は SMTPTransport を生成します。
+// Username が空の場合は認証せずに送信します。
+func NewSMTPTransport(host string, port int, username, password string) *SMTPTransport {
+	return &SMTPTransport{Host: host, Port: port, Username: username, Password: password}
+}
+
+// Send はメールを送信します。
+func (t *SMTPTransport) Send(ctx context.Context, m OutgoingMail) (string, error) {
+	if t.Host == "" {
+		return "", fmt.Errorf("smtp host is empty")
+	}
+
+	messageID := newMessageID(m.From.Address)
+	msg, err := BuildMIMEMessage(m, messageID, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	var auth smtp.Auth
+	if t.Username != "" {
+		auth = smtp.PlainAuth("", t.Username, t.Password, t.Host)
+	}
+
+	addr := net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+	if err := smtp.SendMail(addr, auth, m.From.Address, m.To, msg); err != nil {
+		return "", err
+	}
+
+	return messageID, nil
+}
+
+// newMessageID は送信元のドメインで Message-ID を発行します。
+func newMessageID(from string) string {
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 && i+1 < len(from) {
+		domain = from[i+1:]
+	}
+	return fmt.Sprintf("%s@%s", ulid.Make().String(), domain)
+}
+
+// BuildMIMEMessage はメールを MIME 形式に変換します。
+// HTML がある場合は multipart/alternative、ない場合は text/plain のみのメッセージにします。
+func BuildMIMEMessage(m OutgoingMail, messageID string, date time.Time) ([]byte, error) {
+	var b bytes.Buffer
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", m.From.String())
+	header.Set("To", strings.Join(m.To, ", "))
+	header.Set("Subject", mime.BEncoding.Encode("UTF-8", m.Subject))
+	header.Set("Date", date.Format(time.RFC1123Z))
+	header.Set("Message-ID", "<"+messageID+">")
+	header.Set("MIME-Version", "1.0")
+
+	if m.HTML == "" {
+		header.Set("Content-Type", "text/plain; charset=UTF-8")
+		header.Set("Content-Transfer-Encoding", "base64")
+		writeHeader(&b, header)
+		writeBase64(&b, m.Text)
+		return b.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		// 受信側は後ろのパートを優先して表示するため、HTML を後ろに置く
+		{contentType: "text/plain; charset=UTF-8", content: m.Text},
+		{contentType: "text/html; charset=UTF-8", content: m.HTML},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64(pw, p.content)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	header.Set("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	writeHeader(&b, header)
+	b.Write(body.Bytes())
+
+	return b.Bytes(), nil
+}
+
+// headerOrder はヘッダーを書き出す順序です。
+var headerOrder = []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"}
+
+func writeHeader(w *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, k := range headerOrder {
+		if v := header.Get(k); v != "" {
+			fmt.Fprintf(w, "%s: %s\r\n", k, v)
+		}
+	}
+	w.WriteString("\r\n")
+}
+
+// writeBase64 は本文を Base64 で 76 文字ごとに改行して書き出します。
+func writeBase64(w interface{ Write([]byte) (int, error) }, s string) {
+	const lineLength = 76
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(s))
+	for len(encoded) > lineLength {
+		w.Write([]byte(encoded[:lineLength] + "\r\n"))
+		encoded = encoded[lineLength:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
@@ -0,0 +1,234 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubRecordMailClient struct {
+	Input *sesv2.SendEmailInput
+	Err   error
+}
+
+func (s *stubRecordMailClient) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	s.Input = params
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	messageID := "test-message-id"
+	return &sesv2.SendEmailOutput{
+		MessageId: &messageID,
+	}, nil
+}
+
+func testOutgoingMail(html string) OutgoingMail {
+	return OutgoingMail{
+		From:    mail.Address{Name: "送信者", Address: "sender@example.com"},
+		To:      []string{"to@example.com"},
+		Subject: "件名のテスト",
+		Text:    "本文のテスト",
+		HTML:    html,
+	}
+}
+
+func TestSESTransport_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   *stubRecordMailClient
+		html     string
+		want     string
+		wantHTML bool
+		wantErr  bool
+	}{
+		{name: "テキストと HTML を送信する", client: &stubRecordMailClient{}, html: "<p>本文のテスト</p>", want: "test-message-id", wantHTML: true},
+		{name: "HTML がない場合はテキストのみを送信する", client: &stubRecordMailClient{}, want: "test-message-id"},
+		{name: "異常系: SendEmail がエラー", client: &stubRecordMailClient{Err: errors.New("send email error")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := testOutgoingMail(tt.html)
+			got, err := NewSESTransport(tt.client).Send(context.Background(), m)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Empty(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+
+			in := tt.client.Input
+			require.NotNil(in)
+			assert.Equal(m.From.String(), *in.FromEmailAddress)
+			assert.Equal(m.To, in.Destination.ToAddresses)
+			assert.Equal(m.Subject, *in.Content.Simple.Subject.Data)
+			assert.Equal(m.Text, *in.Content.Simple.Body.Text.Data)
+			if !tt.wantHTML {
+				assert.Nil(in.Content.Simple.Body.Html)
+				return
+			}
+			require.NotNil(in.Content.Simple.Body.Html)
+			assert.Equal(m.HTML, *in.Content.Simple.Body.Html.Data)
+		})
+	}
+}
+
+func TestBuildMIMEMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		wantParts []string
+	}{
+		{name: "HTML がある場合は multipart/alternative", html: "<p>本文のテスト</p>", wantParts: []string{"text/plain", "text/html"}},
+		{name: "HTML がない場合は text/plain のみ", wantParts: []string{"text/plain"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			m := testOutgoingMail(tt.html)
+			date := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+			raw, err := BuildMIMEMessage(m, "test-id@example.com", date)
+			require.NoError(err)
+
+			msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+			require.NoError(err)
+
+			dec := new(mime.WordDecoder)
+			subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+			require.NoError(err)
+			assert.Equal(m.Subject, subject)
+
+			from, err := msg.Header.AddressList("From")
+			require.NoError(err)
+			assert.Equal([]*mail.Address{&m.From}, from)
+			assert.Equal("to@example.com", msg.Header.Get("To"))
+			assert.Equal("<test-id@example.com>", msg.Header.Get("Message-ID"))
+			gotDate, err := msg.Header.Date()
+			require.NoError(err)
+			assert.True(date.Equal(gotDate))
+
+			mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+			require.NoError(err)
+
+			if len(tt.wantParts) == 1 {
+				assert.Equal("text/plain", mediaType)
+				assert.Equal(m.Text, decodeBase64Body(t, msg.Body))
+				return
+			}
+
+			assert.Equal("multipart/alternative", mediaType)
+			mr := multipart.NewReader(msg.Body, params["boundary"])
+			wantContents := []string{m.Text, m.HTML}
+			for i, want := range tt.wantParts {
+				p, err := mr.NextPart()
+				require.NoError(err)
+				partType, _, err := mime.ParseMediaType(p.Header.Get("Content-Type"))
+				require.NoError(err)
+				assert.Equal(want, partType)
+				assert.Equal(wantContents[i], decodeBase64Body(t, p))
+			}
+			_, err = mr.NextPart()
+			assert.Equal(io.EOF, err)
+		})
+	}
+}
+
+func decodeBase64Body(t *testing.T, r io.Reader) string {
+	t.Helper()
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	s := strings.NewReplacer("\r", "", "\n", "").Replace(string(b))
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return string(decoded)
+}
+
+func TestCaptureTransport_Send(t *testing.T) {
+	tests := []struct {
+		name    string
+		withDir bool
+	}{
+		{name: "メモリに保存する"},
+		{name: "ディレクトリを指定した場合は .eml ファイルも書き出す", withDir: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var dir string
+			if tt.withDir {
+				dir = t.TempDir()
+			}
+			c := NewCaptureTransport(dir)
+
+			m := testOutgoingMail("<p>本文のテスト</p>")
+			got, err := c.Send(context.Background(), m)
+			require.NoError(err)
+			assert.True(strings.HasSuffix(got, "@example.com"))
+
+			messages := c.Messages()
+			require.Len(messages, 1)
+			assert.Equal(CapturedMail{MessageID: got, OutgoingMail: m}, messages[0])
+
+			if tt.withDir {
+				raw, err := os.ReadFile(filepath.Join(dir, got+".eml"))
+				require.NoError(err)
+				msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+				require.NoError(err)
+				assert.Equal("<"+got+">", msg.Header.Get("Message-ID"))
+			}
+
+			c.Reset()
+			assert.Empty(c.Messages())
+		})
+	}
+}
+
+func TestNewMailTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    MailTransport
+		wantErr bool
+	}{
+		{name: "SMTP", config: Config{MailTransport: MailTransportSMTP, SMTPHost: "localhost", SMTPPort: 1025}, want: &SMTPTransport{Host: "localhost", Port: 1025}},
+		{name: "キャプチャ", config: Config{MailTransport: MailTransportCapture, MailCaptureDir: "mail"}, want: &CaptureTransport{Dir: "mail"}},
+		{name: "異常系: 未知の種類", config: Config{MailTransport: "unknown"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMailTransport(context.Background(), tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// mailpreview はメールのテンプレートをフィクスチャのデータでレンダリングします。
+// -out を指定した場合はテンプレートと言語ごとにファイルを書き出し、指定しない場合は標準出力に表示します。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/i18n"
+	"github.com/datsukan/attendance-plan/backend/app/component/mailtemplate"
+)
+
+func main() {
+	out := flag.String("out", "", "レンダリング結果を書き出すディレクトリ")
+	flag.Parse()
+
+	if *out != "" {
+		if err := os.MkdirAll(*out, 0o755); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	for _, data := range mailtemplate.Fixtures() {
+		for _, locale := range i18n.Supported() {
+			msg, err := mailtemplate.Render(locale, data)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			if *out == "" {
+				printMessage(data.TemplateName(), locale, msg)
+				continue
+			}
+
+			if err := writeMessage(*out, data.TemplateName(), locale, msg); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if *out != "" {
+		fmt.Println("successful:", *out)
+	}
+}
+
+func printMessage(name mailtemplate.Name, locale i18n.Locale, msg mailtemplate.Message) {
+	fmt.Printf("===== %s (%s) =====\n", name, locale)
+	fmt.Printf("Subject: %s\n\n", msg.Subject)
+	fmt.Printf("----- text -----\n%s\n", msg.Text)
+	fmt.Printf("----- html -----\n%s\n", msg.HTML)
+}
+
+func writeMessage(dir string, name mailtemplate.Name, locale i18n.Locale, msg mailtemplate.Message) error {
+	base := filepath.Join(dir, fmt.Sprintf("%s.%s", name, locale))
+	files := map[string]string{
+		base + ".subject.txt": msg.Subject + "\n",
+		base + ".txt":         msg.Text,
+		base + ".html":        msg.HTML,
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
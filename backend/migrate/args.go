@@ -8,8 +8,9 @@ import (
 type ExecType string
 
 const (
-	ExecTypeUp   ExecType = "up"
-	ExecTypeDown ExecType = "down"
+	ExecTypeUp             ExecType = "up"
+	ExecTypeDown           ExecType = "down"
+	ExecTypeRebaseTimezone ExecType = "rebase-timezone"
 )
 
 func GetArgs() (ExecType, error) {
@@ -22,7 +23,9 @@ func GetArgs() (ExecType, error) {
 		return ExecTypeUp, nil
 	case "down":
 		return ExecTypeDown, nil
+	case "rebase-timezone":
+		return ExecTypeRebaseTimezone, nil
 	default:
-		return "", errors.New("引数はupかdownを指定してください")
+		return "", errors.New("引数はup、down、rebase-timezoneのいずれかを指定してください")
 	}
 }
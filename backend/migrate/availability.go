@@ -0,0 +1,36 @@
+package main
+
+import (
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const TableNameAvailability = "AttendancePlan_Availability"
+
+type Availability struct {
+	UserID            string         `dynamo:"UserID,hash"`
+	Weekdays          []time.Weekday `dynamo:"Weekdays"`
+	MaxLecturesPerDay int            `dynamo:"MaxLecturesPerDay"`
+	CreatedAt         time.Time      `dynamo:"CreatedAt"`
+	UpdatedAt         time.Time      `dynamo:"UpdatedAt"`
+}
+
+func (a Availability) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameAvailability {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameAvailability, Availability{}).Run()
+}
+
+func (a Availability) Down(db *dynamo.DB) error {
+	return db.Table(TableNameAvailability).DeleteTable().Run()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const TableNameBlackout = "AttendancePlan_Blackout"
+
+type Blackout struct {
+	ID        string    `dynamo:"ID,hash"`
+	UserID    string    `dynamo:"UserID" index:"UserID-index,hash"`
+	Label     string    `dynamo:"Label"`
+	StartsAt  time.Time `dynamo:"StartsAt" index:"UserID-index,range"`
+	EndsAt    time.Time `dynamo:"EndsAt"`
+	CreatedAt time.Time `dynamo:"CreatedAt"`
+	UpdatedAt time.Time `dynamo:"UpdatedAt"`
+}
+
+func (b Blackout) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameBlackout {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameBlackout, Blackout{}).Run()
+}
+
+func (b Blackout) Down(db *dynamo.DB) error {
+	return db.Table(TableNameBlackout).DeleteTable().Run()
+}
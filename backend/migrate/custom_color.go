@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const TableNameCustomColor = "AttendancePlan_CustomColor"
+
+type CustomColor struct {
+	ID        string    `dynamo:"ID,hash"`
+	UserID    string    `dynamo:"UserID" index:"UserID-index,hash"`
+	Name      string    `dynamo:"Name"`
+	Hex       string    `dynamo:"Hex"`
+	Style     string    `dynamo:"Style"`
+	CreatedAt time.Time `dynamo:"CreatedAt" index:"UserID-index,range"`
+	UpdatedAt time.Time `dynamo:"UpdatedAt"`
+}
+
+func (c CustomColor) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameCustomColor {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameCustomColor, CustomColor{}).Run()
+}
+
+func (c CustomColor) Down(db *dynamo.DB) error {
+	return db.Table(TableNameCustomColor).DeleteTable().Run()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const TableNameEmailOutbox = "AttendancePlan_EmailOutbox"
+
+type EmailOutbox struct {
+	ID            string    `dynamo:"ID,hash"`
+	Status        string    `dynamo:"Status" index:"Status-index,hash"`
+	NextAttemptAt time.Time `dynamo:"NextAttemptAt" index:"Status-index,range"`
+}
+
+func (e EmailOutbox) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameEmailOutbox {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameEmailOutbox, EmailOutbox{}).Run()
+}
+
+func (e EmailOutbox) Down(db *dynamo.DB) error {
+	return db.Table(TableNameEmailOutbox).DeleteTable().Run()
+}
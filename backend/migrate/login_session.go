@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameLoginSession = "AttendancePlan_LoginSession"
+
+type LoginSession struct {
+	ID     string `dynamo:"ID,hash"`
+	UserID string `dynamo:"UserID" index:"UserID-index,hash"`
+}
+
+func (s LoginSession) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameLoginSession {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameLoginSession, LoginSession{}).Run()
+}
+
+func (s LoginSession) Down(db *dynamo.DB) error {
+	return db.Table(TableNameLoginSession).DeleteTable().Run()
+}
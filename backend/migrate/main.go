@@ -27,6 +27,11 @@ func main() {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+	case ExecTypeRebaseTimezone:
+		if err := rebaseScheduleTimezone(db); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	}
 
 	fmt.Println("successful")
@@ -48,6 +53,81 @@ func up(db *dynamo.DB) error {
 		return err
 	}
 
+	availability := Availability{}
+	if err := availability.Up(db); err != nil {
+		return err
+	}
+
+	blackout := Blackout{}
+	if err := blackout.Up(db); err != nil {
+		return err
+	}
+
+	tag := Tag{}
+	if err := tag.Up(db); err != nil {
+		return err
+	}
+
+	scheduleTag := ScheduleTag{}
+	if err := scheduleTag.Up(db); err != nil {
+		return err
+	}
+
+	customColor := CustomColor{}
+	if err := customColor.Up(db); err != nil {
+		return err
+	}
+
+	emailOutbox := EmailOutbox{}
+	if err := emailOutbox.Up(db); err != nil {
+		return err
+	}
+
+	usedToken := UsedToken{}
+	if err := usedToken.Up(db); err != nil {
+		return err
+	}
+
+	refreshToken := RefreshToken{}
+	if err := refreshToken.Up(db); err != nil {
+		return err
+	}
+
+	loginSession := LoginSession{}
+	if err := loginSession.Up(db); err != nil {
+		return err
+	}
+
+	rateLimit := RateLimit{}
+	if err := rateLimit.Up(db); err != nil {
+		return err
+	}
+
+	twoFactor := TwoFactor{}
+	if err := twoFactor.Up(db); err != nil {
+		return err
+	}
+
+	passkey := Passkey{}
+	if err := passkey.Up(db); err != nil {
+		return err
+	}
+
+	webAuthnChallenge := WebAuthnChallenge{}
+	if err := webAuthnChallenge.Up(db); err != nil {
+		return err
+	}
+
+	oidcState := OIDCState{}
+	if err := oidcState.Up(db); err != nil {
+		return err
+	}
+
+	oidcIdentity := OIDCIdentity{}
+	if err := oidcIdentity.Up(db); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -67,5 +147,80 @@ func down(db *dynamo.DB) error {
 		return err
 	}
 
+	availability := Availability{}
+	if err := availability.Down(db); err != nil {
+		return err
+	}
+
+	blackout := Blackout{}
+	if err := blackout.Down(db); err != nil {
+		return err
+	}
+
+	tag := Tag{}
+	if err := tag.Down(db); err != nil {
+		return err
+	}
+
+	scheduleTag := ScheduleTag{}
+	if err := scheduleTag.Down(db); err != nil {
+		return err
+	}
+
+	customColor := CustomColor{}
+	if err := customColor.Down(db); err != nil {
+		return err
+	}
+
+	emailOutbox := EmailOutbox{}
+	if err := emailOutbox.Down(db); err != nil {
+		return err
+	}
+
+	usedToken := UsedToken{}
+	if err := usedToken.Down(db); err != nil {
+		return err
+	}
+
+	refreshToken := RefreshToken{}
+	if err := refreshToken.Down(db); err != nil {
+		return err
+	}
+
+	loginSession := LoginSession{}
+	if err := loginSession.Down(db); err != nil {
+		return err
+	}
+
+	rateLimit := RateLimit{}
+	if err := rateLimit.Down(db); err != nil {
+		return err
+	}
+
+	twoFactor := TwoFactor{}
+	if err := twoFactor.Down(db); err != nil {
+		return err
+	}
+
+	passkey := Passkey{}
+	if err := passkey.Down(db); err != nil {
+		return err
+	}
+
+	webAuthnChallenge := WebAuthnChallenge{}
+	if err := webAuthnChallenge.Down(db); err != nil {
+		return err
+	}
+
+	oidcState := OIDCState{}
+	if err := oidcState.Down(db); err != nil {
+		return err
+	}
+
+	oidcIdentity := OIDCIdentity{}
+	if err := oidcIdentity.Down(db); err != nil {
+		return err
+	}
+
 	return nil
 }
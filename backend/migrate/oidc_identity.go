@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameOIDCIdentity = "AttendancePlan_OIDCIdentity"
+
+type OIDCIdentity struct {
+	ID string `dynamo:"ID,hash"`
+}
+
+func (i OIDCIdentity) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameOIDCIdentity {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameOIDCIdentity, OIDCIdentity{}).Run()
+}
+
+func (i OIDCIdentity) Down(db *dynamo.DB) error {
+	return db.Table(TableNameOIDCIdentity).DeleteTable().Run()
+}
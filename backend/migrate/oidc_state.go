@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameOIDCState = "AttendancePlan_OIDCState"
+
+type OIDCState struct {
+	State string `dynamo:"State,hash"`
+}
+
+func (s OIDCState) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameOIDCState {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameOIDCState, OIDCState{}).Run()
+}
+
+func (s OIDCState) Down(db *dynamo.DB) error {
+	return db.Table(TableNameOIDCState).DeleteTable().Run()
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNamePasskey = "AttendancePlan_Passkey"
+
+type Passkey struct {
+	ID     string `dynamo:"ID,hash"`
+	UserID string `dynamo:"UserID" index:"UserID-index,hash"`
+}
+
+func (p Passkey) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNamePasskey {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNamePasskey, Passkey{}).Run()
+}
+
+func (p Passkey) Down(db *dynamo.DB) error {
+	return db.Table(TableNamePasskey).DeleteTable().Run()
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameRateLimit = "AttendancePlan_RateLimit"
+
+type RateLimit struct {
+	Key string `dynamo:"Key,hash"`
+}
+
+func (r RateLimit) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameRateLimit {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameRateLimit, RateLimit{}).Run()
+}
+
+func (r RateLimit) Down(db *dynamo.DB) error {
+	return db.Table(TableNameRateLimit).DeleteTable().Run()
+}
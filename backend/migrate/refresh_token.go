@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameRefreshToken = "AttendancePlan_RefreshToken"
+
+type RefreshToken struct {
+	ID       string `dynamo:"ID,hash"`
+	FamilyID string `dynamo:"FamilyID" index:"FamilyID-index,hash"`
+}
+
+func (r RefreshToken) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameRefreshToken {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameRefreshToken, RefreshToken{}).Run()
+}
+
+func (r RefreshToken) Down(db *dynamo.DB) error {
+	return db.Table(TableNameRefreshToken).DeleteTable().Run()
+}
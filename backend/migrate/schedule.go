@@ -9,16 +9,22 @@ import (
 const TableNameSchedule = "AttendancePlan_Schedule"
 
 type Schedule struct {
-	ID        string    `dynamo:"ID,hash"`
-	UserID    string    `dynamo:"UserID" index:"UserID-index,hash"`
-	Name      string    `dynamo:"Name"`
-	StartsAt  time.Time `dynamo:"StartsAt" index:"UserID-index,range"`
-	EndsAt    time.Time `dynamo:"EndsAt"`
-	Color     string    `dynamo:"Color"`
-	Type      string    `dynamo:"Type"`
-	Order     int       `dynamo:"Order"`
-	CreatedAt time.Time `dynamo:"CreatedAt"`
-	UpdatedAt time.Time `dynamo:"UpdatedAt"`
+	ID            string    `dynamo:"ID,hash"`
+	UserID        string    `dynamo:"UserID" index:"UserID-index,hash"`
+	Name          string    `dynamo:"Name"`
+	StartsAt      time.Time `dynamo:"StartsAt" index:"UserID-index,range"`
+	EndsAt        time.Time `dynamo:"EndsAt"`
+	Timed         bool      `dynamo:"Timed"`
+	Color         string    `dynamo:"Color"`
+	Type          string    `dynamo:"Type"`
+	Order         int       `dynamo:"Order"`
+	LectureNumber int       `dynamo:"LectureNumber"`
+	Note          string    `dynamo:"Note"`
+	Links         []string  `dynamo:"Links"`
+	Memo          string    `dynamo:"Memo"`
+	TagIDs        []string  `dynamo:"TagIDs"`
+	CreatedAt     time.Time `dynamo:"CreatedAt"`
+	UpdatedAt     time.Time `dynamo:"UpdatedAt"`
 }
 
 func (s Schedule) Up(db *dynamo.DB) error {
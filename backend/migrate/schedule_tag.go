@@ -0,0 +1,35 @@
+package main
+
+import (
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const TableNameScheduleTag = "AttendancePlan_ScheduleTag"
+
+type ScheduleTag struct {
+	TagID      string    `dynamo:"TagID,hash"`
+	ScheduleID string    `dynamo:"ScheduleID,range" index:"ScheduleID-index,hash"`
+	UserID     string    `dynamo:"UserID"`
+	CreatedAt  time.Time `dynamo:"CreatedAt"`
+}
+
+func (s ScheduleTag) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameScheduleTag {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameScheduleTag, ScheduleTag{}).Run()
+}
+
+func (s ScheduleTag) Down(db *dynamo.DB) error {
+	return db.Table(TableNameScheduleTag).DeleteTable().Run()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/timezone"
+	"github.com/datsukan/attendance-plan/backend/app/model"
+	"github.com/datsukan/attendance-plan/backend/app/repository"
+	"github.com/guregu/dynamo"
+)
+
+// rebaseScheduleTimezone はタイムゾーン対応前に UTC の 0 時として保存された終日のスケジュールを、
+// ユーザーのタイムゾーンの日付に移し替えます。移し替え済みのスケジュールは対象外のため、繰り返し実行できます。
+func rebaseScheduleTimezone(db *dynamo.DB) error {
+	users, err := repository.NewUserRepository(*db).ScanAll(false)
+	if err != nil {
+		return err
+	}
+
+	zones := make(map[string]timezone.Zone, len(users))
+	for _, u := range users {
+		zones[u.ID] = timezone.LoadOrDefault(u.Timezone)
+	}
+
+	var schedules []model.Schedule
+	if err := db.Table(TableNameSchedule).Scan().All(&schedules); err != nil {
+		return err
+	}
+
+	sr := repository.NewScheduleRepository(*db)
+	count := 0
+	for _, s := range schedules {
+		zone, ok := zones[s.UserID]
+		if !ok {
+			zone = timezone.Default()
+		}
+
+		rebased, ok := s.RebaseLegacyDate(zone.Location())
+		if !ok {
+			continue
+		}
+
+		if err := sr.Update(&rebased); err != nil {
+			return err
+		}
+		count++
+	}
+
+	fmt.Printf("rebased %d schedules\n", count)
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"time"
+
+	"github.com/guregu/dynamo"
+)
+
+const TableNameTag = "AttendancePlan_Tag"
+
+type Tag struct {
+	ID        string    `dynamo:"ID,hash"`
+	UserID    string    `dynamo:"UserID" index:"UserID-index,hash"`
+	Name      string    `dynamo:"Name" index:"UserID-index,range"`
+	Color     string    `dynamo:"Color"`
+	CreatedAt time.Time `dynamo:"CreatedAt"`
+	UpdatedAt time.Time `dynamo:"UpdatedAt"`
+}
+
+func (t Tag) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameTag {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameTag, Tag{}).Run()
+}
+
+func (t Tag) Down(db *dynamo.DB) error {
+	return db.Table(TableNameTag).DeleteTable().Run()
+}
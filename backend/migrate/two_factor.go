@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameTwoFactor = "AttendancePlan_TwoFactor"
+
+type TwoFactor struct {
+	UserID string `dynamo:"UserID,hash"`
+}
+
+func (r TwoFactor) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameTwoFactor {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameTwoFactor, TwoFactor{}).Run()
+}
+
+func (r TwoFactor) Down(db *dynamo.DB) error {
+	return db.Table(TableNameTwoFactor).DeleteTable().Run()
+}
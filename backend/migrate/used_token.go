@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameUsedToken = "AttendancePlan_UsedToken"
+
+type UsedToken struct {
+	ID string `dynamo:"ID,hash"`
+}
+
+func (u UsedToken) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameUsedToken {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameUsedToken, UsedToken{}).Run()
+}
+
+func (u UsedToken) Down(db *dynamo.DB) error {
+	return db.Table(TableNameUsedToken).DeleteTable().Run()
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/guregu/dynamo"
+)
+
+const TableNameWebAuthnChallenge = "AttendancePlan_WebAuthnChallenge"
+
+type WebAuthnChallenge struct {
+	Challenge string `dynamo:"Challenge,hash"`
+}
+
+func (c WebAuthnChallenge) Up(db *dynamo.DB) error {
+	tables, err := db.ListTables().All()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if table == TableNameWebAuthnChallenge {
+			return nil
+		}
+	}
+
+	return db.CreateTable(TableNameWebAuthnChallenge, WebAuthnChallenge{}).Run()
+}
+
+func (c WebAuthnChallenge) Down(db *dynamo.DB) error {
+	return db.Table(TableNameWebAuthnChallenge).DeleteTable().Run()
+}
@@ -0,0 +1,44 @@
+// mockoidc はローカルでの開発に使うモックの OpenID Connect のプロバイダーを起動します。
+// 認可のリクエストではログインの画面を表示せず、指定したユーザーとしてすぐにコールバックにリダイレクトします。
+// 認可のリクエストに login_hint を指定した場合は、そのメールアドレスのユーザーとしてサインインします。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/datsukan/attendance-plan/backend/app/component/oidc/oidctest"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9000", "待ち受けるアドレス")
+	issuer := flag.String("issuer", "", "発行者の URL (指定しない場合は http://<addr>)")
+	clientID := flag.String("client-id", "attendance-plan", "クライアント ID")
+	clientSecret := flag.String("client-secret", "", "クライアントシークレット (指定しない場合は公開クライアント)")
+	sub := flag.String("sub", "mock-user", "サインインするユーザーの sub")
+	email := flag.String("email", "student@example.ac.jp", "サインインするユーザーのメールアドレス")
+	name := flag.String("name", "モック ユーザー", "サインインするユーザーの名前")
+	unverified := flag.Bool("unverified", false, "メールアドレスを確認されていないものとする")
+	flag.Parse()
+
+	if *issuer == "" {
+		*issuer = "http://" + *addr
+	}
+
+	user := oidctest.User{Subject: *sub, Email: *email, EmailVerified: !*unverified, Name: *name}
+	p, err := oidctest.New(*issuer, *clientID, *clientSecret, user)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println("issuer:", *issuer)
+	fmt.Printf("OIDC_PROVIDERS='[{\"id\":\"mock\",\"name\":\"Mock\",\"issuer\":%q,\"client_id\":%q,\"client_secret\":%q}]'\n", *issuer, *clientID, *clientSecret)
+
+	if err := http.ListenAndServe(*addr, p); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -1,6 +1,6 @@
 'use client';
 
-import { useState, FormEventHandler } from 'react';
+import { useState, useEffect, FormEventHandler } from 'react';
 import { useRouter } from 'next/navigation';
 import toast from 'react-hot-toast';
 
@@ -8,8 +8,16 @@ import { InputTextField } from '@/component/form/InputTextField';
 import { SubmitButton } from '@/component/form/SubmitButton';
 
 import { signin } from '@/backend-api/signin';
+import { signinWithPasskey } from '@/backend-api/signinWithPasskey';
+import { requestMagicLink } from '@/backend-api/requestMagicLink';
+import { fetchOIDCProviders, OIDCProvider } from '@/backend-api/fetchOIDCProviders';
+import { beginOIDCSignIn } from '@/backend-api/beginOIDCSignIn';
+import { saveMagicLinkNonce } from '@/storage/magicLink';
+import { saveOIDCState } from '@/storage/oidcState';
 import { useUser } from '@/provider/UserProvider';
 
+import { TwoFactorForm } from './TwoFactorForm';
+
 export const Form = () => {
   const router = useRouter();
   const { saveUser } = useUser();
@@ -17,6 +25,19 @@ export const Form = () => {
   const [emailErrorMessage, setEmailErrorMessage] = useState('');
   const [passwordErrorMessage, setPasswordErrorMessage] = useState('');
   const [loading, setLoading] = useState(false);
+  const [twoFactorToken, setTwoFactorToken] = useState('');
+  const [oidcProviders, setOIDCProviders] = useState<OIDCProvider[]>([]);
+
+  useEffect(() => {
+    (async () => {
+      try {
+        setOIDCProviders(await fetchOIDCProviders());
+      } catch {
+        // 外部アカウントでサインインできない場合もパスワードなどでサインインできるため、ボタンを表示しないのみとする
+        setOIDCProviders([]);
+      }
+    })();
+  }, []);
 
   const submit: FormEventHandler<HTMLFormElement> = (event) => {
     event.preventDefault();
@@ -45,11 +66,18 @@ export const Form = () => {
 
       try {
         const user = await signin(email.toString(), password.toString());
+        if (user.twoFactorRequired) {
+          // 二段階認証が有効な場合は認証コードを入力してからサインインを完了する
+          setLoading(false);
+          setTwoFactorToken(user.twoFactorToken);
+          return;
+        }
         saveUser({
           id: user.id,
           email: user.email,
           name: user.name,
           session_token: user.sessionToken,
+          refresh_token: user.refreshToken,
         });
       } catch (e) {
         setLoading(false);
@@ -70,6 +98,99 @@ export const Form = () => {
       router.push('/');
     })();
   };
+
+  const signinPasskey = () => {
+    (async () => {
+      setLoading(true);
+
+      try {
+        const user = await signinWithPasskey();
+        saveUser({
+          id: user.id,
+          email: user.email,
+          name: user.name,
+          session_token: user.sessionToken,
+          refresh_token: user.refreshToken,
+        });
+      } catch (e) {
+        setLoading(false);
+
+        // 端末のダイアログで取り消した場合は何もしない
+        if (e instanceof DOMException && e.name === 'NotAllowedError') {
+          return;
+        }
+
+        if (e instanceof Error) {
+          toast.error(e.message);
+          return;
+        }
+
+        toast.error(String(e));
+        return;
+      }
+
+      setLoading(false);
+      router.push('/');
+    })();
+  };
+
+  const sendMagicLink = (form: HTMLFormElement | null) => {
+    const email = form ? (new FormData(form).get('email') || '').toString() : '';
+    if (!email) {
+      setEmailErrorMessage('メールアドレスを入力してください');
+      return;
+    }
+
+    (async () => {
+      setLoading(true);
+
+      try {
+        const { nonce } = await requestMagicLink(email);
+        saveMagicLinkNonce(nonce);
+      } catch (e) {
+        setLoading(false);
+
+        if (e instanceof Error) {
+          setEmailErrorMessage(e.message);
+          return;
+        }
+
+        toast.error(String(e));
+        return;
+      }
+
+      setLoading(false);
+      setEmailErrorMessage('');
+      // 登録されていないメールアドレスの場合もメールアドレスが存在するかどうかを表示しない
+      toast.success('サインイン用のリンクを送信しました。このブラウザでメールのリンクを開いてください。');
+    })();
+  };
+
+  const signinOIDC = (providerId: string) => {
+    (async () => {
+      setLoading(true);
+
+      try {
+        const { authorizationUrl, state } = await beginOIDCSignIn(providerId);
+        saveOIDCState(state);
+        window.location.assign(authorizationUrl);
+      } catch (e) {
+        setLoading(false);
+
+        if (e instanceof Error) {
+          toast.error(e.message);
+          return;
+        }
+
+        toast.error(String(e));
+      }
+    })();
+  };
+
+  if (twoFactorToken) {
+    return <TwoFactorForm twoFactorToken={twoFactorToken} />;
+  }
+
   return (
     <form className="flex w-full flex-col gap-8" onSubmit={submit}>
       <div className="flex w-full flex-col gap-5">
@@ -83,6 +204,33 @@ export const Form = () => {
         />
       </div>
       <SubmitButton label="サインインする" loadingLabel="サインイン中..." loading={loading} />
+      <button
+        type="button"
+        className="text-sm text-blue-600 hover:underline disabled:text-blue-400"
+        onClick={signinPasskey}
+        disabled={loading}
+      >
+        パスキーでサインインする
+      </button>
+      <button
+        type="button"
+        className="text-sm text-blue-600 hover:underline disabled:text-blue-400"
+        onClick={(event) => sendMagicLink(event.currentTarget.form)}
+        disabled={loading}
+      >
+        メールでサインイン用のリンクを受け取る
+      </button>
+      {oidcProviders.map((provider) => (
+        <button
+          key={provider.id}
+          type="button"
+          className="text-sm text-blue-600 hover:underline disabled:text-blue-400"
+          onClick={() => signinOIDC(provider.id)}
+          disabled={loading}
+        >
+          {provider.name}のアカウントでサインインする
+        </button>
+      ))}
     </form>
   );
 };
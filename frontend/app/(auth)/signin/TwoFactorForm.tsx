@@ -0,0 +1,94 @@
+'use client';
+
+import { useState, FormEventHandler } from 'react';
+import { useRouter } from 'next/navigation';
+import toast from 'react-hot-toast';
+
+import { InputTextField } from '@/component/form/InputTextField';
+import { SubmitButton } from '@/component/form/SubmitButton';
+
+import { verifyTwoFactor } from '@/backend-api/verifyTwoFactor';
+import { useUser } from '@/provider/UserProvider';
+
+type Props = {
+  twoFactorToken: string;
+};
+
+export const TwoFactorForm = ({ twoFactorToken }: Props) => {
+  const router = useRouter();
+  const { saveUser } = useUser();
+
+  const [useRecoveryCode, setUseRecoveryCode] = useState(false);
+  const [codeErrorMessage, setCodeErrorMessage] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const submit: FormEventHandler<HTMLFormElement> = (event) => {
+    event.preventDefault();
+
+    const form = new FormData(event.currentTarget);
+    const code = (form.get('code') || '').toString();
+
+    if (!code) {
+      setCodeErrorMessage(useRecoveryCode ? 'リカバリーコードを入力してください' : '認証コードを入力してください');
+      return;
+    }
+
+    (async () => {
+      setLoading(true);
+
+      try {
+        const user = await verifyTwoFactor(twoFactorToken, useRecoveryCode ? { recoveryCode: code } : { code });
+        saveUser({
+          id: user.id,
+          email: user.email,
+          name: user.name,
+          session_token: user.sessionToken,
+          refresh_token: user.refreshToken,
+        });
+      } catch (e) {
+        setLoading(false);
+
+        if (e instanceof Error) {
+          setCodeErrorMessage(e.message);
+          return;
+        }
+
+        toast.error(String(e));
+        return;
+      }
+
+      setLoading(false);
+      setCodeErrorMessage('');
+      router.push('/');
+    })();
+  };
+
+  return (
+    <form className="flex w-full flex-col gap-8" onSubmit={submit}>
+      <div className="flex w-full flex-col gap-5">
+        <p className="text-sm">
+          {useRecoveryCode ? '保存しておいたリカバリーコードを入力してください。' : '認証アプリに表示されている6桁の認証コードを入力してください。'}
+        </p>
+        <InputTextField
+          key={useRecoveryCode ? 'recovery-code' : 'code'}
+          name="code"
+          label={useRecoveryCode ? 'リカバリーコード' : '認証コード'}
+          type="text"
+          autocomplete={useRecoveryCode ? 'off' : 'one-time-code'}
+          errorMessage={codeErrorMessage}
+        />
+        <button
+          type="button"
+          className="self-start text-xs text-blue-600 hover:underline"
+          onClick={() => {
+            setUseRecoveryCode(!useRecoveryCode);
+            setCodeErrorMessage('');
+          }}
+        >
+          {useRecoveryCode ? '認証コードを使う' : 'リカバリーコードを使う'}
+        </button>
+      </div>
+      <SubmitButton label="サインインする" loadingLabel="サインイン中..." loading={loading} />
+    </form>
+  );
+};
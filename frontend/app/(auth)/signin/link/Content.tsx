@@ -0,0 +1,86 @@
+'use client';
+
+import { useState, useEffect, useRef } from 'react';
+import { useRouter, useSearchParams } from 'next/navigation';
+import toast from 'react-hot-toast';
+
+import { LinkText } from '@/component/form/LinkText';
+import { Loading } from './Loading';
+import { TwoFactorForm } from '../TwoFactorForm';
+
+import { signinWithMagicLink } from '@/backend-api/signinWithMagicLink';
+import { loadMagicLinkNonce, removeMagicLinkNonce } from '@/storage/magicLink';
+import { useUser } from '@/provider/UserProvider';
+
+export const Content = () => {
+  const router = useRouter();
+  const searchParams = useSearchParams();
+  const { saveUser } = useUser();
+  const [twoFactorToken, setTwoFactorToken] = useState('');
+  const [failed, setFailed] = useState(false);
+  // リンクは1回のみ使えるため、開発時に effect が2回実行されても1回のみ送信する
+  const requested = useRef(false);
+
+  useEffect(() => {
+    if (requested.current) return;
+
+    const token = searchParams.get('token') || '';
+    if (!token) {
+      toast.error('URLが不正です。');
+      setFailed(true);
+      return;
+    }
+
+    const nonce = loadMagicLinkNonce();
+    if (!nonce) {
+      toast.error('サインイン用のリンクはリクエストしたブラウザで開いてください。');
+      setFailed(true);
+      return;
+    }
+
+    requested.current = true;
+    (async () => {
+      try {
+        const user = await signinWithMagicLink(token, nonce);
+        removeMagicLinkNonce();
+        if (user.twoFactorRequired) {
+          // 二段階認証が有効な場合は認証コードを入力してからサインインを完了する
+          setTwoFactorToken(user.twoFactorToken);
+          return;
+        }
+        saveUser({
+          id: user.id,
+          email: user.email,
+          name: user.name,
+          session_token: user.sessionToken,
+          refresh_token: user.refreshToken,
+        });
+        router.push('/');
+      } catch (e) {
+        setFailed(true);
+        toast.error(e instanceof Error ? e.message : String(e));
+      }
+    })();
+  }, [searchParams, router, saveUser]);
+
+  if (twoFactorToken) {
+    return <TwoFactorForm twoFactorToken={twoFactorToken} />;
+  }
+
+  if (failed) {
+    return (
+      <div className="pt-16 text-center leading-8">
+        <p>サインインできませんでした。</p>
+        <p>
+          <LinkText href="/signin">サインイン</LinkText>からやり直してください。
+        </p>
+      </div>
+    );
+  }
+
+  return (
+    <div className="pt-16">
+      <Loading />
+    </div>
+  );
+};
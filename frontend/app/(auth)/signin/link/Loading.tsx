@@ -0,0 +1,10 @@
+import { ArrowPathIcon } from '@heroicons/react/24/outline';
+
+export const Loading = () => {
+  return (
+    <div className="flex items-center gap-2">
+      <ArrowPathIcon className="size-8 animate-spin" />
+      サインイン中...
+    </div>
+  );
+};
@@ -0,0 +1,21 @@
+import type { Metadata } from 'next';
+import { Suspense } from 'react';
+
+import { FormTitle } from '@/component/form/FormTitle';
+import { Content } from './Content';
+
+export const metadata: Metadata = {
+  title: 'サインイン',
+  description: 'TOU 受講スケジュール管理の外部アカウントでサインインするページです。',
+};
+
+export default function SignInOIDCCallback() {
+  return (
+    <>
+      <FormTitle label="サインイン" />
+      <Suspense>
+        <Content />
+      </Suspense>
+    </>
+  );
+}
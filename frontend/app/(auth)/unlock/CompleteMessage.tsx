@@ -0,0 +1,12 @@
+import { LinkText } from '@/component/form/LinkText';
+
+export const CompleteMessage = () => {
+  return (
+    <div className="text-center leading-8">
+      <p>アカウントのロックを解除しました。</p>
+      <p>
+        <LinkText href="/signin">サインイン</LinkText>してください。
+      </p>
+    </div>
+  );
+};
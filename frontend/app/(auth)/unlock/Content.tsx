@@ -0,0 +1,43 @@
+'use client';
+
+import { useState, useEffect, useRef } from 'react';
+import { useSearchParams } from 'next/navigation';
+import toast from 'react-hot-toast';
+
+import { CompleteMessage } from './CompleteMessage';
+import { Loading } from './Loading';
+
+import { unlockAccount } from '@/backend-api/unlockAccount';
+import { SessionExpiredError } from '@/backend-api/error';
+
+export const Content = () => {
+  const searchParams = useSearchParams();
+  const [isComplete, setIsComplete] = useState(false);
+  // トークンは1回のみ使えるため、開発時に effect が2回実行されても1回のみ送信する
+  const requested = useRef(false);
+
+  useEffect(() => {
+    if (requested.current) return;
+
+    const token = searchParams.get('token') || '';
+
+    if (!token) {
+      toast.error('URLが不正です。');
+      return;
+    }
+
+    requested.current = true;
+    (async () => {
+      try {
+        await unlockAccount(token);
+        setIsComplete(true);
+      } catch (e) {
+        if (e instanceof SessionExpiredError) return;
+        toast.error('ロックの解除に失敗しました。');
+        toast.error(String(e));
+      }
+    })();
+  }, [searchParams]);
+
+  return <div className="pt-16">{isComplete ? <CompleteMessage /> : <Loading />}</div>;
+};
@@ -0,0 +1,21 @@
+import type { Metadata } from 'next';
+import { Suspense } from 'react';
+
+import { FormTitle } from '@/component/form/FormTitle';
+import { Content } from './Content';
+
+export const metadata: Metadata = {
+  title: 'ロックの解除',
+  description: 'TOU 受講スケジュール管理のアカウントのロックを解除するページです。',
+};
+
+export default function Unlock() {
+  return (
+    <>
+      <FormTitle label="ロックの解除" />
+      <Suspense>
+        <Content />
+      </Suspense>
+    </>
+  );
+}
@@ -5,7 +5,7 @@ export const CompleteMessage = () => {
     <div className="text-center leading-8">
       <p>メールアドレスの変更が完了しました。</p>
       <p>
-        <LinkText href="/settings">設定</LinkText>から確認できます。
+        新しいメールアドレスで<LinkText href="/signin">サインイン</LinkText>してください。
       </p>
     </div>
   );
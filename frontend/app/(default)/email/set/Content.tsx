@@ -8,41 +8,38 @@ import { CompleteMessage } from './CompleteMessage';
 import { Loading } from './Loading';
 
 import { setEmail } from '@/backend-api/setEmail';
-import { getUser } from '@/backend-api/getUser';
 import { useUser } from '@/provider/UserProvider';
 import { SessionExpiredError } from '@/backend-api/error';
 
 export const Content = () => {
   const searchParams = useSearchParams();
   const [isComplete, setIsComplete] = useState(false);
-  const { user, saveUser } = useUser();
+  const { user, removeUser } = useUser();
 
   useEffect(() => {
     if (!user) return;
     if (isComplete) return;
 
-    const idToken = searchParams.get('id_token') || '';
-    const emailToken = searchParams.get('email_token') || '';
+    const token = searchParams.get('token') || '';
 
-    if (!idToken || !emailToken) {
+    if (!token) {
       toast.error('URLが不正です。');
       return;
     }
 
     (async () => {
       try {
-        await setEmail(idToken, emailToken);
+        await setEmail(token);
         setIsComplete(true);
-        const newUser = await getUser();
-        const newUserWithToken = { ...newUser, session_token: user.session_token };
-        saveUser(newUserWithToken);
+        // メールアドレスを変更するとすべての端末のセッションが無効になるため、サインインし直してもらう
+        removeUser();
       } catch (e) {
         if (e instanceof SessionExpiredError) return;
         toast.error('メールアドレスの変更に失敗しました。');
         toast.error(String(e));
       }
     })();
-  }, [searchParams, isComplete, user, saveUser]);
+  }, [searchParams, isComplete, user, removeUser]);
 
   return <div className="pt-16">{isComplete ? <CompleteMessage /> : <Loading />}</div>;
 };
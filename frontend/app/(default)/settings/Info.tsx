@@ -1,5 +1,7 @@
 import { Email } from './Email';
 import { Name } from './Name';
+import { TwoFactor } from './TwoFactor';
+import { Passkeys } from './Passkeys';
 import { DeleteButton } from './DeleteButton';
 
 export const Info = () => {
@@ -8,6 +10,8 @@ export const Info = () => {
       <div className="flex w-full flex-col gap-8">
         <Email />
         <Name />
+        <TwoFactor />
+        <Passkeys />
       </div>
       <DeleteButton />
     </div>
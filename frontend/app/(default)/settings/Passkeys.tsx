@@ -0,0 +1,102 @@
+'use client';
+
+import { useEffect, useState } from 'react';
+import toast from 'react-hot-toast';
+
+import { fetchPasskeys, Passkey } from '@/backend-api/fetchPasskeys';
+import { registerPasskey } from '@/backend-api/registerPasskey';
+import { deletePasskey } from '@/backend-api/deletePasskey';
+import { SessionExpiredError } from '@/backend-api/error';
+
+export const Passkeys = () => {
+  const [loading, setLoading] = useState(false);
+  const [passkeys, setPasskeys] = useState<Passkey[] | null>(null);
+
+  useEffect(() => {
+    (async () => {
+      try {
+        setPasskeys(await fetchPasskeys());
+      } catch (e) {
+        if (e instanceof SessionExpiredError) return;
+        toast.error(String(e));
+      }
+    })();
+  }, []);
+
+  if (passkeys === null) return null;
+
+  const register = () => {
+    (async () => {
+      setLoading(true);
+
+      try {
+        const passkey = await registerPasskey('');
+        setPasskeys([...passkeys, passkey]);
+        toast.success('パスキーを登録しました');
+      } catch (e) {
+        // 端末のダイアログで取り消した場合は何もしない
+        if (e instanceof SessionExpiredError || (e instanceof DOMException && e.name === 'NotAllowedError')) {
+          setLoading(false);
+          return;
+        }
+        toast.error(String(e));
+      }
+
+      setLoading(false);
+    })();
+  };
+
+  const remove = (passkey: Passkey) => {
+    if (!window.confirm(`「${passkey.name}」を削除しますか？削除したパスキーではサインインできなくなります。`)) return;
+
+    (async () => {
+      setLoading(true);
+
+      try {
+        await deletePasskey(passkey.id);
+        setPasskeys(passkeys.filter((p) => p.id !== passkey.id));
+        toast.success('パスキーを削除しました');
+      } catch (e) {
+        if (e instanceof SessionExpiredError) return;
+        toast.error(String(e));
+      }
+
+      setLoading(false);
+    })();
+  };
+
+  return (
+    <div className="flex w-full flex-col gap-2">
+      <label className="text-xs">パスキー</label>
+      {passkeys.length === 0 && <div className="text-sm">登録されていません</div>}
+      {passkeys.length > 0 && (
+        <ul className="flex flex-col gap-1">
+          {passkeys.map((passkey) => (
+            <li key={passkey.id} className="flex items-center justify-between gap-2">
+              <div className="flex flex-col">
+                <span className="text-sm">{passkey.name}</span>
+                <span className="text-xs text-gray-500">
+                  登録 {passkey.createdAt}
+                  {passkey.lastUsedAt && ` / 最終使用 ${passkey.lastUsedAt}`}
+                </span>
+              </div>
+              <button
+                type="button"
+                className="text-sm text-red-600 hover:underline disabled:text-red-400"
+                onClick={() => remove(passkey)}
+                disabled={loading}
+              >
+                削除
+              </button>
+            </li>
+          ))}
+        </ul>
+      )}
+      <div className="flex justify-end">
+        <button type="button" className="text-sm text-blue-600 hover:underline disabled:text-blue-400" onClick={register} disabled={loading}>
+          パスキーを追加する
+        </button>
+      </div>
+    </div>
+  );
+};
@@ -0,0 +1,139 @@
+'use client';
+
+import { useEffect, useState, FormEventHandler } from 'react';
+import { ArrowPathIcon } from '@heroicons/react/24/outline';
+import toast from 'react-hot-toast';
+
+import { getTwoFactor } from '@/backend-api/getTwoFactor';
+import { enrollTwoFactor } from '@/backend-api/enrollTwoFactor';
+import { activateTwoFactor } from '@/backend-api/activateTwoFactor';
+import { SessionExpiredError } from '@/backend-api/error';
+
+type Enrollment = {
+  secret: string;
+  uri: string;
+};
+
+export const TwoFactor = () => {
+  const [loading, setLoading] = useState(false);
+  const [enabled, setEnabled] = useState<boolean | null>(null);
+  const [recoveryCodesRemaining, setRecoveryCodesRemaining] = useState(0);
+  const [enrollment, setEnrollment] = useState<Enrollment | null>(null);
+  const [recoveryCodes, setRecoveryCodes] = useState<string[]>([]);
+
+  useEffect(() => {
+    (async () => {
+      try {
+        const result = await getTwoFactor();
+        setEnabled(result.enabled);
+        setRecoveryCodesRemaining(result.recoveryCodesRemaining);
+      } catch (e) {
+        if (e instanceof SessionExpiredError) return;
+        toast.error(String(e));
+      }
+    })();
+  }, []);
+
+  if (enabled === null) return null;
+
+  const enroll = () => {
+    (async () => {
+      setLoading(true);
+
+      try {
+        setEnrollment(await enrollTwoFactor());
+      } catch (e) {
+        if (e instanceof SessionExpiredError) return;
+        toast.error(String(e));
+      }
+
+      setLoading(false);
+    })();
+  };
+
+  const activate: FormEventHandler<HTMLFormElement> = (event) => {
+    event.preventDefault();
+
+    const form = new FormData(event.currentTarget);
+    const code = form.get('code') || '';
+
+    (async () => {
+      setLoading(true);
+
+      try {
+        const codes = await activateTwoFactor(code.toString());
+        setRecoveryCodes(codes);
+        setRecoveryCodesRemaining(codes.length);
+        setEnrollment(null);
+        setEnabled(true);
+        toast.success('二段階認証を有効にしました');
+      } catch (e) {
+        if (e instanceof SessionExpiredError) return;
+        toast.error(String(e));
+      }
+
+      setLoading(false);
+    })();
+  };
+
+  return (
+    <div className="flex w-full flex-col gap-2">
+      <label className="text-xs">二段階認証</label>
+      {enabled && (
+        <div className="text-sm">
+          有効 (未使用のリカバリーコード {recoveryCodesRemaining}個)
+        </div>
+      )}
+      {recoveryCodes.length > 0 && (
+        <div className="flex flex-col gap-2 rounded-lg border p-2">
+          <p className="text-xs">
+            認証アプリを使えなくなったときにサインインするためのリカバリーコードです。それぞれ1回のみ使え、この画面を閉じると再び表示できないため、安全な場所に保存してください。
+          </p>
+          <ul className="grid grid-cols-2 gap-1 font-mono text-sm">
+            {recoveryCodes.map((code) => (
+              <li key={code}>{code}</li>
+            ))}
+          </ul>
+        </div>
+      )}
+      {!enabled && !enrollment && (
+        <div className="flex items-center justify-between gap-2">
+          <div className="text-sm">無効</div>
+          <button type="button" className="text-sm text-blue-600 hover:underline disabled:text-blue-400" onClick={enroll} disabled={loading}>
+            設定する
+          </button>
+        </div>
+      )}
+      {!enabled && enrollment && (
+        <form className="flex flex-col gap-2" onSubmit={activate}>
+          <p className="text-xs">
+            認証アプリで
+            <a href={enrollment.uri} className="text-blue-600 hover:underline">
+              このリンク
+            </a>
+            を開くか、次のキーを入力して登録し、表示された6桁の認証コードを入力してください。
+          </p>
+          <div className="break-all font-mono text-sm">{enrollment.secret}</div>
+          <div className="flex">
+            <input
+              id="code"
+              name="code"
+              type="text"
+              inputMode="numeric"
+              autoComplete="one-time-code"
+              disabled={loading}
+              className="w-full rounded-s-lg border p-2"
+            />
+            <button
+              type="submit"
+              className="flex min-w-14 flex-shrink-0 items-center justify-center rounded-e-lg bg-blue-600 text-sm text-white hover:bg-blue-800 disabled:bg-blue-400"
+              disabled={loading}
+            >
+              {loading ? <ArrowPathIcon className="size-5 animate-spin" /> : '確認'}
+            </button>
+          </div>
+        </form>
+      )}
+    </div>
+  );
+};
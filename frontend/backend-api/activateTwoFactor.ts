@@ -0,0 +1,27 @@
+import axios from 'axios';
+
+import { loadAuthUser } from '@/storage/user';
+import { newThrowResponseError } from './error';
+
+export const activateTwoFactor = async (code: string): Promise<string[]> => {
+  const user = loadAuthUser();
+  if (!user) {
+    throw new Error('User not found');
+  }
+
+  const param = { code };
+
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/users/${user.id}/totp/verify`, param, {
+      headers: {
+        'Content-Type': 'application/json',
+        Authorization: `Bearer ${user.session_token}`,
+      },
+    });
+
+    return response.data.recovery_codes;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
@@ -0,0 +1,24 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+
+type result = {
+  authorizationUrl: string;
+  state: string;
+};
+
+export const beginOIDCSignIn = async (providerId: string): Promise<result> => {
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/oidc/${encodeURIComponent(providerId)}/authorize`);
+
+    const result: result = {
+      authorizationUrl: response.data.authorization_url,
+      state: response.data.state,
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
@@ -0,0 +1,35 @@
+import axios from 'axios';
+
+import { loadAuthUser } from '@/storage/user';
+import { newThrowResponseError } from './error';
+
+type result = {
+  secret: string;
+  uri: string;
+};
+
+export const enrollTwoFactor = async (): Promise<result> => {
+  const user = loadAuthUser();
+  if (!user) {
+    throw new Error('User not found');
+  }
+
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/users/${user.id}/totp`, null, {
+      headers: {
+        'Content-Type': 'application/json',
+        Authorization: `Bearer ${user.session_token}`,
+      },
+    });
+
+    const result: result = {
+      secret: response.data.secret,
+      uri: response.data.uri,
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
@@ -2,6 +2,7 @@ import axios from 'axios';
 import toast from 'react-hot-toast';
 
 import { removeAuthUser } from '@/storage/user';
+import './refreshSession';
 
 export const UnknownErrorMessage = 'エラーが発生しました';
 
@@ -0,0 +1,22 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+
+export type OIDCProvider = {
+  id: string;
+  name: string;
+};
+
+export const fetchOIDCProviders = async (): Promise<OIDCProvider[]> => {
+  try {
+    const response = await axios.get(`${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/oidc/providers`);
+
+    return response.data.providers.map((data: any) => ({
+      id: data.id,
+      name: data.name,
+    }));
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
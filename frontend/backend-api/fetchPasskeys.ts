@@ -0,0 +1,38 @@
+import axios from 'axios';
+
+import { loadAuthUser } from '@/storage/user';
+import { newThrowResponseError } from './error';
+
+export type Passkey = {
+  id: string;
+  name: string;
+  backedUp: boolean;
+  createdAt: string;
+  lastUsedAt: string;
+};
+
+export const fetchPasskeys = async (): Promise<Passkey[]> => {
+  const user = loadAuthUser();
+  if (!user) {
+    throw new Error('User not found');
+  }
+
+  try {
+    const response = await axios.get(`${process.env.NEXT_PUBLIC_API_BASE_URL}/users/${user.id}/passkeys`, {
+      headers: {
+        Authorization: `Bearer ${user.session_token}`,
+      },
+    });
+
+    return response.data.passkeys.map((data: any) => ({
+      id: data.id,
+      name: data.name,
+      backedUp: data.backed_up,
+      createdAt: data.created_at,
+      lastUsedAt: data.last_used_at,
+    }));
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
@@ -0,0 +1,35 @@
+import axios from 'axios';
+
+import { loadAuthUser } from '@/storage/user';
+import { newThrowResponseError } from './error';
+
+type result = {
+  enabled: boolean;
+  recoveryCodesRemaining: number;
+};
+
+export const getTwoFactor = async (): Promise<result> => {
+  const user = loadAuthUser();
+  if (!user) {
+    throw new Error('User not found');
+  }
+
+  try {
+    const response = await axios.get(`${process.env.NEXT_PUBLIC_API_BASE_URL}/users/${user.id}/totp`, {
+      headers: {
+        'Content-Type': 'application/json',
+        Authorization: `Bearer ${user.session_token}`,
+      },
+    });
+
+    const result: result = {
+      enabled: response.data.enabled,
+      recoveryCodesRemaining: response.data.recovery_codes_remaining,
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
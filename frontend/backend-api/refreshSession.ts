@@ -0,0 +1,65 @@
+import axios from 'axios';
+
+import { loadAuthUser, saveAuthUser } from '@/storage/user';
+
+let refreshing: Promise<string | null> | null = null;
+
+// アクセストークンを再発行し、新しいアクセストークンを返す。再発行できない場合は null を返す。
+// 同時に複数のリクエストが期限切れになっても、リフレッシュトークンは1回だけ使う。
+export const refreshSession = (): Promise<string | null> => {
+  if (!refreshing) {
+    refreshing = doRefresh().finally(() => {
+      refreshing = null;
+    });
+  }
+  return refreshing;
+};
+
+const doRefresh = async (): Promise<string | null> => {
+  const user = loadAuthUser();
+  if (!user?.refresh_token) {
+    return null;
+  }
+
+  try {
+    const response = await axios.post(
+      `${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/refresh`,
+      { refresh_token: user.refresh_token },
+      { headers: { 'Content-Type': 'application/json' } },
+    );
+
+    saveAuthUser({
+      ...user,
+      session_token: response.data.session_token,
+      refresh_token: response.data.refresh_token,
+    });
+    return response.data.session_token;
+  } catch {
+    return null;
+  }
+};
+
+type RetryableConfig = {
+  _retried?: boolean;
+};
+
+// 期限切れのアクセストークンで 401 になったリクエストを、再発行したアクセストークンで1回だけ再送する。
+axios.interceptors.response.use(undefined, async (error) => {
+  if (!axios.isAxiosError(error) || error.response?.status !== 401 || !error.config) {
+    throw error;
+  }
+
+  const config = error.config as typeof error.config & RetryableConfig;
+  if (config._retried || !config.headers?.Authorization) {
+    throw error;
+  }
+
+  const sessionToken = await refreshSession();
+  if (!sessionToken) {
+    throw error;
+  }
+
+  config._retried = true;
+  config.headers.Authorization = `Bearer ${sessionToken}`;
+  return axios.request(config);
+});
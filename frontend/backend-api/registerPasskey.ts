@@ -0,0 +1,46 @@
+import axios from 'axios';
+
+import { loadAuthUser } from '@/storage/user';
+import { newThrowResponseError } from './error';
+import { attestationToJSON, toCreationOptions } from './webauthn';
+import type { Passkey } from './fetchPasskeys';
+
+export const registerPasskey = async (name: string): Promise<Passkey> => {
+  const user = loadAuthUser();
+  if (!user) {
+    throw new Error('User not found');
+  }
+
+  const headers = {
+    'Content-Type': 'application/json',
+    Authorization: `Bearer ${user.session_token}`,
+  };
+
+  let options: PublicKeyCredentialCreationOptions;
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/users/${user.id}/passkeys/options`, null, { headers });
+    options = toCreationOptions(response.data.public_key);
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+
+  // ユーザーが登録を取り消した場合は DOMException をそのまま投げる
+  const credential = (await navigator.credentials.create({ publicKey: options })) as PublicKeyCredential;
+
+  try {
+    const param = { name, credential: attestationToJSON(credential) };
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/users/${user.id}/passkeys`, param, { headers });
+
+    return {
+      id: response.data.id,
+      name: response.data.name,
+      backedUp: response.data.backed_up,
+      createdAt: response.data.created_at,
+      lastUsedAt: response.data.last_used_at,
+    };
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
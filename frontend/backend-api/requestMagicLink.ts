@@ -0,0 +1,28 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+
+type result = {
+  nonce: string;
+};
+
+export const requestMagicLink = async (email: string): Promise<result> => {
+  const param = { email };
+
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/magic-link`, param, {
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    });
+
+    const result: result = {
+      nonce: response.data.nonce,
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
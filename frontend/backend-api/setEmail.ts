@@ -3,15 +3,14 @@ import axios from 'axios';
 import { loadAuthUser } from '@/storage/user';
 import { newThrowResponseError } from './error';
 
-export const setEmail = async (idToken: string, emailToken: string): Promise<void> => {
+export const setEmail = async (token: string): Promise<void> => {
   const user = loadAuthUser();
   if (!user) {
     throw new Error('User not found');
   }
 
   const param = {
-    id_token: idToken,
-    email_token: emailToken,
+    token: token,
   };
 
   try {
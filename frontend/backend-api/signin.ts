@@ -9,6 +9,9 @@ type result = {
   createdAt: string;
   updatedAt: string;
   sessionToken: string;
+  refreshToken: string;
+  twoFactorRequired: boolean;
+  twoFactorToken: string;
 };
 
 export const signin = async (email: string, password: string): Promise<result> => {
@@ -28,6 +31,9 @@ export const signin = async (email: string, password: string): Promise<result> =
       createdAt: response.data.created_at,
       updatedAt: response.data.updated_at,
       sessionToken: response.data.session_token,
+      refreshToken: response.data.refresh_token,
+      twoFactorRequired: response.data.two_factor_required ?? false,
+      twoFactorToken: response.data.two_factor_token ?? '',
     };
 
     return result;
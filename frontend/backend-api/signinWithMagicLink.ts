@@ -0,0 +1,44 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+
+type result = {
+  id: string;
+  email: string;
+  name: string;
+  createdAt: string;
+  updatedAt: string;
+  sessionToken: string;
+  refreshToken: string;
+  twoFactorRequired: boolean;
+  twoFactorToken: string;
+};
+
+export const signinWithMagicLink = async (token: string, nonce: string): Promise<result> => {
+  const param = { token, nonce };
+
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/magic-link/consume`, param, {
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    });
+
+    const result: result = {
+      id: response.data.id,
+      email: response.data.email,
+      name: response.data.name,
+      createdAt: response.data.created_at,
+      updatedAt: response.data.updated_at,
+      sessionToken: response.data.session_token,
+      refreshToken: response.data.refresh_token,
+      twoFactorRequired: response.data.two_factor_required ?? false,
+      twoFactorToken: response.data.two_factor_token ?? '',
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
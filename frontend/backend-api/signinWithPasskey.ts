@@ -0,0 +1,56 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+import { assertionToJSON, toRequestOptions } from './webauthn';
+
+type result = {
+  id: string;
+  email: string;
+  name: string;
+  createdAt: string;
+  updatedAt: string;
+  sessionToken: string;
+  refreshToken: string;
+};
+
+export const signinWithPasskey = async (): Promise<result> => {
+  let options: PublicKeyCredentialRequestOptions;
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/signin/passkey/options`, null, {
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    });
+    options = toRequestOptions(response.data.public_key);
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+
+  // ユーザーがパスキーの選択を取り消した場合は DOMException をそのまま投げる
+  const credential = (await navigator.credentials.get({ publicKey: options })) as PublicKeyCredential;
+
+  try {
+    const param = { credential: assertionToJSON(credential) };
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/signin/passkey`, param, {
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    });
+
+    const result: result = {
+      id: response.data.id,
+      email: response.data.email,
+      name: response.data.name,
+      createdAt: response.data.created_at,
+      updatedAt: response.data.updated_at,
+      sessionToken: response.data.session_token,
+      refreshToken: response.data.refresh_token,
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
@@ -0,0 +1,21 @@
+import axios from 'axios';
+
+import { loadAuthUser } from '@/storage/user';
+
+export const signout = async (): Promise<void> => {
+  const user = loadAuthUser();
+  if (!user?.refresh_token) {
+    return;
+  }
+
+  try {
+    await axios.post(
+      `${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/signout`,
+      { refresh_token: user.refresh_token },
+      { headers: { 'Content-Type': 'application/json' } },
+    );
+  } catch (e) {
+    // サーバー側で無効にできなくても、ブラウザからはサインアウトする
+    console.error(e);
+  }
+};
@@ -0,0 +1,20 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+
+export const unlockAccount = async (token: string): Promise<void> => {
+  const param = { token };
+
+  try {
+    await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/auth/unlock`, param, {
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    });
+
+    return;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
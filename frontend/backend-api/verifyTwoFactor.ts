@@ -0,0 +1,45 @@
+import axios from 'axios';
+
+import { newThrowResponseError } from './error';
+
+type result = {
+  id: string;
+  email: string;
+  name: string;
+  createdAt: string;
+  updatedAt: string;
+  sessionToken: string;
+  refreshToken: string;
+};
+
+type param = {
+  code?: string;
+  recoveryCode?: string;
+};
+
+export const verifyTwoFactor = async (twoFactorToken: string, { code, recoveryCode }: param): Promise<result> => {
+  const param = { two_factor_token: twoFactorToken, code: code ?? '', recovery_code: recoveryCode ?? '' };
+
+  try {
+    const response = await axios.post(`${process.env.NEXT_PUBLIC_API_BASE_URL}/signin/totp`, param, {
+      headers: {
+        'Content-Type': 'application/json',
+      },
+    });
+
+    const result: result = {
+      id: response.data.id,
+      email: response.data.email,
+      name: response.data.name,
+      createdAt: response.data.created_at,
+      updatedAt: response.data.updated_at,
+      sessionToken: response.data.session_token,
+      refreshToken: response.data.refresh_token,
+    };
+
+    return result;
+  } catch (e) {
+    newThrowResponseError(e);
+    throw e;
+  }
+};
@@ -0,0 +1,64 @@
+// WebAuthn のバイト列と、API でやり取りするパディングなしの Base64URL の文字列を変換する
+
+export const toBase64URL = (buffer: ArrayBuffer): string => {
+  let binary = '';
+  new Uint8Array(buffer).forEach((b) => {
+    binary += String.fromCharCode(b);
+  });
+  return btoa(binary).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+};
+
+export const fromBase64URL = (value: string): ArrayBuffer => {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+  const binary = atob(base64.padEnd(Math.ceil(base64.length / 4) * 4, '='));
+  const bytes = new Uint8Array(binary.length);
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i);
+  }
+  return bytes.buffer;
+};
+
+type descriptor = { type: 'public-key'; id: string };
+
+// API が返す navigator.credentials.create のオプションをブラウザに渡せる形に変換する
+export const toCreationOptions = (options: any): PublicKeyCredentialCreationOptions => ({
+  ...options,
+  challenge: fromBase64URL(options.challenge),
+  user: { ...options.user, id: fromBase64URL(options.user.id) },
+  excludeCredentials: (options.excludeCredentials ?? []).map((c: descriptor) => ({ ...c, id: fromBase64URL(c.id) })),
+});
+
+// API が返す navigator.credentials.get のオプションをブラウザに渡せる形に変換する
+export const toRequestOptions = (options: any): PublicKeyCredentialRequestOptions => ({
+  ...options,
+  challenge: fromBase64URL(options.challenge),
+  allowCredentials: (options.allowCredentials ?? []).map((c: descriptor) => ({ ...c, id: fromBase64URL(c.id) })),
+});
+
+// 登録の応答を API に送る形に変換する
+export const attestationToJSON = (credential: PublicKeyCredential) => {
+  const response = credential.response as AuthenticatorAttestationResponse;
+  return {
+    id: credential.id,
+    type: credential.type,
+    response: {
+      clientDataJSON: toBase64URL(response.clientDataJSON),
+      attestationObject: toBase64URL(response.attestationObject),
+    },
+  };
+};
+
+// 認証の応答を API に送る形に変換する
+export const assertionToJSON = (credential: PublicKeyCredential) => {
+  const response = credential.response as AuthenticatorAssertionResponse;
+  return {
+    id: credential.id,
+    type: credential.type,
+    response: {
+      clientDataJSON: toBase64URL(response.clientDataJSON),
+      authenticatorData: toBase64URL(response.authenticatorData),
+      signature: toBase64URL(response.signature),
+      userHandle: response.userHandle ? toBase64URL(response.userHandle) : '',
+    },
+  };
+};
@@ -3,11 +3,13 @@
 import { useRouter } from 'next/navigation';
 
 import { removeAuthUser } from '@/storage/user';
+import { signout as signoutSession } from '@/backend-api/signout';
 
 export const SignoutButton = () => {
   const router = useRouter();
 
-  const signout = () => {
+  const signout = async () => {
+    await signoutSession();
     removeAuthUser();
     router.push('/signin');
   };
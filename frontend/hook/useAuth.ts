@@ -21,6 +21,7 @@ export const useAuth = () => {
       switch (pathname) {
         case '/settings':
         case '/usage':
+        case '/unlock':
           break;
         default:
           router.push('/');
@@ -33,8 +34,11 @@ export const useAuth = () => {
 
     switch (pathname) {
       case '/signup':
+      case '/signin/link':
+      case '/signin/oidc/callback':
       case '/password/set':
       case '/password/reset':
+      case '/unlock':
         break;
       default:
         router.push('/signin');
@@ -53,10 +53,10 @@ export const UserProvider = ({ children }: Props) => {
       try {
         const u = await getUser();
         const nu = {
+          ...au,
           id: u.id,
           name: u.name,
           email: u.email,
-          session_token: au.session_token,
         };
         setUser(nu);
       } catch (e) {
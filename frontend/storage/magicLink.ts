@@ -0,0 +1,14 @@
+const storage_key = 'magic-link-nonce';
+
+// サインインのリンクはリクエストしたブラウザでのみ使えるため、リクエストしたときに受け取った nonce を保存する
+export const saveMagicLinkNonce = (nonce: string) => {
+  localStorage.setItem(storage_key, nonce);
+};
+
+export const loadMagicLinkNonce = (): string => {
+  return localStorage.getItem(storage_key) || '';
+};
+
+export const removeMagicLinkNonce = () => {
+  localStorage.removeItem(storage_key);
+};
@@ -0,0 +1,14 @@
+const storage_key = 'oidc-state';
+
+// ID プロバイダーから戻ったときに同じブラウザで始めたサインインであることを確かめるため、開始したときの state を保存する
+export const saveOIDCState = (state: string) => {
+  sessionStorage.setItem(storage_key, state);
+};
+
+export const loadOIDCState = (): string => {
+  return sessionStorage.getItem(storage_key) || '';
+};
+
+export const removeOIDCState = () => {
+  sessionStorage.removeItem(storage_key);
+};
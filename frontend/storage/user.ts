@@ -5,6 +5,7 @@ export type AuthUser = {
   email: string;
   name: string;
   session_token: string;
+  refresh_token?: string;
 };
 
 export const saveAuthUser = (authUser: AuthUser) => {